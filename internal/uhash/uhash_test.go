@@ -0,0 +1,45 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package uhash_test
+
+import (
+	"hash/maphash"
+	"math"
+	"testing"
+
+	"github.com/kordax/basic-utils/internal/uhash"
+	"github.com/stretchr/testify/assert"
+)
+
+type key struct {
+	Name  string
+	ID    int
+	Ratio float64
+	Extra any
+}
+
+func TestComparable(t *testing.T) {
+	seed := maphash.MakeSeed()
+
+	assert.Equal(t, int64(42), uhash.Comparable(seed, 42))
+	assert.Equal(t, uhash.Comparable(seed, "key"), uhash.Comparable(seed, "key"))
+	assert.NotEqual(t, uhash.Comparable(seed, "key"), uhash.Comparable(seed, "other"))
+
+	a := key{Name: "a", ID: 1, Ratio: 0, Extra: int8(1)}
+	b := key{Name: "a", ID: 1, Ratio: math.Copysign(0, -1), Extra: int8(1)}
+	assert.Equal(t, uhash.Comparable(seed, a), uhash.Comparable(seed, b), "equal values must have equal hashes")
+
+	b.Extra = uint8(1)
+	assert.NotEqual(t, uhash.Comparable(seed, a), uhash.Comparable(seed, b), "the dynamic types must be hashed")
+	assert.Equal(t, uhash.Comparable(seed, [2]bool{true, false}), uhash.Comparable(seed, [2]bool{true, false}))
+	assert.Equal(t, uhash.Comparable(seed, complex(1, 2)), uhash.Comparable(seed, complex(1, 2)))
+}
+
+func TestMix(t *testing.T) {
+	assert.Equal(t, uint64(0), uhash.Mix(0))
+	assert.NotEqual(t, uhash.Mix(1)>>32, uhash.Mix(2)>>32, "the low bits must be spread to the high ones")
+}
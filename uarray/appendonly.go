@@ -0,0 +1,104 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package uarray
+
+import (
+	"iter"
+	"sync"
+)
+
+const (
+	appendOnlyMinChunk = 16
+	appendOnlyMaxChunk = 4096
+)
+
+// AppendOnlyList collects values appended by concurrent goroutines in the order of the Append calls,
+// e.g. the results of workers to be processed with uarray functions once they finish.
+// The values are stored in chunks that are never reallocated, so growing the list doesn't copy the stored values,
+// and the iteration doesn't block the writers. The zero value is an empty list ready to use.
+// The operations are thread-safe.
+type AppendOnlyList[T any] struct {
+	mtx    sync.Mutex
+	chunks [][]T
+	len    int
+}
+
+// NewAppendOnlyList creates an empty AppendOnlyList.
+func NewAppendOnlyList[T any]() *AppendOnlyList[T] {
+	return &AppendOnlyList[T]{}
+}
+
+// Append adds the values to the end of the list. The values of one call are kept together.
+func (l *AppendOnlyList[T]) Append(values ...T) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	for len(values) > 0 {
+		if len(l.chunks) == 0 || len(l.chunks[len(l.chunks)-1]) == cap(l.chunks[len(l.chunks)-1]) {
+			size := appendOnlyMinChunk
+			if len(l.chunks) > 0 {
+				size = min(cap(l.chunks[len(l.chunks)-1])*2, appendOnlyMaxChunk)
+			}
+			l.chunks = append(l.chunks, make([]T, 0, size))
+		}
+		last := &l.chunks[len(l.chunks)-1]
+		n := min(cap(*last)-len(*last), len(values))
+		*last = append(*last, values[:n]...)
+		values = values[n:]
+		l.len += n
+	}
+}
+
+// Len returns the number of values in the list.
+func (l *AppendOnlyList[T]) Len() int {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	return l.len
+}
+
+// Snapshot returns a copy of the values in the order they were appended.
+func (l *AppendOnlyList[T]) Snapshot() []T {
+	chunks, n := l.view()
+	result := make([]T, 0, n)
+	for _, chunk := range chunks {
+		result = append(result, chunk...)
+	}
+
+	return result
+}
+
+// All returns an iterator over the indices and values of the list. The iteration covers the values appended
+// before All was called, while the values appended concurrently are not visited.
+func (l *AppendOnlyList[T]) All() iter.Seq2[int, T] {
+	chunks, _ := l.view()
+	return func(yield func(int, T) bool) {
+		i := 0
+		for _, chunk := range chunks {
+			for _, v := range chunk {
+				if !yield(i, v) {
+					return
+				}
+				i++
+			}
+		}
+	}
+}
+
+// view returns the chunks truncated to the values appended so far, so they can be read without the lock:
+// the values within the returned lengths are never modified.
+func (l *AppendOnlyList[T]) view() ([][]T, int) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	chunks := make([][]T, len(l.chunks))
+	for i, chunk := range l.chunks {
+		chunks[i] = chunk[:len(chunk):len(chunk)]
+	}
+
+	return chunks, l.len
+}
@@ -0,0 +1,100 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package uarray_test
+
+import (
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/kordax/basic-utils/uarray"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppendOnlyList(t *testing.T) {
+	var l uarray.AppendOnlyList[int]
+	assert.Zero(t, l.Len())
+	assert.Empty(t, l.Snapshot())
+
+	expected := make([]int, 0, 10000)
+	for i := range 10000 {
+		expected = append(expected, i)
+	}
+	l.Append(expected[:3]...)
+	l.Append()
+	l.Append(expected[3:5000]...) // spans several chunks
+	for _, v := range expected[5000:] {
+		l.Append(v)
+	}
+
+	assert.Equal(t, len(expected), l.Len())
+	assert.Equal(t, expected, l.Snapshot())
+
+	var iterated []int
+	for i, v := range l.All() {
+		require.Equal(t, len(iterated), i)
+		iterated = append(iterated, v)
+		if i == 99 {
+			break
+		}
+	}
+	assert.Equal(t, expected[:100], iterated)
+}
+
+func TestAppendOnlyList_Snapshot(t *testing.T) {
+	l := uarray.NewAppendOnlyList[string]()
+	l.Append("a", "b")
+	snapshot := l.Snapshot()
+	seq := l.All()
+	l.Append("c")
+	snapshot[0] = "x"
+
+	assert.Equal(t, []string{"a", "b", "c"}, l.Snapshot(), "modifying a snapshot must not affect the list")
+	var iterated []string
+	for _, v := range seq {
+		iterated = append(iterated, v)
+	}
+	assert.Equal(t, []string{"a", "b"}, iterated, "values appended after All must not be visited")
+}
+
+func TestAppendOnlyList_Concurrency(t *testing.T) {
+	l := uarray.NewAppendOnlyList[int]()
+	var wg sync.WaitGroup
+	for w := range 8 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for i := range 1000 {
+				l.Append(w*1000+i, -1)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for range 10 {
+				n := 0
+				for range l.All() {
+					n++
+				}
+				assert.LessOrEqual(t, n, l.Len())
+			}
+		}()
+	}
+	wg.Wait()
+
+	values := l.Snapshot()
+	require.Len(t, values, 16000)
+	positive := make([]int, 0, 8000)
+	for i := 0; i < len(values); i += 2 {
+		assert.Equal(t, -1, values[i+1], "the values of one Append must be kept together")
+		positive = append(positive, values[i])
+	}
+	sort.Ints(positive)
+	for i, v := range positive {
+		require.Equal(t, i, v)
+	}
+}
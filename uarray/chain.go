@@ -0,0 +1,148 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package uarray
+
+import "iter"
+
+// LazyChain is a lazy pipeline of slice operations.
+// Operations are fused into a single pass over the source, so no intermediate slices are allocated
+// and processing stops as soon as Take or First have got enough elements:
+//
+//	names := uarray.MapChain(uarray.Chain(users).Filter(isActive), toName).Take(10).Collect()
+//
+// Methods can't introduce new type parameters in Go, so type-changing mapping is provided by MapChain.
+// LazyChain is immutable, every operation returns a new chain and can be reused.
+type LazyChain[V any] struct {
+	seq iter.Seq[V]
+}
+
+// Chain creates a LazyChain over the values. The values are not copied, so they must not be modified until the chain is consumed.
+func Chain[V any](values []V) LazyChain[V] {
+	return LazyChain[V]{seq: func(yield func(V) bool) {
+		for _, v := range values {
+			if !yield(v) {
+				return
+			}
+		}
+	}}
+}
+
+// ChainSeq creates a LazyChain over the sequence.
+func ChainSeq[V any](seq iter.Seq[V]) LazyChain[V] {
+	return LazyChain[V]{seq: seq}
+}
+
+// MapChain returns a chain that maps every element of the chain to a different type.
+func MapChain[V, R any](c LazyChain[V], mapping func(v *V) R) LazyChain[R] {
+	return LazyChain[R]{seq: func(yield func(R) bool) {
+		for v := range c.seq {
+			if !yield(mapping(&v)) {
+				return
+			}
+		}
+	}}
+}
+
+// Filter returns a chain of the elements that match the filter.
+func (c LazyChain[V]) Filter(filter func(v *V) bool) LazyChain[V] {
+	return LazyChain[V]{seq: func(yield func(V) bool) {
+		for v := range c.seq {
+			if filter(&v) && !yield(v) {
+				return
+			}
+		}
+	}}
+}
+
+// Map returns a chain of the elements transformed with the mapping func. See MapChain to map to a different type.
+func (c LazyChain[V]) Map(mapping func(v *V) V) LazyChain[V] {
+	return MapChain(c, mapping)
+}
+
+// Take returns a chain of the first n elements at most.
+func (c LazyChain[V]) Take(n int) LazyChain[V] {
+	return LazyChain[V]{seq: func(yield func(V) bool) {
+		if n <= 0 {
+			return
+		}
+		taken := 0
+		for v := range c.seq {
+			taken++
+			if !yield(v) || taken >= n {
+				return
+			}
+		}
+	}}
+}
+
+// Skip returns a chain without the first n elements.
+func (c LazyChain[V]) Skip(n int) LazyChain[V] {
+	return LazyChain[V]{seq: func(yield func(V) bool) {
+		skipped := 0
+		for v := range c.seq {
+			if skipped < n {
+				skipped++
+				continue
+			}
+			if !yield(v) {
+				return
+			}
+		}
+	}}
+}
+
+// TakeWhile returns a chain of the leading elements that match the predicate.
+func (c LazyChain[V]) TakeWhile(predicate func(v *V) bool) LazyChain[V] {
+	return LazyChain[V]{seq: func(yield func(V) bool) {
+		for v := range c.seq {
+			if !predicate(&v) || !yield(v) {
+				return
+			}
+		}
+	}}
+}
+
+// Seq returns the chain as an iterator.
+func (c LazyChain[V]) Seq() iter.Seq[V] {
+	return c.seq
+}
+
+// Collect runs the chain and returns the resulting elements.
+func (c LazyChain[V]) Collect() []V {
+	result := make([]V, 0)
+	for v := range c.seq {
+		result = append(result, v)
+	}
+
+	return result
+}
+
+// ForEach runs the chain and calls the func for every resulting element.
+func (c LazyChain[V]) ForEach(f func(v *V)) {
+	for v := range c.seq {
+		f(&v)
+	}
+}
+
+// Count runs the chain and returns the number of resulting elements.
+func (c LazyChain[V]) Count() int {
+	count := 0
+	for range c.seq {
+		count++
+	}
+
+	return count
+}
+
+// First runs the chain until the first element and returns it, nil is returned if the chain is empty.
+func (c LazyChain[V]) First() *V {
+	for v := range c.seq {
+		return &v
+	}
+
+	return nil
+}
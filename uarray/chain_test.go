@@ -0,0 +1,68 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package uarray_test
+
+import (
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/kordax/basic-utils/uarray"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLazyChain(t *testing.T) {
+	values := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	isEven := func(v *int) bool { return *v%2 == 0 }
+	square := func(v *int) int { return *v * *v }
+
+	assert.Equal(t, []int{4, 16, 36}, uarray.Chain(values).Filter(isEven).Map(square).Take(3).Collect())
+	assert.Equal(t, []int{36, 64, 100}, uarray.Chain(values).Filter(isEven).Map(square).Skip(2).Collect())
+	assert.Equal(t, []int{1, 2, 3}, uarray.Chain(values).TakeWhile(func(v *int) bool { return *v < 4 }).Collect())
+	assert.Equal(t, 5, uarray.Chain(values).Filter(isEven).Count())
+	assert.Empty(t, uarray.Chain(values).Take(0).Collect())
+	assert.Equal(t, values, uarray.Chain(values).Take(100).Collect())
+	assert.Equal(t, []int{}, uarray.Chain([]int{}).Collect())
+
+	strs := uarray.MapChain(uarray.Chain(values).Filter(isEven), func(v *int) string { return strconv.Itoa(*v) }).Collect()
+	assert.Equal(t, []string{"2", "4", "6", "8", "10"}, strs)
+
+	first := uarray.Chain(values).Filter(func(v *int) bool { return *v > 7 }).First()
+	require.NotNil(t, first)
+	assert.Equal(t, 8, *first)
+	assert.Nil(t, uarray.Chain(values).Filter(func(v *int) bool { return *v > 10 }).First())
+
+	var seen []int
+	uarray.Chain(values).Skip(8).ForEach(func(v *int) { seen = append(seen, *v) })
+	assert.Equal(t, []int{9, 10}, seen)
+
+	assert.Equal(t, []int{3, 2, 1}, uarray.ChainSeq(slices.Values([]int{3, 2, 1})).Collect())
+	assert.Equal(t, []int{2, 4}, slices.Collect(uarray.Chain(values).Filter(isEven).Take(2).Seq()))
+}
+
+func TestLazyChain_Laziness(t *testing.T) {
+	values := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+
+	calls := 0
+	result := uarray.Chain(values).Map(func(v *int) int {
+		calls++
+		return *v * 10
+	}).Take(2).Collect()
+	assert.Equal(t, []int{10, 20}, result)
+	assert.Equal(t, 2, calls, "elements after Take must not be processed")
+
+	calls = 0
+	chain := uarray.Chain(values).Filter(func(v *int) bool {
+		calls++
+		return true
+	})
+	assert.Equal(t, 0, calls, "nothing must be processed until the chain is consumed")
+	assert.Equal(t, 10, chain.Count())
+	assert.Equal(t, 10, chain.Count(), "chain must be reusable")
+	assert.Equal(t, 20, calls)
+}
@@ -0,0 +1,131 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package uarray
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+)
+
+var (
+	// ErrInvalidPageSize is returned when the number of items per page is not positive.
+	ErrInvalidPageSize = errors.New("uarray: page size must be positive")
+	// ErrPageOutOfRange is returned when the requested page doesn't exist.
+	ErrPageOutOfRange = errors.New("uarray: page out of range")
+)
+
+// Paginate returns the items of the page of the values along with the total number of values and pages,
+// which is what in-memory paginated API handlers usually respond with.
+// Pages are numbered from 1. Empty values have no pages, but the first page is still valid and has no items.
+// Returns ErrInvalidPageSize if perPage is not positive and ErrPageOutOfRange if the page doesn't exist.
+// The items share the memory with the values, but their capacity is capped, so appending to them doesn't overwrite the values.
+//
+// Example usage:
+//
+//	items, total, pages, err := uarray.Paginate(users, req.Page, req.PerPage)
+//	if err != nil {
+//		return nil, status.Error(codes.InvalidArgument, err.Error())
+//	}
+func Paginate[T any](values []T, page, perPage int) (items []T, total, pages int, err error) {
+	if perPage <= 0 {
+		return nil, 0, 0, fmt.Errorf("%w: %d", ErrInvalidPageSize, perPage)
+	}
+	total = len(values)
+	pages = pageCount(total, perPage)
+	if page < 1 || page > max(pages, 1) {
+		return nil, total, pages, fmt.Errorf("%w: page %d of %d", ErrPageOutOfRange, page, pages)
+	}
+
+	start := min((page-1)*perPage, total)
+	end := start + min(perPage, total-start)
+
+	return values[start:end:end], total, pages, nil
+}
+
+// pageCount returns the number of pages of perPage items needed to fit total items, without overflowing for large perPage.
+func pageCount(total, perPage int) int {
+	pages := total / perPage
+	if total%perPage != 0 {
+		pages++
+	}
+
+	return pages
+}
+
+// PageIterator consumes the values page by page.
+//
+// Example usage:
+//
+//	it, err := uarray.NewPageIterator(records, 500)
+//	if err != nil {
+//		return err
+//	}
+//	for it.Next() {
+//		if err := client.Upload(it.Items()); err != nil {
+//			return fmt.Errorf("page %d: %w", it.Page(), err)
+//		}
+//	}
+type PageIterator[T any] struct {
+	values  []T
+	perPage int
+	page    int
+	items   []T
+}
+
+// NewPageIterator creates a new PageIterator positioned before the first page.
+// Returns ErrInvalidPageSize if perPage is not positive.
+func NewPageIterator[T any](values []T, perPage int) (*PageIterator[T], error) {
+	if perPage <= 0 {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidPageSize, perPage)
+	}
+
+	return &PageIterator[T]{values: values, perPage: perPage}, nil
+}
+
+// Next advances the iterator to the next page and reports whether it exists.
+func (it *PageIterator[T]) Next() bool {
+	if it.page >= it.Pages() {
+		it.items = nil
+		return false
+	}
+	it.page++
+	it.items, _, _, _ = Paginate(it.values, it.page, it.perPage)
+
+	return true
+}
+
+// Page returns the number of the current page starting from 1, or 0 if Next wasn't called yet.
+func (it *PageIterator[T]) Page() int {
+	return it.page
+}
+
+// Items returns the items of the current page.
+func (it *PageIterator[T]) Items() []T {
+	return it.items
+}
+
+// Total returns the total number of values.
+func (it *PageIterator[T]) Total() int {
+	return len(it.values)
+}
+
+// Pages returns the total number of pages.
+func (it *PageIterator[T]) Pages() int {
+	return pageCount(len(it.values), it.perPage)
+}
+
+// All returns an iterator over the remaining pages yielding the page numbers and their items.
+func (it *PageIterator[T]) All() iter.Seq2[int, []T] {
+	return func(yield func(int, []T) bool) {
+		for it.Next() {
+			if !yield(it.page, it.items) {
+				return
+			}
+		}
+	}
+}
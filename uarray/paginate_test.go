@@ -0,0 +1,103 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package uarray_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/kordax/basic-utils/uarray"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPaginate(t *testing.T) {
+	values := uarray.Range(0, 7)
+
+	items, total, pages, err := uarray.Paginate(values, 1, 3)
+	require.NoError(t, err)
+	assert.Equal(t, []int{0, 1, 2}, items)
+	assert.Equal(t, 7, total)
+	assert.Equal(t, 3, pages)
+
+	items, _, _, err = uarray.Paginate(values, 3, 3)
+	require.NoError(t, err)
+	assert.Equal(t, []int{6}, items)
+
+	items, _, _, _ = uarray.Paginate(values, 2, 3)
+	assert.Equal(t, []int{3, 4, 5}, items)
+	_ = append(items, 100)
+	assert.Equal(t, 6, values[6], "appending to a page must not overwrite the values")
+
+	items, total, pages, err = uarray.Paginate([]int{}, 1, 10)
+	require.NoError(t, err)
+	assert.Empty(t, items)
+	assert.Zero(t, total)
+	assert.Zero(t, pages)
+
+	_, total, pages, err = uarray.Paginate(values, 4, 3)
+	assert.ErrorIs(t, err, uarray.ErrPageOutOfRange)
+	assert.Equal(t, 7, total)
+	assert.Equal(t, 3, pages)
+	_, _, _, err = uarray.Paginate(values, 0, 3)
+	assert.ErrorIs(t, err, uarray.ErrPageOutOfRange)
+	_, _, _, err = uarray.Paginate(values, 1, 0)
+	assert.ErrorIs(t, err, uarray.ErrInvalidPageSize)
+
+	items, total, pages, err = uarray.Paginate([]int{1, 2, 3, 4, 5}, 1, math.MaxInt)
+	require.NoError(t, err, "large page sizes must not overflow")
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, items)
+	assert.Equal(t, 5, total)
+	assert.Equal(t, 1, pages)
+}
+
+func TestPageIterator(t *testing.T) {
+	it, err := uarray.NewPageIterator(uarray.Range(0, 5), 2)
+	require.NoError(t, err)
+	assert.Equal(t, 5, it.Total())
+	assert.Equal(t, 3, it.Pages())
+	assert.Zero(t, it.Page())
+
+	var pages [][]int
+	for it.Next() {
+		assert.Equal(t, len(pages)+1, it.Page())
+		pages = append(pages, it.Items())
+	}
+	assert.Equal(t, [][]int{{0, 1}, {2, 3}, {4}}, pages)
+	assert.False(t, it.Next())
+	assert.Nil(t, it.Items())
+
+	it, err = uarray.NewPageIterator(uarray.Range(0, 5), math.MaxInt)
+	require.NoError(t, err)
+	assert.Equal(t, 1, it.Pages())
+	require.True(t, it.Next())
+	assert.Equal(t, []int{0, 1, 2, 3, 4}, it.Items())
+	assert.False(t, it.Next())
+
+	it, err = uarray.NewPageIterator(uarray.Range(0, 5), 2)
+	require.NoError(t, err)
+	var numbers []int
+	for page, items := range it.All() {
+		numbers = append(numbers, page)
+		assert.NotEmpty(t, items)
+		if page == 2 {
+			break
+		}
+	}
+	assert.Equal(t, []int{1, 2}, numbers)
+	for page := range it.All() {
+		numbers = append(numbers, page)
+	}
+	assert.Equal(t, []int{1, 2, 3}, numbers, "All must continue from the current page")
+
+	it, err = uarray.NewPageIterator([]int{}, 2)
+	require.NoError(t, err)
+	assert.False(t, it.Next())
+
+	_, err = uarray.NewPageIterator([]int{1}, -1)
+	assert.ErrorIs(t, err, uarray.ErrInvalidPageSize)
+}
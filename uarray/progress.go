@@ -0,0 +1,107 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package uarray
+
+// Progress describes the state of a long-running transform reported by the WithProgress functions.
+type Progress struct {
+	Done  int // Done is the number of processed elements.
+	Total int // Total is the number of elements to process.
+}
+
+// Percent returns the completion percentage in the [0, 100] range. An empty transform is reported as complete.
+func (p Progress) Percent() float64 {
+	if p.Total == 0 {
+		return 100
+	}
+
+	return float64(p.Done) * 100 / float64(p.Total)
+}
+
+// progressReporter calls the callback every n processed elements and once the transform is complete.
+type progressReporter struct {
+	total    int
+	every    int
+	next     int
+	callback func(p Progress)
+}
+
+// newProgressReporter creates a reporter. If every is not positive, the progress is reported every percent.
+func newProgressReporter(total, every int, callback func(p Progress)) *progressReporter {
+	if every <= 0 {
+		every = max(total/100, 1)
+	}
+
+	return &progressReporter{total: total, every: every, next: every, callback: callback}
+}
+
+func (r *progressReporter) advance(done int) {
+	if done >= r.next && done < r.total {
+		r.callback(Progress{Done: done, Total: r.total})
+		r.next = done - done%r.every + r.every
+	}
+}
+
+func (r *progressReporter) complete() {
+	r.callback(Progress{Done: r.total, Total: r.total})
+}
+
+// MapWithProgress works like Map, but calls progress every n mapped elements and once the mapping is complete.
+// If n is not positive, the progress is reported every percent.
+//
+// Example usage:
+//
+//	MapWithProgress(rows, convert, 10_000, func(p Progress) {
+//		fmt.Printf("\r%.1f%%", p.Percent())
+//	})
+func MapWithProgress[V, R any](values []V, m func(v *V) R, n int, progress func(p Progress)) []R {
+	reporter := newProgressReporter(len(values), n, progress)
+	result := make([]R, len(values))
+	for i, v := range values {
+		result[i] = m(&v)
+		reporter.advance(i + 1)
+	}
+	reporter.complete()
+
+	return result
+}
+
+// FilterWithProgress works like Filter, but calls progress every n tested elements and once the filtering is complete.
+// If n is not positive, the progress is reported every percent.
+func FilterWithProgress[V any](values []V, filter func(v *V) bool, n int, progress func(p Progress)) []V {
+	reporter := newProgressReporter(len(values), n, progress)
+	result := make([]V, 0, len(values))
+	for i, v := range values {
+		if filter(&v) {
+			result = append(result, v)
+		}
+		reporter.advance(i + 1)
+	}
+	reporter.complete()
+
+	return result
+}
+
+// SplitWithProgress works like Split, but calls progress after every n elements split into chunks and once the split is complete.
+// If n is not positive, the progress is reported every percent. As chunks are processed at once, the reported counts
+// are multiples of chunkSize.
+func SplitWithProgress[T any](slice []T, chunkSize int, n int, progress func(p Progress)) [][]T {
+	reporter := newProgressReporter(len(slice), n, progress)
+	if chunkSize <= 0 {
+		reporter.complete()
+		return [][]T{slice}
+	}
+
+	chunks := make([][]T, 0, (len(slice)+chunkSize-1)/chunkSize)
+	for i := 0; i < len(slice); i += chunkSize {
+		end := min(i+chunkSize, len(slice))
+		chunks = append(chunks, slice[i:end])
+		reporter.advance(end)
+	}
+	reporter.complete()
+
+	return chunks
+}
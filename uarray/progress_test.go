@@ -0,0 +1,62 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package uarray_test
+
+import (
+	"testing"
+
+	"github.com/kordax/basic-utils/uarray"
+	"github.com/stretchr/testify/assert"
+)
+
+func collectProgress(reports *[]uarray.Progress) func(p uarray.Progress) {
+	return func(p uarray.Progress) {
+		*reports = append(*reports, p)
+	}
+}
+
+func TestMapWithProgress(t *testing.T) {
+	var reports []uarray.Progress
+	result := uarray.MapWithProgress(uarray.Range(0, 10), func(v *int) int { return *v * 2 }, 4, collectProgress(&reports))
+
+	assert.Equal(t, []int{0, 2, 4, 6, 8, 10, 12, 14, 16, 18}, result)
+	assert.Equal(t, []uarray.Progress{{4, 10}, {8, 10}, {10, 10}}, reports)
+	assert.Equal(t, 100.0, reports[len(reports)-1].Percent())
+	assert.Equal(t, 40.0, reports[0].Percent())
+}
+
+func TestMapWithProgress_DefaultEvery(t *testing.T) {
+	var reports []uarray.Progress
+	uarray.MapWithProgress(make([]int, 1000), func(v *int) int { return *v }, 0, collectProgress(&reports))
+	assert.Len(t, reports, 100)
+
+	reports = nil
+	uarray.MapWithProgress([]int{}, func(v *int) int { return *v }, 0, collectProgress(&reports))
+	assert.Equal(t, []uarray.Progress{{0, 0}}, reports)
+	assert.Equal(t, 100.0, reports[0].Percent())
+}
+
+func TestFilterWithProgress(t *testing.T) {
+	var reports []uarray.Progress
+	result := uarray.FilterWithProgress(uarray.Range(0, 6), func(v *int) bool { return *v%2 == 0 }, 3, collectProgress(&reports))
+
+	assert.Equal(t, []int{0, 2, 4}, result)
+	assert.Equal(t, []uarray.Progress{{3, 6}, {6, 6}}, reports)
+}
+
+func TestSplitWithProgress(t *testing.T) {
+	var reports []uarray.Progress
+	chunks := uarray.SplitWithProgress(uarray.Range(0, 10), 3, 5, collectProgress(&reports))
+
+	assert.Equal(t, uarray.Split(uarray.Range(0, 10), 3), chunks)
+	assert.Equal(t, []uarray.Progress{{6, 10}, {10, 10}}, reports)
+
+	reports = nil
+	chunks = uarray.SplitWithProgress([]int{1, 2}, 0, 1, collectProgress(&reports))
+	assert.Equal(t, [][]int{{1, 2}}, chunks)
+	assert.Equal(t, []uarray.Progress{{2, 2}}, reports)
+}
@@ -0,0 +1,132 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package uarray
+
+import (
+	"sync"
+)
+
+// RingBuffer keeps the last N pushed values, overwriting the oldest ones once it is full,
+// e.g. to remember the recent errors or the rolling latency samples.
+// The buffer returned by NewRingBuffer is not thread-safe, use NewSyncRingBuffer to share it between goroutines.
+type RingBuffer[T any] struct {
+	mtx      *sync.Mutex // nil unless synchronized
+	values   []T
+	head     int // the index of the oldest value once the buffer is full
+	capacity int // immutable, so it's read without the lock
+}
+
+// NewRingBuffer creates an empty RingBuffer of the fixed capacity. It panics if the capacity is not positive.
+func NewRingBuffer[T any](capacity int) *RingBuffer[T] {
+	if capacity <= 0 {
+		panic("uarray: ring buffer capacity must be positive")
+	}
+
+	return &RingBuffer[T]{values: make([]T, 0, capacity), capacity: capacity}
+}
+
+// NewSyncRingBuffer is the same as NewRingBuffer, but all the operations of the returned buffer are thread-safe.
+func NewSyncRingBuffer[T any](capacity int) *RingBuffer[T] {
+	b := NewRingBuffer[T](capacity)
+	b.mtx = new(sync.Mutex)
+
+	return b
+}
+
+// Push appends the values, overwriting the oldest ones if the buffer is full.
+func (b *RingBuffer[T]) Push(values ...T) {
+	b.lock()
+	defer b.unlock()
+
+	for _, v := range values {
+		if len(b.values) < b.capacity {
+			b.values = append(b.values, v)
+			continue
+		}
+		b.values[b.head] = v
+		b.head = (b.head + 1) % len(b.values)
+	}
+}
+
+// Snapshot returns a copy of the values from the oldest to the newest.
+func (b *RingBuffer[T]) Snapshot() []T {
+	b.lock()
+	defer b.unlock()
+
+	result := make([]T, len(b.values))
+	copy(result, b.values)
+	Rotate(result, b.head)
+
+	return result
+}
+
+// Oldest returns the oldest value, or false if the buffer is empty.
+func (b *RingBuffer[T]) Oldest() (T, bool) {
+	b.lock()
+	defer b.unlock()
+
+	if len(b.values) == 0 {
+		var zero T
+		return zero, false
+	}
+	return b.values[b.head], true
+}
+
+// Newest returns the most recently pushed value, or false if the buffer is empty.
+func (b *RingBuffer[T]) Newest() (T, bool) {
+	b.lock()
+	defer b.unlock()
+
+	if len(b.values) == 0 {
+		var zero T
+		return zero, false
+	}
+	return b.values[(b.head+len(b.values)-1)%len(b.values)], true
+}
+
+// Len returns the number of values in the buffer.
+func (b *RingBuffer[T]) Len() int {
+	b.lock()
+	defer b.unlock()
+
+	return len(b.values)
+}
+
+// Cap returns the capacity of the buffer.
+func (b *RingBuffer[T]) Cap() int {
+	return b.capacity
+}
+
+// Full reports whether the next Push overwrites the oldest value.
+func (b *RingBuffer[T]) Full() bool {
+	b.lock()
+	defer b.unlock()
+
+	return len(b.values) == b.capacity
+}
+
+// Clear removes all the values, keeping the capacity.
+func (b *RingBuffer[T]) Clear() {
+	b.lock()
+	defer b.unlock()
+
+	clear(b.values)
+	b.values = b.values[:0]
+	b.head = 0
+}
+
+func (b *RingBuffer[T]) lock() {
+	if b.mtx != nil {
+		b.mtx.Lock()
+	}
+}
+
+func (b *RingBuffer[T]) unlock() {
+	if b.mtx != nil {
+		b.mtx.Unlock()
+	}
+}
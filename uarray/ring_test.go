@@ -0,0 +1,78 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package uarray_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/kordax/basic-utils/uarray"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRingBuffer(t *testing.T) {
+	b := uarray.NewRingBuffer[int](3)
+	assert.Empty(t, b.Snapshot())
+	_, ok := b.Oldest()
+	assert.False(t, ok)
+	_, ok = b.Newest()
+	assert.False(t, ok)
+
+	b.Push(1, 2)
+	assert.Equal(t, []int{1, 2}, b.Snapshot())
+	assert.False(t, b.Full())
+
+	b.Push(3, 4, 5)
+	assert.Equal(t, []int{3, 4, 5}, b.Snapshot())
+	assert.True(t, b.Full())
+	assert.Equal(t, 3, b.Len())
+	assert.Equal(t, 3, b.Cap())
+
+	oldest, ok := b.Oldest()
+	assert.True(t, ok)
+	assert.Equal(t, 3, oldest)
+	newest, ok := b.Newest()
+	assert.True(t, ok)
+	assert.Equal(t, 5, newest)
+
+	b.Push(6)
+	assert.Equal(t, []int{4, 5, 6}, b.Snapshot())
+
+	snapshot := b.Snapshot()
+	snapshot[0] = 100
+	assert.Equal(t, []int{4, 5, 6}, b.Snapshot(), "Snapshot must return a copy")
+
+	b.Clear()
+	assert.Zero(t, b.Len())
+	b.Push(7)
+	assert.Equal(t, []int{7}, b.Snapshot())
+
+	assert.Panics(t, func() { uarray.NewRingBuffer[int](0) })
+}
+
+func TestSyncRingBuffer(t *testing.T) {
+	b := uarray.NewSyncRingBuffer[int](100)
+
+	var wg sync.WaitGroup
+	for g := range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 1000 {
+				b.Push(g*1000 + i)
+				_ = b.Snapshot()
+				_ = b.Full()
+				_ = b.Cap()
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 100, b.Len())
+	assert.Len(t, b.Snapshot(), 100)
+	assert.True(t, b.Full())
+}
@@ -7,13 +7,19 @@
 package uarray
 
 import (
+	"cmp"
+	"context"
+	"fmt"
+	"math"
 	"slices"
 	"sort"
 	"strings"
 
 	"github.com/kordax/basic-utils/ucast"
 	"github.com/kordax/basic-utils/uconst"
-	"golang.org/x/exp/constraints"
+	"github.com/kordax/basic-utils/uerror"
+	"github.com/kordax/basic-utils/uopt"
+	"github.com/kordax/basic-utils/uorderedmap"
 	"golang.org/x/exp/maps"
 )
 
@@ -54,6 +60,28 @@ func ContainsPredicate[T any](values []T, predicate func(v *T) bool) (int, *T) {
 	return -1, nil
 }
 
+// IndexBy returns the index of the first element matching the predicate, -1 otherwise.
+func IndexBy[T any](values []T, predicate func(v *T) bool) int {
+	for i := range values {
+		if predicate(&values[i]) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// LastIndexBy returns the index of the last element matching the predicate, -1 otherwise.
+func LastIndexBy[T any](values []T, predicate func(v *T) bool) int {
+	for i := len(values) - 1; i >= 0; i-- {
+		if predicate(&values[i]) {
+			return i
+		}
+	}
+
+	return -1
+}
+
 // ContainsStruct checks if slice contains specified struct element.
 // Returns its index and value if found, -1 and nil otherwise.
 func ContainsStruct[K comparable, V Indexed[K]](values []V, val V) (int, *V) {
@@ -122,7 +150,7 @@ func Filter[V any](values []V, filter func(v *V) bool) []V {
 	if len(values) == 0 {
 		return []V{}
 	}
-	result := make([]V, 0)
+	result := make([]V, 0, len(values))
 	for _, v := range values {
 		if filter(&v) {
 			result = append(result, v)
@@ -132,6 +160,68 @@ func Filter[V any](values []V, filter func(v *V) bool) []V {
 	return result
 }
 
+// Compact returns a new slice with the nil pointers removed.
+func Compact[T any](values []*T) []*T {
+	return Filter(values, func(v **T) bool {
+		return *v != nil
+	})
+}
+
+// CompactOpt returns the values of the present options, skipping the absent ones.
+func CompactOpt[T any](values []uopt.Opt[T]) []T {
+	result := make([]T, 0, len(values))
+	for _, v := range values {
+		if p := v.Get(); p != nil {
+			result = append(result, *p)
+		}
+	}
+
+	return result
+}
+
+// NonZero returns a new slice with the zero values removed.
+func NonZero[T comparable](values []T) []T {
+	var zero T
+	return Filter(values, func(v *T) bool {
+		return *v != zero
+	})
+}
+
+// FilterInPlace works like Filter, but compacts the matching elements at the beginning of the slice
+// and truncates it instead of allocating a new one.
+// The original backing array is reused, so any other slices sharing it observe the reordered elements.
+// The elements past the new length are zeroed, so they don't keep references alive.
+func FilterInPlace[V any](values *[]V, filter func(v *V) bool) {
+	s := *values
+	n := 0
+	for i := range s {
+		if filter(&s[i]) {
+			s[n] = s[i]
+			n++
+		}
+	}
+	clear(s[n:])
+	*values = s[:n]
+}
+
+// FilterErr works like Filter, but the filter function can fail.
+// Filtering stops at the first error, which is returned as *uerror.IndexedError holding the index of the failed element,
+// along with the elements matched so far.
+func FilterErr[V any](values []V, filter func(v *V) (bool, error)) ([]V, error) {
+	result := make([]V, 0, len(values))
+	for i, v := range values {
+		ok, err := filter(&v)
+		if err != nil {
+			return result, &uerror.IndexedError{Index: i, Err: err}
+		}
+		if ok {
+			result = append(result, v)
+		}
+	}
+
+	return result, nil
+}
+
 // FilterAll filters values slice and returns a copy with filtered elements matching a predicate and elements that do not match any filter.
 // Returns its index if found, -1 otherwise.
 func FilterAll[V any](values []V, filter func(v *V) bool) ([]V, []V) {
@@ -152,6 +242,32 @@ func FilterAll[V any](values []V, filter func(v *V) bool) ([]V, []V) {
 	return result, nonMatching
 }
 
+// TakeWhile returns a copy of the leading elements matching the predicate, stopping at the first non-matching one.
+func TakeWhile[V any](values []V, predicate func(v *V) bool) []V {
+	head, _ := SplitBy(values, predicate)
+	return head
+}
+
+// DropWhile returns a copy of the elements following the leading elements that match the predicate.
+func DropWhile[V any](values []V, predicate func(v *V) bool) []V {
+	_, tail := SplitBy(values, predicate)
+	return tail
+}
+
+// SplitBy cuts values at the first element that doesn't match the predicate and returns copies of both parts.
+// Unlike FilterAll, elements after the cut are not tested, e.g. SplitBy([1, 2, 5, 1], v < 3) returns [1, 2] and [5, 1].
+func SplitBy[V any](values []V, predicate func(v *V) bool) ([]V, []V) {
+	i := 0
+	for ; i < len(values); i++ {
+		v := values[i]
+		if !predicate(&v) {
+			break
+		}
+	}
+
+	return append([]V{}, values[:i]...), append([]V{}, values[i:]...)
+}
+
 // FilterBySet filters values slice and returns a copy with filtered elements matching values from filter.
 // Returns its index if found, -1 otherwise.
 func FilterBySet[V comparable](values []V, filter ...V) []V {
@@ -246,6 +362,71 @@ func SortFind[V any](values []V, less func(a, b V) bool, filter func(*V) bool) *
 	return Find(sortedValues, filter)
 }
 
+// Comparator compares two values and returns a negative number if a < b, a positive number if a > b and zero otherwise.
+type Comparator[V any] func(a, b *V) int
+
+// By returns a Comparator that orders values by the key in ascending order.
+func By[V any, K uconst.Ordered](key func(v *V) K) Comparator[V] {
+	return func(a, b *V) int {
+		return cmp.Compare(key(a), key(b))
+	}
+}
+
+// ByDesc returns a Comparator that orders values by the key in descending order.
+func ByDesc[V any, K uconst.Ordered](key func(v *V) K) Comparator[V] {
+	return func(a, b *V) int {
+		return cmp.Compare(key(b), key(a))
+	}
+}
+
+// Then returns a Comparator that uses next to order the values that are equal according to c.
+func (c Comparator[V]) Then(next Comparator[V]) Comparator[V] {
+	return func(a, b *V) int {
+		if r := c(a, b); r != 0 {
+			return r
+		}
+		return next(a, b)
+	}
+}
+
+// ThenBy is a shorthand for c.Then(By(key)).
+func ThenBy[V any, K uconst.Ordered](c Comparator[V], key func(v *V) K) Comparator[V] {
+	return c.Then(By(key))
+}
+
+// ThenByDesc is a shorthand for c.Then(ByDesc(key)).
+func ThenByDesc[V any, K uconst.Ordered](c Comparator[V], key func(v *V) K) Comparator[V] {
+	return c.Then(ByDesc(key))
+}
+
+// SortBy sorts the values in place in ascending order of the key. The sort is not guaranteed to be stable.
+func SortBy[V any, K uconst.Ordered](values []V, key func(v *V) K) {
+	Sort(values, By(key))
+}
+
+// SortStableBy sorts the values in place in ascending order of the key, keeping the original order of equal elements.
+func SortStableBy[V any, K uconst.Ordered](values []V, key func(v *V) K) {
+	SortStable(values, By(key))
+}
+
+// Sort sorts the values in place using the comparator. The sort is not guaranteed to be stable.
+//
+// Example:
+//
+//	uarray.Sort(users, uarray.ThenByDesc(uarray.By(func(u *User) string { return u.Name }), func(u *User) int { return u.Age }))
+func Sort[V any](values []V, c Comparator[V]) {
+	slices.SortFunc(values, func(a, b V) int {
+		return c(&a, &b)
+	})
+}
+
+// SortStable sorts the values in place using the comparator, keeping the original order of equal elements.
+func SortStable[V any](values []V, c Comparator[V]) {
+	slices.SortStableFunc(values, func(a, b V) int {
+		return c(&a, &b)
+	})
+}
+
 // Find finds the first match in a sorted slice using binary search.
 // The slice must be sorted for binary search to work correctly.
 // The filter function should implement a comparison suitable for binary search.
@@ -258,6 +439,40 @@ func Find[V any](values []V, filter func(v *V) bool) *V {
 	return nil
 }
 
+// FindLast returns a pointer to the last element that matches the filter, nil otherwise.
+func FindLast[V any](values []V, filter func(v *V) bool) *V {
+	if i := LastIndexBy(values, filter); i >= 0 {
+		return &values[i]
+	}
+
+	return nil
+}
+
+// FindBinary finds the first element of the sorted values matching the predicate using binary search.
+// The predicate must be false for a prefix of the values and true for the rest of them, e.g. v >= target
+// for the values sorted in ascending order. Returns the index of the element and a pointer to it,
+// len(values) and nil if no element matches, so the index is always where a matching element would be inserted.
+//
+// Example:
+//
+//	i, user := uarray.FindBinary(usersByAge, func(u *User) bool { return u.Age >= 18 })
+func FindBinary[V any](values []V, predicate func(v *V) bool) (int, *V) {
+	lo, hi := 0, len(values)
+	for lo < hi {
+		mid := int(uint(lo+hi) >> 1)
+		if predicate(&values[mid]) {
+			hi = mid
+		} else {
+			lo = mid + 1
+		}
+	}
+	if lo == len(values) {
+		return lo, nil
+	}
+
+	return lo, &values[lo]
+}
+
 // MapAggr maps a func to each set of elements and returns an aggregated result.
 func MapAggr[V, R any](values []V, aggr func(v *V) []R) []R {
 	result := make([]R, 0)
@@ -270,35 +485,223 @@ func MapAggr[V, R any](values []V, aggr func(v *V) []R) []R {
 
 // Map maps a func and returns a result.
 func Map[V, R any](values []V, m func(v *V) R) []R {
-	result := make([]R, 0)
-	for _, v := range values {
-		result = append(result, m(&v))
+	result := make([]R, len(values))
+	for i, v := range values {
+		result[i] = m(&v)
 	}
 
 	return result
 }
 
+// MapErr works like Map, but the mapping function can fail.
+// Mapping stops at the first error, which is returned as *uerror.IndexedError holding the index of the failed element,
+// along with the results of the elements mapped so far.
+func MapErr[V, R any](values []V, m func(v *V) (R, error)) ([]R, error) {
+	result := make([]R, 0, len(values))
+	for i, v := range values {
+		r, err := m(&v)
+		if err != nil {
+			return result, &uerror.IndexedError{Index: i, Err: err}
+		}
+		result = append(result, r)
+	}
+
+	return result, nil
+}
+
+// ForEachErr calls f for every element until f returns an error. f receives a pointer to the slice element itself.
+// The error is returned as *uerror.IndexedError holding the index of the failed element.
+func ForEachErr[V any](values []V, f func(v *V) error) error {
+	for i := range values {
+		if err := f(&values[i]); err != nil {
+			return &uerror.IndexedError{Index: i, Err: err}
+		}
+	}
+
+	return nil
+}
+
+// ValidateEach calls validate for every element and returns the errors aggregated into *uerror.Multi,
+// each one wrapped into *uerror.IndexedError holding the element index, in ascending order of the indices.
+// Unlike ForEachErr it doesn't stop at the first error, so all the invalid elements are reported at once,
+// e.g. to return them all in a response to a bulk request.
+// Returns nil if all the elements are valid. validate receives a pointer to the slice element itself.
+func ValidateEach[V any](values []V, validate func(v *V) error) error {
+	var errs uerror.Multi
+	for i := range values {
+		errs.AppendAt(i, validate(&values[i]))
+	}
+
+	return errs.ErrorOrNil()
+}
+
+// ValidateAll reports whether validate succeeds for all the elements. It stops at the first invalid element,
+// use ValidateEach to find all of them. validate receives a pointer to the slice element itself.
+func ValidateAll[V any](values []V, validate func(v *V) error) bool {
+	for i := range values {
+		if validate(&values[i]) != nil {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Rotate rotates the values in place by n positions to the left, so the element at index n becomes the first one.
+// Negative n rotates to the right, n may exceed the length of the slice.
+//
+//	values := []int{1, 2, 3, 4, 5}
+//	uarray.Rotate(values, 2) // [3 4 5 1 2]
+func Rotate[V any](values []V, n int) {
+	if len(values) == 0 {
+		return
+	}
+	n %= len(values)
+	if n < 0 {
+		n += len(values)
+	}
+	if n == 0 {
+		return
+	}
+	slices.Reverse(values[:n])
+	slices.Reverse(values[n:])
+	slices.Reverse(values)
+}
+
 // FlatMap applies the Map method and the Flat method consequently.
 func FlatMap[V, R any](values [][]V, m func(v *V) R) []R {
 	flatten := Flat(values)
-	result := make([]R, 0)
-	for _, v := range flatten {
-		result = append(result, m(&v))
+	result := make([]R, len(flatten))
+	for i, v := range flatten {
+		result[i] = m(&v)
 	}
 
 	return result
 }
 
+// ctxCheckInterval is the number of elements processed between the context checks of the context-aware functions,
+// checking it on every element would cost more than the mapping itself for cheap mapping functions.
+const ctxCheckInterval = 1024
+
+// MapCtx works like Map, but the mapping function can fail and the context is checked every 1024 elements,
+// so mapping huge slices can be aborted once the deadline is exceeded.
+// Mapping stops at the first error: a mapping error is returned as *uerror.IndexedError holding the element index,
+// the context error is returned as is. In both cases the results of the elements mapped so far are returned.
+func MapCtx[V, R any](ctx context.Context, values []V, m func(v *V) (R, error)) ([]R, error) {
+	result := make([]R, 0, len(values))
+	for i := range values {
+		if i%ctxCheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return result, err
+			}
+		}
+		r, err := m(&values[i])
+		if err != nil {
+			return result, &uerror.IndexedError{Index: i, Err: err}
+		}
+		result = append(result, r)
+	}
+
+	return result, nil
+}
+
+// FlatMapCtx is a context-aware FlatMap with a failing mapping function, see MapCtx for the error handling.
+// The indices of *uerror.IndexedError refer to the flattened values. Unlike FlatMap, the values aren't copied to be flattened.
+func FlatMapCtx[V, R any](ctx context.Context, values [][]V, m func(v *V) (R, error)) ([]R, error) {
+	size := 0
+	for _, v := range values {
+		size += len(v)
+	}
+
+	result := make([]R, 0, size)
+	for _, chunk := range values {
+		for i := range chunk {
+			if len(result)%ctxCheckInterval == 0 {
+				if err := ctx.Err(); err != nil {
+					return result, err
+				}
+			}
+			r, err := m(&chunk[i])
+			if err != nil {
+				return result, &uerror.IndexedError{Index: len(result), Err: err}
+			}
+			result = append(result, r)
+		}
+	}
+
+	return result, nil
+}
+
+// MapAggrCtx is a context-aware MapAggr with a failing aggregation function, see MapCtx for the error handling.
+func MapAggrCtx[V, R any](ctx context.Context, values []V, aggr func(v *V) ([]R, error)) ([]R, error) {
+	result := make([]R, 0)
+	for i := range values {
+		if i%ctxCheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return result, err
+			}
+		}
+		r, err := aggr(&values[i])
+		if err != nil {
+			return result, &uerror.IndexedError{Index: i, Err: err}
+		}
+		result = append(result, r...)
+	}
+
+	return result, nil
+}
+
 // Flat flattens the stream (slice).
 func Flat[V any](values [][]V) []V {
-	result := make([]V, 0)
-	for _, v := range values {
-		result = append(result, v...)
+	return Concat(values...)
+}
+
+// Concat joins all the provided slices into a new slice in the order they were passed.
+// Unlike a chain of append calls, the result is allocated only once with the exact total length.
+// Always returns a non-nil slice.
+func Concat[V any](slices ...[]V) []V {
+	size := 0
+	for _, s := range slices {
+		size += len(s)
+	}
+
+	result := make([]V, 0, size)
+	for _, s := range slices {
+		result = append(result, s...)
 	}
 
 	return result
 }
 
+// Grow guarantees that another n elements can be appended to the slice without an allocation.
+// If the slice already has enough spare capacity it is returned as is, otherwise a new slice with the same
+// elements and an increased capacity is returned. The length of the slice is never changed.
+// Panics if n is negative.
+func Grow[V any](slice []V, n int) []V {
+	if n < 0 {
+		panic("Grow n must be a non-negative value")
+	}
+	if cap(slice)-len(slice) >= n {
+		return slice
+	}
+
+	grown := make([]V, len(slice), len(slice)+n)
+	copy(grown, slice)
+
+	return grown
+}
+
+// EnsureCapacity guarantees that the slice capacity is at least capacity elements.
+// This is the same as Grow, but accepts an absolute capacity instead of the number of elements to be appended.
+// The length of the slice is never changed.
+func EnsureCapacity[V any](slice []V, capacity int) []V {
+	if capacity <= cap(slice) {
+		return slice
+	}
+
+	return Grow(slice, capacity-len(slice))
+}
+
 // ToMap collects elements of a slice into a map using a collector function.
 // Note:
 //
@@ -385,6 +788,140 @@ func Unique[V comparable](values []V, transform ...func(v *V) V) []V {
 	return result
 }
 
+// DedupeAdjacent collapses the runs of equal consecutive elements into a single element, e.g. [1 1 2 2 1] becomes [1 2 1].
+// Unlike Unique, the elements repeated non-consecutively are kept.
+func DedupeAdjacent[V comparable](values []V) []V {
+	return DistinctUntilChanged(values, func(a, b *V) bool {
+		return *a == *b
+	})
+}
+
+// DistinctUntilChanged is the same as DedupeAdjacent, but compares the consecutive elements with the equal func.
+// The first element of every run is kept.
+func DistinctUntilChanged[V any](values []V, equal func(a, b *V) bool) []V {
+	result := make([]V, 0)
+	for i := range values {
+		if i == 0 || !equal(&values[i-1], &values[i]) {
+			result = append(result, values[i])
+		}
+	}
+
+	return result
+}
+
+// RunLengthEncode compresses the values into the runs of equal consecutive elements, each run holding the element
+// and the number of its repetitions, e.g. [a a a b a] becomes [{a 3} {b 1} {a 1}].
+// This suits sequences with long runs, like sensor readings or status telemetry, see RunLengthDecode.
+func RunLengthEncode[V comparable](values []V) []Pair[V, int] {
+	result := make([]Pair[V, int], 0)
+	for _, v := range values {
+		if n := len(result); n > 0 && result[n-1].Left == v {
+			result[n-1].Right++
+			continue
+		}
+		result = append(result, Pair[V, int]{Left: v, Right: 1})
+	}
+
+	return result
+}
+
+// RunLengthDecode restores the values compressed with RunLengthEncode. Runs with a non-positive count are skipped.
+func RunLengthDecode[V any](runs []Pair[V, int]) []V {
+	size := 0
+	for _, run := range runs {
+		size += max(run.Right, 0)
+	}
+
+	result := make([]V, 0, size)
+	for _, run := range runs {
+		for range run.Right {
+			result = append(result, run.Left)
+		}
+	}
+
+	return result
+}
+
+// Intersect returns the distinct elements of left that are also present in right, in the order of left.
+func Intersect[V comparable](left []V, right []V) []V {
+	return IntersectBy(left, right, identity[V])
+}
+
+// IntersectBy is the same as Intersect, but compares the elements by the key returned from the key func.
+func IntersectBy[V any, K comparable](left []V, right []V, key func(v *V) K) []V {
+	keys := keySet(right, key)
+	seen := make(map[K]struct{}, min(len(left), len(keys)))
+	result := make([]V, 0)
+	for i := range left {
+		k := key(&left[i])
+		if _, ok := keys[k]; !ok {
+			continue
+		}
+		if _, ok := seen[k]; !ok {
+			seen[k] = struct{}{}
+			result = append(result, left[i])
+		}
+	}
+
+	return result
+}
+
+// Union returns the distinct elements of all the slices in the order of their first occurrence.
+func Union[V comparable](values ...[]V) []V {
+	return UnionBy(identity[V], values...)
+}
+
+// UnionBy is the same as Union, but compares the elements by the key returned from the key func.
+// The first element with every key is kept.
+func UnionBy[V any, K comparable](key func(v *V) K, values ...[]V) []V {
+	seen := make(map[K]struct{})
+	result := make([]V, 0)
+	for _, slice := range values {
+		for i := range slice {
+			k := key(&slice[i])
+			if _, ok := seen[k]; !ok {
+				seen[k] = struct{}{}
+				result = append(result, slice[i])
+			}
+		}
+	}
+
+	return result
+}
+
+// Except returns the distinct elements of left that are not present in right, in the order of left.
+func Except[V comparable](left []V, right []V) []V {
+	return ExceptBy(left, right, identity[V])
+}
+
+// ExceptBy is the same as Except, but compares the elements by the key returned from the key func.
+func ExceptBy[V any, K comparable](left []V, right []V, key func(v *V) K) []V {
+	excluded := keySet(right, key)
+	result := make([]V, 0)
+	for i := range left {
+		k := key(&left[i])
+		if _, ok := excluded[k]; !ok {
+			excluded[k] = struct{}{} // drops the duplicates of left as well
+			result = append(result, left[i])
+		}
+	}
+
+	return result
+}
+
+func keySet[V any, K comparable](values []V, key func(v *V) K) map[K]struct{} {
+	result := make(map[K]struct{}, len(values))
+	for i := range values {
+		result[key(&values[i])] = struct{}{}
+	}
+
+	return result
+}
+
+func identity[V any](v *V) V {
+	return *v
+}
+
 // GroupBy groups and aggregates elements with aggregator method func
 func GroupBy[V any, G comparable](values []V, group func(v *V) G, aggregator func(v1, v2 *V) V) []V {
 	result := make(map[G]V)
@@ -411,6 +948,51 @@ func GroupToMapBy[V any, G comparable](values []V, group func(v *V) G) map[G][]V
 	return result
 }
 
+// GroupToOrderedMap groups elements with group method func like GroupToMapBy,
+// but the groups are iterated in the order they were first encountered, so the result is deterministic.
+func GroupToOrderedMap[V any, G comparable](values []V, group func(v *V) G) *uorderedmap.OrderedMap[G, []V] {
+	result := uorderedmap.New[G, []V]()
+	for _, v := range values {
+		g := group(&v)
+		existing, _ := result.Get(g)
+		result.Set(g, append(existing, v))
+	}
+
+	return result
+}
+
+// CountBy returns the number of elements that match the predicate.
+func CountBy[V any](values []V, predicate func(v *V) bool) int {
+	count := 0
+	for i := range values {
+		if predicate(&values[i]) {
+			count++
+		}
+	}
+
+	return count
+}
+
+// Frequencies returns a map of distinct elements to the number of their occurrences.
+func Frequencies[V comparable](values []V) map[V]int {
+	result := make(map[V]int)
+	for _, v := range values {
+		result[v]++
+	}
+
+	return result
+}
+
+// FrequencyBy returns a map of keys produced by the key func to the number of elements that share the key.
+func FrequencyBy[V any, K comparable](values []V, key func(v *V) K) map[K]int {
+	result := make(map[K]int)
+	for i := range values {
+		result[key(&values[i])]++
+	}
+
+	return result
+}
+
 // MapAndGroupToMapBy same as GroupToMapBy, but allows elements to be mapped to a different type.
 func MapAndGroupToMapBy[V any, G comparable, R any](values []V, group func(v *V) (G, R)) map[G][]R {
 	result := make(map[G][]R)
@@ -494,8 +1076,9 @@ func EqualsCompareWithOrder[T any](left []T, right []T, compare func(t1 T, t2 T)
 	return true
 }
 
-// EqualValues compares values of two slices regardless of elements order
-func EqualValues[T constraints.Ordered](left []T, right []T) bool {
+// EqualValues compares values of two slices regardless of elements order.
+// Both slices are sorted in place, use EqualsUnordered to keep the inputs untouched.
+func EqualValues[T uconst.Ordered](left []T, right []T) bool {
 	if len(left) != len(right) {
 		return false
 	}
@@ -516,6 +1099,28 @@ func EqualValues[T constraints.Ordered](left []T, right []T) bool {
 	return true
 }
 
+// EqualsUnordered compares values of two slices regardless of elements order, taking duplicates into account.
+// Unlike EqualValues it accepts any comparable type and doesn't modify the inputs.
+func EqualsUnordered[T comparable](left []T, right []T) bool {
+	if len(left) != len(right) {
+		return false
+	}
+
+	counts := make(map[T]int, len(left))
+	for _, v := range left {
+		counts[v]++
+	}
+	for _, v := range right {
+		n := counts[v]
+		if n == 0 {
+			return false
+		}
+		counts[v] = n - 1
+	}
+
+	return true
+}
+
 // EqualValuesCompare compares values of two slices regardless of elements order
 func EqualValuesCompare[T any](left []T, right []T, compare func(t1, t2 T) bool, less func(t1, t2 T) bool) bool {
 	if len(left) != len(right) {
@@ -538,6 +1143,67 @@ func EqualValuesCompare[T any](left []T, right []T, compare func(t1, t2 T) bool,
 	return true
 }
 
+type approxOptions struct {
+	relative float64
+	nanEqual bool
+}
+
+// ApproxOption configures EqualsApprox.
+type ApproxOption func(o *approxOptions)
+
+// WithRelativeTolerance makes EqualsApprox also accept the elements whose difference is within the tolerance
+// relative to the larger of their magnitudes, e.g. 1e-9 for the nine matching significant digits.
+// Relative tolerance suits the values of any scale, while the absolute one is needed for the values close to zero.
+func WithRelativeTolerance(tolerance float64) ApproxOption {
+	return func(o *approxOptions) {
+		o.relative = tolerance
+	}
+}
+
+// WithNaNEqual makes EqualsApprox consider NaN elements equal to each other. By default NaN equals nothing.
+func WithNaNEqual() ApproxOption {
+	return func(o *approxOptions) {
+		o.nanEqual = true
+	}
+}
+
+// EqualsApprox compares two float slices element by element, considering the elements equal if they differ
+// by at most epsilon, or by the relative tolerance set with WithRelativeTolerance. Infinities are only equal
+// to the infinities of the same sign. Use it instead of the exact comparison of the computed values,
+// which is broken by the rounding errors.
+func EqualsApprox[F uconst.Float](left, right []F, epsilon F, opts ...ApproxOption) bool {
+	if len(left) != len(right) {
+		return false
+	}
+	var o approxOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	for i, l := range left {
+		a, b := float64(l), float64(right[i])
+		if a == b {
+			continue
+		}
+		if math.IsNaN(a) || math.IsNaN(b) {
+			if o.nanEqual && math.IsNaN(a) && math.IsNaN(b) {
+				continue
+			}
+			return false
+		}
+		if math.IsInf(a, 0) || math.IsInf(b, 0) {
+			return false
+		}
+		diff := math.Abs(a - b)
+		if diff <= float64(epsilon) || diff <= o.relative*math.Max(math.Abs(a), math.Abs(b)) {
+			continue
+		}
+		return false
+	}
+
+	return true
+}
+
 // Merge merges two slices with t1 elements prioritized against elements of t2.
 func Merge[K comparable, T any](t1 []T, t2 []T, key func(t *T) K) []T {
 	hashes := make(map[K]struct{})
@@ -560,6 +1226,100 @@ func Merge[K comparable, T any](t1 []T, t2 []T, key func(t *T) K) []T {
 	return result
 }
 
+// MergeBy merges two slices, reconciling elements with the same key using the resolve function.
+// resolve receives the element merged so far and the conflicting one and returns the element to keep.
+// Elements keep the position of their first occurrence.
+//
+// Example usage:
+//
+//	merged := MergeBy(cached, fetched, func(u *User) int { return u.ID }, func(a, b *User) User {
+//		if b.UpdatedAt.After(a.UpdatedAt) {
+//			return *b
+//		}
+//		return *a
+//	})
+func MergeBy[K comparable, T any](t1 []T, t2 []T, key func(t *T) K, resolve func(a, b *T) T) []T {
+	return MergeAllBy(key, resolve, t1, t2)
+}
+
+// MergeAll merges any number of slices with elements of earlier slices prioritized against elements of later ones.
+func MergeAll[K comparable, T any](key func(t *T) K, values ...[]T) []T {
+	return MergeAllBy(key, func(a, _ *T) T { return *a }, values...)
+}
+
+// MergeAllBy merges any number of slices, reconciling elements with the same key using the resolve function.
+// See MergeBy for details.
+func MergeAllBy[K comparable, T any](key func(t *T) K, resolve func(a, b *T) T, values ...[]T) []T {
+	positions := make(map[K]int)
+	var result []T
+	for _, slice := range values {
+		for _, t := range slice {
+			k := key(&t)
+			if i, ok := positions[k]; ok {
+				result[i] = resolve(&result[i], &t)
+				continue
+			}
+			positions[k] = len(result)
+			result = append(result, t)
+		}
+	}
+
+	return result
+}
+
+// Interleave alternates the elements of the slices, taking one element from each slice in turn.
+// Interleaving stops as soon as any of the slices is exhausted, so the result length is the shortest length
+// multiplied by the number of slices.
+// Example: Interleave([]int{1, 2, 3}, []int{4, 5}) returns []int{1, 4, 2, 5}.
+func Interleave[T any](slices ...[]T) []T {
+	if len(slices) == 0 {
+		return []T{}
+	}
+
+	shortest := len(slices[0])
+	for _, slice := range slices[1:] {
+		shortest = min(shortest, len(slice))
+	}
+
+	result := make([]T, 0, shortest*len(slices))
+	for i := 0; i < shortest; i++ {
+		for _, slice := range slices {
+			result = append(result, slice[i])
+		}
+	}
+
+	return result
+}
+
+// RoundRobinMerge takes elements from the slices in turn like Interleave, but exhausted slices are skipped,
+// so the other slices keep contributing their remaining elements.
+// At most limit elements are returned, a non-positive limit means all the elements,
+// which makes it handy for combining paginated results of several sources fairly.
+// Example: RoundRobinMerge(0, []int{1, 2, 3}, []int{4}) returns []int{1, 4, 2, 3}.
+func RoundRobinMerge[T any](limit int, slices ...[]T) []T {
+	total := 0
+	for _, slice := range slices {
+		total += len(slice)
+	}
+	if limit > 0 {
+		total = min(total, limit)
+	}
+
+	result := make([]T, 0, total)
+	for i := 0; len(result) < total; i++ {
+		for _, slice := range slices {
+			if i < len(slice) {
+				result = append(result, slice[i])
+				if len(result) == total {
+					break
+				}
+			}
+		}
+	}
+
+	return result
+}
+
 // Range generates a slice of integers from 'from' to 'to' (exclusive).
 // The type T must be an integer type (e.g., int, int64, uint, etc.).
 // The returned slice includes 'from', but is exclusive to 'to'.
@@ -621,6 +1381,70 @@ func BestMatchBy[T any](values []T, predicate func(currentBest, candidate *T) bo
 	return bestMatch
 }
 
+// BestMatchIndexBy works like BestMatchBy, but also returns the index of the selected element.
+// Returns -1 and nil if the slice is empty.
+func BestMatchIndexBy[T any](values []T, predicate func(currentBest, candidate *T) bool) (int, *T) {
+	if len(values) == 0 {
+		return -1, nil
+	}
+
+	best := 0
+	for i := 1; i < len(values); i++ {
+		if predicate(&values[best], &values[i]) {
+			best = i
+		}
+	}
+
+	return best, &values[best]
+}
+
+// BestScoreBy returns the element with the highest score along with the score.
+// The first of the equally scored elements wins. NaN scores are ignored, so a failed computation doesn't win.
+// Returns nil if the slice is empty or no element has a valid score.
+//
+// Example:
+//
+//	best, score := uarray.BestScoreBy(candidates, func(c *Candidate) float64 {
+//		return c.Relevance * c.Freshness
+//	})
+func BestScoreBy[T any](values []T, score func(v *T) float64) (*T, float64) {
+	var best *T
+	bestScore := math.NaN()
+	for i := range values {
+		s := score(&values[i])
+		if math.IsNaN(s) {
+			continue
+		}
+		if best == nil || s > bestScore {
+			best, bestScore = &values[i], s
+		}
+	}
+
+	return best, bestScore
+}
+
+// BestScoreByErr works like BestScoreBy, but the scoring function can fail. Elements failing to be scored are skipped,
+// so a single bad element doesn't prevent finding the best one. The scoring errors are returned aggregated
+// into *uerror.Multi like with ValidateEach, each one wrapped into *uerror.IndexedError holding the element index,
+// along with the best of the successfully scored elements.
+func BestScoreByErr[T any](values []T, score func(v *T) (float64, error)) (*T, float64, error) {
+	var errs uerror.Multi
+	var best *T
+	bestScore := math.NaN()
+	for i := range values {
+		s, err := score(&values[i])
+		if err != nil {
+			errs.AppendAt(i, err)
+			continue
+		}
+		if !math.IsNaN(s) && (best == nil || s > bestScore) {
+			best, bestScore = &values[i], s
+		}
+	}
+
+	return best, bestScore, errs.ErrorOrNil()
+}
+
 // Split divides a slice into multiple smaller slices (chunks) of a specified size and returns a slice of these chunks.
 //
 // If chunkSize is less than or equal to zero, the function returns a slice containing the original slice as its only element.
@@ -660,6 +1484,38 @@ func Split[T any](slice []T, chunkSize int) [][]T {
 	return chunks
 }
 
+// ChunkReduce splits the values into chunks of chunkSize elements like Split and reduces every chunk to a single result,
+// which is the common pattern for batched writes to APIs limiting the batch size.
+// Chunks are processed in order and processing stops at the first error, which is returned as *uerror.IndexedError
+// holding the chunk index, along with the results of the chunks processed so far.
+// Chunks share the memory with the values, but their capacity is capped, so appending to a chunk doesn't overwrite the next one.
+//
+// Example:
+//
+//	counts, err := ChunkReduce(items, 25, func(batch []Item) (int, error) {
+//		return client.BatchWrite(ctx, batch)
+//	})
+func ChunkReduce[V, R any](values []V, chunkSize int, reduce func(chunk []V) (R, error)) ([]R, error) {
+	if len(values) == 0 {
+		return []R{}, nil
+	}
+	if chunkSize <= 0 {
+		chunkSize = len(values)
+	}
+
+	results := make([]R, 0, (len(values)+chunkSize-1)/chunkSize)
+	for i := 0; i < len(values); i += chunkSize {
+		end := min(i+chunkSize, len(values))
+		r, err := reduce(values[i:end:end])
+		if err != nil {
+			return results, &uerror.IndexedError{Index: i / chunkSize, Err: err}
+		}
+		results = append(results, r)
+	}
+
+	return results, nil
+}
+
 // AsString converts any supported numeric value to a string and joins them with the specified delimiter.
 func AsString[T uconst.Stringable](delimiter string, values ...T) string {
 	var parts []string
@@ -697,6 +1553,27 @@ func AsString[T uconst.Stringable](delimiter string, values ...T) string {
 	return strings.Join(parts, delimiter)
 }
 
+// JoinFunc converts the values to strings with format and joins them with the specified delimiter.
+// It's an equivalent of joining the result of Map without allocating the intermediate slice.
+func JoinFunc[T any](values []T, delimiter string, format func(v *T) string) string {
+	var sb strings.Builder
+	for i := range values {
+		if i > 0 {
+			sb.WriteString(delimiter)
+		}
+		sb.WriteString(format(&values[i]))
+	}
+
+	return sb.String()
+}
+
+// Join converts the values to strings with their String method and joins them with the specified delimiter.
+func Join[T fmt.Stringer](values []T, delimiter string) string {
+	return JoinFunc(values, delimiter, func(v *T) string {
+		return (*v).String()
+	})
+}
+
 func equals[T comparable](t1, t2 T) bool {
 	return t1 == t2
 }
@@ -63,3 +63,58 @@ func BenchmarkMapAndGroupToMapBy(b *testing.B) {
 		})
 	}
 }
+
+func BenchmarkMap(b *testing.B) {
+	values := Range(0, 1000)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = Map(values, func(v *int) int64 {
+			return int64(*v) * 2
+		})
+	}
+}
+
+func BenchmarkFilter(b *testing.B) {
+	values := Range(0, 1000)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = Filter(values, func(v *int) bool {
+			return *v%2 == 0
+		})
+	}
+}
+
+func BenchmarkConcat(b *testing.B) {
+	chunks := Split(Range(0, 1000), 10)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = Concat(chunks...)
+	}
+}
+
+func BenchmarkFilterMapTake(b *testing.B) {
+	values := make([]int, 1000)
+	for i := range values {
+		values[i] = i
+	}
+	isEven := func(v *int) bool { return *v%2 == 0 }
+	square := func(v *int) int { return *v * *v }
+
+	b.Run("Slices", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			result := Map(Filter(values, isEven), square)
+			_ = result[:10]
+		}
+	})
+	b.Run("LazyChain", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = Chain(values).Filter(isEven).Map(square).Take(10).Collect()
+		}
+	})
+}
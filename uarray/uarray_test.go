@@ -7,15 +7,23 @@
 package uarray_test
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"math"
+	"net"
 	"reflect"
 	"sort"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/kordax/basic-utils/uarray"
 	"github.com/kordax/basic-utils/ucast"
+	"github.com/kordax/basic-utils/uerror"
 	"github.com/kordax/basic-utils/umath"
+	"github.com/kordax/basic-utils/uopt"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -322,6 +330,48 @@ func TestFilter(t *testing.T) {
 	}
 }
 
+func TestCompact(t *testing.T) {
+	a, b := 1, 2
+	assert.Equal(t, []*int{&a, &b}, uarray.Compact([]*int{nil, &a, nil, &b}))
+	assert.Empty(t, uarray.Compact([]*int{nil}))
+	assert.Empty(t, uarray.Compact[int](nil))
+}
+
+func TestCompactOpt(t *testing.T) {
+	values := []uopt.Opt[string]{uopt.Of("a"), uopt.Null[string](), uopt.Of(""), uopt.Null[string]()}
+	assert.Equal(t, []string{"a", ""}, uarray.CompactOpt(values))
+	assert.Empty(t, uarray.CompactOpt[int](nil))
+}
+
+func TestNonZero(t *testing.T) {
+	assert.Equal(t, []int{1, 2}, uarray.NonZero([]int{0, 1, 0, 2}))
+	assert.Equal(t, []string{"a"}, uarray.NonZero([]string{"", "a", ""}))
+
+	type point struct{ X, Y int }
+	assert.Equal(t, []point{{0, 1}}, uarray.NonZero([]point{{}, {0, 1}}))
+}
+
+func TestFilterInPlace(t *testing.T) {
+	values := []int{1, 2, 3, 4, 5, 6}
+	backing := values
+	uarray.FilterInPlace(&values, func(v *int) bool { return *v%2 == 0 })
+
+	assert.Equal(t, []int{2, 4, 6}, values)
+	assert.Equal(t, []int{2, 4, 6, 0, 0, 0}, backing, "the backing array must be reused and the tail zeroed")
+
+	var empty []int
+	uarray.FilterInPlace(&empty, func(v *int) bool { return true })
+	assert.Empty(t, empty)
+
+	buffer := make([]int, 3)
+	allocs := testing.AllocsPerRun(10, func() {
+		s := buffer[:3]
+		copy(s, []int{1, 2, 3})
+		uarray.FilterInPlace(&s, func(v *int) bool { return *v > 1 })
+	})
+	assert.Zero(t, allocs)
+}
+
 func TestFilterOut(t *testing.T) {
 	values := []int{1, 2, 3, 4, 5}
 	filteredOut := uarray.FilterOut(values, func(v *int) bool {
@@ -334,6 +384,37 @@ func TestFilterOut(t *testing.T) {
 	}
 }
 
+func TestSplitBy(t *testing.T) {
+	less3 := func(v *int) bool { return *v < 3 }
+
+	head, tail := uarray.SplitBy([]int{1, 2, 5, 1}, less3)
+	assert.Equal(t, []int{1, 2}, head)
+	assert.Equal(t, []int{5, 1}, tail)
+
+	head, tail = uarray.SplitBy([]int{1, 2}, less3)
+	assert.Equal(t, []int{1, 2}, head)
+	assert.Empty(t, tail)
+
+	head, tail = uarray.SplitBy(nil, less3)
+	assert.Empty(t, head)
+	assert.Empty(t, tail)
+
+	values := []int{1, 5}
+	head, _ = uarray.SplitBy(values, less3)
+	head[0] = 100
+	assert.Equal(t, []int{1, 5}, values, "SplitBy must return copies")
+}
+
+func TestTakeWhileDropWhile(t *testing.T) {
+	even := func(v *int) bool { return *v%2 == 0 }
+	values := []int{2, 4, 5, 6}
+
+	assert.Equal(t, []int{2, 4}, uarray.TakeWhile(values, even))
+	assert.Equal(t, []int{5, 6}, uarray.DropWhile(values, even))
+	assert.Empty(t, uarray.TakeWhile([]int{1, 2}, even))
+	assert.Equal(t, []int{1, 2}, uarray.DropWhile([]int{1, 2}, even))
+}
+
 func TestFilterAll(t *testing.T) {
 	values := []int{1, 2, 3, 4, 5}
 	matching, nonMatching := uarray.FilterAll(values, func(v *int) bool {
@@ -476,6 +557,60 @@ func TestFind(t *testing.T) {
 	}
 }
 
+func TestIndexBy(t *testing.T) {
+	values := []int{1, 2, 3, 2, 1}
+	isTwo := func(v *int) bool { return *v == 2 }
+
+	assert.Equal(t, 1, uarray.IndexBy(values, isTwo))
+	assert.Equal(t, 3, uarray.LastIndexBy(values, isTwo))
+	assert.Equal(t, 0, uarray.LastIndexBy([]int{2}, isTwo))
+
+	isSix := func(v *int) bool { return *v == 6 }
+	assert.Equal(t, -1, uarray.IndexBy(values, isSix))
+	assert.Equal(t, -1, uarray.LastIndexBy(values, isSix))
+	assert.Equal(t, -1, uarray.IndexBy([]int{}, isTwo))
+	assert.Equal(t, -1, uarray.LastIndexBy[int](nil, isTwo))
+}
+
+func TestFindLast(t *testing.T) {
+	type item struct {
+		id   int
+		name string
+	}
+	values := []item{{1, "a"}, {2, "b"}, {3, "a"}}
+
+	found := uarray.FindLast(values, func(v *item) bool { return v.name == "a" })
+	require.NotNil(t, found)
+	assert.Equal(t, 3, found.id)
+	found.id = 4
+	assert.Equal(t, 4, values[2].id, "FindLast must return a pointer to the slice element")
+
+	assert.Nil(t, uarray.FindLast(values, func(v *item) bool { return v.name == "c" }))
+	assert.Nil(t, uarray.FindLast([]item{}, func(v *item) bool { return true }))
+}
+
+func TestFindBinary(t *testing.T) {
+	values := []int{1, 3, 3, 5, 7}
+
+	i, found := uarray.FindBinary(values, func(v *int) bool { return *v >= 3 })
+	assert.Equal(t, 1, i, "the first match must be returned")
+	require.NotNil(t, found)
+	*found = 2
+	assert.Equal(t, 2, values[1], "FindBinary must return a pointer to the slice element")
+
+	i, found = uarray.FindBinary(values, func(v *int) bool { return *v > 5 })
+	assert.Equal(t, 4, i)
+	assert.Equal(t, 7, *found)
+
+	i, found = uarray.FindBinary(values, func(v *int) bool { return *v > 7 })
+	assert.Equal(t, len(values), i, "the insertion index must be returned if nothing matches")
+	assert.Nil(t, found)
+
+	i, found = uarray.FindBinary([]int{}, func(v *int) bool { return true })
+	assert.Zero(t, i)
+	assert.Nil(t, found)
+}
+
 func TestSortFind(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -639,6 +774,18 @@ func TestGroupToMapBy(t *testing.T) {
 	}
 }
 
+func TestGroupToOrderedMap(t *testing.T) {
+	values := []string{"banana", "apple", "cherry", "kiwi", "avocado", "blueberry"}
+	result := uarray.GroupToOrderedMap(values, func(v *string) byte {
+		return (*v)[0]
+	})
+	assert.Equal(t, []byte{'b', 'a', 'c', 'k'}, result.Keys(), "groups must be ordered by their first element")
+	assert.Equal(t, [][]string{{"banana", "blueberry"}, {"apple", "avocado"}, {"cherry"}, {"kiwi"}}, result.Values())
+
+	empty := uarray.GroupToOrderedMap([]string{}, func(v *string) int { return len(*v) })
+	assert.Zero(t, empty.Len())
+}
+
 func TestMapAndGroupToMapBy(t *testing.T) {
 	values := []string{"apple", "banana", "cherry", "avocado"}
 	expected := map[int][]string{
@@ -772,6 +919,50 @@ func TestMerge(t *testing.T) {
 	}
 }
 
+func TestMergeBy(t *testing.T) {
+	type record struct {
+		ID      int
+		Version int
+	}
+	t1 := []record{{1, 1}, {2, 5}, {3, 1}}
+	t2 := []record{{2, 3}, {3, 2}, {4, 1}}
+	merged := uarray.MergeBy(t1, t2, func(r *record) int { return r.ID }, func(a, b *record) record {
+		if b.Version > a.Version {
+			return *b
+		}
+		return *a
+	})
+	assert.Equal(t, []record{{1, 1}, {2, 5}, {3, 2}, {4, 1}}, merged)
+	assert.Equal(t, []record{{1, 1}, {2, 5}, {3, 1}}, t1)
+}
+
+func TestMergeAll(t *testing.T) {
+	identity := func(v *int) int { return *v }
+	assert.Equal(t, []int{1, 2, 3, 5, 4}, uarray.MergeAll(identity, []int{1, 2}, []int{2, 3}, []int{5, 4, 1}))
+	assert.Empty(t, uarray.MergeAll[int, int](identity))
+
+	sum := uarray.MergeAllBy(func(v *[2]int) int { return v[0] }, func(a, b *[2]int) [2]int {
+		return [2]int{a[0], a[1] + b[1]}
+	}, [][2]int{{1, 1}}, [][2]int{{2, 1}, {1, 2}}, [][2]int{{1, 3}})
+	assert.Equal(t, [][2]int{{1, 6}, {2, 1}}, sum)
+}
+
+func TestInterleave(t *testing.T) {
+	assert.Equal(t, []int{1, 4, 2, 5}, uarray.Interleave([]int{1, 2, 3}, []int{4, 5}))
+	assert.Equal(t, []int{1, 3, 5, 2, 4, 6}, uarray.Interleave([]int{1, 2}, []int{3, 4}, []int{5, 6}))
+	assert.Equal(t, []int{1, 2}, uarray.Interleave([]int{1, 2}))
+	assert.Empty(t, uarray.Interleave([]int{1, 2}, nil))
+	assert.NotNil(t, uarray.Interleave[int]())
+}
+
+func TestRoundRobinMerge(t *testing.T) {
+	assert.Equal(t, []int{1, 4, 2, 3}, uarray.RoundRobinMerge(0, []int{1, 2, 3}, []int{4}))
+	assert.Equal(t, []int{1, 4, 6, 2, 5, 3}, uarray.RoundRobinMerge(-1, []int{1, 2, 3}, []int{4, 5}, nil, []int{6}))
+	assert.Equal(t, []int{1, 4, 6}, uarray.RoundRobinMerge(3, []int{1, 2, 3}, []int{4, 5}, []int{6}))
+	assert.Equal(t, []int{1, 2}, uarray.RoundRobinMerge(10, []int{1}, []int{2}))
+	assert.Empty(t, uarray.RoundRobinMerge[int](5))
+}
+
 func TestRange(t *testing.T) {
 	// Test the Range function
 	expected := []int{1, 2, 3, 4}
@@ -901,6 +1092,68 @@ func TestBestMatchBy(t *testing.T) {
 	})
 }
 
+func TestBestMatchIndexBy(t *testing.T) {
+	i, best := uarray.BestMatchIndexBy([]int{10, 45, 20, 45}, func(current, candidate *int) bool {
+		return *candidate > *current
+	})
+	assert.Equal(t, 1, i)
+	assert.Equal(t, 45, *best)
+
+	i, best = uarray.BestMatchIndexBy([]int{}, func(current, candidate *int) bool { return true })
+	assert.Equal(t, -1, i)
+	assert.Nil(t, best)
+}
+
+func TestBestScoreBy(t *testing.T) {
+	type candidate struct {
+		name  string
+		score float64
+	}
+	values := []candidate{{"a", 0.5}, {"b", math.NaN()}, {"c", 0.9}, {"d", 0.9}, {"e", -1}}
+	best, score := uarray.BestScoreBy(values, func(v *candidate) float64 { return v.score })
+	assert.Equal(t, "c", best.name, "the first of the equally scored elements must win")
+	assert.Equal(t, 0.9, score)
+
+	best, score = uarray.BestScoreBy([]candidate{{"a", -5}, {"b", -2}}, func(v *candidate) float64 { return v.score })
+	assert.Equal(t, "b", best.name)
+	assert.Equal(t, -2.0, score)
+
+	best, score = uarray.BestScoreBy([]candidate{{"nan", math.NaN()}}, func(v *candidate) float64 { return v.score })
+	assert.Nil(t, best)
+	assert.True(t, math.IsNaN(score))
+	best, _ = uarray.BestScoreBy([]candidate{}, func(v *candidate) float64 { return v.score })
+	assert.Nil(t, best)
+}
+
+func TestBestScoreByErr(t *testing.T) {
+	errParse := errors.New("parse error")
+	best, score, err := uarray.BestScoreByErr([]string{"1.5", "x", "7", "y", "3"}, func(v *string) (float64, error) {
+		f, err := strconv.ParseFloat(*v, 64)
+		if err != nil {
+			return 0, errParse
+		}
+		return f, nil
+	})
+	assert.Equal(t, "7", *best)
+	assert.Equal(t, 7.0, score)
+	assert.ErrorIs(t, err, errParse)
+	var multi *uerror.Multi
+	require.ErrorAs(t, err, &multi)
+	var indices []int
+	for _, e := range multi.Errors() {
+		var indexedErr *uerror.IndexedError
+		require.ErrorAs(t, e, &indexedErr)
+		indices = append(indices, indexedErr.Index)
+	}
+	assert.Equal(t, []int{1, 3}, indices)
+
+	best, _, err = uarray.BestScoreByErr([]string{"1", "2"}, func(v *string) (float64, error) {
+		return strconv.ParseFloat(*v, 64)
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "2", *best)
+}
+
 func TestSplit_EmptySlice(t *testing.T) {
 	var slice []int
 	chunkSize := 3
@@ -1128,6 +1381,24 @@ func TestAsString(t *testing.T) {
 	}
 }
 
+func TestJoinFunc(t *testing.T) {
+	type point struct{ x, y int }
+	format := func(p *point) string { return fmt.Sprintf("(%d;%d)", p.x, p.y) }
+
+	assert.Equal(t, "", uarray.JoinFunc(nil, ",", format))
+	assert.Equal(t, "(1;2)", uarray.JoinFunc([]point{{1, 2}}, ",", format))
+	assert.Equal(t, "(1;2), (3;4), (5;6)", uarray.JoinFunc([]point{{1, 2}, {3, 4}, {5, 6}}, ", ", format))
+	assert.Equal(t, "a-b", uarray.JoinFunc([]string{"A", "b"}, "-", func(v *string) string { return strings.ToLower(*v) }))
+}
+
+func TestJoin(t *testing.T) {
+	assert.Equal(t, "", uarray.Join([]time.Duration{}, ","))
+	assert.Equal(t, "1s,1m30s,5ms", uarray.Join([]time.Duration{time.Second, 90 * time.Second, 5 * time.Millisecond}, ","))
+
+	ips := []net.IP{net.IPv4(127, 0, 0, 1), net.IPv4(10, 0, 0, 1)}
+	assert.Equal(t, "127.0.0.1 10.0.0.1", uarray.Join(ips, " "))
+}
+
 func TestMapInt8ToString(t *testing.T) {
 	values := []int8{10, -20, 30}
 	expected := []string{"10", "-20", "30"}
@@ -1265,3 +1536,492 @@ func TestUnique_WithTransform_BothTransformed(t *testing.T) {
 	require.NotNil(t, result, "Result should not be nil")
 	assert.Equal(t, expected, result, "Result should match the expected output")
 }
+
+func TestConcat(t *testing.T) {
+	result := uarray.Concat([]int{1, 2}, nil, []int{}, []int{3}, []int{4, 5})
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, result)
+	assert.Equal(t, 5, cap(result), "Concat should allocate exactly the total length")
+
+	empty := uarray.Concat[int]()
+	require.NotNil(t, empty, "Concat should never return nil")
+	assert.Empty(t, empty)
+
+	src := []string{"a", "b"}
+	cpy := uarray.Concat(src)
+	cpy[0] = "z"
+	assert.Equal(t, "a", src[0], "Concat should not share memory with the source slices")
+}
+
+func TestGrow(t *testing.T) {
+	t.Run("AllocatesWhenNeeded", func(t *testing.T) {
+		src := make([]int, 2)
+		src[0], src[1] = 1, 2
+		grown := uarray.Grow(src, 10)
+		assert.Equal(t, []int{1, 2}, grown)
+		assert.GreaterOrEqual(t, cap(grown), 12)
+	})
+
+	t.Run("KeepsSliceWithEnoughCapacity", func(t *testing.T) {
+		src := make([]int, 1, 10)
+		grown := uarray.Grow(src, 5)
+		assert.Equal(t, &src[0], &grown[0], "Grow should return the same slice if capacity is sufficient")
+		assert.Equal(t, 10, cap(grown))
+	})
+
+	t.Run("NilSlice", func(t *testing.T) {
+		grown := uarray.Grow[string](nil, 3)
+		assert.Len(t, grown, 0)
+		assert.GreaterOrEqual(t, cap(grown), 3)
+	})
+
+	t.Run("NegativeN", func(t *testing.T) {
+		assert.Panics(t, func() {
+			_ = uarray.Grow([]int{1}, -1)
+		})
+	})
+}
+
+func TestEnsureCapacity(t *testing.T) {
+	src := []int{1, 2, 3}
+	result := uarray.EnsureCapacity(src, 16)
+	assert.Equal(t, []int{1, 2, 3}, result)
+	assert.GreaterOrEqual(t, cap(result), 16)
+
+	same := uarray.EnsureCapacity(result, 8)
+	assert.Equal(t, &result[0], &same[0], "EnsureCapacity should not reallocate if capacity is sufficient")
+
+	smaller := uarray.EnsureCapacity(src, 1)
+	assert.Equal(t, src, smaller)
+}
+
+func TestMapErr(t *testing.T) {
+	values := []string{"1", "2", "3"}
+	result, err := uarray.MapErr(values, func(v *string) (int, error) {
+		return strconv.Atoi(*v)
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3}, result)
+
+	calls := 0
+	result, err = uarray.MapErr([]string{"1", "x", "3"}, func(v *string) (int, error) {
+		calls++
+		return strconv.Atoi(*v)
+	})
+	var indexed *uerror.IndexedError
+	require.ErrorAs(t, err, &indexed)
+	assert.Equal(t, 1, indexed.Index)
+	assert.ErrorIs(t, err, strconv.ErrSyntax)
+	assert.Equal(t, []int{1}, result)
+	assert.Equal(t, 2, calls)
+
+	result, err = uarray.MapErr([]string{}, func(v *string) (int, error) { return 0, nil })
+	require.NoError(t, err)
+	assert.Empty(t, result)
+}
+
+func TestMapCtx(t *testing.T) {
+	atoi := func(v *string) (int, error) {
+		return strconv.Atoi(*v)
+	}
+	result, err := uarray.MapCtx(context.Background(), []string{"1", "2", "3"}, atoi)
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3}, result)
+
+	result, err = uarray.MapCtx(context.Background(), []string{"1", "x", "3"}, atoi)
+	var indexed *uerror.IndexedError
+	require.ErrorAs(t, err, &indexed)
+	assert.Equal(t, 1, indexed.Index)
+	assert.Equal(t, []int{1}, result, "partial results must be returned")
+
+	values := make([]int, 10000)
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	mapped, err := uarray.MapCtx(ctx, values, func(v *int) (int, error) {
+		calls++
+		if calls == 1500 {
+			cancel()
+		}
+		return *v + 1, nil
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Len(t, mapped, 2048, "the context must be checked periodically")
+	assert.Equal(t, 2048, calls)
+}
+
+func TestFlatMapCtx(t *testing.T) {
+	values := [][]int{{1, 2}, {}, {3, 4, 5}}
+	result, err := uarray.FlatMapCtx(context.Background(), values, func(v *int) (string, error) {
+		return strconv.Itoa(*v), nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1", "2", "3", "4", "5"}, result)
+
+	errOdd := errors.New("odd")
+	result, err = uarray.FlatMapCtx(context.Background(), [][]int{{2}, {4, 5}}, func(v *int) (string, error) {
+		if *v%2 != 0 {
+			return "", errOdd
+		}
+		return strconv.Itoa(*v), nil
+	})
+	assert.ErrorIs(t, err, errOdd)
+	var indexed *uerror.IndexedError
+	require.ErrorAs(t, err, &indexed)
+	assert.Equal(t, 2, indexed.Index, "the index must refer to the flattened values")
+	assert.Equal(t, []string{"2", "4"}, result)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	result, err = uarray.FlatMapCtx(ctx, values, func(v *int) (string, error) { return "", nil })
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Empty(t, result)
+}
+
+func TestMapAggrCtx(t *testing.T) {
+	repeat := func(v *int) ([]int, error) {
+		if *v < 0 {
+			return nil, errors.New("negative")
+		}
+		result := make([]int, *v)
+		for i := range result {
+			result[i] = *v
+		}
+		return result, nil
+	}
+	result, err := uarray.MapAggrCtx(context.Background(), []int{1, 2, 0, 3}, repeat)
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 2, 3, 3, 3}, result)
+
+	result, err = uarray.MapAggrCtx(context.Background(), []int{1, -1, 2}, repeat)
+	var indexed *uerror.IndexedError
+	require.ErrorAs(t, err, &indexed)
+	assert.Equal(t, 1, indexed.Index)
+	assert.Equal(t, []int{1}, result)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 0)
+	defer cancel()
+	result, err = uarray.MapAggrCtx(ctx, []int{1}, repeat)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Empty(t, result)
+}
+
+func TestFilterErr(t *testing.T) {
+	errNegative := errors.New("negative")
+	filter := func(v *int) (bool, error) {
+		if *v < 0 {
+			return false, errNegative
+		}
+		return *v%2 == 0, nil
+	}
+
+	result, err := uarray.FilterErr([]int{1, 2, 3, 4}, filter)
+	require.NoError(t, err)
+	assert.Equal(t, []int{2, 4}, result)
+
+	result, err = uarray.FilterErr([]int{2, 4, -1, 6}, filter)
+	assert.ErrorIs(t, err, errNegative)
+	var indexed *uerror.IndexedError
+	require.ErrorAs(t, err, &indexed)
+	assert.Equal(t, 2, indexed.Index)
+	assert.Equal(t, []int{2, 4}, result)
+}
+
+func TestForEachErr(t *testing.T) {
+	values := []int{1, 2, 3}
+	err := uarray.ForEachErr(values, func(v *int) error {
+		*v *= 10
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []int{10, 20, 30}, values)
+
+	errStop := errors.New("stop")
+	var visited []int
+	err = uarray.ForEachErr([]int{1, 2, 3, 4}, func(v *int) error {
+		visited = append(visited, *v)
+		if *v == 3 {
+			return errStop
+		}
+		return nil
+	})
+	assert.ErrorIs(t, err, errStop)
+	var indexed *uerror.IndexedError
+	require.ErrorAs(t, err, &indexed)
+	assert.Equal(t, 2, indexed.Index)
+	assert.Equal(t, []int{1, 2, 3}, visited)
+}
+
+func TestValidateEach(t *testing.T) {
+	errNegative := errors.New("negative")
+	validate := func(v *int) error {
+		if *v < 0 {
+			return errNegative
+		}
+		return nil
+	}
+
+	assert.NoError(t, uarray.ValidateEach([]int{1, 2, 3}, validate))
+	assert.NoError(t, uarray.ValidateEach(nil, validate))
+
+	err := uarray.ValidateEach([]int{-1, 2, -3, 4, -5}, validate)
+	assert.ErrorIs(t, err, errNegative)
+	var multi *uerror.Multi
+	require.ErrorAs(t, err, &multi)
+	errs := multi.Errors()
+	require.Len(t, errs, 3)
+	for i, index := range []int{0, 2, 4} {
+		var indexed *uerror.IndexedError
+		require.ErrorAs(t, errs[i], &indexed)
+		assert.Equal(t, index, indexed.Index)
+		assert.ErrorIs(t, indexed, errNegative)
+	}
+
+	assert.True(t, uarray.ValidateAll([]int{1, 2}, validate))
+	assert.True(t, uarray.ValidateAll(nil, validate))
+	var visited []int
+	assert.False(t, uarray.ValidateAll([]int{1, -2, -3}, func(v *int) error {
+		visited = append(visited, *v)
+		return validate(v)
+	}))
+	assert.Equal(t, []int{1, -2}, visited, "ValidateAll must stop at the first invalid element")
+}
+
+func TestRotate(t *testing.T) {
+	tests := []struct {
+		n        int
+		expected []int
+	}{
+		{0, []int{1, 2, 3, 4, 5}},
+		{2, []int{3, 4, 5, 1, 2}},
+		{5, []int{1, 2, 3, 4, 5}},
+		{7, []int{3, 4, 5, 1, 2}},
+		{-1, []int{5, 1, 2, 3, 4}},
+		{-6, []int{5, 1, 2, 3, 4}},
+	}
+	for _, tt := range tests {
+		values := []int{1, 2, 3, 4, 5}
+		uarray.Rotate(values, tt.n)
+		assert.Equal(t, tt.expected, values, "n = %d", tt.n)
+	}
+
+	assert.NotPanics(t, func() { uarray.Rotate([]int{}, 3) })
+}
+
+type sortPerson struct {
+	Name string
+	Age  int
+	Seq  int
+}
+
+func TestSortBy(t *testing.T) {
+	values := []int{5, 2, 8, 1}
+	uarray.SortBy(values, func(v *int) int { return *v })
+	assert.Equal(t, []int{1, 2, 5, 8}, values)
+
+	words := []string{"ccc", "a", "bb"}
+	uarray.SortBy(words, func(v *string) int { return len(*v) })
+	assert.Equal(t, []string{"a", "bb", "ccc"}, words)
+
+	uarray.SortBy([]int{}, func(v *int) int { return *v })
+}
+
+func TestSortStableBy(t *testing.T) {
+	people := []sortPerson{
+		{Name: "b", Age: 30, Seq: 0},
+		{Name: "a", Age: 20, Seq: 1},
+		{Name: "c", Age: 30, Seq: 2},
+		{Name: "d", Age: 20, Seq: 3},
+	}
+	uarray.SortStableBy(people, func(p *sortPerson) int { return p.Age })
+	assert.Equal(t, []int{1, 3, 0, 2}, uarray.Map(people, func(p *sortPerson) int { return p.Seq }))
+}
+
+func TestSortThenBy(t *testing.T) {
+	people := []sortPerson{
+		{Name: "bob", Age: 30},
+		{Name: "alice", Age: 20},
+		{Name: "bob", Age: 25},
+		{Name: "alice", Age: 40},
+		{Name: "carl", Age: 25},
+	}
+
+	uarray.Sort(people, uarray.ThenByDesc(uarray.By(func(p *sortPerson) string { return p.Name }), func(p *sortPerson) int { return p.Age }))
+	assert.Equal(t, []sortPerson{
+		{Name: "alice", Age: 40},
+		{Name: "alice", Age: 20},
+		{Name: "bob", Age: 30},
+		{Name: "bob", Age: 25},
+		{Name: "carl", Age: 25},
+	}, people)
+
+	byAge := uarray.ByDesc(func(p *sortPerson) int { return p.Age })
+	uarray.SortStable(people, uarray.ThenBy(byAge, func(p *sortPerson) string { return p.Name }))
+	assert.Equal(t, []sortPerson{
+		{Name: "alice", Age: 40},
+		{Name: "bob", Age: 30},
+		{Name: "bob", Age: 25},
+		{Name: "carl", Age: 25},
+		{Name: "alice", Age: 20},
+	}, people)
+}
+
+func TestCountBy(t *testing.T) {
+	values := []int{1, 2, 3, 4, 5, 6}
+
+	assert.Equal(t, 3, uarray.CountBy(values, func(v *int) bool { return *v%2 == 0 }))
+	assert.Equal(t, 0, uarray.CountBy(values, func(v *int) bool { return *v > 10 }))
+	assert.Equal(t, 0, uarray.CountBy([]int{}, func(v *int) bool { return true }))
+}
+
+func TestFrequencies(t *testing.T) {
+	assert.Equal(t, map[string]int{"a": 2, "b": 1, "c": 3}, uarray.Frequencies([]string{"a", "c", "b", "c", "a", "c"}))
+	assert.Empty(t, uarray.Frequencies([]string{}))
+}
+
+func TestFrequencyBy(t *testing.T) {
+	words := []string{"go", "is", "fun", "and", "fast"}
+
+	result := uarray.FrequencyBy(words, func(v *string) int { return len(*v) })
+	assert.Equal(t, map[int]int{2: 2, 3: 2, 4: 1}, result)
+	assert.Empty(t, uarray.FrequencyBy([]string{}, func(v *string) int { return len(*v) }))
+}
+
+func TestEqualsUnordered(t *testing.T) {
+	type point struct{ X, Y int }
+
+	left := []point{{1, 2}, {3, 4}, {1, 2}}
+	right := []point{{3, 4}, {1, 2}, {1, 2}}
+	assert.True(t, uarray.EqualsUnordered(left, right))
+	assert.Equal(t, []point{{1, 2}, {3, 4}, {1, 2}}, left, "inputs must not be modified")
+	assert.Equal(t, []point{{3, 4}, {1, 2}, {1, 2}}, right, "inputs must not be modified")
+
+	assert.False(t, uarray.EqualsUnordered([]int{1, 1, 2}, []int{1, 2, 2}))
+	assert.False(t, uarray.EqualsUnordered([]int{1, 2}, []int{1, 2, 3}))
+	assert.True(t, uarray.EqualsUnordered([]string{}, nil))
+	assert.True(t, uarray.EqualsUnordered([]any{1, "a"}, []any{"a", 1}))
+}
+
+func TestEqualsApprox(t *testing.T) {
+	a, b := 0.1, 0.2
+	assert.True(t, uarray.EqualsApprox([]float64{a + b, 1}, []float64{0.3, 1}, 1e-9))
+	assert.False(t, uarray.EqualsApprox([]float64{a + b}, []float64{0.3}, 0))
+	assert.False(t, uarray.EqualsApprox([]float64{1, 2}, []float64{1, 2.1}, 0.01))
+	assert.False(t, uarray.EqualsApprox([]float64{1}, []float64{1, 2}, 1))
+	assert.True(t, uarray.EqualsApprox([]float32{}, nil, 0))
+	assert.True(t, uarray.EqualsApprox([]float32{1.0000001}, []float32{1}, 1e-6))
+
+	large := []float64{1e12, -3e15}
+	assert.False(t, uarray.EqualsApprox(large, []float64{1e12 + 1, -3e15 + 1}, 1e-9))
+	assert.True(t, uarray.EqualsApprox(large, []float64{1e12 + 1, -3e15 + 1}, 1e-9, uarray.WithRelativeTolerance(1e-9)))
+	assert.False(t, uarray.EqualsApprox([]float64{1e-12}, []float64{0}, 0, uarray.WithRelativeTolerance(1e-9)),
+		"relative tolerance can't match zero")
+
+	inf := math.Inf(1)
+	assert.True(t, uarray.EqualsApprox([]float64{inf, -inf}, []float64{inf, -inf}, 0))
+	assert.False(t, uarray.EqualsApprox([]float64{inf}, []float64{math.MaxFloat64}, 1, uarray.WithRelativeTolerance(1)))
+	assert.False(t, uarray.EqualsApprox([]float64{inf}, []float64{-inf}, inf))
+
+	nan := math.NaN()
+	assert.False(t, uarray.EqualsApprox([]float64{nan}, []float64{nan}, 1))
+	assert.True(t, uarray.EqualsApprox([]float64{nan}, []float64{nan}, 0, uarray.WithNaNEqual()))
+	assert.False(t, uarray.EqualsApprox([]float64{nan}, []float64{1}, 0, uarray.WithNaNEqual()))
+}
+
+func TestIntersect(t *testing.T) {
+	assert.Equal(t, []int{3, 1}, uarray.Intersect([]int{3, 1, 2, 3, 1}, []int{1, 3, 5}))
+	assert.Empty(t, uarray.Intersect([]int{1, 2}, []int{3}))
+	assert.Empty(t, uarray.Intersect(nil, []int{3}))
+
+	type user struct {
+		ID   int
+		Name string
+	}
+	left := []user{{1, "a"}, {2, "b"}, {3, "c"}}
+	right := []user{{3, "other"}, {1, "other"}}
+	assert.Equal(t, []user{{1, "a"}, {3, "c"}}, uarray.IntersectBy(left, right, func(v *user) int { return v.ID }))
+}
+
+func TestUnion(t *testing.T) {
+	assert.Equal(t, []int{1, 2, 3, 4}, uarray.Union([]int{1, 2, 1}, []int{3, 2}, []int{4}))
+	assert.Equal(t, []int{1}, uarray.Union([]int{1}))
+	assert.Empty(t, uarray.Union[int]())
+
+	lower := func(v *string) string { return strings.ToLower(*v) }
+	assert.Equal(t, []string{"Go", "rust", "Zig"}, uarray.UnionBy(lower, []string{"Go", "rust"}, []string{"GO", "Zig"}))
+}
+
+func TestExcept(t *testing.T) {
+	assert.Equal(t, []int{2, 4}, uarray.Except([]int{1, 2, 3, 4, 2}, []int{1, 3}))
+	assert.Equal(t, []int{1, 2}, uarray.Except([]int{1, 2, 1}, nil))
+	assert.Empty(t, uarray.Except([]int{1}, []int{1}))
+
+	lower := func(v *string) string { return strings.ToLower(*v) }
+	assert.Equal(t, []string{"b"}, uarray.ExceptBy([]string{"A", "b", "a"}, []string{"a"}, lower))
+}
+
+func TestChunkReduce(t *testing.T) {
+	sum := func(chunk []int) (int, error) {
+		total := 0
+		for _, v := range chunk {
+			total += v
+		}
+		return total, nil
+	}
+
+	result, err := uarray.ChunkReduce([]int{1, 2, 3, 4, 5}, 2, sum)
+	require.NoError(t, err)
+	assert.Equal(t, []int{3, 7, 5}, result)
+
+	result, err = uarray.ChunkReduce([]int{1, 2, 3}, 0, sum)
+	require.NoError(t, err)
+	assert.Equal(t, []int{6}, result)
+
+	result, err = uarray.ChunkReduce(nil, 2, sum)
+	require.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Empty(t, result)
+
+	failure := errors.New("throttled")
+	result, err = uarray.ChunkReduce([]int{1, 2, 3, 4, 5}, 2, func(chunk []int) (int, error) {
+		if chunk[0] == 3 {
+			return 0, failure
+		}
+		return sum(chunk)
+	})
+	assert.ErrorIs(t, err, failure)
+	var indexed *uerror.IndexedError
+	require.ErrorAs(t, err, &indexed)
+	assert.Equal(t, 1, indexed.Index)
+	assert.Equal(t, []int{3}, result, "results of the processed chunks must be returned")
+
+	values := []int{1, 2, 3, 4}
+	_, _ = uarray.ChunkReduce(values, 2, func(chunk []int) (int, error) {
+		_ = append(chunk, 100)
+		return 0, nil
+	})
+	assert.Equal(t, []int{1, 2, 3, 4}, values, "appending to a chunk must not overwrite the next one")
+}
+
+func TestDedupeAdjacent(t *testing.T) {
+	assert.Equal(t, []int{1, 2, 1, 3}, uarray.DedupeAdjacent([]int{1, 1, 2, 2, 2, 1, 3, 3}))
+	assert.Empty(t, uarray.DedupeAdjacent([]int{}))
+	assert.Equal(t, []string{"a"}, uarray.DedupeAdjacent([]string{"a", "a"}))
+}
+
+func TestDistinctUntilChanged(t *testing.T) {
+	readings := []float64{20.01, 20.04, 20.5, 20.52, 19.9}
+	result := uarray.DistinctUntilChanged(readings, func(a, b *float64) bool {
+		return math.Abs(*a-*b) < 0.1
+	})
+	assert.Equal(t, []float64{20.01, 20.5, 19.9}, result)
+}
+
+func TestRunLengthEncode(t *testing.T) {
+	values := []string{"ok", "ok", "ok", "fail", "ok", "ok"}
+	runs := uarray.RunLengthEncode(values)
+	assert.Equal(t, []uarray.Pair[string, int]{{"ok", 3}, {"fail", 1}, {"ok", 2}}, runs)
+	assert.Equal(t, values, uarray.RunLengthDecode(runs))
+
+	assert.Empty(t, uarray.RunLengthEncode([]int{}))
+	assert.Empty(t, uarray.RunLengthDecode([]uarray.Pair[int, int]{}))
+	assert.Equal(t, []int{7, 7}, uarray.RunLengthDecode([]uarray.Pair[int, int]{{5, 0}, {6, -1}, {7, 2}}))
+}
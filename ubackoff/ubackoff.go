@@ -0,0 +1,173 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+/*
+Package ubackoff provides delay strategies between the attempts of retried operations,
+shared by retry helpers, schedulers and reconnect loops:
+
+	b := ubackoff.NewExponential(100*time.Millisecond, 10*time.Second)
+	for {
+		if err := connect(); err == nil {
+			b.Reset()
+			serve()
+			continue
+		}
+		if err := ubackoff.Wait(ctx, b); err != nil {
+			return err
+		}
+	}
+
+Backoffs are stateful and not thread-safe, every retried operation should use its own backoff.
+*/
+package ubackoff
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/kordax/basic-utils/urand"
+)
+
+// Backoff produces the delays before the consecutive attempts.
+type Backoff interface {
+	// Next returns the delay before the next attempt.
+	Next() time.Duration
+	// Reset restarts the sequence of delays, e.g. after a successful attempt.
+	Reset()
+}
+
+type options struct {
+	rand *urand.Rand
+}
+
+// Option configures the randomized backoffs.
+type Option func(o *options)
+
+// WithRand sets the source of the random delays, e.g. urand.NewSeeded to make them reproducible in tests.
+// A randomly seeded source is used by default.
+func WithRand(r *urand.Rand) Option {
+	return func(o *options) {
+		o.rand = r
+	}
+}
+
+// Wait sleeps for the next delay of the backoff. It returns the context error if the context is done earlier.
+func Wait(ctx context.Context, b Backoff) error {
+	timer := time.NewTimer(b.Next())
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// Constant is a Backoff returning the same delay for every attempt.
+type Constant struct {
+	delay time.Duration
+}
+
+// NewConstant creates a Constant backoff.
+func NewConstant(delay time.Duration) *Constant {
+	return &Constant{delay: delay}
+}
+
+// Next returns the constant delay.
+func (c *Constant) Next() time.Duration {
+	return c.delay
+}
+
+// Reset is a no-op, since the delay never changes.
+func (c *Constant) Reset() {}
+
+// Exponential is a Backoff multiplying the delay by the factor for every attempt, up to the max delay.
+type Exponential struct {
+	base   time.Duration
+	max    time.Duration
+	factor float64
+	next   time.Duration
+}
+
+// NewExponential creates an Exponential backoff doubling the delay for every attempt: base, 2*base, 4*base and so on,
+// but never more than the max delay.
+func NewExponential(base, max time.Duration) *Exponential {
+	return NewExponentialFactor(base, max, 2)
+}
+
+// NewExponentialFactor is the same as NewExponential, but multiplies the delay by the factor.
+// Factors less than 1 are treated as 1.
+func NewExponentialFactor(base, max time.Duration, factor float64) *Exponential {
+	return &Exponential{base: base, max: max, factor: math.Max(factor, 1), next: min(base, max)}
+}
+
+// Next returns the current delay and multiplies it for the next attempt.
+func (e *Exponential) Next() time.Duration {
+	delay := e.next
+	e.next = scale(delay, e.factor, e.max)
+
+	return delay
+}
+
+// Reset restarts from the base delay.
+func (e *Exponential) Reset() {
+	e.next = min(e.base, e.max)
+}
+
+/*
+DecorrelatedJitter is a Backoff picking every delay randomly between the base delay and three times the previous delay,
+up to the max delay. The delays grow roughly exponentially, while the randomness spreads the retries of many clients
+over time, so they don't hit the recovering service all at once.
+*/
+type DecorrelatedJitter struct {
+	base time.Duration
+	max  time.Duration
+	prev time.Duration
+	rand *urand.Rand
+}
+
+// NewDecorrelatedJitter creates a DecorrelatedJitter backoff.
+func NewDecorrelatedJitter(base, max time.Duration, opts ...Option) *DecorrelatedJitter {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return &DecorrelatedJitter{base: base, max: max, prev: base, rand: o.rand}
+}
+
+// Next returns a random delay within [base, min(3*previous delay, max)].
+func (d *DecorrelatedJitter) Next() time.Duration {
+	upper := max(scale(d.prev, 3, d.max), d.base)
+	lower := min(d.base, upper)
+
+	var delay time.Duration
+	if d.rand != nil {
+		delay = time.Duration(d.rand.Int64Between(int64(lower), int64(upper)))
+	} else {
+		delay = time.Duration(urand.Int64Between(int64(lower), int64(upper)))
+	}
+	d.prev = delay
+
+	return delay
+}
+
+// Reset restarts from the base delay.
+func (d *DecorrelatedJitter) Reset() {
+	d.prev = d.base
+}
+
+// scale multiplies the delay by the factor, capping the result at the max delay without overflowing.
+func scale(delay time.Duration, factor float64, maxDelay time.Duration) time.Duration {
+	scaled := float64(delay) * factor
+	if scaled >= float64(maxDelay) {
+		return maxDelay
+	}
+
+	return time.Duration(scaled)
+}
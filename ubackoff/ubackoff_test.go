@@ -0,0 +1,84 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package ubackoff_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kordax/basic-utils/ubackoff"
+	"github.com/kordax/basic-utils/urand"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func next(b ubackoff.Backoff, n int) []time.Duration {
+	result := make([]time.Duration, n)
+	for i := range result {
+		result[i] = b.Next()
+	}
+	return result
+}
+
+func TestConstant(t *testing.T) {
+	b := ubackoff.NewConstant(time.Second)
+	assert.Equal(t, []time.Duration{time.Second, time.Second, time.Second}, next(b, 3))
+	b.Reset()
+	assert.Equal(t, time.Second, b.Next())
+}
+
+func TestExponential(t *testing.T) {
+	b := ubackoff.NewExponential(100*time.Millisecond, time.Second)
+	assert.Equal(t, []time.Duration{
+		100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond, time.Second, time.Second,
+	}, next(b, 6))
+
+	b.Reset()
+	assert.Equal(t, 100*time.Millisecond, b.Next())
+
+	factor := ubackoff.NewExponentialFactor(time.Second, time.Hour, 1.5)
+	assert.Equal(t, []time.Duration{time.Second, 1500 * time.Millisecond, 2250 * time.Millisecond}, next(factor, 3))
+
+	huge := ubackoff.NewExponential(time.Hour, time.Duration(1<<62))
+	for _, d := range next(huge, 100) {
+		require.Positive(t, d, "delays must not overflow")
+	}
+}
+
+func TestDecorrelatedJitter(t *testing.T) {
+	base, maxDelay := 10*time.Millisecond, time.Second
+	b := ubackoff.NewDecorrelatedJitter(base, maxDelay, ubackoff.WithRand(urand.NewSeeded(1)))
+
+	prev := base
+	for _, d := range next(b, 100) {
+		assert.GreaterOrEqual(t, d, base)
+		assert.LessOrEqual(t, d, min(3*prev, maxDelay))
+		prev = d
+	}
+
+	first := ubackoff.NewDecorrelatedJitter(base, maxDelay, ubackoff.WithRand(urand.NewSeeded(2)))
+	second := ubackoff.NewDecorrelatedJitter(base, maxDelay, ubackoff.WithRand(urand.NewSeeded(2)))
+	assert.Equal(t, next(first, 10), next(second, 10), "the same seed must produce the same delays")
+
+	unseeded := ubackoff.NewDecorrelatedJitter(base, maxDelay)
+	for _, d := range next(unseeded, 10) {
+		assert.GreaterOrEqual(t, d, base)
+		assert.LessOrEqual(t, d, maxDelay)
+	}
+}
+
+func TestWait(t *testing.T) {
+	require.NoError(t, ubackoff.Wait(context.Background(), ubackoff.NewConstant(time.Millisecond)))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	start := time.Now()
+	err := ubackoff.Wait(ctx, ubackoff.NewConstant(time.Hour))
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Less(t, time.Since(start), time.Second)
+}
@@ -0,0 +1,177 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package ubitset
+
+import (
+	"math/bits"
+	"sort"
+)
+
+const (
+	chunkBits  = 16
+	chunkWords = (1 << chunkBits) / 64
+	// arrayMax is the cardinality above which a container switches from a sorted array to a bitmap,
+	// at this point both representations take 8KiB.
+	arrayMax = 4096
+)
+
+// container stores the lower 16 bits of the indices sharing the same upper bits.
+// Like in Roaring bitmaps, sparse containers are kept as sorted arrays and dense ones as bitmaps.
+type container struct {
+	array  []uint16
+	bitmap []uint64
+	card   int
+}
+
+func (c *container) set(low uint16) bool {
+	if c.bitmap != nil {
+		w, m := low/64, uint64(1)<<(low%64)
+		if c.bitmap[w]&m != 0 {
+			return false
+		}
+		c.bitmap[w] |= m
+		c.card++
+		return true
+	}
+
+	i := sort.Search(len(c.array), func(i int) bool { return c.array[i] >= low })
+	if i < len(c.array) && c.array[i] == low {
+		return false
+	}
+	c.array = append(c.array, 0)
+	copy(c.array[i+1:], c.array[i:])
+	c.array[i] = low
+	c.card++
+	if c.card > arrayMax {
+		c.bitmap = c.toBitmap()
+		c.array = nil
+	}
+
+	return true
+}
+
+func (c *container) clear(low uint16) bool {
+	if c.bitmap != nil {
+		w, m := low/64, uint64(1)<<(low%64)
+		if c.bitmap[w]&m == 0 {
+			return false
+		}
+		c.bitmap[w] &^= m
+		c.card--
+		// Converting back at half of the threshold avoids flapping between representations.
+		if c.card <= arrayMax/2 {
+			c.array = c.toArray()
+			c.bitmap = nil
+		}
+		return true
+	}
+
+	i := sort.Search(len(c.array), func(i int) bool { return c.array[i] >= low })
+	if i == len(c.array) || c.array[i] != low {
+		return false
+	}
+	c.array = append(c.array[:i], c.array[i+1:]...)
+	c.card--
+
+	return true
+}
+
+func (c *container) test(low uint16) bool {
+	if c.bitmap != nil {
+		return c.bitmap[low/64]&(uint64(1)<<(low%64)) != 0
+	}
+	i := sort.Search(len(c.array), func(i int) bool { return c.array[i] >= low })
+
+	return i < len(c.array) && c.array[i] == low
+}
+
+// next returns the first set value greater than or equal to from.
+func (c *container) next(from uint16) (uint16, bool) {
+	if c.bitmap != nil {
+		w := int(from / 64)
+		word := c.bitmap[w] & (^uint64(0) << (from % 64))
+		for {
+			if word != 0 {
+				return uint16(w*64 + bits.TrailingZeros64(word)), true
+			}
+			w++
+			if w == chunkWords {
+				return 0, false
+			}
+			word = c.bitmap[w]
+		}
+	}
+
+	i := sort.Search(len(c.array), func(i int) bool { return c.array[i] >= from })
+	if i == len(c.array) {
+		return 0, false
+	}
+
+	return c.array[i], true
+}
+
+// toBitmap returns a bitmap copy of the container values.
+func (c *container) toBitmap() []uint64 {
+	result := make([]uint64, chunkWords)
+	if c.bitmap != nil {
+		copy(result, c.bitmap)
+		return result
+	}
+	for _, v := range c.array {
+		result[v/64] |= uint64(1) << (v % 64)
+	}
+
+	return result
+}
+
+func (c *container) toArray() []uint16 {
+	result := make([]uint16, 0, c.card)
+	for w, word := range c.bitmap {
+		for word != 0 {
+			result = append(result, uint16(w*64+bits.TrailingZeros64(word)))
+			word &= word - 1
+		}
+	}
+
+	return result
+}
+
+func (c *container) clone() *container {
+	result := &container{card: c.card}
+	if c.bitmap != nil {
+		result.bitmap = append([]uint64(nil), c.bitmap...)
+	} else {
+		result.array = append([]uint16(nil), c.array...)
+	}
+
+	return result
+}
+
+// fromBitmap builds a container in the most compact representation, nil is returned for an empty bitmap.
+func fromBitmap(bitmap []uint64) *container {
+	card := popcount(bitmap)
+	if card == 0 {
+		return nil
+	}
+
+	c := &container{bitmap: bitmap, card: card}
+	if card <= arrayMax {
+		c.array = c.toArray()
+		c.bitmap = nil
+	}
+
+	return c
+}
+
+func popcount(words []uint64) int {
+	count := 0
+	for _, w := range words {
+		count += bits.OnesCount64(w)
+	}
+
+	return count
+}
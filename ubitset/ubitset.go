@@ -0,0 +1,377 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+// Package ubitset provides a compact set of non-negative integers backed by bits.
+//
+// A BitSet works in one of two modes:
+//   - Dense mode (New) stores a flat slice of 64-bit words and is the fastest choice for indices
+//     that are reasonably packed near zero.
+//   - Sparse mode (NewSparse) splits the index range into 65536-bit chunks, each stored either as a sorted
+//     array or as a bitmap depending on its cardinality, similarly to Roaring bitmaps. Memory usage depends
+//     on the number of set bits rather than on the largest index, which makes it suitable for very large ranges.
+//
+// BitSet is not safe for concurrent use.
+package ubitset
+
+import (
+	"fmt"
+	"iter"
+	"math/bits"
+	"sort"
+	"strings"
+
+	"github.com/kordax/basic-utils/uconst"
+)
+
+// BitSet is a set of non-negative integer indices of type I.
+type BitSet[I uconst.Integer] struct {
+	sparse bool
+
+	words []uint64
+
+	keys       []uint64
+	containers map[uint64]*container
+}
+
+// New creates an empty dense BitSet.
+func New[I uconst.Integer]() *BitSet[I] {
+	return &BitSet[I]{}
+}
+
+// NewSparse creates an empty sparse BitSet intended for indices spread over very large ranges.
+func NewSparse[I uconst.Integer]() *BitSet[I] {
+	return &BitSet[I]{sparse: true, containers: make(map[uint64]*container)}
+}
+
+// Of creates a dense BitSet with the specified indices set.
+func Of[I uconst.Integer](indices ...I) *BitSet[I] {
+	b := New[I]()
+	for _, i := range indices {
+		b.Set(i)
+	}
+
+	return b
+}
+
+// Sparse reports whether the BitSet works in sparse mode.
+func (b *BitSet[I]) Sparse() bool {
+	return b.sparse
+}
+
+// Set adds the index to the set. It panics if the index is negative.
+func (b *BitSet[I]) Set(i I) {
+	idx := index(i)
+	if b.sparse {
+		c, ok := b.containers[idx>>chunkBits]
+		if !ok {
+			c = &container{}
+			b.addContainer(idx>>chunkBits, c)
+		}
+		c.set(uint16(idx))
+		return
+	}
+
+	w := idx / 64
+	if w >= uint64(len(b.words)) {
+		b.grow(int(w + 1))
+	}
+	b.words[w] |= uint64(1) << (idx % 64)
+}
+
+// Clear removes the index from the set. Negative indices are ignored.
+func (b *BitSet[I]) Clear(i I) {
+	if i < 0 {
+		return
+	}
+	idx := uint64(i)
+	if b.sparse {
+		key := idx >> chunkBits
+		c, ok := b.containers[key]
+		if ok && c.clear(uint16(idx)) && c.card == 0 {
+			b.removeContainer(key)
+		}
+		return
+	}
+
+	if w := idx / 64; w < uint64(len(b.words)) {
+		b.words[w] &^= uint64(1) << (idx % 64)
+	}
+}
+
+// Test reports whether the index is in the set. Negative indices are never present.
+func (b *BitSet[I]) Test(i I) bool {
+	if i < 0 {
+		return false
+	}
+	idx := uint64(i)
+	if b.sparse {
+		c, ok := b.containers[idx>>chunkBits]
+		return ok && c.test(uint16(idx))
+	}
+
+	w := idx / 64
+
+	return w < uint64(len(b.words)) && b.words[w]&(uint64(1)<<(idx%64)) != 0
+}
+
+// Count returns the number of indices in the set.
+func (b *BitSet[I]) Count() int {
+	if b.sparse {
+		count := 0
+		for _, c := range b.containers {
+			count += c.card
+		}
+		return count
+	}
+
+	return popcount(b.words)
+}
+
+// Empty reports whether the set has no indices.
+func (b *BitSet[I]) Empty() bool {
+	_, ok := b.NextSetBit(0)
+	return !ok
+}
+
+// NextSetBit returns the smallest index in the set that is greater than or equal to from.
+// The second value is false if there is no such index.
+func (b *BitSet[I]) NextSetBit(from I) (I, bool) {
+	if from < 0 {
+		from = 0
+	}
+	idx := uint64(from)
+
+	if b.sparse {
+		key := idx >> chunkBits
+		k := sort.Search(len(b.keys), func(k int) bool { return b.keys[k] >= key })
+		for ; k < len(b.keys); k++ {
+			low := uint16(0)
+			if b.keys[k] == key {
+				low = uint16(idx)
+			}
+			if v, ok := b.containers[b.keys[k]].next(low); ok {
+				return I(b.keys[k]<<chunkBits | uint64(v)), true
+			}
+		}
+		return 0, false
+	}
+
+	w := idx / 64
+	if w >= uint64(len(b.words)) {
+		return 0, false
+	}
+	word := b.words[w] & (^uint64(0) << (idx % 64))
+	for {
+		if word != 0 {
+			return I(w*64 + uint64(bits.TrailingZeros64(word))), true
+		}
+		w++
+		if w >= uint64(len(b.words)) {
+			return 0, false
+		}
+		word = b.words[w]
+	}
+}
+
+// All returns an iterator over the indices in the set in ascending order.
+// The set must not be modified during the iteration.
+func (b *BitSet[I]) All() iter.Seq[I] {
+	return func(yield func(I) bool) {
+		for i, ok := b.NextSetBit(0); ok; i, ok = b.NextSetBit(i + 1) {
+			if !yield(i) || i+1 < i {
+				return
+			}
+		}
+	}
+}
+
+// Slice returns the indices in the set in ascending order.
+func (b *BitSet[I]) Slice() []I {
+	result := make([]I, 0, b.Count())
+	for i := range b.All() {
+		result = append(result, i)
+	}
+
+	return result
+}
+
+// Clone returns a deep copy of the set that keeps its mode.
+func (b *BitSet[I]) Clone() *BitSet[I] {
+	if !b.sparse {
+		return &BitSet[I]{words: append([]uint64(nil), b.words...)}
+	}
+
+	result := NewSparse[I]()
+	result.keys = append([]uint64(nil), b.keys...)
+	for key, c := range b.containers {
+		result.containers[key] = c.clone()
+	}
+
+	return result
+}
+
+// Equal reports whether both sets contain the same indices regardless of their modes.
+func (b *BitSet[I]) Equal(other *BitSet[I]) bool {
+	if b.Count() != other.Count() {
+		return false
+	}
+	for i := range b.All() {
+		if !other.Test(i) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// And keeps only the indices present in both sets.
+func (b *BitSet[I]) And(other *BitSet[I]) {
+	switch {
+	case !b.sparse && !other.sparse:
+		if len(b.words) > len(other.words) {
+			clear(b.words[len(other.words):])
+			b.words = b.words[:len(other.words)]
+		}
+		for w := range b.words {
+			b.words[w] &= other.words[w]
+		}
+	case b.sparse && other.sparse:
+		for _, key := range append([]uint64(nil), b.keys...) {
+			oc, ok := other.containers[key]
+			if !ok {
+				b.removeContainer(key)
+				continue
+			}
+			b.combine(key, oc, func(l, r uint64) uint64 { return l & r })
+		}
+	default:
+		for _, i := range b.Slice() {
+			if !other.Test(i) {
+				b.Clear(i)
+			}
+		}
+	}
+}
+
+// Or adds all the indices of the other set.
+func (b *BitSet[I]) Or(other *BitSet[I]) {
+	switch {
+	case !b.sparse && !other.sparse:
+		if len(other.words) > len(b.words) {
+			b.grow(len(other.words))
+		}
+		for w, word := range other.words {
+			b.words[w] |= word
+		}
+	case b.sparse && other.sparse:
+		for _, key := range other.keys {
+			b.combine(key, other.containers[key], func(l, r uint64) uint64 { return l | r })
+		}
+	default:
+		for i := range other.All() {
+			b.Set(i)
+		}
+	}
+}
+
+// Xor keeps the indices present in exactly one of the sets.
+func (b *BitSet[I]) Xor(other *BitSet[I]) {
+	switch {
+	case !b.sparse && !other.sparse:
+		if len(other.words) > len(b.words) {
+			b.grow(len(other.words))
+		}
+		for w, word := range other.words {
+			b.words[w] ^= word
+		}
+	case b.sparse && other.sparse:
+		for _, key := range other.keys {
+			b.combine(key, other.containers[key], func(l, r uint64) uint64 { return l ^ r })
+		}
+	default:
+		for _, i := range other.Slice() {
+			if b.Test(i) {
+				b.Clear(i)
+			} else {
+				b.Set(i)
+			}
+		}
+	}
+}
+
+// String returns the set in the {1, 2, 3} notation.
+func (b *BitSet[I]) String() string {
+	var sb strings.Builder
+	sb.WriteByte('{')
+	for i := range b.All() {
+		if sb.Len() > 1 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString(fmt.Sprint(i))
+	}
+	sb.WriteByte('}')
+
+	return sb.String()
+}
+
+func (b *BitSet[I]) grow(words int) {
+	if words <= cap(b.words) {
+		b.words = b.words[:words]
+		return
+	}
+	grown := make([]uint64, words, max(words, 2*cap(b.words)))
+	copy(grown, b.words)
+	b.words = grown
+}
+
+// combine applies the word operation to the receiver and the other container sharing the same key.
+func (b *BitSet[I]) combine(key uint64, other *container, op func(l, r uint64) uint64) {
+	left := make([]uint64, chunkWords)
+	if c, ok := b.containers[key]; ok {
+		left = c.toBitmap()
+	}
+	right := other.toBitmap()
+	for w := range left {
+		left[w] = op(left[w], right[w])
+	}
+
+	c := fromBitmap(left)
+	if c == nil {
+		if _, ok := b.containers[key]; ok {
+			b.removeContainer(key)
+		}
+		return
+	}
+	if _, ok := b.containers[key]; ok {
+		b.containers[key] = c
+		return
+	}
+	b.addContainer(key, c)
+}
+
+func (b *BitSet[I]) addContainer(key uint64, c *container) {
+	b.containers[key] = c
+	k := sort.Search(len(b.keys), func(k int) bool { return b.keys[k] >= key })
+	b.keys = append(b.keys, 0)
+	copy(b.keys[k+1:], b.keys[k:])
+	b.keys[k] = key
+}
+
+func (b *BitSet[I]) removeContainer(key uint64) {
+	delete(b.containers, key)
+	k := sort.Search(len(b.keys), func(k int) bool { return b.keys[k] >= key })
+	if k < len(b.keys) && b.keys[k] == key {
+		b.keys = append(b.keys[:k], b.keys[k+1:]...)
+	}
+}
+
+func index[I uconst.Integer](i I) uint64 {
+	if i < 0 {
+		panic(fmt.Sprintf("ubitset: negative index %v", i))
+	}
+
+	return uint64(i)
+}
@@ -0,0 +1,172 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package ubitset_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/kordax/basic-utils/ubitset"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func constructors() map[string]func() *ubitset.BitSet[int] {
+	return map[string]func() *ubitset.BitSet[int]{
+		"dense":  ubitset.New[int],
+		"sparse": ubitset.NewSparse[int],
+	}
+}
+
+func TestBitSet_SetClearTest(t *testing.T) {
+	for name, ctor := range constructors() {
+		t.Run(name, func(t *testing.T) {
+			b := ctor()
+			assert.True(t, b.Empty())
+			b.Set(1)
+			b.Set(64)
+			b.Set(100000)
+			b.Set(64)
+
+			assert.True(t, b.Test(1))
+			assert.True(t, b.Test(64))
+			assert.True(t, b.Test(100000))
+			assert.False(t, b.Test(2))
+			assert.False(t, b.Test(-1))
+			assert.False(t, b.Test(1<<30))
+			assert.Equal(t, 3, b.Count())
+			assert.Equal(t, []int{1, 64, 100000}, b.Slice())
+			assert.Equal(t, "{1, 64, 100000}", b.String())
+
+			b.Clear(64)
+			b.Clear(-5)
+			b.Clear(1 << 30)
+			assert.False(t, b.Test(64))
+			assert.Equal(t, 2, b.Count())
+
+			assert.Panics(t, func() { b.Set(-1) })
+		})
+	}
+}
+
+func TestBitSet_NextSetBit(t *testing.T) {
+	for name, ctor := range constructors() {
+		t.Run(name, func(t *testing.T) {
+			b := ctor()
+			_, ok := b.NextSetBit(0)
+			assert.False(t, ok)
+
+			b.Set(3)
+			b.Set(70000)
+			next, ok := b.NextSetBit(-10)
+			require.True(t, ok)
+			assert.Equal(t, 3, next)
+			next, ok = b.NextSetBit(4)
+			require.True(t, ok)
+			assert.Equal(t, 70000, next)
+			_, ok = b.NextSetBit(70001)
+			assert.False(t, ok)
+		})
+	}
+}
+
+func TestBitSet_All_MaxIndex(t *testing.T) {
+	b := ubitset.Of[uint8](0, 255)
+	assert.Equal(t, []uint8{0, 255}, b.Slice())
+
+	var first []uint8
+	for i := range b.All() {
+		first = append(first, i)
+		break
+	}
+	assert.Equal(t, []uint8{0}, first)
+}
+
+func TestBitSet_Operations(t *testing.T) {
+	for leftName, left := range constructors() {
+		for rightName, right := range constructors() {
+			t.Run(leftName+"-"+rightName, func(t *testing.T) {
+				build := func(ctor func() *ubitset.BitSet[int], values ...int) *ubitset.BitSet[int] {
+					b := ctor()
+					for _, v := range values {
+						b.Set(v)
+					}
+					return b
+				}
+
+				a := build(left, 1, 2, 3, 200000)
+				a.And(build(right, 2, 3, 4))
+				assert.Equal(t, []int{2, 3}, a.Slice())
+
+				a = build(left, 1, 2)
+				a.Or(build(right, 2, 3, 200000))
+				assert.Equal(t, []int{1, 2, 3, 200000}, a.Slice())
+
+				a = build(left, 1, 2, 200000)
+				a.Xor(build(right, 2, 3, 200000))
+				assert.Equal(t, []int{1, 3}, a.Slice())
+			})
+		}
+	}
+}
+
+func TestBitSet_SparseMatchesDense(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	dense, sparse := ubitset.New[int](), ubitset.NewSparse[int]()
+	other := ubitset.NewSparse[int]()
+
+	// Enough values in a single chunk to switch between array and bitmap containers.
+	for i := 0; i < 20000; i++ {
+		v := r.Intn(1 << 17)
+		dense.Set(v)
+		sparse.Set(v)
+		other.Set(r.Intn(1 << 17))
+	}
+	require.True(t, dense.Equal(sparse))
+	assert.Equal(t, dense.Count(), sparse.Count())
+
+	for i := 0; i < 15000; i++ {
+		v := r.Intn(1 << 17)
+		dense.Clear(v)
+		sparse.Clear(v)
+	}
+	require.True(t, sparse.Equal(dense))
+	assert.Equal(t, dense.Slice(), sparse.Slice())
+
+	denseOther := ubitset.New[int]()
+	denseOther.Or(other)
+
+	for _, op := range []func(l, r *ubitset.BitSet[int]){
+		(*ubitset.BitSet[int]).And,
+		(*ubitset.BitSet[int]).Or,
+		(*ubitset.BitSet[int]).Xor,
+	} {
+		d, s := dense.Clone(), sparse.Clone()
+		op(d, denseOther)
+		op(s, other)
+		assert.True(t, s.Sparse())
+		assert.Equal(t, d.Slice(), s.Slice())
+	}
+	assert.True(t, dense.Equal(sparse), "clones must not share state")
+}
+
+func TestBitSet_SparseLargeRange(t *testing.T) {
+	b := ubitset.NewSparse[uint64]()
+	b.Set(1 << 62)
+	b.Set(1<<62 + 1)
+	b.Set(5)
+
+	assert.Equal(t, []uint64{5, 1 << 62, 1<<62 + 1}, b.Slice())
+	next, ok := b.NextSetBit(6)
+	require.True(t, ok)
+	assert.Equal(t, uint64(1<<62), next)
+
+	b.Clear(5)
+	b.Clear(1 << 62)
+	b.Clear(1<<62 + 1)
+	assert.True(t, b.Empty())
+}
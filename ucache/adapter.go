@@ -0,0 +1,224 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package ucache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/kordax/basic-utils/uopt"
+)
+
+// ErrNotFound is returned by the adapters when the key is not present in the cache or has expired.
+var ErrNotFound = errors.New("ucache: key not found")
+
+/*
+ContextAdapter adapts a BaseCache to the context-aware interface shared by the community cache libraries
+(gocache, ristretto wrappers etc.), so ucache can be adopted incrementally behind the existing caching abstractions:
+
+	Get(ctx, key) (value, error)
+	Set(ctx, key, value, ttl) error
+
+Missing and expired keys are reported with ErrNotFound. Entries expire either when the underlying cache considers them
+outdated, or after the TTL passed to Set, which the adapter tracks on its own.
+Operations fail with the context error if the context is already done.
+Use Untyped for the interfaces operating on any keys and values.
+*/
+type ContextAdapter[K comparable, T any] struct {
+	cache BaseCache[K, T]
+
+	mtx       sync.Mutex
+	deadlines map[K]time.Time
+	sweepAt   int
+}
+
+// NewContextAdapter wraps the cache into a ContextAdapter.
+func NewContextAdapter[K comparable, T any](cache BaseCache[K, T]) *ContextAdapter[K, T] {
+	return &ContextAdapter[K, T]{
+		cache:     cache,
+		deadlines: make(map[K]time.Time),
+		sweepAt:   64,
+	}
+}
+
+// Get returns the value of the key or ErrNotFound.
+func (a *ContextAdapter[K, T]) Get(ctx context.Context, key K) (T, error) {
+	value, _, err := a.GetWithTTL(ctx, key)
+	return value, err
+}
+
+// GetWithTTL returns the value of the key along with its remaining TTL, which is zero for the entries set without TTL.
+func (a *ContextAdapter[K, T]) GetWithTTL(ctx context.Context, key K) (T, time.Duration, error) {
+	var zero T
+	if err := ctx.Err(); err != nil {
+		return zero, 0, err
+	}
+
+	value, ok := a.cache.Get(key)
+	if !ok || a.cache.Outdated(uopt.Of(key)) {
+		return zero, 0, ErrNotFound
+	}
+
+	a.mtx.Lock()
+	deadline, limited := a.deadlines[key]
+	a.mtx.Unlock()
+	if !limited {
+		return *value, 0, nil
+	}
+
+	ttl := time.Until(deadline)
+	if ttl <= 0 {
+		a.drop(key)
+		return zero, 0, ErrNotFound
+	}
+
+	return *value, ttl, nil
+}
+
+// Set stores the value. A positive TTL limits the entry lifetime, otherwise only the cache TTL applies.
+func (a *ContextAdapter[K, T]) Set(ctx context.Context, key K, value T, ttl time.Duration) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+	a.cache.Set(key, value)
+	if ttl <= 0 {
+		delete(a.deadlines, key)
+		return nil
+	}
+
+	now := time.Now()
+	a.deadlines[key] = now.Add(ttl)
+	if len(a.deadlines) >= a.sweepAt {
+		for k, deadline := range a.deadlines {
+			if now.After(deadline) {
+				delete(a.deadlines, k)
+			}
+		}
+		a.sweepAt = max(2*len(a.deadlines), 64)
+	}
+
+	return nil
+}
+
+// Delete removes the key.
+func (a *ContextAdapter[K, T]) Delete(ctx context.Context, key K) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	a.drop(key)
+
+	return nil
+}
+
+// Clear removes all the entries.
+func (a *ContextAdapter[K, T]) Clear(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+	a.cache.Drop()
+	clear(a.deadlines)
+
+	return nil
+}
+
+// Close closes the wrapped cache, so the adapter can be used where the community interfaces expect an io.Closer.
+func (a *ContextAdapter[K, T]) Close() error {
+	return closeCache(a.cache)
+}
+
+// Untyped returns a view of the adapter operating on any keys and values.
+func (a *ContextAdapter[K, T]) Untyped() *UntypedAdapter[K, T] {
+	return &UntypedAdapter[K, T]{adapter: a}
+}
+
+func (a *ContextAdapter[K, T]) drop(key K) {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+	a.cache.DropKey(key)
+	delete(a.deadlines, key)
+}
+
+// UntypedAdapter is a ContextAdapter view for the interfaces operating on any keys and values.
+// Keys and values of unexpected types are rejected with an error.
+type UntypedAdapter[K comparable, T any] struct {
+	adapter *ContextAdapter[K, T]
+}
+
+// Get returns the value of the key or ErrNotFound.
+func (a *UntypedAdapter[K, T]) Get(ctx context.Context, key any) (any, error) {
+	k, err := castAdapterArg[K]("key", key)
+	if err != nil {
+		return nil, err
+	}
+	value, err := a.adapter.Get(ctx, k)
+	if err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
+
+// GetWithTTL returns the value of the key along with its remaining TTL, which is zero for the entries set without TTL.
+func (a *UntypedAdapter[K, T]) GetWithTTL(ctx context.Context, key any) (any, time.Duration, error) {
+	k, err := castAdapterArg[K]("key", key)
+	if err != nil {
+		return nil, 0, err
+	}
+	value, ttl, err := a.adapter.GetWithTTL(ctx, k)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return value, ttl, nil
+}
+
+// Set stores the value. A positive TTL limits the entry lifetime, otherwise only the cache TTL applies.
+func (a *UntypedAdapter[K, T]) Set(ctx context.Context, key any, value any, ttl time.Duration) error {
+	k, err := castAdapterArg[K]("key", key)
+	if err != nil {
+		return err
+	}
+	v, err := castAdapterArg[T]("value", value)
+	if err != nil {
+		return err
+	}
+
+	return a.adapter.Set(ctx, k, v, ttl)
+}
+
+// Delete removes the key.
+func (a *UntypedAdapter[K, T]) Delete(ctx context.Context, key any) error {
+	k, err := castAdapterArg[K]("key", key)
+	if err != nil {
+		return err
+	}
+
+	return a.adapter.Delete(ctx, k)
+}
+
+// Clear removes all the entries.
+func (a *UntypedAdapter[K, T]) Clear(ctx context.Context) error {
+	return a.adapter.Clear(ctx)
+}
+
+func castAdapterArg[V any](name string, arg any) (V, error) {
+	v, ok := arg.(V)
+	if !ok {
+		return v, fmt.Errorf("ucache: unexpected %s type %T, expected %T", name, arg, *new(V))
+	}
+
+	return v, nil
+}
@@ -0,0 +1,102 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package ucache_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kordax/basic-utils/ucache"
+	"github.com/kordax/basic-utils/uopt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// communityStore mirrors the interface commonly expected by the community cache abstractions.
+type communityStore interface {
+	Get(ctx context.Context, key any) (any, error)
+	Set(ctx context.Context, key any, value any, ttl time.Duration) error
+	Delete(ctx context.Context, key any) error
+	Clear(ctx context.Context) error
+}
+
+func TestContextAdapter(t *testing.T) {
+	ctx := context.Background()
+	a := ucache.NewContextAdapter(ucache.NewSimpleCache[string, int](uopt.Null[time.Duration]()))
+
+	_, err := a.Get(ctx, "a")
+	assert.ErrorIs(t, err, ucache.ErrNotFound)
+
+	require.NoError(t, a.Set(ctx, "a", 1, 0))
+	value, ttl, err := a.GetWithTTL(ctx, "a")
+	require.NoError(t, err)
+	assert.Equal(t, 1, value)
+	assert.Zero(t, ttl)
+
+	require.NoError(t, a.Set(ctx, "b", 2, 30*time.Millisecond))
+	value, ttl, err = a.GetWithTTL(ctx, "b")
+	require.NoError(t, err)
+	assert.Equal(t, 2, value)
+	assert.Positive(t, ttl)
+	time.Sleep(40 * time.Millisecond)
+	_, err = a.Get(ctx, "b")
+	assert.ErrorIs(t, err, ucache.ErrNotFound)
+
+	// resetting the TTL
+	require.NoError(t, a.Set(ctx, "a", 3, time.Millisecond))
+	require.NoError(t, a.Set(ctx, "a", 4, 0))
+	time.Sleep(5 * time.Millisecond)
+	value, err = a.Get(ctx, "a")
+	require.NoError(t, err)
+	assert.Equal(t, 4, value)
+
+	require.NoError(t, a.Delete(ctx, "a"))
+	_, err = a.Get(ctx, "a")
+	assert.ErrorIs(t, err, ucache.ErrNotFound)
+
+	require.NoError(t, a.Set(ctx, "c", 5, 0))
+	require.NoError(t, a.Clear(ctx))
+	_, err = a.Get(ctx, "c")
+	assert.ErrorIs(t, err, ucache.ErrNotFound)
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	assert.ErrorIs(t, a.Set(canceled, "d", 1, 0), context.Canceled)
+	_, err = a.Get(canceled, "d")
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestContextAdapter_CacheTTL(t *testing.T) {
+	ctx := context.Background()
+	a := ucache.NewContextAdapter(ucache.NewSimpleCache[string, int](uopt.Of(20 * time.Millisecond)))
+	require.NoError(t, a.Set(ctx, "a", 1, time.Hour))
+	time.Sleep(30 * time.Millisecond)
+
+	_, err := a.Get(ctx, "a")
+	assert.ErrorIs(t, err, ucache.ErrNotFound, "the cache TTL must be respected")
+}
+
+func TestUntypedAdapter(t *testing.T) {
+	ctx := context.Background()
+	var store communityStore = ucache.NewContextAdapter(ucache.NewSimpleCache[string, int](uopt.Null[time.Duration]())).Untyped()
+
+	require.NoError(t, store.Set(ctx, "a", 1, 0))
+	value, err := store.Get(ctx, "a")
+	require.NoError(t, err)
+	assert.Equal(t, 1, value)
+
+	assert.ErrorContains(t, store.Set(ctx, 1, 1, 0), "unexpected key type int")
+	assert.ErrorContains(t, store.Set(ctx, "a", "1", 0), "unexpected value type string")
+	_, err = store.Get(ctx, 1)
+	assert.Error(t, err)
+	_, err = store.Get(ctx, "b")
+	assert.ErrorIs(t, err, ucache.ErrNotFound)
+
+	require.NoError(t, store.Delete(ctx, "a"))
+	require.NoError(t, store.Clear(ctx))
+}
@@ -0,0 +1,188 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+// Package bench provides reusable benchmark harnesses for ucache implementations,
+// so changes of eviction, sharding or storage strategies can be evaluated with the same workloads.
+//
+//	func BenchmarkMyCache(b *testing.B) {
+//		c := ucache.NewInMemoryComparableMapCache[int, int](uopt.Null[time.Duration]())
+//		bench.Run(b, c, bench.Workload{Keys: 10_000, Distribution: bench.Zipfian(1.1), ReadRatio: 0.9}, bench.IntKeys, bench.IntKeys)
+//	}
+//
+// Besides the standard ns/op and allocs/op, the hit rate of reads is reported as the "hit%" metric.
+package bench
+
+import (
+	"math/rand"
+	"runtime"
+	"sync/atomic"
+	"testing"
+
+	"github.com/kordax/basic-utils/ucache"
+)
+
+// KeyGenerator returns the index of the next key to access.
+type KeyGenerator func() int
+
+// Distribution creates a KeyGenerator for the key space of the provided size using the random source.
+type Distribution func(r *rand.Rand, keys int) KeyGenerator
+
+// Uniform accesses all the keys with equal probability.
+func Uniform() Distribution {
+	return func(r *rand.Rand, keys int) KeyGenerator {
+		return func() int {
+			return r.Intn(keys)
+		}
+	}
+}
+
+// Zipfian accesses the keys with the Zipf distribution, so a few keys are hot and most of the keys are cold,
+// like in the most real-world caches. The skew s must be greater than 1, the bigger it is the hotter the top keys are.
+func Zipfian(s float64) Distribution {
+	return func(r *rand.Rand, keys int) KeyGenerator {
+		z := rand.NewZipf(r, s, 1, uint64(keys-1))
+		return func() int {
+			return int(z.Uint64())
+		}
+	}
+}
+
+// Workload describes the benchmark load.
+type Workload struct {
+	// Keys is the size of the key space, 1000 by default.
+	Keys int
+	// Distribution of the accessed keys, Uniform by default.
+	Distribution Distribution
+	// ReadRatio is the share of Get operations, the rest are Set operations. 0 means write-only load.
+	ReadRatio float64
+	// Prefill is the share of the key space populated before the measurement.
+	Prefill float64
+	// Seed of the random source, so runs are reproducible.
+	Seed int64
+}
+
+// Result contains the operation counters of a benchmark run.
+type Result struct {
+	Reads  int64
+	Writes int64
+	Hits   int64
+}
+
+// HitRate returns the share of reads that found a value, 0 if there were no reads.
+func (r Result) HitRate() float64 {
+	if r.Reads == 0 {
+		return 0
+	}
+
+	return float64(r.Hits) / float64(r.Reads)
+}
+
+// IntKeys maps a key index to itself, it can be used for both keys and values.
+func IntKeys(i int) int {
+	return i
+}
+
+// Run executes b.N operations of the workload against the cache sequentially.
+// The key and value funcs map the key index produced by the distribution to the cache types.
+// Allocations and the "hit%" metric are reported.
+func Run[K, T any](b *testing.B, cache ucache.BaseCache[K, T], w Workload, key func(i int) K, value func(i int) T) Result {
+	w = w.withDefaults()
+	prefill(cache, w, key, value)
+
+	r := rand.New(rand.NewSource(w.Seed))
+	next := w.Distribution(r, w.Keys)
+	var result Result
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		runOp(cache, r, next(), w.ReadRatio, key, value, &result)
+	}
+	b.StopTimer()
+	b.ReportMetric(result.HitRate()*100, "hit%")
+
+	return result
+}
+
+// RunParallel is the same as Run, but executes the operations with b.RunParallel to measure the contention.
+// Every goroutine gets its own random source derived from the workload seed.
+func RunParallel[K, T any](b *testing.B, cache ucache.BaseCache[K, T], w Workload, key func(i int) K, value func(i int) T) Result {
+	w = w.withDefaults()
+	prefill(cache, w, key, value)
+
+	var seed atomic.Int64
+	seed.Store(w.Seed)
+	var reads, writes, hits atomic.Int64
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		r := rand.New(rand.NewSource(seed.Add(1)))
+		next := w.Distribution(r, w.Keys)
+		var local Result
+		for pb.Next() {
+			runOp(cache, r, next(), w.ReadRatio, key, value, &local)
+		}
+		reads.Add(local.Reads)
+		writes.Add(local.Writes)
+		hits.Add(local.Hits)
+	})
+	b.StopTimer()
+
+	result := Result{Reads: reads.Load(), Writes: writes.Load(), Hits: hits.Load()}
+	b.ReportMetric(result.HitRate()*100, "hit%")
+
+	return result
+}
+
+// MeasureMemory returns the growth of the live heap in bytes caused by the func, e.g. by filling a cache.
+// Garbage is collected before and after the call, so the result approximates the retained memory.
+// Objects created by the func must stay reachable until MeasureMemory returns.
+func MeasureMemory(fn func()) uint64 {
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	fn()
+	runtime.GC()
+	runtime.ReadMemStats(&after)
+
+	if after.HeapAlloc < before.HeapAlloc {
+		return 0
+	}
+
+	return after.HeapAlloc - before.HeapAlloc
+}
+
+func (w Workload) withDefaults() Workload {
+	if w.Keys <= 0 {
+		w.Keys = 1000
+	}
+	if w.Distribution == nil {
+		w.Distribution = Uniform()
+	}
+
+	return w
+}
+
+func prefill[K, T any](cache ucache.BaseCache[K, T], w Workload, key func(i int) K, value func(i int) T) {
+	n := int(float64(w.Keys) * w.Prefill)
+	for i := 0; i < n && i < w.Keys; i++ {
+		cache.SetQuietly(key(i), value(i))
+	}
+}
+
+func runOp[K, T any](cache ucache.BaseCache[K, T], r *rand.Rand, i int, readRatio float64, key func(i int) K, value func(i int) T, result *Result) {
+	if r.Float64() < readRatio {
+		result.Reads++
+		if _, ok := cache.Get(key(i)); ok {
+			result.Hits++
+		}
+		return
+	}
+
+	result.Writes++
+	cache.Set(key(i), value(i))
+}
@@ -0,0 +1,89 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package bench_test
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/kordax/basic-utils/ucache"
+	"github.com/kordax/basic-utils/ucache/bench"
+	"github.com/kordax/basic-utils/uopt"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDistributions(t *testing.T) {
+	const keys, samples = 100, 10_000
+	r := rand.New(rand.NewSource(1))
+
+	for name, d := range map[string]bench.Distribution{"uniform": bench.Uniform(), "zipfian": bench.Zipfian(1.2)} {
+		t.Run(name, func(t *testing.T) {
+			next := d(r, keys)
+			counts := make([]int, keys)
+			for i := 0; i < samples; i++ {
+				k := next()
+				assert.True(t, k >= 0 && k < keys)
+				counts[k]++
+			}
+			if name == "zipfian" {
+				assert.Greater(t, counts[0], samples/5, "the first key must be hot")
+			} else {
+				assert.Less(t, counts[0], samples/20)
+			}
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	var result bench.Result
+	res := testing.Benchmark(func(b *testing.B) {
+		c := ucache.NewInMemoryComparableMapCache[int, int](uopt.Null[time.Duration]())
+		result = bench.Run(b, c, bench.Workload{Keys: 100, ReadRatio: 1, Prefill: 0.5, Seed: 1}, bench.IntKeys, bench.IntKeys)
+	})
+
+	assert.Positive(t, res.N)
+	assert.EqualValues(t, res.N, result.Reads)
+	assert.Zero(t, result.Writes)
+	assert.InDelta(t, 0.5, result.HitRate(), 0.15)
+	assert.Contains(t, res.Extra, "hit%")
+}
+
+func TestRunParallel(t *testing.T) {
+	var result bench.Result
+	res := testing.Benchmark(func(b *testing.B) {
+		c := ucache.NewInMemoryComparableMapCache[int, int](uopt.Null[time.Duration]())
+		result = bench.RunParallel(b, c, bench.Workload{ReadRatio: 0.5, Distribution: bench.Zipfian(1.1)}, bench.IntKeys, bench.IntKeys)
+	})
+
+	assert.EqualValues(t, res.N, result.Reads+result.Writes)
+	assert.Positive(t, result.Writes)
+}
+
+func TestMeasureMemory(t *testing.T) {
+	var data []byte
+	size := bench.MeasureMemory(func() {
+		data = make([]byte, 1<<20)
+	})
+	assert.InDelta(t, 1<<20, size, 1<<16)
+	assert.Len(t, data, 1<<20)
+	assert.Zero(t, bench.Result{}.HitRate())
+}
+
+func BenchmarkComparableMapCache(b *testing.B) {
+	workloads := map[string]bench.Workload{
+		"uniform-read-heavy": {Keys: 10_000, Distribution: bench.Uniform(), ReadRatio: 0.9, Prefill: 1},
+		"zipfian-read-heavy": {Keys: 10_000, Distribution: bench.Zipfian(1.1), ReadRatio: 0.9, Prefill: 0.5},
+		"zipfian-write-only": {Keys: 10_000, Distribution: bench.Zipfian(1.1)},
+	}
+	for name, w := range workloads {
+		b.Run(name, func(b *testing.B) {
+			c := ucache.NewInMemoryComparableMapCache[int, int](uopt.Of(time.Minute))
+			bench.RunParallel(b, c, w, bench.IntKeys, bench.IntKeys)
+		})
+	}
+}
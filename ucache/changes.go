@@ -0,0 +1,493 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package ucache
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/kordax/basic-utils/uopt"
+)
+
+// ChangeKind describes what happened to a cache entry.
+type ChangeKind int
+
+const (
+	// ChangeSet means that the value was set or loaded for the first time.
+	ChangeSet ChangeKind = iota
+	// ChangeDelete means that the key was removed from the cache.
+	ChangeDelete
+	// ChangeRefresh means that an existing value was replaced with a freshly loaded one.
+	ChangeRefresh
+	// ChangeClear means that the whole cache was dropped. The event key is the zero value.
+	ChangeClear
+	// ChangeExpire means that the key was removed because it was outdated, e.g. by the ManagedCache cleanup.
+	ChangeExpire
+)
+
+func (k ChangeKind) String() string {
+	switch k {
+	case ChangeSet:
+		return "set"
+	case ChangeDelete:
+		return "delete"
+	case ChangeRefresh:
+		return "refresh"
+	case ChangeClear:
+		return "clear"
+	case ChangeExpire:
+		return "expire"
+	default:
+		return "unknown"
+	}
+}
+
+// ChangeEvent describes a single modification of a cache entry.
+type ChangeEvent[K any] struct {
+	Key  K
+	Kind ChangeKind
+	At   time.Time
+}
+
+// ChangeLogReader is implemented by the caches that keep a typed log of their changes, including all the caches
+// of this package. It's not a part of BaseCache and MultiCache, so custom caches don't have to implement it.
+type ChangeLogReader[K any] interface {
+	// ChangeLog returns the latest change of every key modified since the last ResetChanges, in the order they were made.
+	// Set and Put are reported as ChangeSet, DropKey and evictions as ChangeDelete and the removal of outdated keys
+	// by ManagedCache and ManagedMultiCache as ChangeExpire. Drop replaces the whole log with a single ChangeClear event.
+	// The quiet modifications are not reported and Get never alters the change history.
+	// The keys that were set are kept until the changes are reset, while the removed keys are not present in the cache
+	// anymore, so only the latest 256 to 512 removals are kept.
+	// The returned slice is a copy owned by the caller. This method should be thread-safe.
+	ChangeLog() []ChangeEvent[K]
+}
+
+// changeLogOf returns the change log of the cache, or nil if it doesn't implement ChangeLogReader.
+func changeLogOf[K any](cache any) []ChangeEvent[K] {
+	if r, ok := cache.(ChangeLogReader[K]); ok {
+		return r.ChangeLog()
+	}
+
+	return nil
+}
+
+// ChangesCounter is implemented by the caches able to count their changes cheaply, including all the caches of this package.
+type ChangesCounter interface {
+	// ChangesCount returns the number of keys returned by Changes without copying them, which is cheap enough for monitoring.
+	// This method should be thread-safe.
+	ChangesCount() int
+}
+
+// changesCount returns the number of the changes of the cache, counting the keys returned by Changes
+// if it doesn't implement ChangesCounter.
+func changesCount[K any](cache interface{ Changes() []K }) int {
+	if c, ok := cache.(ChangesCounter); ok {
+		return c.ChangesCount()
+	}
+
+	return len(cache.Changes())
+}
+
+// ChangesResetter is implemented by the caches able to clear their change history, including all the caches of this package.
+type ChangesResetter[K any] interface {
+	// ResetChanges atomically returns the keys returned by Changes and clears the change history including ChangeLog,
+	// so every change is returned exactly once even if the cache is modified concurrently.
+	// This method should be thread-safe.
+	ResetChanges() []K
+}
+
+// resetChanges resets the changes of the cache, or returns nil if it doesn't implement ChangesResetter.
+func resetChanges[K any](cache any) []K {
+	if r, ok := cache.(ChangesResetter[K]); ok {
+		return r.ResetChanges()
+	}
+
+	return nil
+}
+
+// OutdatedKeysLister is implemented by the caches able to list their outdated keys, including all the caches of this package.
+// ManagedCache and ManagedMultiCache check every changed key with Outdated for the caches that don't implement it.
+type OutdatedKeysLister[K any] interface {
+	// OutdatedKeys returns the keys that are outdated based on the set TTL. The order of keys is not defined.
+	// If no TTL is set returns an empty slice. This method should be thread-safe.
+	OutdatedKeys() []K
+}
+
+// changesOutdater is the part of BaseCache and MultiCache used to find the outdated keys.
+type changesOutdater[K any] interface {
+	Changes() []K
+	Outdated(key uopt.Opt[K]) bool
+}
+
+// outdatedKeysOf returns the outdated keys of the cache. If the cache doesn't implement OutdatedKeysLister,
+// its changed keys are checked with Outdated.
+func outdatedKeysOf[K any](cache changesOutdater[K]) []K {
+	if l, ok := cache.(OutdatedKeysLister[K]); ok {
+		return l.OutdatedKeys()
+	}
+	var keys []K
+	for _, key := range cache.Changes() {
+		if cache.Outdated(uopt.Of(key)) {
+			keys = append(keys, key)
+		}
+	}
+
+	return keys
+}
+
+// expirer is implemented by the caches that distinguish the removal of outdated keys from DropKey in their change logs.
+type expirer[K any] interface {
+	expireKeys(keys []K)
+}
+
+// expireKeys removes the outdated keys, reporting ChangeExpire if the cache supports it.
+func expireKeys[K any](cache interface{ DropKey(key K) }, keys []K) {
+	if e, ok := cache.(expirer[K]); ok {
+		e.expireKeys(keys)
+		return
+	}
+	for _, key := range keys {
+		cache.DropKey(key)
+	}
+}
+
+// outdatedExpirer is implemented by the caches able to remove their outdated entries by the key fingerprints
+// computed on write, which keeps the removal correct even if the callers mutated the keys since.
+type outdatedExpirer[K any] interface {
+	expireOutdated() []K
+}
+
+// expireOutdated removes the outdated entries of the cache, reporting ChangeExpire if the cache supports it,
+// and returns their keys.
+func expireOutdated[K any](cache interface {
+	changesOutdater[K]
+	DropKey(key K)
+}) []K {
+	if e, ok := cache.(outdatedExpirer[K]); ok {
+		return e.expireOutdated()
+	}
+	keys := outdatedKeysOf[K](cache)
+	expireKeys(cache, keys)
+
+	return keys
+}
+
+type changeEntry[K any] struct {
+	event ChangeEvent[K]
+	seq   uint64
+}
+
+// maxChangeRemovals bounds the number of removals kept in a change log, so caches whose changes are never reset,
+// e.g. the ones cleaned up by ManagedCache, don't accumulate the removed keys forever.
+const maxChangeRemovals = 256
+
+type removalRef[H comparable] struct {
+	id  H
+	seq uint64
+}
+
+// changeLog keeps the latest change of every modified key, identified by H, until it's reset.
+// Keeping a single change per key bounds the log by the number of keys rather than the number of modifications.
+// Removed keys are not present in the cache anymore, so only the latest removals are kept, from maxChangeRemovals to twice as many.
+// The legacy Changes view consists of the keys whose latest change is ChangeSet. Not thread-safe.
+type changeLog[H comparable, K any] struct {
+	entries  map[H]changeEntry[K]
+	cleared  *changeEntry[K]
+	removals []removalRef[H] // removals in the order they were made, may refer to the entries replaced since then
+	seq      uint64
+	sets     int
+	peak     int // the peak number of entries, see shrinkMap
+}
+
+func newChangeLog[H comparable, K any]() *changeLog[H, K] {
+	return &changeLog[H, K]{entries: make(map[H]changeEntry[K])}
+}
+
+func (l *changeLog[H, K]) record(id H, key K, kind ChangeKind) {
+	if prev, ok := l.entries[id]; ok && prev.event.Kind == ChangeSet {
+		l.sets--
+	}
+	l.seq++
+	l.entries[id] = changeEntry[K]{event: ChangeEvent[K]{Key: key, Kind: kind, At: time.Now()}, seq: l.seq}
+	if kind == ChangeSet {
+		l.sets++
+		return
+	}
+
+	l.removals = append(l.removals, removalRef[H]{id: id, seq: l.seq})
+	if len(l.removals) <= 2*maxChangeRemovals {
+		return
+	}
+	// The excess is dropped in batches, so the cost is amortized over the removals.
+	// Only the references to the entries that weren't replaced are actual removals.
+	live := l.removals[:0]
+	for _, r := range l.removals {
+		if e, ok := l.entries[r.id]; ok && e.seq == r.seq {
+			live = append(live, r)
+		}
+	}
+	if excess := len(live) - maxChangeRemovals; excess > 0 {
+		for _, r := range live[:excess] {
+			delete(l.entries, r.id)
+		}
+		live = live[excess:]
+	}
+	l.removals = live
+	l.entries, l.peak = shrinkMap(l.entries, l.peak)
+}
+
+// event returns the latest change of the key identified by id.
+func (l *changeLog[H, K]) event(id H) (ChangeEvent[K], bool) {
+	e, ok := l.entries[id]
+	return e.event, ok
+}
+
+// recordClear replaces all the changes with a single ChangeClear.
+func (l *changeLog[H, K]) recordClear() {
+	clear(l.entries)
+	l.removals = nil
+	l.sets = 0
+	l.seq++
+	l.cleared = &changeEntry[K]{event: ChangeEvent[K]{Kind: ChangeClear, At: time.Now()}, seq: l.seq}
+}
+
+// keys returns the keys whose latest change is ChangeSet in the order of their changes.
+func (l *changeLog[H, K]) keys() []K {
+	result := make([]K, 0, l.sets)
+	for _, e := range l.sorted() {
+		if e.event.Kind == ChangeSet {
+			result = append(result, e.event.Key)
+		}
+	}
+
+	return result
+}
+
+func (l *changeLog[H, K]) count() int {
+	return l.sets
+}
+
+// events returns all the changes in the order they were made.
+func (l *changeLog[H, K]) events() []ChangeEvent[K] {
+	sorted := l.sorted()
+	result := make([]ChangeEvent[K], len(sorted))
+	for i, e := range sorted {
+		result[i] = e.event
+	}
+
+	return result
+}
+
+// reset returns the legacy keys view and clears the log.
+func (l *changeLog[H, K]) reset() []K {
+	keys := l.keys()
+	clear(l.entries)
+	l.cleared = nil
+	l.removals = nil
+	l.sets = 0
+
+	return keys
+}
+
+func (l *changeLog[H, K]) sorted() []changeEntry[K] {
+	result := make([]changeEntry[K], 0, len(l.entries)+1)
+	if l.cleared != nil {
+		result = append(result, *l.cleared)
+	}
+	for _, e := range l.entries {
+		result = append(result, e)
+	}
+	slices.SortFunc(result, func(a, b changeEntry[K]) int {
+		return cmp.Compare(a.seq, b.seq)
+	})
+
+	return result
+}
+
+// Backpressure defines what a change stream does when its consumer can't keep up and the buffer is full.
+type Backpressure int
+
+const (
+	// BackpressureBlock blocks the goroutine that modifies the cache until the consumer reads the event
+	// or the stream is closed. No events are lost, but a slow consumer slows the cache writers down.
+	BackpressureBlock Backpressure = iota
+	// BackpressureDropNewest discards the new event if the buffer is full.
+	BackpressureDropNewest
+	// BackpressureDropOldest discards the oldest buffered event to make room for the new one.
+	BackpressureDropOldest
+)
+
+const defaultStreamBuffer = 64
+
+type streamOptions struct {
+	buffer       int
+	backpressure Backpressure
+}
+
+// StreamOption configures a change stream.
+type StreamOption func(o *streamOptions)
+
+// WithStreamBuffer sets the size of the stream channel buffer, 64 by default.
+// Dropping strategies always use a buffer of at least one event.
+func WithStreamBuffer(size int) StreamOption {
+	return func(o *streamOptions) {
+		o.buffer = size
+	}
+}
+
+// WithBackpressure sets the strategy for a full stream buffer, BackpressureBlock by default.
+func WithBackpressure(backpressure Backpressure) StreamOption {
+	return func(o *streamOptions) {
+		o.backpressure = backpressure
+	}
+}
+
+// changeFeed delivers change events to the registered listeners and streams.
+type changeFeed[K any] struct {
+	mu        sync.RWMutex
+	listeners []func(event ChangeEvent[K])
+	streams   map[*changeStream[K]]struct{}
+	closed    bool
+}
+
+func (f *changeFeed[K]) subscribe(listener func(event ChangeEvent[K])) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if !f.closed {
+		f.listeners = append(f.listeners, listener)
+	}
+}
+
+func (f *changeFeed[K]) stream(ctx context.Context, opts ...StreamOption) <-chan ChangeEvent[K] {
+	o := &streamOptions{buffer: defaultStreamBuffer, backpressure: BackpressureBlock}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if o.backpressure != BackpressureBlock {
+		o.buffer = max(o.buffer, 1)
+	}
+
+	s := &changeStream[K]{
+		ch:           make(chan ChangeEvent[K], max(o.buffer, 0)),
+		done:         make(chan struct{}),
+		backpressure: o.backpressure,
+	}
+	f.mu.Lock()
+	if f.closed {
+		f.mu.Unlock()
+		s.close()
+		return s.ch
+	}
+	if f.streams == nil {
+		f.streams = make(map[*changeStream[K]]struct{})
+	}
+	f.streams[s] = struct{}{}
+	f.mu.Unlock()
+
+	context.AfterFunc(ctx, func() {
+		f.mu.Lock()
+		delete(f.streams, s)
+		f.mu.Unlock()
+		s.close()
+	})
+
+	return s.ch
+}
+
+func (f *changeFeed[K]) publish(key K, kind ChangeKind) {
+	f.mu.RLock()
+	if len(f.listeners) == 0 && len(f.streams) == 0 {
+		f.mu.RUnlock()
+		return
+	}
+	listeners := f.listeners
+	streams := make([]*changeStream[K], 0, len(f.streams))
+	for s := range f.streams {
+		streams = append(streams, s)
+	}
+	f.mu.RUnlock()
+
+	event := ChangeEvent[K]{Key: key, Kind: kind, At: time.Now()}
+	for _, listener := range listeners {
+		listener(event)
+	}
+	for _, s := range streams {
+		s.send(event)
+	}
+}
+
+// close unsubscribes all the listeners and closes all the streams. Subsequent subscriptions are ignored
+// and new streams are returned closed. Closing the feed again has no effect.
+func (f *changeFeed[K]) close() {
+	f.mu.Lock()
+	streams := f.streams
+	f.streams = nil
+	f.listeners = nil
+	f.closed = true
+	f.mu.Unlock()
+
+	for s := range streams {
+		s.close()
+	}
+}
+
+type changeStream[K any] struct {
+	ch           chan ChangeEvent[K]
+	done         chan struct{}
+	backpressure Backpressure
+
+	mu        sync.Mutex
+	closed    bool
+	closeOnce sync.Once
+}
+
+func (s *changeStream[K]) send(event ChangeEvent[K]) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return
+	}
+
+	switch s.backpressure {
+	case BackpressureDropNewest:
+		select {
+		case s.ch <- event:
+		default:
+		}
+	case BackpressureDropOldest:
+		for {
+			select {
+			case s.ch <- event:
+				return
+			default:
+			}
+			select {
+			case <-s.ch:
+			default:
+			}
+		}
+	default:
+		select {
+		case s.ch <- event:
+		case <-s.done:
+		}
+	}
+}
+
+// close closes the stream channel, the stream can be closed both by its context and by the feed.
+func (s *changeStream[K]) close() {
+	s.closeOnce.Do(func() {
+		close(s.done) // unblocks a pending send, so the lock can be acquired
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.closed = true
+		close(s.ch)
+	})
+}
@@ -0,0 +1,170 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package ucache
+
+import (
+	"sync"
+	"time"
+
+	"github.com/kordax/basic-utils/uconst"
+)
+
+type counterOptions struct {
+	clock uconst.Clock
+}
+
+// CounterOption configures an ExpiringCounter.
+type CounterOption func(o *counterOptions)
+
+// WithCounterClock sets the time source of the ExpiringCounter, uconst.SystemClock is used by default.
+func WithCounterClock(clock uconst.Clock) CounterOption {
+	return func(o *counterOptions) {
+		o.clock = clock
+	}
+}
+
+/*
+ExpiringCounter counts the events per key in time buckets, e.g. the requests of a client for rate limiting,
+the failed logins of a user for abuse detection or the errors of an endpoint for metrics:
+
+	counter := ucache.NewExpiringCounter[string](time.Second, time.Minute)
+	counter.Incr(clientIP)
+	if counter.Count(clientIP, 10*time.Second) > 100 {
+		// reject the request
+	}
+
+Every key keeps the counts of the buckets within the retention in a fixed-size ring, so the memory is bounded by
+the number of keys and the counts older than the retention are discarded. The keys that haven't been incremented
+within the retention are only removed by Prune, which should be called periodically. The operations are thread-safe.
+*/
+type ExpiringCounter[K comparable] struct {
+	bucket  time.Duration
+	size    int64 // the number of buckets in the ring
+	options counterOptions
+
+	mtx      sync.Mutex
+	counters map[K]*counterRing
+	expiry   *expiryQueue[K] // the last increment time of every key
+}
+
+type counterRing struct {
+	epochs []int64 // the bucket numbers the counts belong to
+	counts []int64
+}
+
+// NewExpiringCounter creates a new ExpiringCounter counting in buckets of the provided width and keeping them
+// for the retention, which is rounded up to a multiple of the bucket width.
+// It panics if the bucket width is not positive or the retention is shorter than a bucket.
+func NewExpiringCounter[K comparable](bucket, retention time.Duration, opts ...CounterOption) *ExpiringCounter[K] {
+	if bucket <= 0 || retention < bucket {
+		panic("ucache: counter bucket must be positive and not longer than the retention")
+	}
+
+	c := &ExpiringCounter[K]{
+		bucket:   bucket,
+		size:     int64((retention + bucket - 1) / bucket),
+		options:  counterOptions{clock: uconst.SystemClock{}},
+		counters: make(map[K]*counterRing),
+		expiry:   newExpiryQueue[K](),
+	}
+	for _, opt := range opts {
+		opt(&c.options)
+	}
+
+	return c
+}
+
+// Incr increments the count of the key in the current bucket.
+func (c *ExpiringCounter[K]) Incr(key K) {
+	c.Add(key, 1)
+}
+
+// Add adds delta to the count of the key in the current bucket.
+func (c *ExpiringCounter[K]) Add(key K, delta int64) {
+	now := c.options.clock.Now()
+	epoch := c.epoch(now)
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	ring, ok := c.counters[key]
+	if !ok {
+		ring = &counterRing{epochs: make([]int64, c.size), counts: make([]int64, c.size)}
+		for i := range ring.epochs {
+			ring.epochs[i] = -1
+		}
+		c.counters[key] = ring
+	}
+	slot := (epoch%c.size + c.size) % c.size // the times before 1970 have negative epochs
+	if ring.epochs[slot] != epoch {
+		ring.epochs[slot] = epoch
+		ring.counts[slot] = 0
+	}
+	ring.counts[slot] += delta
+	c.expiry.touch(key, now)
+}
+
+// Count returns the sum of the counts of the key in the buckets overlapping the window ending now,
+// including the current bucket. The window is rounded up to a multiple of the bucket width
+// and is limited by the retention.
+func (c *ExpiringCounter[K]) Count(key K, window time.Duration) int64 {
+	epoch := c.epoch(c.options.clock.Now())
+	buckets := min(max(int64((window+c.bucket-1)/c.bucket), 1), c.size)
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	ring, ok := c.counters[key]
+	if !ok {
+		return 0
+	}
+	var sum int64
+	for i, e := range ring.epochs {
+		if e > epoch-buckets && e <= epoch {
+			sum += ring.counts[i]
+		}
+	}
+
+	return sum
+}
+
+// Delete removes the counts of the key.
+func (c *ExpiringCounter[K]) Delete(key K) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	delete(c.counters, key)
+	c.expiry.remove(key)
+}
+
+// Len returns the number of keys, including the ones not yet removed by Prune.
+func (c *ExpiringCounter[K]) Len() int {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	return len(c.counters)
+}
+
+// Prune removes the keys that haven't been incremented within the retention and returns them.
+func (c *ExpiringCounter[K]) Prune() []K {
+	deadline := c.options.clock.Now().Add(-time.Duration(c.size) * c.bucket)
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	keys := c.expiry.expired(deadline)
+	for _, key := range keys {
+		delete(c.counters, key)
+		c.expiry.remove(key)
+	}
+
+	return keys
+}
+
+func (c *ExpiringCounter[K]) epoch(t time.Time) int64 {
+	return t.UnixNano() / int64(c.bucket)
+}
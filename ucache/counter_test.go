@@ -0,0 +1,117 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package ucache_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kordax/basic-utils/ucache"
+	"github.com/stretchr/testify/assert"
+)
+
+type counterClock struct {
+	mtx sync.Mutex
+	now time.Time
+}
+
+func (c *counterClock) Now() time.Time {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.now
+}
+
+func (c *counterClock) After(time.Duration) <-chan time.Time {
+	panic("not used")
+}
+
+func (c *counterClock) Advance(d time.Duration) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func TestExpiringCounter_Count(t *testing.T) {
+	clock := &counterClock{now: time.Unix(1000, 0)}
+	counter := ucache.NewExpiringCounter[string](time.Second, 10*time.Second, ucache.WithCounterClock(clock))
+
+	counter.Incr("a")
+	counter.Add("a", 2)
+	counter.Incr("b")
+	assert.Equal(t, int64(3), counter.Count("a", time.Second))
+	assert.Equal(t, int64(1), counter.Count("b", 0), "the current bucket must always be counted")
+	assert.Zero(t, counter.Count("missing", time.Minute))
+
+	clock.Advance(time.Second)
+	counter.Incr("a")
+	assert.Equal(t, int64(1), counter.Count("a", time.Second))
+	assert.Equal(t, int64(4), counter.Count("a", 2*time.Second))
+	assert.Equal(t, int64(4), counter.Count("a", 1500*time.Millisecond), "the window must be rounded up to the buckets")
+
+	clock.Advance(8 * time.Second)
+	counter.Incr("a")
+	assert.Equal(t, int64(5), counter.Count("a", time.Hour), "the window must be limited by the retention")
+	assert.Equal(t, int64(1), counter.Count("a", 8*time.Second))
+
+	clock.Advance(time.Second)
+	assert.Equal(t, int64(2), counter.Count("a", time.Hour), "the buckets older than the retention must be discarded")
+
+	clock.Advance(10 * time.Second)
+	counter.Add("a", 7) // reuses the ring slot of an outdated bucket
+	assert.Equal(t, int64(7), counter.Count("a", time.Hour))
+}
+
+func TestExpiringCounter_Prune(t *testing.T) {
+	clock := &counterClock{now: time.Unix(1000, 0)}
+	counter := ucache.NewExpiringCounter[int](time.Second, 5*time.Second, ucache.WithCounterClock(clock))
+
+	counter.Incr(1)
+	counter.Incr(2)
+	clock.Advance(3 * time.Second)
+	counter.Incr(2)
+	counter.Incr(3)
+	assert.Equal(t, 3, counter.Len())
+	assert.Empty(t, counter.Prune())
+
+	clock.Advance(3 * time.Second)
+	assert.Equal(t, []int{1}, counter.Prune())
+	assert.Equal(t, 2, counter.Len())
+	assert.Zero(t, counter.Count(1, time.Hour))
+
+	counter.Delete(2)
+	assert.Zero(t, counter.Count(2, time.Hour))
+	assert.Equal(t, 1, counter.Len())
+
+	clock.Advance(time.Hour)
+	assert.Equal(t, []int{3}, counter.Prune())
+	assert.Zero(t, counter.Len())
+}
+
+func TestExpiringCounter_Concurrency(t *testing.T) {
+	counter := ucache.NewExpiringCounter[string](time.Hour, time.Hour)
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 1000 {
+				counter.Incr("key")
+				counter.Count("key", time.Hour)
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.LessOrEqual(t, counter.Count("key", time.Hour), int64(8000))
+	assert.Positive(t, counter.Count("key", time.Hour))
+}
+
+func TestNewExpiringCounter_Panics(t *testing.T) {
+	assert.Panics(t, func() { ucache.NewExpiringCounter[string](0, time.Second) })
+	assert.Panics(t, func() { ucache.NewExpiringCounter[string](time.Second, time.Millisecond) })
+}
@@ -0,0 +1,127 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package ucache
+
+import (
+	"container/heap"
+	"time"
+)
+
+type expiryEntry[K comparable] struct {
+	key       K
+	updatedAt time.Time
+	index     int
+}
+
+// expiryHeap is a min-heap of entries ordered by the update time, so the oldest entry is always on top.
+type expiryHeap[K comparable] []*expiryEntry[K]
+
+func (h expiryHeap[K]) Len() int {
+	return len(h)
+}
+
+func (h expiryHeap[K]) Less(i, j int) bool {
+	return h[i].updatedAt.Before(h[j].updatedAt)
+}
+
+func (h expiryHeap[K]) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *expiryHeap[K]) Push(x any) {
+	entry := x.(*expiryEntry[K])
+	entry.index = len(*h)
+	*h = append(*h, entry)
+}
+
+func (h *expiryHeap[K]) Pop() any {
+	old := *h
+	n := len(old)
+	entry := old[n-1]
+	old[n-1] = nil
+	entry.index = -1
+	*h = old[:n-1]
+
+	return entry
+}
+
+// expiryQueue tracks the last update time of every key.
+// Lookups are O(1), updates are O(log n) and listing k expired keys is O(k) instead of scanning all the keys.
+// expiryQueue is not thread-safe.
+type expiryQueue[K comparable] struct {
+	entries map[K]*expiryEntry[K]
+	heap    expiryHeap[K]
+}
+
+func newExpiryQueue[K comparable]() *expiryQueue[K] {
+	return &expiryQueue[K]{
+		entries: make(map[K]*expiryEntry[K]),
+	}
+}
+
+func (q *expiryQueue[K]) touch(key K, at time.Time) {
+	if entry, ok := q.entries[key]; ok {
+		entry.updatedAt = at
+		heap.Fix(&q.heap, entry.index)
+		return
+	}
+
+	entry := &expiryEntry[K]{key: key, updatedAt: at}
+	q.entries[key] = entry
+	heap.Push(&q.heap, entry)
+}
+
+func (q *expiryQueue[K]) updatedAt(key K) (time.Time, bool) {
+	entry, ok := q.entries[key]
+	if !ok {
+		return time.Time{}, false
+	}
+
+	return entry.updatedAt, true
+}
+
+func (q *expiryQueue[K]) remove(key K) {
+	entry, ok := q.entries[key]
+	if !ok {
+		return
+	}
+	heap.Remove(&q.heap, entry.index)
+	delete(q.entries, key)
+}
+
+func (q *expiryQueue[K]) clear() {
+	q.entries = make(map[K]*expiryEntry[K])
+	q.heap = nil
+}
+
+// expired returns the keys that were updated before the deadline.
+// Thanks to the heap property only the expired entries and their direct children are visited.
+func (q *expiryQueue[K]) expired(deadline time.Time) []K {
+	result := make([]K, 0)
+	stack := make([]int, 0)
+	if len(q.heap) > 0 {
+		stack = append(stack, 0)
+	}
+	for len(stack) > 0 {
+		i := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if !q.heap[i].updatedAt.Before(deadline) {
+			continue
+		}
+		result = append(result, q.heap[i].key)
+		if left := 2*i + 1; left < len(q.heap) {
+			stack = append(stack, left)
+		}
+		if right := 2*i + 2; right < len(q.heap) {
+			stack = append(stack, right)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,526 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package ucache
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/gob"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/kordax/basic-utils/ulog"
+	"github.com/kordax/basic-utils/uopt"
+)
+
+// Codec serializes the values of a FileBackedCache.
+type Codec[T any] interface {
+	Marshal(value T) ([]byte, error)
+	Unmarshal(data []byte) (T, error)
+}
+
+type jsonCodec[T any] struct{}
+
+// JSONCodec returns a Codec serializing values with encoding/json.
+func JSONCodec[T any]() Codec[T] {
+	return jsonCodec[T]{}
+}
+
+func (jsonCodec[T]) Marshal(value T) ([]byte, error) {
+	return json.Marshal(value)
+}
+
+func (jsonCodec[T]) Unmarshal(data []byte) (T, error) {
+	var value T
+	err := json.Unmarshal(data, &value)
+	return value, err
+}
+
+type gobCodec[T any] struct{}
+
+// GobCodec returns a Codec serializing values with encoding/gob. Unlike JSON it keeps the exact numeric types
+// and supports maps with non-string keys, but the types stored in interfaces must be registered with gob.Register.
+func GobCodec[T any]() Codec[T] {
+	return gobCodec[T]{}
+}
+
+func (gobCodec[T]) Marshal(value T) ([]byte, error) {
+	var buf bytes.Buffer
+	err := gob.NewEncoder(&buf).Encode(&value)
+	return buf.Bytes(), err
+}
+
+func (gobCodec[T]) Unmarshal(data []byte) (T, error) {
+	var value T
+	err := gob.NewDecoder(bytes.NewReader(data)).Decode(&value)
+	return value, err
+}
+
+const (
+	fileEntryExt     = ".entry"
+	fileEntryVersion = 1
+	// version, update time in unix nanoseconds and key length
+	fileEntryHeaderSize = 1 + 8 + 4
+)
+
+var errCorruptedEntry = errors.New("ucache: corrupted cache entry")
+
+/*
+FileBackedCache is a ComparableCache persisting every entry as a file in a directory, so the entries survive restarts.
+It suits CLI tools and edge services that need a persistent cache without running a cache server.
+
+Values are serialized with the provided Codec and keys with encoding/json, so the keys must survive a JSON round trip,
+which is the case for strings, numbers and structs of them. Every entry is stored in a file named after the hash
+of its key, along with its update time. Files are replaced atomically, so a crash never leaves a partially written entry.
+
+All the entries are loaded into memory by NewFileBackedCache, which skips and deletes the entries outdated
+according to the TTL. Reads are served from memory and never wait for the disk, while writes update the files
+before returning. The files are updated outside the lock guarding the entries, one at a time and in the order
+of the modifications, skipping the updates of the keys modified again in the meantime.
+Write failures don't fail the operations, as the cache interface doesn't return errors: the entries stay in memory
+and the failures are reported to a ulog.Logger, which discards everything unless replaced with SetLogger.
+The entries that failed to load are reported to the first logger set, since the entries are loaded before it can be set.
+
+The cache must not be shared by several processes. The operations are thread-safe.
+*/
+type FileBackedCache[K comparable, T any] struct {
+	dir   string
+	codec Codec[T]
+	ttl   *time.Duration
+
+	ioMtx sync.Mutex // serializes the file updates, acquired before mtx
+
+	mtx         sync.Mutex
+	values      map[K]T
+	changes     *changeLog[K, K]
+	expiry      *expiryQueue[K]
+	lastUpdated time.Time
+	pending     map[K]uint64 // the versions of the latest modifications of the keys whose files weren't updated yet
+	version     uint64
+
+	logger       atomic.Pointer[ulog.Logger]
+	loadFailures []loadFailure // the entries that failed to load, kept until the first logger is set
+}
+
+type loadFailure struct {
+	file string
+	err  error
+}
+
+// NewFileBackedCache creates a FileBackedCache storing its entries in the directory, which is created if it doesn't exist,
+// and loads the entries persisted there. The ttl is enforced on load: outdated entries are deleted instead of loaded.
+// Entries that can't be read or decoded, e.g. because the value type changed, are deleted as well.
+func NewFileBackedCache[K comparable, T any](
+	dir string,
+	codec Codec[T],
+	ttl uopt.Opt[time.Duration],
+) (*FileBackedCache[K, T], error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("ucache: failed to create cache directory: %w", err)
+	}
+
+	c := &FileBackedCache[K, T]{
+		dir:     dir,
+		codec:   codec,
+		values:  make(map[K]T),
+		changes: newChangeLog[K, K](),
+		expiry:  newExpiryQueue[K](),
+		pending: make(map[K]uint64),
+	}
+	ttl.IfPresent(func(t time.Duration) {
+		c.ttl = &t
+	})
+	nop := ulog.Nop()
+	c.logger.Store(&nop)
+
+	if err := c.load(); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// SetLogger replaces the logger used to report the file failures. Passing nil disables logging.
+// The first logger set also receives the failures to load the entries. The operation is thread-safe.
+func (c *FileBackedCache[K, T]) SetLogger(logger ulog.Logger) {
+	logger = ulog.OrNop(logger)
+	c.logger.Store(&logger)
+
+	c.mtx.Lock()
+	failures := c.loadFailures
+	c.loadFailures = nil
+	c.mtx.Unlock()
+	for _, f := range failures {
+		logger.Warn("deleted unreadable cache entry", ulog.F("file", f.file), ulog.F("error", f.err))
+	}
+}
+
+func (c *FileBackedCache[K, T]) getLogger() ulog.Logger {
+	return *c.logger.Load()
+}
+
+// Set stores the value in memory and persists it. The operation is thread-safe.
+func (c *FileBackedCache[K, T]) Set(key K, value T) {
+	c.mtx.Lock()
+	at, version := c.put(key, value)
+	c.changes.record(key, key, ChangeSet)
+	c.mtx.Unlock()
+
+	c.persist(key, value, at, version)
+}
+
+// SetQuietly is the same as Set, but doesn't alter the change history. The operation is thread-safe.
+func (c *FileBackedCache[K, T]) SetQuietly(key K, value T) {
+	c.mtx.Lock()
+	at, version := c.put(key, value)
+	c.mtx.Unlock()
+
+	c.persist(key, value, at, version)
+}
+
+// Get retrieves the value associated with the provided key from memory.
+// It returns a pointer to a copy of the value and a boolean indicating whether the key was found.
+// The operation is thread-safe.
+func (c *FileBackedCache[K, T]) Get(key K) (*T, bool) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	value, ok := c.values[key]
+	if !ok {
+		return nil, false
+	}
+	return &value, true
+}
+
+// ChangeLog returns the latest change of every modified key in the order they were made.
+// Entries loaded from the directory are not reported. The operation is thread-safe.
+func (c *FileBackedCache[K, T]) ChangeLog() []ChangeEvent[K] {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	return c.changes.events()
+}
+
+// Changes returns the keys whose latest change is a Set, in the order they were set. The operation is thread-safe.
+func (c *FileBackedCache[K, T]) Changes() []K {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	return c.changes.keys()
+}
+
+// ChangesCount returns the number of modified keys. The operation is thread-safe.
+func (c *FileBackedCache[K, T]) ChangesCount() int {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	return c.changes.count()
+}
+
+// ResetChanges atomically returns the modified keys and clears the change history. The operation is thread-safe.
+func (c *FileBackedCache[K, T]) ResetChanges() []K {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	return c.changes.reset()
+}
+
+// Drop removes all the entries along with their files. The operation is thread-safe.
+func (c *FileBackedCache[K, T]) Drop() {
+	c.ioMtx.Lock()
+	defer c.ioMtx.Unlock()
+
+	c.mtx.Lock()
+	c.values = make(map[K]T)
+	c.changes.recordClear()
+	c.expiry.clear()
+	c.lastUpdated = time.Time{}
+	clear(c.pending) // the files are removed anyway
+	c.mtx.Unlock()
+
+	files, err := filepath.Glob(filepath.Join(c.dir, "*"+fileEntryExt))
+	if err != nil {
+		c.getLogger().Error("failed to list cache entries", ulog.F("error", err))
+		return
+	}
+	for _, file := range files {
+		c.removeFile(file)
+	}
+}
+
+// DropKey removes the entry of the key along with its file. The operation is thread-safe.
+func (c *FileBackedCache[K, T]) DropKey(key K) {
+	c.expire([]K{key}, ChangeDelete)
+}
+
+func (c *FileBackedCache[K, T]) expireKeys(keys []K) {
+	c.expire(keys, ChangeExpire)
+}
+
+// expire removes the entries of the keys and then their files.
+func (c *FileBackedCache[K, T]) expire(keys []K, kind ChangeKind) {
+	versions := make([]uint64, len(keys))
+	c.mtx.Lock()
+	for i, key := range keys {
+		versions[i] = c.remove(key, kind)
+	}
+	c.mtx.Unlock()
+
+	for i, key := range keys {
+		c.unpersist(key, versions[i])
+	}
+}
+
+// Outdated checks if the provided key or the entire cache (if no key is provided) is outdated based on the TTL.
+// The update times persisted with the entries are taken into account, so the TTL spans restarts.
+// If no TTL is set it returns false. If the key does not exist, it is considered outdated.
+func (c *FileBackedCache[K, T]) Outdated(key uopt.Opt[K]) bool {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if c.ttl == nil {
+		return false
+	}
+	if k := key.Get(); k != nil {
+		updatedAt, ok := c.expiry.updatedAt(*k)
+		return !ok || time.Since(updatedAt) > *c.ttl
+	}
+
+	return time.Since(c.lastUpdated) > *c.ttl
+}
+
+// OutdatedKeys returns the keys that are outdated based on the TTL. If no TTL is set returns an empty slice.
+// The operation is thread-safe.
+func (c *FileBackedCache[K, T]) OutdatedKeys() []K {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if c.ttl == nil {
+		return make([]K, 0)
+	}
+	return c.expiry.expired(time.Now().Add(-*c.ttl))
+}
+
+// Close is a no-op, since the entries are persisted before the modifications return.
+func (c *FileBackedCache[K, T]) Close() error {
+	return nil
+}
+
+// put stores the value in memory and returns its update time and the version of the file update. Must be called under mtx.
+func (c *FileBackedCache[K, T]) put(key K, value T) (time.Time, uint64) {
+	now := time.Now()
+	c.values[key] = value
+	c.touch(key, now)
+
+	return now, c.schedule(key)
+}
+
+// remove removes the entry from memory and returns the version of the file removal. Must be called under mtx.
+func (c *FileBackedCache[K, T]) remove(key K, kind ChangeKind) uint64 {
+	delete(c.values, key)
+	c.changes.record(key, key, kind)
+	c.expiry.remove(key)
+
+	return c.schedule(key)
+}
+
+// schedule returns the version of the file update of the key, which supersedes the pending ones. Must be called under mtx.
+func (c *FileBackedCache[K, T]) schedule(key K) uint64 {
+	c.version++
+	c.pending[key] = c.version
+
+	return c.version
+}
+
+// claim reports whether the file update of the key is the latest one, so it has to be done, and marks it as done.
+// Must be called under ioMtx, so the later updates of the key wait for this one to finish.
+func (c *FileBackedCache[K, T]) claim(key K, version uint64) bool {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if c.pending[key] != version {
+		return false
+	}
+	delete(c.pending, key)
+
+	return true
+}
+
+// persist writes the entry file unless the key was modified again since the version was scheduled.
+func (c *FileBackedCache[K, T]) persist(key K, value T, updatedAt time.Time, version uint64) {
+	c.ioMtx.Lock()
+	defer c.ioMtx.Unlock()
+
+	if !c.claim(key, version) {
+		return
+	}
+	if err := c.write(key, value, updatedAt); err != nil {
+		c.getLogger().Error("failed to persist cache entry", ulog.F("key", key), ulog.F("error", err))
+	}
+}
+
+// unpersist removes the entry file unless the key was modified again since the version was scheduled.
+func (c *FileBackedCache[K, T]) unpersist(key K, version uint64) {
+	c.ioMtx.Lock()
+	defer c.ioMtx.Unlock()
+
+	if !c.claim(key, version) {
+		return
+	}
+	name, err := c.fileName(key)
+	if err != nil {
+		c.getLogger().Error("failed to remove cache entry", ulog.F("key", key), ulog.F("error", err))
+		return
+	}
+	c.removeFile(name)
+}
+
+// touch updates the key expiration. Update times are not tracked if no TTL is set, since nothing can expire.
+func (c *FileBackedCache[K, T]) touch(key K, at time.Time) {
+	if c.ttl != nil {
+		c.expiry.touch(key, at)
+	}
+	if at.After(c.lastUpdated) {
+		c.lastUpdated = at
+	}
+}
+
+func (c *FileBackedCache[K, T]) removeFile(name string) {
+	if err := os.Remove(name); err != nil && !errors.Is(err, os.ErrNotExist) {
+		c.getLogger().Error("failed to remove cache entry", ulog.F("file", name), ulog.F("error", err))
+	}
+}
+
+// fileName returns the path of the entry file, which is named after the SHA-256 hash of the encoded key.
+func (c *FileBackedCache[K, T]) fileName(key K) (string, error) {
+	encoded, err := json.Marshal(key)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(encoded)
+
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:16])+fileEntryExt), nil
+}
+
+// write persists the entry to a temporary file and renames it, so the entry file is replaced atomically.
+func (c *FileBackedCache[K, T]) write(key K, value T, updatedAt time.Time) error {
+	encodedKey, err := json.Marshal(key)
+	if err != nil {
+		return err
+	}
+	encodedValue, err := c.codec.Marshal(value)
+	if err != nil {
+		return err
+	}
+	name, err := c.fileName(key)
+	if err != nil {
+		return err
+	}
+
+	data := make([]byte, fileEntryHeaderSize, fileEntryHeaderSize+len(encodedKey)+len(encodedValue))
+	data[0] = fileEntryVersion
+	binary.BigEndian.PutUint64(data[1:9], uint64(updatedAt.UnixNano()))
+	binary.BigEndian.PutUint32(data[9:13], uint32(len(encodedKey)))
+	data = append(append(data, encodedKey...), encodedValue...)
+
+	tmp, err := os.CreateTemp(c.dir, "tmp-*")
+	if err != nil {
+		return err
+	}
+	if _, err = tmp.Write(data); err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), name)
+	}
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+	}
+
+	return err
+}
+
+// load reads the persisted entries, deleting the outdated and unreadable ones along with the leftover temporary files.
+func (c *FileBackedCache[K, T]) load() error {
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		return fmt.Errorf("ucache: failed to read cache directory: %w", err)
+	}
+
+	var deadline time.Time
+	if c.ttl != nil {
+		deadline = time.Now().Add(-*c.ttl)
+	}
+	for _, entry := range entries {
+		name := filepath.Join(c.dir, entry.Name())
+		if entry.IsDir() {
+			continue
+		}
+		if strings.HasPrefix(entry.Name(), "tmp-") {
+			c.removeFile(name)
+			continue
+		}
+		if !strings.HasSuffix(entry.Name(), fileEntryExt) {
+			continue
+		}
+
+		key, value, updatedAt, err := c.read(name)
+		if err != nil {
+			c.loadFailures = append(c.loadFailures, loadFailure{file: name, err: err})
+			c.removeFile(name)
+			continue
+		}
+		if c.ttl != nil && updatedAt.Before(deadline) {
+			c.removeFile(name)
+			continue
+		}
+		c.values[key] = value
+		c.touch(key, updatedAt)
+	}
+
+	return nil
+}
+
+func (c *FileBackedCache[K, T]) read(name string) (K, T, time.Time, error) {
+	var key K
+	var value T
+
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return key, value, time.Time{}, err
+	}
+	if len(data) < fileEntryHeaderSize || data[0] != fileEntryVersion {
+		return key, value, time.Time{}, errCorruptedEntry
+	}
+	updatedAt := time.Unix(0, int64(binary.BigEndian.Uint64(data[1:9])))
+	keyLen := binary.BigEndian.Uint32(data[9:13])
+	if uint64(keyLen) > uint64(len(data)-fileEntryHeaderSize) {
+		return key, value, time.Time{}, errCorruptedEntry
+	}
+	data = data[fileEntryHeaderSize:]
+
+	if err = json.Unmarshal(data[:keyLen], &key); err != nil {
+		return key, value, time.Time{}, err
+	}
+	if value, err = c.codec.Unmarshal(data[keyLen:]); err != nil {
+		return key, value, time.Time{}, err
+	}
+
+	return key, value, updatedAt, nil
+}
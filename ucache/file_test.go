@@ -0,0 +1,207 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package ucache_test
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kordax/basic-utils/ucache"
+	"github.com/kordax/basic-utils/uopt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fileCacheValue struct {
+	Name  string
+	Count int
+}
+
+func TestFileBackedCache_Persistence(t *testing.T) {
+	dir := t.TempDir()
+	c, err := ucache.NewFileBackedCache[string, fileCacheValue](dir, ucache.JSONCodec[fileCacheValue](), uopt.Null[time.Duration]())
+	require.NoError(t, err)
+
+	c.Set("a", fileCacheValue{Name: "a", Count: 1})
+	c.SetQuietly("b", fileCacheValue{Name: "b", Count: 2})
+	c.Set("c", fileCacheValue{Name: "c", Count: 3})
+	c.Set("a", fileCacheValue{Name: "a", Count: 10})
+	c.DropKey("c")
+	assert.Equal(t, []string{"a"}, c.Changes())
+	require.NoError(t, c.Close())
+
+	restored, err := ucache.NewFileBackedCache[string, fileCacheValue](dir, ucache.JSONCodec[fileCacheValue](), uopt.Null[time.Duration]())
+	require.NoError(t, err)
+
+	v, ok := restored.Get("a")
+	require.True(t, ok)
+	assert.Equal(t, fileCacheValue{Name: "a", Count: 10}, *v)
+	v, ok = restored.Get("b")
+	require.True(t, ok)
+	assert.Equal(t, fileCacheValue{Name: "b", Count: 2}, *v)
+	_, ok = restored.Get("c")
+	assert.False(t, ok)
+	assert.Empty(t, restored.Changes(), "loaded entries are not changes")
+
+	restored.Drop()
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, files)
+}
+
+func TestFileBackedCache_TTL(t *testing.T) {
+	dir := t.TempDir()
+	ttl := 50 * time.Millisecond
+	c, err := ucache.NewFileBackedCache[int, string](dir, ucache.GobCodec[string](), uopt.Of(ttl))
+	require.NoError(t, err)
+
+	c.Set(1, "old")
+	time.Sleep(2 * ttl)
+	c.Set(2, "fresh")
+	assert.True(t, c.Outdated(uopt.Of(1)))
+	assert.False(t, c.Outdated(uopt.Of(2)))
+	assert.Equal(t, []int{1}, c.OutdatedKeys())
+
+	restored, err := ucache.NewFileBackedCache[int, string](dir, ucache.GobCodec[string](), uopt.Of(ttl))
+	require.NoError(t, err)
+	_, ok := restored.Get(1)
+	assert.False(t, ok, "outdated entries must not be loaded")
+	v, ok := restored.Get(2)
+	require.True(t, ok)
+	assert.Equal(t, "fresh", *v)
+	assert.False(t, restored.Outdated(uopt.Of(2)), "the persisted update time must be kept")
+
+	files, err := filepath.Glob(filepath.Join(dir, "*"))
+	require.NoError(t, err)
+	assert.Len(t, files, 1, "outdated entries must be deleted on load")
+}
+
+func TestFileBackedCache_Managed(t *testing.T) {
+	ttl := 10 * time.Millisecond
+	c, err := ucache.NewFileBackedCache[string, int](t.TempDir(), ucache.JSONCodec[int](), uopt.Of(ttl))
+	require.NoError(t, err)
+	managed := ucache.NewManagedCache[string, int](c, time.Millisecond)
+	defer managed.Stop()
+
+	managed.Set("key", 1)
+	require.Eventually(t, func() bool {
+		_, ok := c.Get("key")
+		return !ok
+	}, time.Second, time.Millisecond)
+	changes := c.ChangeLog()
+	require.Len(t, changes, 1)
+	assert.Equal(t, "key", changes[0].Key)
+	assert.Equal(t, ucache.ChangeExpire, changes[0].Kind)
+}
+
+func TestFileBackedCache_CorruptedEntries(t *testing.T) {
+	dir := t.TempDir()
+	c, err := ucache.NewFileBackedCache[string, int](dir, ucache.JSONCodec[int](), uopt.Null[time.Duration]())
+	require.NoError(t, err)
+	c.Set("valid", 1)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "broken.entry"), []byte{1, 2, 3}, 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "tmp-123"), []byte("partial"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "unrelated.txt"), []byte("keep"), 0o644))
+
+	restored, err := ucache.NewFileBackedCache[string, int](dir, ucache.JSONCodec[int](), uopt.Null[time.Duration]())
+	require.NoError(t, err)
+	v, ok := restored.Get("valid")
+	require.True(t, ok)
+	assert.Equal(t, 1, *v)
+
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	var names []string
+	for _, f := range files {
+		names = append(names, f.Name())
+	}
+	assert.Len(t, names, 2)
+	assert.Contains(t, names, "unrelated.txt")
+
+	// values of another type can't be decoded and are dropped
+	other, err := ucache.NewFileBackedCache[string, []string](dir, ucache.JSONCodec[[]string](), uopt.Null[time.Duration]())
+	require.NoError(t, err)
+	_, ok = other.Get("valid")
+	assert.False(t, ok)
+}
+
+func TestFileBackedCache_SetLogger(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "broken.entry"), []byte{1, 2, 3}, 0o644))
+
+	c, err := ucache.NewFileBackedCache[string, int](dir, ucache.JSONCodec[int](), uopt.Null[time.Duration]())
+	require.NoError(t, err)
+	logger := &recordingLogger{}
+	c.SetLogger(logger)
+	assert.Equal(t, []string{"deleted unreadable cache entry"}, logger.Messages(), "the load failures must be reported")
+
+	other := &recordingLogger{}
+	c.SetLogger(other)
+	assert.Empty(t, other.Messages(), "the load failures are reported once")
+}
+
+// blockingCodec blocks marshalling until released, simulating a slow disk.
+type blockingCodec struct {
+	ucache.Codec[int]
+	started, release chan struct{}
+}
+
+func (c blockingCodec) Marshal(value int) ([]byte, error) {
+	c.started <- struct{}{}
+	<-c.release
+	return c.Codec.Marshal(value)
+}
+
+func TestFileBackedCache_ReadsDontWaitForWrites(t *testing.T) {
+	dir := t.TempDir()
+	codec := blockingCodec{Codec: ucache.JSONCodec[int](), started: make(chan struct{}, 2), release: make(chan struct{})}
+	c, err := ucache.NewFileBackedCache[string, int](dir, codec, uopt.Null[time.Duration]())
+	require.NoError(t, err)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		c.Set("key", 1)
+	}()
+	<-codec.started
+
+	value, ok := c.Get("key")
+	require.True(t, ok, "reads must not wait for the entry to be written")
+	assert.Equal(t, 1, *value)
+
+	// the first write is still in progress, so the second one and the removal wait for it
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		c.Set("key", 2)
+	}()
+	require.Eventually(t, func() bool {
+		value, ok := c.Get("key")
+		return ok && *value == 2
+	}, time.Second, time.Millisecond)
+	go func() {
+		defer wg.Done()
+		c.DropKey("key")
+	}()
+	require.Eventually(t, func() bool {
+		_, ok := c.Get("key")
+		return !ok
+	}, time.Second, time.Millisecond)
+	close(codec.release)
+	<-done
+	wg.Wait()
+
+	restored, err := ucache.NewFileBackedCache[string, int](dir, ucache.JSONCodec[int](), uopt.Null[time.Duration]())
+	require.NoError(t, err)
+	_, ok = restored.Get("key")
+	assert.False(t, ok, "the files must be updated in the order of the modifications")
+}
@@ -0,0 +1,94 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package ucache
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// flightPanic carries the value of a panic recovered from a call, so it can be rethrown to the caller that started it.
+type flightPanic struct {
+	value any
+}
+
+func (p *flightPanic) Error() string {
+	return fmt.Sprintf("ucache: loader panicked: %v", p.value)
+}
+
+// flightGroup deduplicates concurrent calls with the same key with singleflight: while a call is in flight,
+// other callers of the same key wait for it and receive its result instead of running their own.
+// The keys are compared with the == operator, they are mapped to the singleflight keys by the ids allocated
+// while their calls are in flight.
+type flightGroup[K comparable, T any] struct {
+	group  singleflight.Group
+	mtx    sync.Mutex
+	ids    map[K]string
+	nextID uint64
+}
+
+// do runs fn for the key in its own goroutine unless another call for the same key is in flight, and waits for
+// the result or for the context to be done, whichever comes first. The call runs to completion even if its callers
+// stopped waiting for it. If fn panics, the panic is rethrown to the caller that started the call, while the other
+// callers receive an error.
+func (g *flightGroup[K, T]) do(ctx context.Context, key K, fn func() (T, error)) (T, error) {
+	id := g.id(key)
+	var started bool
+	results := g.group.DoChan(id, func() (value any, err error) {
+		started = true
+		defer g.forget(key, id)
+		defer func() {
+			if r := recover(); r != nil {
+				err = &flightPanic{value: r}
+			}
+		}()
+
+		return fn()
+	})
+
+	select {
+	case res := <-results:
+		if p, ok := res.Err.(*flightPanic); ok && started {
+			panic(p.value)
+		}
+		value, _ := res.Val.(T)
+		return value, res.Err
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
+
+// id returns the singleflight key of the key, allocating a new one unless a call for the key is in flight.
+func (g *flightGroup[K, T]) id(key K) string {
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+	if id, ok := g.ids[key]; ok {
+		return id
+	}
+	if g.ids == nil {
+		g.ids = make(map[K]string)
+	}
+	g.nextID++
+	id := strconv.FormatUint(g.nextID, 10)
+	g.ids[key] = id
+
+	return id
+}
+
+// forget releases the singleflight key of a finished call.
+func (g *flightGroup[K, T]) forget(key K, id string) {
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+	if g.ids[key] == id {
+		delete(g.ids, key)
+	}
+}
@@ -0,0 +1,211 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package ucache
+
+import (
+	"cmp"
+	"hash/maphash"
+	"math/bits"
+	"slices"
+	"sync"
+
+	"github.com/kordax/basic-utils/internal/uhash"
+)
+
+const hotKeysDepth = 4
+
+// KeyCount is the estimated number of accesses to a key.
+type KeyCount[K any] struct {
+	Key   K
+	Count uint64
+}
+
+/*
+HotKeys estimates how often keys are accessed to find the most popular ones, e.g. to replicate them locally
+or give them a longer TTL. Access counts are kept in a count-min sketch, so the memory doesn't grow with the number
+of distinct keys, and only the capacity most popular candidates are remembered.
+
+Counts are estimates: they may be overestimated because of hash collisions, but are never underestimated.
+To let the popularity follow the traffic, all the counts are halved once the number of recorded accesses reaches
+ten times the sketch width, so the old accesses weigh less than the recent ones.
+
+HotKeys is thread-safe. LoadingCache tracks the keys read through it with the WithHotKeys option.
+*/
+type HotKeys[K comparable] struct {
+	mtx      sync.Mutex
+	seed     maphash.Seed
+	sketch   [hotKeysDepth][]uint32
+	mask     uint64
+	recorded uint64
+	resetAt  uint64
+
+	capacity int
+	top      map[K]uint64
+	minKey   K
+	minCount uint64
+	minDirty bool
+}
+
+// NewHotKeys creates a HotKeys tracking up to capacity most popular keys. A non-positive capacity defaults to 16.
+func NewHotKeys[K comparable](capacity int) *HotKeys[K] {
+	if capacity <= 0 {
+		capacity = 16
+	}
+	width := max(uint64(1)<<bits.Len64(uint64(capacity)*32-1), 1024)
+
+	h := &HotKeys[K]{
+		seed:     maphash.MakeSeed(),
+		mask:     width - 1,
+		resetAt:  width * 10,
+		capacity: capacity,
+		top:      make(map[K]uint64, capacity),
+	}
+	for i := range h.sketch {
+		h.sketch[i] = make([]uint32, width)
+	}
+
+	return h
+}
+
+// Record registers an access to the key.
+func (h *HotKeys[K]) Record(key K) {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+
+	count := h.increment(key)
+	h.recorded++
+	if h.recorded >= h.resetAt {
+		h.halve()
+		count /= 2
+	}
+
+	if _, ok := h.top[key]; ok {
+		h.top[key] = count
+		if key == h.minKey {
+			h.minDirty = true
+		}
+		return
+	}
+	if len(h.top) < h.capacity {
+		h.top[key] = count
+		h.minDirty = true
+		return
+	}
+	if h.minDirty {
+		h.updateMin()
+	}
+	if count > h.minCount {
+		delete(h.top, h.minKey)
+		h.top[key] = count
+		h.updateMin()
+	}
+}
+
+// Count returns the estimated number of accesses to the key.
+func (h *HotKeys[K]) Count(key K) uint64 {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+
+	h1, h2 := h.hashes(key)
+	count := uint32(0)
+	for i := range h.sketch {
+		c := h.sketch[i][(h1+uint64(i)*h2)&h.mask]
+		if i == 0 || c < count {
+			count = c
+		}
+	}
+
+	return uint64(count)
+}
+
+// TopKeys returns up to n most popular keys ordered by their estimated counts, the most popular first.
+// At most the capacity keys passed to NewHotKeys are returned.
+func (h *HotKeys[K]) TopKeys(n int) []KeyCount[K] {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+
+	result := make([]KeyCount[K], 0, len(h.top))
+	for key, count := range h.top {
+		if count > 0 {
+			result = append(result, KeyCount[K]{Key: key, Count: count})
+		}
+	}
+	slices.SortStableFunc(result, func(a, b KeyCount[K]) int {
+		return cmp.Compare(b.Count, a.Count)
+	})
+
+	return result[:min(max(n, 0), len(result))]
+}
+
+// Reset forgets all the recorded accesses.
+func (h *HotKeys[K]) Reset() {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+
+	for i := range h.sketch {
+		clear(h.sketch[i])
+	}
+	clear(h.top)
+	h.recorded = 0
+	h.minDirty = true
+}
+
+// increment increases the counters of the key and returns its new estimate.
+// Only the minimal counters are increased (conservative update), which reduces the overestimation.
+func (h *HotKeys[K]) increment(key K) uint64 {
+	h1, h2 := h.hashes(key)
+	var indices [hotKeysDepth]uint64
+	estimate := uint32(0)
+	for i := range h.sketch {
+		indices[i] = (h1 + uint64(i)*h2) & h.mask
+		c := h.sketch[i][indices[i]]
+		if i == 0 || c < estimate {
+			estimate = c
+		}
+	}
+	if estimate == ^uint32(0) {
+		return uint64(estimate)
+	}
+	estimate++
+	for i := range h.sketch {
+		if h.sketch[i][indices[i]] < estimate {
+			h.sketch[i][indices[i]] = estimate
+		}
+	}
+
+	return uint64(estimate)
+}
+
+// hashes returns two hashes of the key, the i-th sketch row uses h1 + i*h2.
+func (h *HotKeys[K]) hashes(key K) (uint64, uint64) {
+	h1 := uhash.Mix(uint64(uhash.Comparable(h.seed, key)))
+	return h1, uhash.Mix(h1) | 1
+}
+
+func (h *HotKeys[K]) halve() {
+	for i := range h.sketch {
+		for j := range h.sketch[i] {
+			h.sketch[i][j] /= 2
+		}
+	}
+	for key, count := range h.top {
+		h.top[key] = count / 2
+	}
+	h.recorded /= 2
+	h.minDirty = true
+}
+
+func (h *HotKeys[K]) updateMin() {
+	first := true
+	for key, count := range h.top {
+		if first || count < h.minCount {
+			h.minKey, h.minCount = key, count
+			first = false
+		}
+	}
+	h.minDirty = false
+}
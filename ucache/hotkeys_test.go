@@ -0,0 +1,60 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package ucache_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/kordax/basic-utils/ucache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHotKeys_TopKeys(t *testing.T) {
+	h := ucache.NewHotKeys[string](3)
+	for i := range 1000 {
+		h.Record("key" + strconv.Itoa(i)) // long tail accessed once
+		if i%2 == 0 {
+			h.Record("hot")
+		}
+		if i%5 == 0 {
+			h.Record("warm")
+		}
+		if i%10 == 0 {
+			h.Record("mild")
+		}
+	}
+
+	top := h.TopKeys(5)
+	require.Len(t, top, 3)
+	assert.Equal(t, []string{"hot", "warm", "mild"}, []string{top[0].Key, top[1].Key, top[2].Key})
+	assert.GreaterOrEqual(t, top[0].Count, uint64(500), "counts are never underestimated")
+	assert.GreaterOrEqual(t, h.Count("warm"), uint64(200))
+	assert.Len(t, h.TopKeys(1), 1)
+	assert.Empty(t, h.TopKeys(0))
+
+	h.Reset()
+	assert.Empty(t, h.TopKeys(3))
+	assert.Zero(t, h.Count("hot"))
+}
+
+func TestHotKeys_Decay(t *testing.T) {
+	h := ucache.NewHotKeys[int](2)
+	for range 5000 {
+		h.Record(1)
+	}
+	// the old favourite is overtaken once the counts are halved enough times
+	for range 20000 {
+		h.Record(2)
+	}
+
+	top := h.TopKeys(2)
+	require.Len(t, top, 2)
+	assert.Equal(t, 2, top[0].Key)
+	assert.Less(t, top[1].Count, uint64(5000), "the old accesses must weigh less")
+}
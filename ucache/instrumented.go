@@ -0,0 +1,180 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package ucache
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kordax/basic-utils/uopt"
+)
+
+// OperationKind is the kind of a cache operation reported to the Hooks.
+type OperationKind int
+
+const (
+	OperationGet OperationKind = iota
+	OperationSet
+)
+
+func (k OperationKind) String() string {
+	switch k {
+	case OperationGet:
+		return "get"
+	case OperationSet:
+		return "set"
+	default:
+		return fmt.Sprintf("OperationKind(%d)", int(k))
+	}
+}
+
+// Operation describes a cache operation observed by the Hooks.
+// The same Operation is passed to the Before and After hooks of the call.
+type Operation[K any] struct {
+	Context context.Context // Context is the context passed to GetCtx or SetCtx, context.Background() otherwise.
+	Kind    OperationKind
+	Key     K
+	Start   time.Time     // Start is the time the operation started at, right after the Before hook.
+	Elapsed time.Duration // Elapsed is the duration of the operation, set for the After hooks.
+	Hit     bool          // Hit reports whether Get found the key, set for the AfterGet hook.
+	// Data is an arbitrary state of the hooks, e.g. the tracing span started by the Before hook and ended by the After one.
+	Data any
+}
+
+// Hooks are the callbacks invoked by InstrumentedCache around its reads and writes, any of them may be nil.
+// Hooks are called synchronously in the goroutine performing the operation, so they should return quickly.
+type Hooks[K any] struct {
+	BeforeGet func(op *Operation[K])
+	AfterGet  func(op *Operation[K])
+	BeforeSet func(op *Operation[K])
+	AfterSet  func(op *Operation[K])
+}
+
+/*
+InstrumentedCache wraps a BaseCache and reports Get, Set and SetQuietly to the Hooks along with their timing and hits,
+e.g. to trace them with TracingHooks or export latency and hit-rate metrics with MetricsHooks.
+Use GetCtx and SetCtx to pass the request context to the hooks, so the tracing spans get the right parent.
+The other operations are delegated to the wrapped cache as is.
+*/
+type InstrumentedCache[K, T any] struct {
+	cache BaseCache[K, T]
+	hooks Hooks[K]
+}
+
+// NewInstrumentedCache wraps the cache reporting its operations to the hooks.
+func NewInstrumentedCache[K, T any](cache BaseCache[K, T], hooks Hooks[K]) *InstrumentedCache[K, T] {
+	return &InstrumentedCache[K, T]{cache: cache, hooks: hooks}
+}
+
+// Get retrieves the value associated with the provided key, see GetCtx.
+func (c *InstrumentedCache[K, T]) Get(key K) (*T, bool) {
+	return c.GetCtx(context.Background(), key)
+}
+
+// GetCtx retrieves the value associated with the provided key, passing the context to the hooks.
+// The operation is thread-safe if the wrapped cache is.
+func (c *InstrumentedCache[K, T]) GetCtx(ctx context.Context, key K) (*T, bool) {
+	op := &Operation[K]{Context: ctx, Kind: OperationGet, Key: key}
+	c.before(c.hooks.BeforeGet, op)
+	value, ok := c.cache.Get(key)
+	op.Hit = ok
+	c.after(c.hooks.AfterGet, op)
+
+	return value, ok
+}
+
+// Set updates the cache value for the provided key, see SetCtx.
+func (c *InstrumentedCache[K, T]) Set(key K, value T) {
+	c.SetCtx(context.Background(), key, value)
+}
+
+// SetCtx updates the cache value for the provided key, passing the context to the hooks.
+// The operation is thread-safe if the wrapped cache is.
+func (c *InstrumentedCache[K, T]) SetCtx(ctx context.Context, key K, value T) {
+	op := &Operation[K]{Context: ctx, Kind: OperationSet, Key: key}
+	c.before(c.hooks.BeforeSet, op)
+	c.cache.Set(key, value)
+	c.after(c.hooks.AfterSet, op)
+}
+
+// SetQuietly adds a value to the cache for the provided key without altering the change history.
+// It's reported to the hooks like Set. The operation is thread-safe if the wrapped cache is.
+func (c *InstrumentedCache[K, T]) SetQuietly(key K, value T) {
+	op := &Operation[K]{Context: context.Background(), Kind: OperationSet, Key: key}
+	c.before(c.hooks.BeforeSet, op)
+	c.cache.SetQuietly(key, value)
+	c.after(c.hooks.AfterSet, op)
+}
+
+func (c *InstrumentedCache[K, T]) before(hook func(op *Operation[K]), op *Operation[K]) {
+	if hook != nil {
+		hook(op)
+	}
+	op.Start = time.Now()
+}
+
+func (c *InstrumentedCache[K, T]) after(hook func(op *Operation[K]), op *Operation[K]) {
+	op.Elapsed = time.Since(op.Start)
+	if hook != nil {
+		hook(op)
+	}
+}
+
+// ChangeLog returns the latest change of every modified key in the order they were made. The operation is thread-safe.
+func (c *InstrumentedCache[K, T]) ChangeLog() []ChangeEvent[K] {
+	return changeLogOf[K](c.cache)
+}
+
+// Changes returns a slice of keys that have been modified in the cache. The operation is thread-safe.
+func (c *InstrumentedCache[K, T]) Changes() []K {
+	return c.cache.Changes()
+}
+
+// ChangesCount returns the number of modified keys. The operation is thread-safe.
+func (c *InstrumentedCache[K, T]) ChangesCount() int {
+	return changesCount(c.cache)
+}
+
+// ResetChanges atomically returns the modified keys and clears the change history. The operation is thread-safe.
+func (c *InstrumentedCache[K, T]) ResetChanges() []K {
+	return resetChanges[K](c.cache)
+}
+
+// Drop completely clears the cache. The operation is thread-safe.
+func (c *InstrumentedCache[K, T]) Drop() {
+	c.cache.Drop()
+}
+
+// DropKey removes the value associated with the provided key from the cache. The operation is thread-safe.
+func (c *InstrumentedCache[K, T]) DropKey(key K) {
+	c.cache.DropKey(key)
+}
+
+func (c *InstrumentedCache[K, T]) expireKeys(keys []K) {
+	expireKeys(c.cache, keys)
+}
+
+func (c *InstrumentedCache[K, T]) expireOutdated() []K {
+	return expireOutdated(c.cache)
+}
+
+// Outdated checks if the provided key or the entire cache (if no key is provided)
+// is outdated based on the set TTL. Returns true if outdated, false otherwise.
+func (c *InstrumentedCache[K, T]) Outdated(key uopt.Opt[K]) bool {
+	return c.cache.Outdated(key)
+}
+
+// OutdatedKeys returns the keys that are outdated based on the set TTL. The operation is thread-safe.
+func (c *InstrumentedCache[K, T]) OutdatedKeys() []K {
+	return outdatedKeysOf[K](c.cache)
+}
+
+// Close closes the wrapped cache.
+func (c *InstrumentedCache[K, T]) Close() error {
+	return closeCache(c.cache)
+}
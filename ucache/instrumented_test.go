@@ -0,0 +1,232 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package ucache_test
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kordax/basic-utils/ucache"
+	"github.com/kordax/basic-utils/uopt"
+	"github.com/kordax/basic-utils/uprom"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInstrumentedCache_Hooks(t *testing.T) {
+	var calls []string
+	var ops []ucache.Operation[string]
+	record := func(name string) func(op *ucache.Operation[string]) {
+		return func(op *ucache.Operation[string]) {
+			calls = append(calls, name+":"+op.Key)
+			if name == "afterGet" || name == "afterSet" {
+				ops = append(ops, *op)
+			}
+		}
+	}
+	cache := ucache.NewInstrumentedCache[string, int](
+		ucache.NewInMemoryComparableMapCache[string, int](uopt.Null[time.Duration]()),
+		ucache.Hooks[string]{
+			BeforeGet: record("beforeGet"),
+			AfterGet:  record("afterGet"),
+			BeforeSet: record("beforeSet"),
+			AfterSet:  record("afterSet"),
+		},
+	)
+
+	cache.Set("a", 1)
+	cache.SetQuietly("b", 2)
+	v, ok := cache.Get("a")
+	require.True(t, ok)
+	assert.Equal(t, 1, *v)
+	_, ok = cache.Get("missing")
+	assert.False(t, ok)
+
+	assert.Equal(t, []string{
+		"beforeSet:a", "afterSet:a",
+		"beforeSet:b", "afterSet:b",
+		"beforeGet:a", "afterGet:a",
+		"beforeGet:missing", "afterGet:missing",
+	}, calls)
+	require.Len(t, ops, 4)
+	assert.Equal(t, ucache.OperationSet, ops[0].Kind)
+	assert.Equal(t, ucache.OperationGet, ops[2].Kind)
+	assert.True(t, ops[2].Hit)
+	assert.False(t, ops[3].Hit)
+	for _, op := range ops {
+		assert.NotNil(t, op.Context)
+		assert.False(t, op.Start.IsZero())
+		assert.GreaterOrEqual(t, op.Elapsed, time.Duration(0))
+	}
+
+	assert.Equal(t, []string{"a"}, cache.Changes(), "SetQuietly must not be recorded")
+	cache.DropKey("a")
+	assert.False(t, cache.Outdated(uopt.Of("b")))
+	cache.Drop()
+	_, ok = cache.Get("b")
+	assert.False(t, ok)
+	require.NoError(t, cache.Close())
+}
+
+func TestInstrumentedCache_NilHooks(t *testing.T) {
+	cache := ucache.NewInstrumentedCache[string, int](
+		ucache.NewInMemoryComparableMapCache[string, int](uopt.Null[time.Duration]()), ucache.Hooks[string]{},
+	)
+	assert.NotPanics(t, func() {
+		cache.Set("a", 1)
+		cache.Get("a")
+	})
+}
+
+func TestInstrumentedCache_Data(t *testing.T) {
+	var data any
+	cache := ucache.NewInstrumentedCache[string, int](
+		ucache.NewInMemoryComparableMapCache[string, int](uopt.Null[time.Duration]()),
+		ucache.Hooks[string]{
+			BeforeSet: func(op *ucache.Operation[string]) { op.Data = time.Now() },
+			AfterSet:  func(op *ucache.Operation[string]) { data = op.Data },
+		},
+	)
+	cache.Set("a", 1)
+	assert.IsType(t, time.Time{}, data, "the data set by the Before hook must be passed to the After hook")
+}
+
+func TestInstrumentedCache_Managed(t *testing.T) {
+	instrumented := ucache.NewInstrumentedCache[string, int](
+		ucache.NewInMemoryComparableMapCache[string, int](uopt.Of(time.Millisecond)), ucache.Hooks[string]{},
+	)
+	managed := ucache.NewManagedCache[string, int](instrumented, time.Millisecond)
+	defer managed.Stop()
+
+	managed.Set("a", 1)
+	require.Eventually(t, func() bool {
+		changes := instrumented.ChangeLog()
+		return len(changes) == 1 && changes[0].Kind == ucache.ChangeExpire
+	}, time.Second, time.Millisecond)
+}
+
+type ctxKey struct{}
+
+type testSpan struct {
+	name   string
+	parent any
+	attrs  map[string]any
+	ended  bool
+}
+
+func (s *testSpan) SetAttribute(key string, value any) { s.attrs[key] = value }
+func (s *testSpan) End()                               { s.ended = true }
+
+type testTracer struct {
+	mtx   sync.Mutex
+	spans []*testSpan
+}
+
+func (t *testTracer) Start(ctx context.Context, name string) (context.Context, ucache.Span) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	span := &testSpan{name: name, parent: ctx.Value(ctxKey{}), attrs: make(map[string]any)}
+	t.spans = append(t.spans, span)
+	return context.WithValue(ctx, ctxKey{}, name), span
+}
+
+func TestTracingHooks(t *testing.T) {
+	tracer := &testTracer{}
+	cache := ucache.NewInstrumentedCache[string, int](
+		ucache.NewInMemoryComparableMapCache[string, int](uopt.Null[time.Duration]()),
+		ucache.TracingHooks[string](tracer, "users"),
+	)
+	ctx := context.WithValue(context.Background(), ctxKey{}, "request")
+
+	cache.SetCtx(ctx, "a", 1)
+	cache.GetCtx(ctx, "a")
+	cache.Get("missing")
+
+	require.Len(t, tracer.spans, 3)
+	for _, span := range tracer.spans {
+		assert.True(t, span.ended)
+		assert.Equal(t, "users", span.attrs[ucache.AttrCacheName])
+		assert.IsType(t, int64(0), span.attrs[ucache.AttrDurationNs])
+		assert.NotContains(t, span.attrs, ucache.AttrCacheKey)
+	}
+	assert.Equal(t, ucache.SpanSet, tracer.spans[0].name)
+	assert.Equal(t, "request", tracer.spans[0].parent)
+	assert.NotContains(t, tracer.spans[0].attrs, ucache.AttrCacheHit)
+	assert.Equal(t, ucache.SpanGet, tracer.spans[1].name)
+	assert.Equal(t, "request", tracer.spans[1].parent)
+	assert.Equal(t, true, tracer.spans[1].attrs[ucache.AttrCacheHit])
+	assert.Nil(t, tracer.spans[2].parent)
+	assert.Equal(t, false, tracer.spans[2].attrs[ucache.AttrCacheHit])
+}
+
+func TestTracingHooks_WithKeyAttribute(t *testing.T) {
+	tracer := &testTracer{}
+	cache := ucache.NewInstrumentedCache[int, int](
+		ucache.NewInMemoryComparableMapCache[int, int](uopt.Null[time.Duration]()),
+		ucache.TracingHooks[int](tracer, "", ucache.WithKeyAttribute()),
+	)
+	cache.Set(42, 1)
+
+	require.Len(t, tracer.spans, 1)
+	assert.Equal(t, "42", tracer.spans[0].attrs[ucache.AttrCacheKey])
+	assert.NotContains(t, tracer.spans[0].attrs, ucache.AttrCacheName)
+}
+
+func TestMetricsHooks(t *testing.T) {
+	registry := uprom.NewPrometheusRegistry()
+	cache := ucache.NewInstrumentedCache[string, int](
+		ucache.NewInMemoryComparableMapCache[string, int](uopt.Null[time.Duration]()),
+		ucache.MetricsHooks[string](registry, "users"),
+	)
+
+	cache.Set("a", 1)
+	cache.SetQuietly("b", 2)
+	cache.Get("a")
+	cache.Get("a")
+	cache.Get("missing")
+
+	var out strings.Builder
+	_, err := registry.WriteTo(&out)
+	require.NoError(t, err)
+	metrics := out.String()
+	assert.Contains(t, metrics, `ucache_gets_total{cache="users",result="hit"} 2`)
+	assert.Contains(t, metrics, `ucache_gets_total{cache="users",result="miss"} 1`)
+	assert.Contains(t, metrics, `ucache_sets_total{cache="users"} 2`)
+	assert.Contains(t, metrics, `ucache_operation_duration_seconds_count{cache="users",op="get"} 3`)
+	assert.Contains(t, metrics, `ucache_operation_duration_seconds_count{cache="users",op="set"} 2`)
+}
+
+func TestMetricsHooks_NilRegistry(t *testing.T) {
+	cache := ucache.NewInstrumentedCache[string, int](
+		ucache.NewInMemoryComparableMapCache[string, int](uopt.Null[time.Duration]()),
+		ucache.MetricsHooks[string](nil, ""),
+	)
+	cache.Set("a", 1)
+	v, ok := cache.Get("a")
+	require.True(t, ok)
+	assert.Equal(t, 1, *v)
+}
+
+func TestLoadingStatsReporter(t *testing.T) {
+	registry := uprom.NewPrometheusRegistry()
+	reporter := ucache.NewLoadingStatsReporter(registry, "users")
+	reporter.Report(ucache.LoadingStats{Hits: 3, PrefetchedHits: 1, Misses: 2})
+	reporter.Report(ucache.LoadingStats{Hits: 5, PrefetchedHits: 1, Misses: 2})
+	reporter.Report(ucache.LoadingStats{Hits: 5, PrefetchedHits: 1, Misses: 4})
+
+	var out strings.Builder
+	_, err := registry.WriteTo(&out)
+	require.NoError(t, err)
+	metrics := out.String()
+	assert.Contains(t, metrics, "# TYPE ucache_loading_hits_total counter\n")
+	assert.Contains(t, metrics, `ucache_loading_hits_total{cache="users"} 5`+"\n")
+	assert.Contains(t, metrics, `ucache_loading_prefetched_hits_total{cache="users"} 1`+"\n")
+	assert.Contains(t, metrics, `ucache_loading_misses_total{cache="users"} 4`+"\n")
+}
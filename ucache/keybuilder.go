@@ -0,0 +1,217 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package ucache
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dgryski/go-farm"
+	"github.com/kordax/basic-utils/uconst"
+)
+
+const (
+	keyPartStr   byte = 's'
+	keyPartInt   byte = 'i'
+	keyPartUint  byte = 'u'
+	keyPartBool  byte = 'b'
+	keyPartFloat byte = 'f'
+	keyPartTime  byte = 't'
+	keyPartBytes byte = 'x'
+)
+
+/*
+KeyBuilder fluently builds a BuiltKey out of typed levels, which is an alternative to implementing CompositeKey
+or passing variadic values to NewGenericCompositeKey:
+
+	key := ucache.Key().Str("user").Int(42).Time(t).Build()
+
+Every level is encoded together with its type, so Str("1") and Int(1) are different keys.
+A KeyBuilder is not safe for concurrent use, but it can be reused after Build to create keys sharing a prefix.
+*/
+type KeyBuilder struct {
+	encoded []byte
+	keys    []uconst.Unique
+}
+
+// Key starts building a new composite key.
+func Key() *KeyBuilder {
+	return &KeyBuilder{encoded: make([]byte, 0, keyBufferSize)}
+}
+
+// Str appends a string level.
+func (b *KeyBuilder) Str(v string) *KeyBuilder {
+	start := b.begin(keyPartStr)
+	b.encoded = binary.AppendUvarint(b.encoded, uint64(len(v)))
+	b.encoded = append(b.encoded, v...)
+
+	return b.end(start)
+}
+
+// Int appends an integer level.
+func (b *KeyBuilder) Int(v int64) *KeyBuilder {
+	start := b.begin(keyPartInt)
+	b.encoded = binary.LittleEndian.AppendUint64(b.encoded, uint64(v))
+
+	return b.end(start)
+}
+
+// Uint appends an unsigned integer level.
+func (b *KeyBuilder) Uint(v uint64) *KeyBuilder {
+	start := b.begin(keyPartUint)
+	b.encoded = binary.LittleEndian.AppendUint64(b.encoded, v)
+
+	return b.end(start)
+}
+
+// Bool appends a boolean level.
+func (b *KeyBuilder) Bool(v bool) *KeyBuilder {
+	start := b.begin(keyPartBool)
+	if v {
+		b.encoded = append(b.encoded, 1)
+	} else {
+		b.encoded = append(b.encoded, 0)
+	}
+
+	return b.end(start)
+}
+
+// Float appends a floating point level. Negative zero is treated as zero.
+func (b *KeyBuilder) Float(v float64) *KeyBuilder {
+	if v == 0 {
+		v = 0
+	}
+	start := b.begin(keyPartFloat)
+	b.encoded = binary.LittleEndian.AppendUint64(b.encoded, math.Float64bits(v))
+
+	return b.end(start)
+}
+
+// Time appends a time level. Times are compared as instants, so the location and the monotonic clock reading are ignored.
+func (b *KeyBuilder) Time(v time.Time) *KeyBuilder {
+	start := b.begin(keyPartTime)
+	b.encoded = binary.LittleEndian.AppendUint64(b.encoded, uint64(v.Unix()))
+	b.encoded = binary.LittleEndian.AppendUint32(b.encoded, uint32(v.Nanosecond()))
+
+	return b.end(start)
+}
+
+// Bytes appends a binary level.
+func (b *KeyBuilder) Bytes(v []byte) *KeyBuilder {
+	start := b.begin(keyPartBytes)
+	b.encoded = binary.AppendUvarint(b.encoded, uint64(len(v)))
+	b.encoded = append(b.encoded, v...)
+
+	return b.end(start)
+}
+
+// Build creates the key. All the hashes are calculated here, so using the key is allocation free.
+func (b *KeyBuilder) Build() BuiltKey {
+	keys := make([]uconst.Unique, len(b.keys))
+	copy(keys, b.keys)
+
+	return BuiltKey{
+		encoded: string(b.encoded),
+		keys:    keys,
+		hash:    int64(farm.Hash64(b.encoded)),
+	}
+}
+
+func (b *KeyBuilder) begin(kind byte) int {
+	start := len(b.encoded)
+	b.encoded = append(b.encoded, kind)
+
+	return start
+}
+
+func (b *KeyBuilder) end(start int) *KeyBuilder {
+	b.keys = append(b.keys, IntKey(farm.Hash64(b.encoded[start:])))
+
+	return b
+}
+
+// BuiltKey is a CompositeKey with precomputed hashes created by KeyBuilder.
+// It also implements uconst.Unique, so it can be used with Cache implementations as well.
+// The zero value is an empty key.
+type BuiltKey struct {
+	encoded string
+	keys    []uconst.Unique
+	hash    int64
+}
+
+// Keys returns the precomputed hashes of the levels. The returned slice must not be modified.
+func (k BuiltKey) Keys() []uconst.Unique {
+	return k.keys
+}
+
+// Key returns the hash of the whole key.
+func (k BuiltKey) Key() int64 {
+	return k.hash
+}
+
+// Len returns the number of levels.
+func (k BuiltKey) Len() int {
+	return len(k.keys)
+}
+
+func (k BuiltKey) Equals(other uconst.Comparable) bool {
+	switch o := other.(type) {
+	case BuiltKey:
+		return k.encoded == o.encoded
+	case *BuiltKey:
+		if o == nil {
+			return false
+		}
+		return k.encoded == o.encoded
+	default:
+		return false
+	}
+}
+
+// String returns the levels separated by commas, e.g. "user, 42".
+func (k BuiltKey) String() string {
+	rep := make([]string, 0, len(k.keys))
+	data := k.encoded
+	for len(data) > 0 {
+		kind := data[0]
+		data = data[1:]
+		switch kind {
+		case keyPartStr, keyPartBytes:
+			size, n := binary.Uvarint([]byte(data))
+			value := data[n : n+int(size)]
+			data = data[n+int(size):]
+			if kind == keyPartStr {
+				rep = append(rep, value)
+			} else {
+				rep = append(rep, base64.StdEncoding.EncodeToString([]byte(value)))
+			}
+		case keyPartInt:
+			rep = append(rep, strconv.FormatInt(int64(binary.LittleEndian.Uint64([]byte(data))), 10))
+			data = data[8:]
+		case keyPartUint:
+			rep = append(rep, strconv.FormatUint(binary.LittleEndian.Uint64([]byte(data)), 10))
+			data = data[8:]
+		case keyPartBool:
+			rep = append(rep, strconv.FormatBool(data[0] == 1))
+			data = data[1:]
+		case keyPartFloat:
+			rep = append(rep, strconv.FormatFloat(math.Float64frombits(binary.LittleEndian.Uint64([]byte(data))), 'g', -1, 64))
+			data = data[8:]
+		case keyPartTime:
+			sec := int64(binary.LittleEndian.Uint64([]byte(data)))
+			nsec := int64(binary.LittleEndian.Uint32([]byte(data[8:])))
+			rep = append(rep, time.Unix(sec, nsec).UTC().Format(time.RFC3339Nano))
+			data = data[12:]
+		}
+	}
+
+	return strings.Join(rep, ", ")
+}
@@ -0,0 +1,66 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package ucache_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/kordax/basic-utils/ucache"
+	"github.com/kordax/basic-utils/uopt"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeyBuilder(t *testing.T) {
+	ts := time.Date(2024, 5, 6, 7, 8, 9, 10, time.UTC)
+	key := ucache.Key().Str("user").Int(42).Uint(7).Bool(true).Float(1.5).Time(ts).Bytes([]byte{1, 2}).Build()
+
+	assert.Equal(t, 7, key.Len())
+	assert.Len(t, key.Keys(), 7)
+	assert.Equal(t, "user, 42, 7, true, 1.5, 2024-05-06T07:08:09.00000001Z, AQI=", key.String())
+
+	same := ucache.Key().Str("user").Int(42).Uint(7).Bool(true).Float(1.5).Time(ts.In(time.FixedZone("X", 3600))).Bytes([]byte{1, 2}).Build()
+	assert.True(t, key.Equals(same))
+	assert.True(t, key.Equals(&same))
+	assert.Equal(t, key.Key(), same.Key())
+	assert.Equal(t, key.Keys(), same.Keys())
+
+	assert.False(t, ucache.Key().Str("1").Build().Equals(ucache.Key().Int(1).Build()), "types must be distinguished")
+	assert.NotEqual(t, ucache.Key().Str("1").Build().Keys(), ucache.Key().Int(1).Build().Keys())
+	assert.False(t, ucache.Key().Str("ab").Str("c").Build().Equals(ucache.Key().Str("a").Str("bc").Build()))
+	assert.True(t, ucache.Key().Float(0).Build().Equals(ucache.Key().Float(math.Copysign(0, -1)).Build()))
+	assert.False(t, key.Equals(ucache.NewIntCompositeKey(42)))
+	assert.False(t, key.Equals((*ucache.BuiltKey)(nil)))
+}
+
+func TestKeyBuilder_SharedPrefix(t *testing.T) {
+	b := ucache.Key().Str("tenant").Int(1)
+	first := b.Build()
+	second := b.Str("user").Build()
+
+	assert.Equal(t, 2, first.Len())
+	assert.Equal(t, 3, second.Len())
+	assert.Equal(t, first.Keys(), second.Keys()[:2])
+	assert.Equal(t, "tenant, 1", first.String())
+}
+
+func TestKeyBuilder_Caches(t *testing.T) {
+	multi := ucache.NewInMemoryTreeMultiCache[ucache.BuiltKey, DummyComparable](uopt.Null[time.Duration]())
+	multi.Put(ucache.Key().Str("user").Int(1).Build(), DummyComparable{Val: 1})
+	multi.Put(ucache.Key().Str("user").Int(2).Build(), DummyComparable{Val: 2})
+	assert.ElementsMatch(t, []DummyComparable{{Val: 1}, {Val: 2}}, multi.Get(ucache.Key().Str("user").Build()))
+	assert.Equal(t, []DummyComparable{{Val: 2}}, multi.Get(ucache.Key().Str("user").Int(2).Build()))
+
+	cache := ucache.NewInMemoryHashMapCache[ucache.BuiltKey, string](uopt.Null[time.Duration]())
+	cache.Set(ucache.Key().Str("user").Int(1).Build(), "a")
+	value, ok := cache.Get(ucache.Key().Str("user").Int(1).Build())
+	assert.True(t, ok)
+	assert.Equal(t, "a", *value)
+	_, ok = cache.Get(ucache.Key().Str("user").Int(2).Build())
+	assert.False(t, ok)
+}
@@ -0,0 +1,483 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package ucache
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/kordax/basic-utils/uerror"
+	"github.com/kordax/basic-utils/uopt"
+)
+
+// Loader loads the actual value for the key, e.g. from a database or a remote service.
+type Loader[K, T any] func(ctx context.Context, key K) (T, error)
+
+// LoadingOption configures a LoadingCache.
+type LoadingOption func(*loadingOptions)
+
+type loadingOptions struct {
+	refreshTTL    time.Duration
+	refreshWindow float64
+	hotKeys       int
+}
+
+// WithRefreshAhead enables refreshing hot entries before they expire. When GetOrLoad returns an entry
+// that is within the window fraction of the ttl from its expiry, e.g. 0.2 for the last 20% of the ttl,
+// the entry is reloaded asynchronously and the cached value is returned immediately.
+// Entries that are not accessed simply expire. The ttl should match the TTL of the wrapped cache.
+// Only the entries written through the LoadingCache are refreshed ahead, as their write times are tracked by it.
+// Refresh errors are ignored: the cached value is kept and the refresh is retried on the next access.
+func WithRefreshAhead(ttl time.Duration, window float64) LoadingOption {
+	return func(o *loadingOptions) {
+		o.refreshTTL = ttl
+		o.refreshWindow = min(max(window, 0), 1)
+	}
+}
+
+// WithHotKeys makes the LoadingCache track the popularity of the keys requested with GetOrLoad, remembering up to
+// capacity most popular keys, see TopKeys and HotKeys.
+func WithHotKeys(capacity int) LoadingOption {
+	return func(o *loadingOptions) {
+		o.hotKeys = max(capacity, 1)
+	}
+}
+
+// LoadingStats holds the GetOrLoad counters of a LoadingCache.
+type LoadingStats struct {
+	Hits           int64 // Hits is the number of values served from the cache, excluding PrefetchedHits.
+	PrefetchedHits int64 // PrefetchedHits is the number of values served from the cache that were loaded by WarmUp.
+	Misses         int64 // Misses is the number of values that had to be loaded.
+}
+
+// LoadingCache wraps a BaseCache and populates it with a Loader on demand.
+// Concurrent loads of the same key are deduplicated, so the loader runs once and all the callers share its result.
+// The shared load runs with the values of the context of the caller that started it, but it isn't cancelled along with it:
+// every caller stops waiting and returns the context error once its own context is done, while the load goes on
+// for the other callers and still stores the loaded value.
+// Keys are deduplicated by the == operator, so pointer keys are only deduplicated when they point to the same object.
+// If the loader panics, the panic is propagated to the caller that started the load, while the callers waiting for
+// the same load receive an error. Loads made by WarmUp and refreshes ahead run in their own goroutines, so a panicking loader
+// crashes the program there like any other goroutine, unless the loader recovers itself.
+//
+// Every modification made through LoadingCache is reported to the change listeners, see OnChange.
+type LoadingCache[K comparable, T any] struct {
+	cache   BaseCache[K, T]
+	loader  Loader[K, T]
+	flights flightGroup[K, T]
+	feed    changeFeed[K]
+	options loadingOptions
+
+	// Write times and in-flight refreshes are only tracked with refresh-ahead enabled.
+	mtx        sync.Mutex
+	written    map[K]time.Time
+	refreshing map[K]struct{}
+	sweepAt    int
+	refreshes  sync.WaitGroup
+	closed     bool
+	closeOnce  sync.Once
+	closeErr   error
+
+	// Keys loaded by WarmUp and not replaced since, the map is allocated by the first WarmUp.
+	prefetched     map[K]struct{}
+	warmedUp       atomic.Bool
+	hits           atomic.Int64
+	prefetchedHits atomic.Int64
+	misses         atomic.Int64
+
+	hotKeys *HotKeys[K] // tracked only with the WithHotKeys option
+}
+
+// NewLoadingCache creates a new LoadingCache on top of the provided cache.
+func NewLoadingCache[K comparable, T any](cache BaseCache[K, T], loader Loader[K, T], opts ...LoadingOption) *LoadingCache[K, T] {
+	c := &LoadingCache[K, T]{
+		cache:  cache,
+		loader: loader,
+	}
+	for _, opt := range opts {
+		opt(&c.options)
+	}
+	if c.refreshAhead() {
+		c.written = make(map[K]time.Time)
+		c.refreshing = make(map[K]struct{})
+	}
+	if c.options.hotKeys > 0 {
+		c.hotKeys = NewHotKeys[K](c.options.hotKeys)
+	}
+
+	return c
+}
+
+// OnChange registers a listener that is called after every Set, DropKey, Drop, successful load or refresh
+// and removal of an outdated key by a wrapping ManagedCache.
+// Listeners are called synchronously in the goroutine that made the change, so they should return quickly.
+// The operation is thread-safe.
+func (c *LoadingCache[K, T]) OnChange(listener func(event ChangeEvent[K])) {
+	c.feed.subscribe(listener)
+}
+
+// ChangesStream returns a channel of change events, see OnChange for the list of reported changes.
+// The channel is closed once the context is done. The operation is thread-safe.
+func (c *LoadingCache[K, T]) ChangesStream(ctx context.Context, opts ...StreamOption) <-chan ChangeEvent[K] {
+	return c.feed.stream(ctx, opts...)
+}
+
+// GetOrLoad returns the cached value for the key if it is present and not outdated,
+// otherwise it loads the value with the loader and stores it in the cache.
+// Loader errors are returned as is and nothing is cached. The operation is thread-safe.
+func (c *LoadingCache[K, T]) GetOrLoad(ctx context.Context, key K) (*T, error) {
+	if c.hotKeys != nil {
+		c.hotKeys.Record(key)
+	}
+	if value, ok := c.cache.Get(key); ok && !c.cache.Outdated(uopt.Of(key)) {
+		c.recordHit(key)
+		c.maybeRefreshAhead(key)
+		return value, nil
+	}
+	c.misses.Add(1)
+
+	return c.load(ctx, key, c.loader, ChangeSet, false)
+}
+
+// WarmUp loads the values of the keys with the loader and stores them in the cache, running up to parallelism
+// loads at once, so the cache can be populated before it starts serving requests. A non-positive parallelism loads
+// the keys one by one. The keys are loaded even if they are already cached, loads of the same key are deduplicated
+// like with GetOrLoad. The loaded entries are marked as prefetched until they are replaced, so their hits are counted
+// separately, see Stats and Prefetched.
+//
+// Loading doesn't stop on errors: the loader errors are returned joined as *uerror.IndexedError holding the key indices
+// in ascending order. Once the context is done no more loads are started and the context error is joined too.
+// The operation is thread-safe.
+func (c *LoadingCache[K, T]) WarmUp(ctx context.Context, keys []K, parallelism int) error {
+	return c.WarmUpWith(ctx, keys, parallelism, c.loader)
+}
+
+// WarmUpWith is like WarmUp, but loads the values with the provided loader instead of the one of the cache,
+// e.g. with a loader reading a snapshot that is cheaper to fetch before serving than the primary source.
+// Loads are still deduplicated with the ones made by GetOrLoad and Refresh, so a key being loaded concurrently
+// receives the value of the load that was started first. The operation is thread-safe.
+func (c *LoadingCache[K, T]) WarmUpWith(ctx context.Context, keys []K, parallelism int, loader Loader[K, T]) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	c.mtx.Lock()
+	if c.prefetched == nil {
+		c.prefetched = make(map[K]struct{})
+	}
+	c.mtx.Unlock()
+	c.warmedUp.Store(true)
+
+	var (
+		wg      sync.WaitGroup
+		errsMtx sync.Mutex
+		errs    []error
+	)
+	indices := make(chan int)
+	for range min(max(parallelism, 1), len(keys)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				if _, err := c.load(ctx, keys[i], loader, ChangeSet, true); err != nil {
+					errsMtx.Lock()
+					errs = append(errs, &uerror.IndexedError{Index: i, Err: err})
+					errsMtx.Unlock()
+				}
+			}
+		}()
+	}
+
+	var ctxErr error
+dispatch:
+	for i := range keys {
+		if ctxErr = ctx.Err(); ctxErr != nil {
+			break
+		}
+		select {
+		case indices <- i:
+		case <-ctx.Done():
+			ctxErr = ctx.Err()
+			break dispatch
+		}
+	}
+	close(indices)
+	wg.Wait()
+
+	slices.SortFunc(errs, func(a, b error) int {
+		return a.(*uerror.IndexedError).Index - b.(*uerror.IndexedError).Index
+	})
+
+	return errors.Join(append(errs, ctxErr)...)
+}
+
+// Prefetched reports whether the cached value of the key was loaded by WarmUp and hasn't been replaced since.
+// The operation is thread-safe.
+func (c *LoadingCache[K, T]) Prefetched(key K) bool {
+	if !c.warmedUp.Load() {
+		return false
+	}
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	_, ok := c.prefetched[key]
+
+	return ok
+}
+
+// Stats returns the GetOrLoad counters. The operation is thread-safe.
+func (c *LoadingCache[K, T]) Stats() LoadingStats {
+	return LoadingStats{
+		Hits:           c.hits.Load(),
+		PrefetchedHits: c.prefetchedHits.Load(),
+		Misses:         c.misses.Load(),
+	}
+}
+
+// TopKeys returns up to n keys most frequently requested with GetOrLoad along with their estimated request counts,
+// the most popular first. It returns nil unless the cache was created with the WithHotKeys option.
+func (c *LoadingCache[K, T]) TopKeys(n int) []KeyCount[K] {
+	if c.hotKeys == nil {
+		return nil
+	}
+	return c.hotKeys.TopKeys(n)
+}
+
+// HotKeys returns the popularity tracker of the cache, or nil unless the cache was created with the WithHotKeys option.
+func (c *LoadingCache[K, T]) HotKeys() *HotKeys[K] {
+	return c.hotKeys
+}
+
+func (c *LoadingCache[K, T]) recordHit(key K) {
+	if c.Prefetched(key) {
+		c.prefetchedHits.Add(1)
+	} else {
+		c.hits.Add(1)
+	}
+}
+
+// markPrefetched marks or unmarks the key as loaded by WarmUp.
+func (c *LoadingCache[K, T]) markPrefetched(key K, prefetched bool) {
+	if !c.warmedUp.Load() {
+		return
+	}
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if prefetched {
+		c.prefetched[key] = struct{}{}
+	} else {
+		delete(c.prefetched, key)
+	}
+}
+
+// Refresh reloads the value for the key with the loader and atomically replaces the cached one,
+// so readers see either the old or the new value, but never a missing one.
+// Concurrent refreshes and loads of the same key are deduplicated. On error the cached value is kept.
+// Refresh can be used to warm up the cache before the values become outdated. The operation is thread-safe.
+func (c *LoadingCache[K, T]) Refresh(ctx context.Context, key K) (*T, error) {
+	return c.load(ctx, key, c.loader, ChangeRefresh, false)
+}
+
+func (c *LoadingCache[K, T]) load(ctx context.Context, key K, loader Loader[K, T], kind ChangeKind, prefetch bool) (*T, error) {
+	// the load is shared by all the callers of the key, so it must not be cancelled along with the one that started it
+	loadCtx := context.WithoutCancel(ctx)
+	value, err := c.flights.do(ctx, key, func() (T, error) {
+		value, err := loader(loadCtx, key)
+		if err != nil {
+			return value, err
+		}
+		c.cache.Set(key, value)
+		c.touch(key)
+		c.markPrefetched(key, prefetch)
+		c.feed.publish(key, kind)
+
+		return value, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &value, nil
+}
+
+// Set updates the cache value for the provided key. The operation is thread-safe.
+func (c *LoadingCache[K, T]) Set(key K, value T) {
+	c.cache.Set(key, value)
+	c.touch(key)
+	c.markPrefetched(key, false)
+	c.feed.publish(key, ChangeSet)
+}
+
+// SetQuietly adds a value to the cache for the provided key without altering the change history.
+// Change listeners are not notified either. The operation is thread-safe.
+func (c *LoadingCache[K, T]) SetQuietly(key K, value T) {
+	c.cache.SetQuietly(key, value)
+	c.touch(key)
+	c.markPrefetched(key, false)
+}
+
+// Get retrieves the value associated with the provided key from the cache without loading it.
+// The operation is thread-safe.
+func (c *LoadingCache[K, T]) Get(key K) (*T, bool) {
+	return c.cache.Get(key)
+}
+
+// ChangeLog returns the latest change of every modified key in the order they were made. The operation is thread-safe.
+func (c *LoadingCache[K, T]) ChangeLog() []ChangeEvent[K] {
+	return changeLogOf[K](c.cache)
+}
+
+// Changes returns a slice of keys that have been modified in the cache. The operation is thread-safe.
+func (c *LoadingCache[K, T]) Changes() []K {
+	return c.cache.Changes()
+}
+
+// ChangesCount returns the number of modified keys. The operation is thread-safe.
+func (c *LoadingCache[K, T]) ChangesCount() int {
+	return changesCount(c.cache)
+}
+
+// ResetChanges atomically returns the modified keys and clears the change history. The operation is thread-safe.
+func (c *LoadingCache[K, T]) ResetChanges() []K {
+	return resetChanges[K](c.cache)
+}
+
+// Drop completely clears the cache, removing all entries. The operation is thread-safe.
+func (c *LoadingCache[K, T]) Drop() {
+	c.cache.Drop()
+	if c.refreshAhead() {
+		c.mtx.Lock()
+		c.written = make(map[K]time.Time)
+		c.mtx.Unlock()
+	}
+	if c.warmedUp.Load() {
+		c.mtx.Lock()
+		clear(c.prefetched)
+		c.mtx.Unlock()
+	}
+	var zero K
+	c.feed.publish(zero, ChangeClear)
+}
+
+// DropKey removes the value associated with the provided key from the cache. The operation is thread-safe.
+func (c *LoadingCache[K, T]) DropKey(key K) {
+	c.cache.DropKey(key)
+	c.forget(key, ChangeDelete)
+}
+
+func (c *LoadingCache[K, T]) expireKeys(keys []K) {
+	expireKeys(c.cache, keys)
+	for _, key := range keys {
+		c.forget(key, ChangeExpire)
+	}
+}
+
+func (c *LoadingCache[K, T]) expireOutdated() []K {
+	keys := expireOutdated(c.cache)
+	for _, key := range keys {
+		c.forget(key, ChangeExpire)
+	}
+
+	return keys
+}
+
+func (c *LoadingCache[K, T]) forget(key K, kind ChangeKind) {
+	if c.refreshAhead() {
+		c.mtx.Lock()
+		delete(c.written, key)
+		c.mtx.Unlock()
+	}
+	c.markPrefetched(key, false)
+	c.feed.publish(key, kind)
+}
+
+// Outdated checks if the provided key or the entire cache (if no key is provided)
+// is outdated based on the set TTL. Returns true if outdated, false otherwise.
+func (c *LoadingCache[K, T]) Outdated(key uopt.Opt[K]) bool {
+	return c.cache.Outdated(key)
+}
+
+// OutdatedKeys returns the keys that are outdated based on the set TTL. The operation is thread-safe.
+func (c *LoadingCache[K, T]) OutdatedKeys() []K {
+	return outdatedKeysOf[K](c.cache)
+}
+
+// Close stops starting refreshes ahead, waits for the in-flight ones to finish, closes the change streams
+// and closes the wrapped cache. Refreshes use the loader without a deadline, so Close waits for the loader to return.
+// Closing the cache more than once has no effect and returns the result of the first Close.
+func (c *LoadingCache[K, T]) Close() error {
+	c.closeOnce.Do(func() {
+		c.mtx.Lock()
+		c.closed = true
+		c.mtx.Unlock()
+		c.refreshes.Wait()
+		c.feed.close()
+		c.closeErr = closeCache(c.cache)
+	})
+
+	return c.closeErr
+}
+
+func (c *LoadingCache[K, T]) refreshAhead() bool {
+	return c.options.refreshTTL > 0 && c.options.refreshWindow > 0
+}
+
+// touch records the write time of the key.
+// Records older than the TTL belong to expired entries, so they are swept whenever the number of records doubles.
+func (c *LoadingCache[K, T]) touch(key K) {
+	if !c.refreshAhead() {
+		return
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	now := time.Now()
+	c.written[key] = now
+	if len(c.written) < c.sweepAt {
+		return
+	}
+	for k, at := range c.written {
+		if now.Sub(at) > c.options.refreshTTL {
+			delete(c.written, k)
+		}
+	}
+	c.sweepAt = max(2*len(c.written), 64)
+}
+
+// maybeRefreshAhead starts an asynchronous refresh if the entry is close to its expiry and isn't being refreshed already.
+func (c *LoadingCache[K, T]) maybeRefreshAhead(key K) {
+	if !c.refreshAhead() {
+		return
+	}
+
+	c.mtx.Lock()
+	at, ok := c.written[key]
+	threshold := time.Duration(float64(c.options.refreshTTL) * (1 - c.options.refreshWindow))
+	if c.closed || !ok || time.Since(at) < threshold {
+		c.mtx.Unlock()
+		return
+	}
+	if _, ok := c.refreshing[key]; ok {
+		c.mtx.Unlock()
+		return
+	}
+	c.refreshing[key] = struct{}{}
+	c.refreshes.Add(1)
+	c.mtx.Unlock()
+
+	go func() {
+		defer c.refreshes.Done()
+		defer func() {
+			c.mtx.Lock()
+			delete(c.refreshing, key)
+			c.mtx.Unlock()
+		}()
+		_, _ = c.Refresh(context.Background(), key)
+	}()
+}
@@ -0,0 +1,515 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package ucache_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kordax/basic-utils/ucache"
+	"github.com/kordax/basic-utils/uerror"
+	"github.com/kordax/basic-utils/uopt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadingCache_GetOrLoad(t *testing.T) {
+	var calls atomic.Int32
+	c := ucache.NewLoadingCache[string, int](
+		ucache.NewInMemoryComparableMapCache[string, int](uopt.Null[time.Duration]()),
+		func(ctx context.Context, key string) (int, error) {
+			calls.Add(1)
+			return len(key), nil
+		},
+	)
+
+	value, err := c.GetOrLoad(context.Background(), "four")
+	require.NoError(t, err)
+	assert.Equal(t, 4, *value)
+
+	value, err = c.GetOrLoad(context.Background(), "four")
+	require.NoError(t, err)
+	assert.Equal(t, 4, *value)
+	assert.EqualValues(t, 1, calls.Load(), "cached value must be reused")
+
+	c.Set("preset", 100)
+	value, err = c.GetOrLoad(context.Background(), "preset")
+	require.NoError(t, err)
+	assert.Equal(t, 100, *value)
+	assert.EqualValues(t, 1, calls.Load())
+}
+
+func TestLoadingCache_GetOrLoadOutdated(t *testing.T) {
+	var calls atomic.Int32
+	c := ucache.NewLoadingCache[string, int32](
+		ucache.NewInMemoryComparableMapCache[string, int32](uopt.Of(50*time.Millisecond)),
+		func(ctx context.Context, key string) (int32, error) {
+			return calls.Add(1), nil
+		},
+	)
+
+	value, err := c.GetOrLoad(context.Background(), "key")
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, *value)
+
+	time.Sleep(60 * time.Millisecond)
+	value, err = c.GetOrLoad(context.Background(), "key")
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, *value, "outdated value must be reloaded")
+}
+
+func TestLoadingCache_LoaderError(t *testing.T) {
+	loadErr := errors.New("unavailable")
+	c := ucache.NewLoadingCache[string, int](
+		ucache.NewInMemoryComparableMapCache[string, int](uopt.Null[time.Duration]()),
+		func(ctx context.Context, key string) (int, error) {
+			return 0, loadErr
+		},
+	)
+
+	value, err := c.GetOrLoad(context.Background(), "key")
+	assert.ErrorIs(t, err, loadErr)
+	assert.Nil(t, value)
+	_, ok := c.Get("key")
+	assert.False(t, ok, "failed loads must not be cached")
+
+	c.Set("key", 1)
+	_, err = c.Refresh(context.Background(), "key")
+	assert.ErrorIs(t, err, loadErr)
+	cached, ok := c.Get("key")
+	require.True(t, ok)
+	assert.Equal(t, 1, *cached, "failed refresh must keep the cached value")
+}
+
+func TestLoadingCache_LoaderPanic(t *testing.T) {
+	release := make(chan struct{})
+	started := make(chan struct{})
+	c := ucache.NewLoadingCache[string, int](
+		ucache.NewInMemoryComparableMapCache[string, int](uopt.Null[time.Duration]()),
+		func(ctx context.Context, key string) (int, error) {
+			close(started)
+			<-release
+			panic("boom")
+		},
+	)
+
+	waiterErr := make(chan error, 1)
+	go func() {
+		<-started
+		_, err := c.GetOrLoad(context.Background(), "key")
+		waiterErr <- err
+	}()
+	go func() {
+		time.Sleep(50 * time.Millisecond) // lets the waiter join the load
+		close(release)
+	}()
+
+	assert.PanicsWithValue(t, "boom", func() {
+		_, _ = c.GetOrLoad(context.Background(), "key")
+	}, "the panic must be propagated to the caller running the loader")
+	assert.ErrorContains(t, <-waiterErr, "boom", "the waiting callers must receive an error")
+}
+
+func TestLoadingCache_CancelledCaller(t *testing.T) {
+	var calls atomic.Int32
+	started := make(chan struct{})
+	release := make(chan struct{})
+	c := ucache.NewLoadingCache[string, int](
+		ucache.NewInMemoryComparableMapCache[string, int](uopt.Null[time.Duration]()),
+		func(ctx context.Context, key string) (int, error) {
+			calls.Add(1)
+			close(started)
+			<-release
+			return 1, ctx.Err()
+		},
+	)
+
+	firstCtx, cancelFirst := context.WithCancel(context.Background())
+	firstErr := make(chan error, 1)
+	go func() {
+		_, err := c.GetOrLoad(firstCtx, "key")
+		firstErr <- err
+	}()
+	<-started
+
+	secondResult := make(chan *int, 1)
+	go func() {
+		value, err := c.GetOrLoad(context.Background(), "key")
+		assert.NoError(t, err)
+		secondResult <- value
+	}()
+	time.Sleep(20 * time.Millisecond) // lets the second caller join the load
+
+	cancelFirst()
+	assert.ErrorIs(t, <-firstErr, context.Canceled, "the cancelled caller must stop waiting")
+
+	// a waiter gives up on its own context too
+	waiterCtx, cancelWaiter := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancelWaiter()
+	_, err := c.GetOrLoad(waiterCtx, "key")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	close(release)
+	value := <-secondResult
+	require.NotNil(t, value)
+	assert.Equal(t, 1, *value, "the load must not be cancelled with the caller that started it")
+	assert.EqualValues(t, 1, calls.Load())
+	cached, ok := c.Get("key")
+	require.True(t, ok)
+	assert.Equal(t, 1, *cached)
+}
+
+func TestLoadingCache_RefreshDeduplication(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	c := ucache.NewLoadingCache[string, int32](
+		ucache.NewInMemoryComparableMapCache[string, int32](uopt.Null[time.Duration]()),
+		func(ctx context.Context, key string) (int32, error) {
+			<-release
+			return calls.Add(1), nil
+		},
+	)
+	c.Set("key", 0)
+
+	var events atomic.Int32
+	c.OnChange(func(event ucache.ChangeEvent[string]) {
+		if event.Kind == ucache.ChangeRefresh {
+			events.Add(1)
+		}
+	})
+
+	const workers = 10
+	var wg sync.WaitGroup
+	results := make([]int32, workers)
+	started := make(chan struct{}, workers)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			started <- struct{}{}
+			value, err := c.Refresh(context.Background(), "key")
+			assert.NoError(t, err)
+			results[i] = *value
+		}(i)
+	}
+	for i := 0; i < workers; i++ {
+		<-started
+	}
+	time.Sleep(20 * time.Millisecond) // let all the workers join the flight
+	close(release)
+	wg.Wait()
+
+	assert.EqualValues(t, 1, calls.Load(), "concurrent refreshes must be deduplicated")
+	assert.EqualValues(t, 1, events.Load(), "a single event must be emitted per refresh")
+	for _, result := range results {
+		assert.EqualValues(t, 1, result)
+	}
+	cached, ok := c.Get("key")
+	require.True(t, ok)
+	assert.EqualValues(t, 1, *cached)
+
+	_, err := c.Refresh(context.Background(), "key")
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, calls.Load(), "sequential refreshes must reload the value")
+}
+
+func TestLoadingCache_ChangeEvents(t *testing.T) {
+	c := ucache.NewLoadingCache[string, int](
+		ucache.NewInMemoryComparableMapCache[string, int](uopt.Null[time.Duration]()),
+		func(ctx context.Context, key string) (int, error) {
+			return len(key), nil
+		},
+	)
+
+	var events []ucache.ChangeEvent[string]
+	c.OnChange(func(event ucache.ChangeEvent[string]) {
+		events = append(events, event)
+	})
+
+	_, _ = c.GetOrLoad(context.Background(), "loaded")
+	_, _ = c.GetOrLoad(context.Background(), "loaded")
+	c.Set("set", 1)
+	c.SetQuietly("quiet", 1)
+	_, _ = c.Refresh(context.Background(), "set")
+	c.DropKey("set")
+
+	require.Len(t, events, 4)
+	assert.Equal(t, "loaded", events[0].Key)
+	assert.Equal(t, ucache.ChangeSet, events[0].Kind)
+	assert.Equal(t, "set", events[1].Key)
+	assert.Equal(t, ucache.ChangeSet, events[1].Kind)
+	assert.Equal(t, ucache.ChangeRefresh, events[2].Kind)
+	assert.Equal(t, ucache.ChangeDelete, events[3].Kind)
+	assert.Equal(t, "delete", events[3].Kind.String())
+	assert.False(t, events[0].At.IsZero())
+}
+
+func TestLoadingCache_RefreshAhead(t *testing.T) {
+	ttl := 200 * time.Millisecond
+	var calls atomic.Int32
+	c := ucache.NewLoadingCache[string, int32](
+		ucache.NewInMemoryComparableMapCache[string, int32](uopt.Of(ttl)),
+		func(ctx context.Context, key string) (int32, error) {
+			return calls.Add(1), nil
+		},
+		ucache.WithRefreshAhead(ttl, 0.5),
+	)
+	refreshed := make(chan struct{}, 10)
+	c.OnChange(func(event ucache.ChangeEvent[string]) {
+		if event.Kind == ucache.ChangeRefresh {
+			refreshed <- struct{}{}
+		}
+	})
+
+	value, err := c.GetOrLoad(context.Background(), "hot")
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, *value)
+
+	value, err = c.GetOrLoad(context.Background(), "hot")
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, *value)
+	assert.EqualValues(t, 1, calls.Load(), "fresh entries must not be refreshed")
+
+	time.Sleep(ttl * 6 / 10)
+	value, err = c.GetOrLoad(context.Background(), "hot")
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, *value, "the cached value must be returned while refreshing")
+
+	select {
+	case <-refreshed:
+	case <-time.After(time.Second):
+		t.Fatal("entry was not refreshed ahead")
+	}
+	value, _ = c.Get("hot")
+	assert.EqualValues(t, 2, *value)
+	assert.False(t, c.Outdated(uopt.Of("hot")))
+
+	value, err = c.GetOrLoad(context.Background(), "hot")
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, *value)
+	assert.EqualValues(t, 2, calls.Load(), "refreshed entries must be fresh again")
+}
+
+func TestLoadingCache_RefreshAheadDeduplicates(t *testing.T) {
+	ttl := 100 * time.Millisecond
+	var calls atomic.Int32
+	release := make(chan struct{})
+	c := ucache.NewLoadingCache[string, int32](
+		ucache.NewInMemoryComparableMapCache[string, int32](uopt.Of(ttl)),
+		func(ctx context.Context, key string) (int32, error) {
+			if calls.Add(1) > 1 {
+				<-release
+			}
+			return calls.Load(), nil
+		},
+		ucache.WithRefreshAhead(ttl, 0.9),
+	)
+
+	_, err := c.GetOrLoad(context.Background(), "key")
+	require.NoError(t, err)
+	time.Sleep(ttl / 5)
+
+	for i := 0; i < 10; i++ {
+		_, err = c.GetOrLoad(context.Background(), "key")
+		require.NoError(t, err)
+	}
+	require.Eventually(t, func() bool { return calls.Load() == 2 }, time.Second, time.Millisecond)
+	close(release)
+	time.Sleep(10 * time.Millisecond)
+	assert.EqualValues(t, 2, calls.Load(), "concurrent accesses must start a single refresh")
+}
+
+func TestLoadingCache_CloseDrainsRefreshes(t *testing.T) {
+	ttl := 100 * time.Millisecond
+	var calls atomic.Int32
+	release := make(chan struct{})
+	c := ucache.NewLoadingCache[string, int32](
+		ucache.NewInMemoryComparableMapCache[string, int32](uopt.Of(ttl)),
+		func(ctx context.Context, key string) (int32, error) {
+			if calls.Add(1) > 1 {
+				<-release
+			}
+			return calls.Load(), nil
+		},
+		ucache.WithRefreshAhead(ttl, 0.9),
+	)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream := c.ChangesStream(ctx)
+
+	_, err := c.GetOrLoad(context.Background(), "key")
+	require.NoError(t, err)
+	time.Sleep(ttl / 5)
+	_, err = c.GetOrLoad(context.Background(), "key")
+	require.NoError(t, err)
+	require.Eventually(t, func() bool { return calls.Load() == 2 }, time.Second, time.Millisecond)
+
+	closed := make(chan error)
+	go func() {
+		closed <- c.Close()
+	}()
+	select {
+	case <-closed:
+		t.Fatal("Close must wait for the in-flight refreshes")
+	case <-time.After(20 * time.Millisecond):
+	}
+	close(release)
+	select {
+	case err = <-closed:
+		require.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("Close must return once the refreshes are finished")
+	}
+	value, _ := c.Get("key")
+	assert.EqualValues(t, 2, *value, "the in-flight refresh must be applied")
+
+	for range stream {
+		// drains the buffered events until the stream is closed by Close
+	}
+	require.NoError(t, c.Close(), "closing twice must be safe")
+
+	_, err = c.GetOrLoad(context.Background(), "key")
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, calls.Load(), "no refreshes must be started after Close")
+}
+
+func TestLoadingCache_WarmUp(t *testing.T) {
+	loadErr := errors.New("unavailable")
+	var running, peak atomic.Int32
+	c := ucache.NewLoadingCache[string, int](
+		ucache.NewInMemoryComparableMapCache[string, int](uopt.Null[time.Duration]()),
+		func(ctx context.Context, key string) (int, error) {
+			n := running.Add(1)
+			defer running.Add(-1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			if key == "bad" || key == "worse" {
+				return 0, loadErr
+			}
+			return len(key), nil
+		},
+	)
+
+	keys := []string{"a", "bb", "bad", "ccc", "dddd", "worse", "eeeee", "ffffff"}
+	err := c.WarmUp(context.Background(), keys, 3)
+	assert.ErrorIs(t, err, loadErr)
+	var indices []int
+	for _, e := range err.(interface{ Unwrap() []error }).Unwrap() {
+		var indexed *uerror.IndexedError
+		require.ErrorAs(t, e, &indexed)
+		indices = append(indices, indexed.Index)
+	}
+	assert.Equal(t, []int{2, 5}, indices)
+	assert.LessOrEqual(t, peak.Load(), int32(3))
+	assert.Greater(t, peak.Load(), int32(1))
+
+	value, ok := c.Get("dddd")
+	require.True(t, ok)
+	assert.Equal(t, 4, *value)
+	assert.True(t, c.Prefetched("dddd"))
+	_, ok = c.Get("bad")
+	assert.False(t, ok)
+
+	_, err = c.GetOrLoad(context.Background(), "a")
+	require.NoError(t, err)
+	_, err = c.GetOrLoad(context.Background(), "organic")
+	require.NoError(t, err)
+	_, err = c.GetOrLoad(context.Background(), "organic")
+	require.NoError(t, err)
+	assert.Equal(t, ucache.LoadingStats{Hits: 1, PrefetchedHits: 1, Misses: 1}, c.Stats())
+
+	c.Set("a", 10)
+	assert.False(t, c.Prefetched("a"), "a replaced value must not be prefetched")
+	c.DropKey("bb")
+	assert.False(t, c.Prefetched("bb"))
+	c.Drop()
+	assert.False(t, c.Prefetched("ccc"))
+}
+
+func TestLoadingCache_WarmUpCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var calls atomic.Int32
+	c := ucache.NewLoadingCache[int, int](
+		ucache.NewInMemoryComparableMapCache[int, int](uopt.Null[time.Duration]()),
+		func(ctx context.Context, key int) (int, error) {
+			if calls.Add(1) == 2 {
+				cancel()
+			}
+			return key, nil
+		},
+	)
+
+	err := c.WarmUp(ctx, []int{1, 2, 3, 4, 5, 6, 7, 8}, 1)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Less(t, calls.Load(), int32(8))
+	assert.NoError(t, c.WarmUp(context.Background(), nil, 4))
+}
+
+func TestLoadingCache_WarmUpWith(t *testing.T) {
+	var primary atomic.Int32
+	c := ucache.NewLoadingCache[string, int](
+		ucache.NewInMemoryComparableMapCache[string, int](uopt.Null[time.Duration]()),
+		func(ctx context.Context, key string) (int, error) {
+			primary.Add(1)
+			return len(key), nil
+		},
+	)
+	snapshot := map[string]int{"a": 10, "bb": 20}
+
+	err := c.WarmUpWith(context.Background(), []string{"a", "bb"}, 2, func(ctx context.Context, key string) (int, error) {
+		return snapshot[key], nil
+	})
+	require.NoError(t, err)
+	assert.Zero(t, primary.Load(), "the cache loader must not be used")
+
+	value, err := c.GetOrLoad(context.Background(), "bb")
+	require.NoError(t, err)
+	assert.Equal(t, 20, *value)
+	assert.True(t, c.Prefetched("bb"))
+	assert.Equal(t, ucache.LoadingStats{PrefetchedHits: 1}, c.Stats())
+}
+
+func TestLoadingCache_TopKeys(t *testing.T) {
+	c := ucache.NewLoadingCache[string, int](
+		ucache.NewInMemoryComparableMapCache[string, int](uopt.Null[time.Duration]()),
+		func(ctx context.Context, key string) (int, error) {
+			return len(key), nil
+		},
+		ucache.WithHotKeys(2),
+	)
+
+	for i := range 10 {
+		_, err := c.GetOrLoad(context.Background(), "popular")
+		require.NoError(t, err)
+		if i%3 == 0 {
+			_, err = c.GetOrLoad(context.Background(), "rare")
+			require.NoError(t, err)
+		}
+	}
+	_, _ = c.GetOrLoad(context.Background(), "once")
+	c.Get("popular") // only GetOrLoad is tracked
+
+	assert.Equal(t, []ucache.KeyCount[string]{{Key: "popular", Count: 10}, {Key: "rare", Count: 4}}, c.TopKeys(3))
+	assert.NotNil(t, c.HotKeys())
+
+	untracked := ucache.NewLoadingCache[string, int](
+		ucache.NewInMemoryComparableMapCache[string, int](uopt.Null[time.Duration]()),
+		func(ctx context.Context, key string) (int, error) { return 0, nil },
+	)
+	_, _ = untracked.GetOrLoad(context.Background(), "key")
+	assert.Nil(t, untracked.TopKeys(1))
+	assert.Nil(t, untracked.HotKeys())
+}
@@ -2,20 +2,26 @@ package ucache
 
 import (
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/kordax/basic-utils/uconst"
+	"github.com/kordax/basic-utils/ulog"
 	"github.com/kordax/basic-utils/uopt"
 )
 
 // ManagedCache provides a wrapper around a Cache implementation to manage
 // periodic cleanup of outdated cache entries. It uses a background goroutine to perform
 // cleanup tasks based on the provided TTL (time-to-live) value.
-// The Stop method must be called to clean up resources if you want to stop managing the cache.
+// The Stop or Close method must be called to clean up resources if you want to stop managing the cache.
+// Internal events are reported to a ulog.Logger, which discards everything unless replaced with SetLogger.
 type ManagedCache[K any, T any] struct {
 	cache    BaseCache[K, T]
 	stopChan chan struct{}
+	stopOnce sync.Once
 	wg       sync.WaitGroup
+
+	logger atomic.Pointer[ulog.Logger]
 }
 
 func NewManagedCache[K any, T any](cache BaseCache[K, T], tick time.Duration) *ManagedCache[K, T] {
@@ -23,6 +29,7 @@ func NewManagedCache[K any, T any](cache BaseCache[K, T], tick time.Duration) *M
 		cache:    cache,
 		stopChan: make(chan struct{}),
 	}
+	b.SetLogger(ulog.Nop())
 
 	b.wg.Add(1)
 	go b.cleanupRoutine(tick)
@@ -46,16 +53,37 @@ func (b *ManagedCache[K, T]) cleanupRoutine(tick time.Duration) {
 }
 
 func (b *ManagedCache[K, T]) ForceCleanup() {
-	for _, key := range b.cache.Changes() {
-		if b.cache.Outdated(uopt.Of(key)) {
-			b.cache.DropKey(key)
-		}
+	outdated := expireOutdated(b.cache)
+	if dropped := len(outdated); dropped > 0 {
+		b.getLogger().Debug("cache cleanup finished", ulog.F("dropped", dropped))
 	}
 }
 
+// SetLogger replaces the logger used to report internal events. Passing nil disables logging.
+// The operation is thread-safe and can be performed at any time.
+func (b *ManagedCache[K, T]) SetLogger(logger ulog.Logger) {
+	logger = ulog.OrNop(logger)
+	b.logger.Store(&logger)
+}
+
+func (b *ManagedCache[K, T]) getLogger() ulog.Logger {
+	return *b.logger.Load()
+}
+
+// Stop stops the cleanup goroutine and waits for the running cleanup to finish.
+// The wrapped cache stays usable. Stopping the cache more than once has no effect.
 func (b *ManagedCache[K, T]) Stop() {
-	close(b.stopChan)
-	b.wg.Wait()
+	b.stopOnce.Do(func() {
+		close(b.stopChan)
+		b.wg.Wait()
+		b.getLogger().Debug("managed cache stopped")
+	})
+}
+
+// Close stops the cleanup goroutine like Stop and closes the wrapped cache. Closing the cache more than once has no effect.
+func (b *ManagedCache[K, T]) Close() error {
+	b.Stop()
+	return closeCache(b.cache)
 }
 
 func (b *ManagedCache[K, T]) Set(key K, value T) {
@@ -66,10 +94,22 @@ func (b *ManagedCache[K, T]) Get(key K) (*T, bool) {
 	return b.cache.Get(key)
 }
 
+func (b *ManagedCache[K, T]) ChangeLog() []ChangeEvent[K] {
+	return changeLogOf[K](b.cache)
+}
+
 func (b *ManagedCache[K, T]) Changes() []K {
 	return b.cache.Changes()
 }
 
+func (b *ManagedCache[K, T]) ChangesCount() int {
+	return changesCount(b.cache)
+}
+
+func (b *ManagedCache[K, T]) ResetChanges() []K {
+	return resetChanges[K](b.cache)
+}
+
 func (b *ManagedCache[K, T]) Drop() {
 	b.cache.Drop()
 }
@@ -78,10 +118,22 @@ func (b *ManagedCache[K, T]) DropKey(key K) {
 	b.cache.DropKey(key)
 }
 
+func (b *ManagedCache[K, T]) expireKeys(keys []K) {
+	expireKeys(b.cache, keys)
+}
+
+func (b *ManagedCache[K, T]) expireOutdated() []K {
+	return expireOutdated(b.cache)
+}
+
 func (b *ManagedCache[K, T]) Outdated(key uopt.Opt[K]) bool {
 	return b.cache.Outdated(key)
 }
 
+func (b *ManagedCache[K, T]) OutdatedKeys() []K {
+	return outdatedKeysOf[K](b.cache)
+}
+
 func (b *ManagedCache[K, T]) SetQuietly(key K, value T) {
 	b.cache.SetQuietly(key, value)
 }
@@ -89,11 +141,15 @@ func (b *ManagedCache[K, T]) SetQuietly(key K, value T) {
 // ManagedMultiCache provides a wrapper around a MultiCache implementation to manage
 // periodic cleanup of outdated cache entries. It uses a background goroutine to perform
 // cleanup tasks based on the provided TTL (time-to-live) value.
-// The Stop method must be called to clean up resources if you want to stop managing the cache.
+// The Stop or Close method must be called to clean up resources if you want to stop managing the cache.
+// Internal events are reported to a ulog.Logger, which discards everything unless replaced with SetLogger.
 type ManagedMultiCache[K CompositeKey, T uconst.Comparable] struct {
 	cache    MultiCache[K, T]
 	stopChan chan struct{}
+	stopOnce sync.Once
 	wg       sync.WaitGroup
+
+	logger atomic.Pointer[ulog.Logger]
 }
 
 func NewManagedMultiCache[K CompositeKey, T uconst.Comparable](cache MultiCache[K, T], tick time.Duration) *ManagedMultiCache[K, T] {
@@ -101,6 +157,7 @@ func NewManagedMultiCache[K CompositeKey, T uconst.Comparable](cache MultiCache[
 		cache:    cache,
 		stopChan: make(chan struct{}),
 	}
+	b.SetLogger(ulog.Nop())
 
 	b.wg.Add(1)
 	go b.cleanupRoutine(tick)
@@ -124,16 +181,37 @@ func (b *ManagedMultiCache[K, T]) cleanupRoutine(tick time.Duration) {
 }
 
 func (b *ManagedMultiCache[K, T]) performCleanup() {
-	for _, key := range b.cache.Changes() {
-		if b.cache.Outdated(uopt.Of(key)) {
-			b.cache.DropKey(key)
-		}
+	outdated := expireOutdated(b.cache)
+	if dropped := len(outdated); dropped > 0 {
+		b.getLogger().Debug("cache cleanup finished", ulog.F("dropped", dropped))
 	}
 }
 
+// SetLogger replaces the logger used to report internal events. Passing nil disables logging.
+// The operation is thread-safe and can be performed at any time.
+func (b *ManagedMultiCache[K, T]) SetLogger(logger ulog.Logger) {
+	logger = ulog.OrNop(logger)
+	b.logger.Store(&logger)
+}
+
+func (b *ManagedMultiCache[K, T]) getLogger() ulog.Logger {
+	return *b.logger.Load()
+}
+
+// Stop stops the cleanup goroutine and waits for the running cleanup to finish.
+// The wrapped cache stays usable. Stopping the cache more than once has no effect.
 func (b *ManagedMultiCache[K, T]) Stop() {
-	close(b.stopChan)
-	b.wg.Wait()
+	b.stopOnce.Do(func() {
+		close(b.stopChan)
+		b.wg.Wait()
+		b.getLogger().Debug("managed cache stopped")
+	})
+}
+
+// Close stops the cleanup goroutine like Stop and closes the wrapped cache. Closing the cache more than once has no effect.
+func (b *ManagedMultiCache[K, T]) Close() error {
+	b.Stop()
+	return closeCache(b.cache)
 }
 
 func (b *ManagedMultiCache[K, T]) Put(key K, values ...T) {
@@ -148,10 +226,22 @@ func (b *ManagedMultiCache[K, T]) Get(key K) []T {
 	return b.cache.Get(key)
 }
 
+func (b *ManagedMultiCache[K, T]) ChangeLog() []ChangeEvent[K] {
+	return changeLogOf[K](b.cache)
+}
+
 func (b *ManagedMultiCache[K, T]) Changes() []K {
 	return b.cache.Changes()
 }
 
+func (b *ManagedMultiCache[K, T]) ChangesCount() int {
+	return changesCount(b.cache)
+}
+
+func (b *ManagedMultiCache[K, T]) ResetChanges() []K {
+	return resetChanges[K](b.cache)
+}
+
 func (b *ManagedMultiCache[K, T]) Drop() {
 	b.cache.Drop()
 }
@@ -160,10 +250,22 @@ func (b *ManagedMultiCache[K, T]) DropKey(key K) {
 	b.cache.DropKey(key)
 }
 
+func (b *ManagedMultiCache[K, T]) expireKeys(keys []K) {
+	expireKeys(b.cache, keys)
+}
+
+func (b *ManagedMultiCache[K, T]) expireOutdated() []K {
+	return expireOutdated(b.cache)
+}
+
 func (b *ManagedMultiCache[K, T]) Outdated(key uopt.Opt[K]) bool {
 	return b.cache.Outdated(key)
 }
 
+func (b *ManagedMultiCache[K, T]) OutdatedKeys() []K {
+	return outdatedKeysOf[K](b.cache)
+}
+
 func (b *ManagedMultiCache[K, T]) PutQuietly(key K, values ...T) {
 	b.cache.PutQuietly(key, values...)
 }
@@ -7,12 +7,17 @@
 package ucache_test
 
 import (
+	"context"
+	"io"
 	"runtime"
 	"strconv"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/kordax/basic-utils/ucache"
+	"github.com/kordax/basic-utils/uconst"
+	"github.com/kordax/basic-utils/ulog"
 	"github.com/kordax/basic-utils/uopt"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -162,6 +167,74 @@ func TestManagedCache_Outdated(t *testing.T) {
 	assert.False(t, ok)
 }
 
+// plainCache is a BaseCache implementing none of the optional interfaces, whose keys listed in outdated are outdated.
+type plainCache struct {
+	mtx      sync.Mutex
+	values   map[string]int
+	outdated map[string]bool
+}
+
+func (c *plainCache) Set(key string, value int) {
+	c.SetQuietly(key, value)
+}
+
+func (c *plainCache) Get(key string) (*int, bool) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	v, ok := c.values[key]
+	return &v, ok
+}
+
+func (c *plainCache) Changes() []string {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	keys := make([]string, 0, len(c.values))
+	for key := range c.values {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func (c *plainCache) Drop() {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	clear(c.values)
+}
+
+func (c *plainCache) DropKey(key string) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	delete(c.values, key)
+}
+
+func (c *plainCache) Outdated(key uopt.Opt[string]) bool {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return key.Present() && c.outdated[*key.Get()]
+}
+
+func (c *plainCache) SetQuietly(key string, value int) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.values[key] = value
+}
+
+func TestManagedCache_CleanupWithoutOutdatedKeysLister(t *testing.T) {
+	cache := &plainCache{values: make(map[string]int), outdated: map[string]bool{"a": true, "b": true}}
+	var _ ucache.BaseCache[string, int] = cache
+	_, ok := any(cache).(ucache.OutdatedKeysLister[string])
+	require.False(t, ok)
+
+	managedCache := ucache.NewManagedCache[string, int](cache, time.Hour)
+	defer managedCache.Stop()
+	managedCache.Set("a", 1)
+	managedCache.Set("b", 2)
+	managedCache.Set("c", 3)
+
+	managedCache.ForceCleanup()
+	assert.Equal(t, []string{"c"}, cache.Changes(), "the outdated keys must be found with Outdated")
+}
+
 func TestManagedCache_MemoryLeaks(t *testing.T) {
 	ttl := time.Nanosecond
 	cache := ucache.NewInMemoryHashMapCache[ucache.IntKey, string](uopt.Of(ttl))
@@ -187,3 +260,126 @@ func TestManagedCache_MemoryLeaks(t *testing.T) {
 
 	assert.LessOrEqual(t, after.HeapAlloc, before.HeapAlloc*3)
 }
+
+type mutableKey struct {
+	id int64
+}
+
+func (k *mutableKey) Key() int64 {
+	return k.id
+}
+
+func (k *mutableKey) Equals(other uconst.Comparable) bool {
+	o, ok := other.(*mutableKey)
+	return ok && o.id == k.id
+}
+
+func TestManagedCache_MutatedKeys(t *testing.T) {
+	ttl := 20 * time.Millisecond
+	cache := ucache.NewInMemoryHashMapCache[*mutableKey, string](uopt.Of(ttl))
+	managedCache := ucache.NewManagedCache(cache, 5*time.Millisecond)
+	defer managedCache.Stop()
+
+	key := &mutableKey{id: 1}
+	managedCache.Set(key, "value")
+	key.id = 2
+
+	require.Eventually(t, func() bool {
+		changes := cache.(ucache.ChangeLogReader[*mutableKey]).ChangeLog()
+		return len(changes) == 1 && changes[0].Kind == ucache.ChangeExpire
+	}, time.Second, 5*time.Millisecond, "the entry must expire even though its key was mutated")
+	assert.Empty(t, cache.(ucache.OutdatedKeysLister[*mutableKey]).OutdatedKeys())
+}
+
+func TestManagedCache_ComparableExpiry(t *testing.T) {
+	ttl := 20 * time.Millisecond
+	cache := ucache.NewInMemoryComparableMapCache[string, int](uopt.Of(ttl))
+	managedCache := ucache.NewManagedCache[string, int](cache, 5*time.Millisecond)
+	defer managedCache.Stop()
+
+	managedCache.Set("stale", 1)
+	require.Eventually(t, func() bool {
+		changes := cache.(ucache.ChangeLogReader[string]).ChangeLog()
+		return len(changes) == 1 && changes[0].Kind == ucache.ChangeExpire && changes[0].Key == "stale"
+	}, time.Second, 5*time.Millisecond)
+
+	managedCache.Set("fresh", 2)
+	v, ok := managedCache.Get("fresh")
+	require.True(t, ok)
+	assert.Equal(t, 2, *v)
+	assert.Empty(t, cache.(ucache.OutdatedKeysLister[string]).OutdatedKeys())
+}
+
+type recordingLogger struct {
+	mtx      sync.Mutex
+	messages []string
+}
+
+func (l *recordingLogger) record(msg string) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	l.messages = append(l.messages, msg)
+}
+
+func (l *recordingLogger) Messages() []string {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	return append([]string(nil), l.messages...)
+}
+
+func (l *recordingLogger) Debug(msg string, _ ...ulog.Field) { l.record(msg) }
+func (l *recordingLogger) Info(msg string, _ ...ulog.Field)  { l.record(msg) }
+func (l *recordingLogger) Warn(msg string, _ ...ulog.Field)  { l.record(msg) }
+func (l *recordingLogger) Error(msg string, _ ...ulog.Field) { l.record(msg) }
+
+func TestManagedCache_SetLogger(t *testing.T) {
+	cache := ucache.NewInMemoryHashMapCache[ucache.IntKey, string](uopt.Of(time.Nanosecond))
+	managedCache := ucache.NewManagedCache(cache, time.Hour)
+	logger := &recordingLogger{}
+	managedCache.SetLogger(logger)
+
+	managedCache.Set(ucache.IntKey(1), "value")
+	time.Sleep(time.Millisecond)
+	managedCache.ForceCleanup()
+	managedCache.Stop()
+
+	assert.Equal(t, []string{"cache cleanup finished", "managed cache stopped"}, logger.Messages())
+
+	managedCache.SetLogger(nil)
+	assert.NotPanics(t, managedCache.ForceCleanup)
+}
+
+func TestManagedMultiCache_SetLogger(t *testing.T) {
+	cache := ucache.NewInMemoryTreeMultiCache[ucache.StrCompositeKey, DummyComparable](uopt.Of(time.Nanosecond))
+	managedCache := ucache.NewManagedMultiCache(cache, time.Millisecond)
+	logger := &recordingLogger{}
+	managedCache.SetLogger(logger)
+
+	managedCache.Set(ucache.NewStrCompositeKey("category", "key1"), DummyComparable{Val: 1})
+	require.Eventually(t, func() bool {
+		return len(managedCache.Get(ucache.NewStrCompositeKey("category", "key1"))) == 0
+	}, time.Second, time.Millisecond)
+	managedCache.Stop()
+
+	messages := logger.Messages()
+	assert.Contains(t, messages, "cache cleanup finished")
+	assert.Equal(t, "managed cache stopped", messages[len(messages)-1])
+}
+
+func TestManagedCache_Close(t *testing.T) {
+	observable := ucache.NewObservableCache[string, int](ucache.NewInMemoryComparableMapCache[string, int](uopt.Of(time.Millisecond)))
+	stream := observable.ChangesStream(context.Background())
+	var closer io.Closer = ucache.NewManagedCache[string, int](observable, time.Millisecond)
+
+	require.NoError(t, closer.Close())
+	require.NoError(t, closer.Close(), "closing twice must be safe")
+	_, ok := <-stream
+	assert.False(t, ok, "the wrapped cache must be closed")
+
+	multi := ucache.NewManagedMultiCache(
+		ucache.NewInMemoryTreeMultiCache[ucache.IntCompositeKey, ucache.StringValue](uopt.Null[time.Duration]()), time.Millisecond,
+	)
+	multi.Stop()
+	require.NoError(t, multi.Close(), "closing a stopped cache must be safe")
+	require.NoError(t, multi.Close())
+}
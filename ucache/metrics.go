@@ -0,0 +1,108 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package ucache
+
+import (
+	"sync"
+
+	"github.com/kordax/basic-utils/uprom"
+)
+
+// Metric names reported by MetricsHooks and LoadingStatsReporter.
+const (
+	MetricGets              = "ucache_gets_total"
+	MetricSets              = "ucache_sets_total"
+	MetricOperationDuration = "ucache_operation_duration_seconds"
+
+	MetricLoadingHits           = "ucache_loading_hits_total"
+	MetricLoadingPrefetchedHits = "ucache_loading_prefetched_hits_total"
+	MetricLoadingMisses         = "ucache_loading_misses_total"
+
+	LabelCache  = "cache"
+	LabelOp     = "op"
+	LabelResult = "result"
+)
+
+/*
+MetricsHooks returns the Hooks reporting the Get and Set calls of an InstrumentedCache to the registry:
+  - MetricGets: the counter of Get calls, labeled by LabelResult "hit" or "miss";
+  - MetricSets: the counter of Set and SetQuietly calls;
+  - MetricOperationDuration: the histogram of the operation durations in seconds, labeled by LabelOp "get" or "set".
+
+All the metrics are labeled by LabelCache with the cacheName, unless it is empty. A nil registry discards the metrics.
+*/
+func MetricsHooks[K any](registry uprom.Registry, cacheName string) Hooks[K] {
+	registry = uprom.OrNop(registry)
+	labels := func(extra ...uprom.Label) []uprom.Label {
+		if cacheName != "" {
+			return append([]uprom.Label{uprom.L(LabelCache, cacheName)}, extra...)
+		}
+		return extra
+	}
+
+	hits := registry.Counter(MetricGets, "Cache reads.", labels(uprom.L(LabelResult, "hit"))...)
+	misses := registry.Counter(MetricGets, "Cache reads.", labels(uprom.L(LabelResult, "miss"))...)
+	sets := registry.Counter(MetricSets, "Cache writes.", labels()...)
+	getDuration := registry.Histogram(MetricOperationDuration, "Cache operation duration in seconds.", uprom.DefaultBuckets,
+		labels(uprom.L(LabelOp, OperationGet.String()))...)
+	setDuration := registry.Histogram(MetricOperationDuration, "Cache operation duration in seconds.", uprom.DefaultBuckets,
+		labels(uprom.L(LabelOp, OperationSet.String()))...)
+
+	return Hooks[K]{
+		AfterGet: func(op *Operation[K]) {
+			if op.Hit {
+				hits.Inc()
+			} else {
+				misses.Inc()
+			}
+			getDuration.Observe(op.Elapsed.Seconds())
+		},
+		AfterSet: func(op *Operation[K]) {
+			sets.Inc()
+			setDuration.Observe(op.Elapsed.Seconds())
+		},
+	}
+}
+
+// LoadingStatsReporter reports the LoadingStats, e.g. of LoadingCache.Stats called periodically, to the
+// MetricLoadingHits, MetricLoadingPrefetchedHits and MetricLoadingMisses counters.
+type LoadingStatsReporter struct {
+	hits           uprom.Counter
+	prefetchedHits uprom.Counter
+	misses         uprom.Counter
+
+	mtx  sync.Mutex
+	last LoadingStats
+}
+
+// NewLoadingStatsReporter creates a LoadingStatsReporter. The counters are labeled by LabelCache with the cacheName,
+// unless it is empty. A nil registry discards the metrics.
+func NewLoadingStatsReporter(registry uprom.Registry, cacheName string) *LoadingStatsReporter {
+	registry = uprom.OrNop(registry)
+	var labels []uprom.Label
+	if cacheName != "" {
+		labels = append(labels, uprom.L(LabelCache, cacheName))
+	}
+
+	return &LoadingStatsReporter{
+		hits:           registry.Counter(MetricLoadingHits, "Values served from the loading cache, excluding the prefetched ones.", labels...),
+		prefetchedHits: registry.Counter(MetricLoadingPrefetchedHits, "Values served from the loading cache that were loaded by WarmUp.", labels...),
+		misses:         registry.Counter(MetricLoadingMisses, "Values that had to be loaded by the loading cache.", labels...),
+	}
+}
+
+// Report adds the change of the stats since the previous report to the counters. The stats are expected to grow,
+// the counters that went down, e.g. since the stats of another cache are reported, are left as is.
+// The operation is thread-safe.
+func (r *LoadingStatsReporter) Report(stats LoadingStats) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.hits.Add(float64(stats.Hits - r.last.Hits))
+	r.prefetchedHits.Add(float64(stats.PrefetchedHits - r.last.PrefetchedHits))
+	r.misses.Add(float64(stats.Misses - r.last.Misses))
+	r.last = stats
+}
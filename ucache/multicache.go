@@ -1,24 +1,204 @@
 package ucache
 
 import (
-	"bytes"
 	"crypto/sha256"
 	"encoding/binary"
+	"slices"
 	"strconv"
-	"strings"
 	"sync"
 	"time"
 
 	"github.com/dgryski/go-farm"
 	"github.com/kordax/basic-utils/uarray"
 	"github.com/kordax/basic-utils/uconst"
-	"github.com/kordax/basic-utils/umap"
 	"github.com/kordax/basic-utils/uopt"
 )
 
-type container[K CompositeKey, T uconst.Comparable] struct {
-	pairs map[int64][]uarray.Pair[K, T]
-	node  map[int64]any
+// treeSlabSize is the number of elements allocated at once by a treeSlab.
+const treeSlabSize = 64
+
+// treeSlab hands out small slices carved from larger preallocated chunks, so building a tree
+// doesn't cost a separate allocation per node, edge and pair slice.
+// Carved slices are capped, so appending to them never overwrites a neighbour and reallocates as usual.
+// A chunk is released once none of its slices is referenced anymore.
+type treeSlab[E any] struct {
+	chunk []E
+}
+
+func (s *treeSlab[E]) take(n int) []E {
+	if n > treeSlabSize/4 {
+		return make([]E, n)
+	}
+	if len(s.chunk) < n {
+		s.chunk = make([]E, treeSlabSize)
+	}
+	result := s.chunk[:n:n]
+	s.chunk = s.chunk[n:]
+
+	return result
+}
+
+// treeArena allocates the nodes of a single InMemoryTreeMultiCache tree.
+// Removed nodes share the slab chunks with the live ones, so they are zeroed to let the values they held be collected
+// and kept in the free list to be reused by the next allocations.
+type treeArena[K CompositeKey, T uconst.Comparable] struct {
+	nodes  treeSlab[treeNode[K, T]]
+	hashes treeSlab[int64]
+	pairs  treeSlab[uarray.Pair[K, T]]
+	free   []*treeNode[K, T]
+}
+
+// alloc returns a zeroed node, preferring the released ones.
+func (a *treeArena[K, T]) alloc() *treeNode[K, T] {
+	if last := len(a.free) - 1; last >= 0 {
+		node := a.free[last]
+		a.free[last] = nil
+		a.free = a.free[:last]
+		return node
+	}
+
+	return &a.nodes.take(1)[0]
+}
+
+// release zeroes the removed node along with its subtree and puts the nodes to the free list.
+func (a *treeArena[K, T]) release(node *treeNode[K, T]) {
+	for _, child := range node.children {
+		a.release(child)
+	}
+	clear(node.pairs[:cap(node.pairs)]) // the pairs may be carved from a slab chunk shared with the live nodes
+	a.recycle(node)
+}
+
+// recycle zeroes the node that doesn't own its pairs and children anymore and puts it to the free list.
+func (a *treeArena[K, T]) recycle(node *treeNode[K, T]) {
+	*node = treeNode[K, T]{}
+	a.free = append(a.free, node)
+}
+
+// newNode creates a node with the edge made of the hashes of keys.
+func (a *treeArena[K, T]) newNode(keys []uconst.Unique) *treeNode[K, T] {
+	node := a.alloc()
+	if len(keys) > 0 {
+		node.path = a.hashes.take(len(keys))
+		for i, k := range keys {
+			node.path[i] = k.Key()
+		}
+	}
+
+	return node
+}
+
+// treeNode is a node of the InMemoryTreeMultiCache radix tree.
+// The tree is path compressed: a chain of levels that hold no values and have a single descendant is collapsed
+// into the edge of the first node below it, so deep keys cost a single node instead of one per level.
+// The edge is stored in path and the node is registered in its parent's children under the first hash of the edge.
+// Nodes are persistent: they are created on write and never allocated on read.
+// Each node with children lazily caches a flattened view of all values stored in its subtree,
+// so repeated Get calls for broad prefixes don't re-merge the whole subtree.
+// The view is invalidated on every write that passes through the node.
+type treeNode[K CompositeKey, T uconst.Comparable] struct {
+	path     []int64
+	pairs    []uarray.Pair[K, T]
+	children map[int64]*treeNode[K, T]
+
+	flat      []T
+	flatValid bool
+}
+
+func (n *treeNode[K, T]) child(hash int64) *treeNode[K, T] {
+	if n.children == nil {
+		return nil
+	}
+
+	return n.children[hash]
+}
+
+func (n *treeNode[K, T]) addChild(child *treeNode[K, T]) {
+	if n.children == nil {
+		n.children = make(map[int64]*treeNode[K, T])
+	}
+	n.children[child.path[0]] = child
+}
+
+// matchPrefix returns the number of leading edge hashes that match keys.
+// The first hash is never compared, because it's the one the node was looked up by.
+func (n *treeNode[K, T]) matchPrefix(keys []uconst.Unique) int {
+	l := 1
+	for l < len(n.path) && l < len(keys) && n.path[l] == keys[l].Key() {
+		l++
+	}
+
+	return l
+}
+
+// split breaks the edge of the child after l hashes, inserting a node without values in between.
+func (n *treeNode[K, T]) split(child *treeNode[K, T], l int, arena *treeArena[K, T]) *treeNode[K, T] {
+	mid := arena.alloc()
+	mid.path = child.path[:l:l]
+	child.path = child.path[l:]
+	mid.addChild(child)
+	n.children[mid.path[0]] = mid
+
+	return mid
+}
+
+// compress merges the node with its only descendant if it holds no values itself.
+func (n *treeNode[K, T]) compress(arena *treeArena[K, T]) {
+	if len(n.pairs) > 0 || len(n.children) != 1 {
+		return
+	}
+
+	for _, child := range n.children {
+		n.path = slices.Concat(n.path, child.path)
+		n.pairs = child.pairs
+		n.children = child.children
+		n.flat, n.flatValid = child.flat, child.flatValid
+		arena.recycle(child)
+	}
+}
+
+func (n *treeNode[K, T]) invalidate() {
+	n.flat = nil
+	n.flatValid = false
+}
+
+func (n *treeNode[K, T]) size() int {
+	if n.flatValid {
+		return len(n.flat)
+	}
+
+	size := len(n.pairs)
+	for _, child := range n.children {
+		size += child.size()
+	}
+
+	return size
+}
+
+// values returns the flattened view of the subtree. The returned slice must not be modified.
+func (n *treeNode[K, T]) values() []T {
+	if len(n.children) == 0 {
+		result := make([]T, len(n.pairs))
+		for i, p := range n.pairs {
+			result[i] = p.Right
+		}
+
+		return result
+	}
+
+	if !n.flatValid {
+		flat := make([]T, 0, n.size())
+		for _, p := range n.pairs {
+			flat = append(flat, p.Right)
+		}
+		for _, child := range n.children {
+			flat = append(flat, child.values()...)
+		}
+		n.flat = flat
+		n.flatValid = true
+	}
+
+	return n.flat
 }
 
 // The MultiCache interface defines a set of methods for a generic cache implementation.
@@ -32,6 +212,9 @@ type container[K CompositeKey, T uconst.Comparable] struct {
 //
 // This hierarchical key handling is useful for scenarios where more specific keys should override
 // the values of their parent keys, providing a clear and structured way to manage cache entries.
+//
+// The caches of this package also implement the optional ChangeLogReader, ChangesCounter, ChangesResetter,
+// OutdatedKeysLister and io.Closer interfaces.
 type MultiCache[K CompositeKey, T any] interface {
 	// Put inserts a new value(s) into the cache associated with the given key.
 	// If the key already exists in the cache, it appends the new value(s) to the existing values.
@@ -48,9 +231,10 @@ type MultiCache[K CompositeKey, T any] interface {
 	// Supports retrieving a value using a broader key (e.g., [1, 2]) or a full/shallow key (e.g., [1, 2, 3, 4])
 	Get(key K) []T
 
-	// Changes returns a slice of keys that have been modified in the cache.
+	// Changes returns the keys whose latest change is ChangeSet, in the order they were set.
+	// It's a compatibility view of ChangeLogReader.ChangeLog: deleted, expired and dropped keys are not returned.
 	// This method provides a way to track changes made to the cache, useful for scenarios like cache syncing.
-	// Cache changes will be updated only on modifying operations, meaning that in-fact, changes contain all the present keys.
+	// The returned slice is a copy owned by the caller and can be safely modified.
 	Changes() []K
 
 	// Drop removes all entries from the cache.
@@ -61,9 +245,10 @@ type MultiCache[K CompositeKey, T any] interface {
 	DropKey(key K)
 
 	// Outdated checks if a given key or the entire cache is outdated based on the TTL.
+	// If no TTL is set, nothing is ever outdated and false is returned.
 	// If no key is provided it checks the last updated time of the entire cache.
 	// If a key is provided and found, it checks the last updated time of that specific key.
-	// If key was not found returns false.
+	// If key was not found it is considered outdated.
 	Outdated(key uopt.Opt[K]) bool
 
 	// PutQuietly behaves like the Put method but does not update the cache state or add any changes to the cache, making it
@@ -78,6 +263,9 @@ type MultiCache[K CompositeKey, T any] interface {
 // ensuring that outdated entries can be identified and potentially purged. Concurrency-safe
 // operations are ensured through the use of a mutex.
 //
+// The tree is path compressed and its nodes are allocated in slabs, so deep keys that don't share their prefixes
+// cost a single node rather than a chain of nested nodes per level.
+//
 // Benchmark insights:
 // - Put operation performance is fast for shallow depth keys but slows down as the depth increases.
 // - Get operation is particularly efficient, especially for shallow depth keys.
@@ -85,10 +273,11 @@ type MultiCache[K CompositeKey, T any] interface {
 // TTL parameter in cache doesn't automatically clean up all the entries.
 // Use ManagedMultiCache wrapper to automatically manage outdated keys.
 type InMemoryTreeMultiCache[K CompositeKey, T uconst.Comparable] struct {
-	values  map[int64]any
-	changes []K
+	root    *treeNode[K, T]
+	arena   *treeArena[K, T]
+	changes *changeLog[string, K]
 
-	lastUpdatedKeys map[string]time.Time
+	lastUpdatedKeys map[string]keyContainer[K, []uconst.Unique] // the fingerprint is the frozen key path
 	lastUpdated     time.Time
 	ttl             *time.Duration
 
@@ -106,10 +295,12 @@ type InMemoryTreeMultiCache[K CompositeKey, T uconst.Comparable] struct {
 //   - Additionally, retrieving a value using a broader key (e.g., [1, 2]) will return the values of the most specific key
 //     that shares the prefix (e.g., [1, 2, 3, 4]).
 func NewInMemoryTreeMultiCache[K CompositeKey, T uconst.Comparable](ttl uopt.Opt[time.Duration]) MultiCache[K, T] {
+	arena := &treeArena[K, T]{}
 	c := &InMemoryTreeMultiCache[K, T]{
-		values:          make(map[int64]any),
-		changes:         make([]K, 0),
-		lastUpdatedKeys: make(map[string]time.Time),
+		root:            arena.newNode(nil),
+		arena:           arena,
+		changes:         newChangeLog[string, K](),
+		lastUpdatedKeys: make(map[string]keyContainer[K, []uconst.Unique]),
 	}
 	ttl.IfPresent(func(t time.Duration) {
 		c.ttl = &t
@@ -124,9 +315,10 @@ func NewInMemoryTreeMultiCache[K CompositeKey, T uconst.Comparable](ttl uopt.Opt
 func (c *InMemoryTreeMultiCache[K, T]) Put(key K, val ...T) {
 	c.vMtx.Lock()
 	defer c.vMtx.Unlock()
-	c.put(key, val...)
-	c.lastUpdatedKeys[keysAsString(key.Keys())] = time.Now()
-	c.lastUpdated = time.Now()
+	keys := key.Keys()
+	id := keysAsString(keys)
+	c.put(key, id, val...)
+	c.touch(key, keys, id)
 }
 
 // Set inserts a new value(s) into the cache associated with the given key.
@@ -134,10 +326,11 @@ func (c *InMemoryTreeMultiCache[K, T]) Put(key K, val ...T) {
 func (c *InMemoryTreeMultiCache[K, T]) Set(key K, val ...T) {
 	c.vMtx.Lock()
 	defer c.vMtx.Unlock()
-	c.dropKeyRecursively(key.Keys(), 0, c.values)
-	c.put(key, val...)
-	c.lastUpdatedKeys[keysAsString(key.Keys())] = time.Now()
-	c.lastUpdated = time.Now()
+	keys := key.Keys()
+	c.dropKeyRecursively(keys)
+	id := keysAsString(keys)
+	c.put(key, id, val...)
+	c.touch(key, keys, id)
 }
 
 // PutQuietly behaves like the Put method but does not update the cache state or add any changes to the cache, making it
@@ -147,8 +340,8 @@ func (c *InMemoryTreeMultiCache[K, T]) PutQuietly(key K, val ...T) {
 	c.vMtx.Lock()
 	defer c.vMtx.Unlock()
 	c.addTran(key, val...)
-	c.lastUpdatedKeys[keysAsString(key.Keys())] = time.Now()
-	c.lastUpdated = time.Now()
+	keys := key.Keys()
+	c.touch(key, keys, keysAsString(keys))
 }
 
 // Get retrieves the value(s) associated with the given key from the cache.
@@ -158,23 +351,44 @@ func (c *InMemoryTreeMultiCache[K, T]) Get(key K) []T {
 	c.vMtx.Lock()
 	defer c.vMtx.Unlock()
 
-	bucket := c.tryToGetBucket(key.Keys())
-	result := make([]T, 0)
-	for _, pairs := range bucket {
-		for _, p := range pairs {
-			result = append(result, p.Right)
-		}
+	node := c.findNode(key.Keys())
+	if node == nil {
+		return make([]T, 0)
+	}
+	if len(node.children) == 0 {
+		return node.values()
 	}
 
-	return result
+	return slices.Clone(node.values())
+}
+
+// ChangeLog returns the latest change of every modified key in the order they were made.
+func (c *InMemoryTreeMultiCache[K, T]) ChangeLog() []ChangeEvent[K] {
+	c.vMtx.Lock()
+	defer c.vMtx.Unlock()
+	return c.changes.events()
 }
 
-// Changes returns a slice of keys that have been modified in the cache.
+// Changes returns the keys whose latest change is a Put or Set, in the order they were made.
 // This method provides a way to track changes made to the cache, useful for scenarios like cache syncing.
 func (c *InMemoryTreeMultiCache[K, T]) Changes() []K {
 	c.vMtx.Lock()
 	defer c.vMtx.Unlock()
-	return c.changes
+	return c.changes.keys()
+}
+
+// ChangesCount returns the number of modified keys.
+func (c *InMemoryTreeMultiCache[K, T]) ChangesCount() int {
+	c.vMtx.Lock()
+	defer c.vMtx.Unlock()
+	return c.changes.count()
+}
+
+// ResetChanges atomically returns the modified keys and clears the change history.
+func (c *InMemoryTreeMultiCache[K, T]) ResetChanges() []K {
+	c.vMtx.Lock()
+	defer c.vMtx.Unlock()
+	return c.changes.reset()
 }
 
 // Drop removes all entries from the cache.
@@ -183,72 +397,100 @@ func (c *InMemoryTreeMultiCache[K, T]) Drop() {
 	c.vMtx.Lock()
 	defer c.vMtx.Unlock()
 	c.dropAll()
-	c.lastUpdatedKeys = make(map[string]time.Time)
+	c.changes.recordClear()
+	c.lastUpdatedKeys = make(map[string]keyContainer[K, []uconst.Unique])
 }
 
 // DropKey removes the value(s) associated with the given key from the cache.
 func (c *InMemoryTreeMultiCache[K, T]) DropKey(key K) {
 	c.vMtx.Lock()
 	defer c.vMtx.Unlock()
-	c.dropKeyRecursively(key.Keys(), 0, c.values)
-	delete(c.lastUpdatedKeys, keysAsString(key.Keys()))
-	ind, _ := uarray.ContainsPredicate(c.changes, func(v *K) bool {
-		return (*v).Equals(key)
-	})
-	if ind > -1 {
-		c.changes = uarray.CopyWithoutIndex(c.changes, ind)
+	c.remove(key, ChangeDelete)
+}
+
+func (c *InMemoryTreeMultiCache[K, T]) expireKeys(keys []K) {
+	c.vMtx.Lock()
+	defer c.vMtx.Unlock()
+	for _, key := range keys {
+		c.remove(key, ChangeExpire)
 	}
 }
 
+// expireOutdated removes the outdated entries by the key paths frozen on write.
+func (c *InMemoryTreeMultiCache[K, T]) expireOutdated() []K {
+	c.vMtx.Lock()
+	defer c.vMtx.Unlock()
+
+	return removeOutdated(c.lastUpdatedKeys, c.ttl, func(id string, lu keyContainer[K, []uconst.Unique]) {
+		c.dropKeyRecursively(lu.fingerprint)
+		c.changes.record(id, lu.key, ChangeExpire)
+	})
+}
+
+func (c *InMemoryTreeMultiCache[K, T]) remove(key K, kind ChangeKind) {
+	c.dropKeyRecursively(key.Keys())
+	id := keysAsString(key.Keys())
+	delete(c.lastUpdatedKeys, id)
+	c.changes.record(id, key, kind)
+}
+
 // Outdated checks if a given key or the entire cache is outdated based on the TTL.
-// If no key is provided or key was not found, it checks the last updated time of the entire cache.
-// If a key is provided and found, it checks the last updated time of that specific key.
+// If no TTL is set it returns false.
+// If no key is provided, it checks the last updated time of the entire cache.
+// If a key is provided and found, it checks the last updated time of that specific key, otherwise the key is outdated.
 func (c *InMemoryTreeMultiCache[K, T]) Outdated(key uopt.Opt[K]) bool {
-	if !key.Present() {
-		return time.Since(c.lastUpdated) > *c.ttl
-	}
-
 	c.vMtx.Lock()
 	defer c.vMtx.Unlock()
 
 	if c.ttl == nil {
 		return false
-	} else {
-		if key.Present() {
-			k := key.Get()
-			if lu, ok := c.lastUpdatedKeys[keysAsString((*k).Keys())]; ok {
-				return time.Since(lu) > *c.ttl
-			} else {
-				return true
-			}
-		} else {
-			return false
+	}
+
+	if k := key.Get(); k != nil {
+		var buf [keyBufferSize]byte
+		if lu, ok := c.lastUpdatedKeys[string(appendKeysDecimal(buf[:0], (*k).Keys()))]; ok {
+			return time.Since(lu.updatedAt) > *c.ttl
 		}
+		return true
 	}
+
+	return time.Since(c.lastUpdated) > *c.ttl
+}
+
+// OutdatedKeys returns the keys that are outdated based on the TTL.
+// If no TTL is set returns an empty slice.
+func (c *InMemoryTreeMultiCache[K, T]) OutdatedKeys() []K {
+	c.vMtx.Lock()
+	defer c.vMtx.Unlock()
+
+	return outdatedKeys(c.lastUpdatedKeys, c.ttl)
+}
+
+// Close is a no-op, since the cache doesn't own any resources.
+func (c *InMemoryTreeMultiCache[K, T]) Close() error {
+	return nil
 }
 
 func (c *InMemoryTreeMultiCache[K, T]) dropAll() {
-	c.values = make(map[int64]any)
-	c.changes = nil
+	c.arena = &treeArena[K, T]{}
+	c.root = c.arena.newNode(nil)
 }
 
-func (c *InMemoryTreeMultiCache[K, T]) put(key K, val ...T) {
-	c.addTran(key, val...)
-	changes := len(c.changes) == 0
-	found := false
-	for _, diff := range c.changes {
-		if uarray.EqualsWithOrder(diff.Keys(), key.Keys()) {
-			if !diff.Equals(key) {
-				changes = true
-				break
-			}
-			found = true
-			continue
-		}
-	}
-	if changes || !found {
-		c.changes = append(c.changes, key)
+// touch records the key update time along with the key path frozen at the moment.
+func (c *InMemoryTreeMultiCache[K, T]) touch(key K, keys []uconst.Unique, id string) {
+	n := time.Now()
+	c.lastUpdatedKeys[id] = keyContainer[K, []uconst.Unique]{
+		key:         key,
+		fingerprint: freezeKeys(keys),
+		updatedAt:   n,
 	}
+	c.lastUpdated = n
+}
+
+// put adds the values and records the change, id is the string representation of the key.
+func (c *InMemoryTreeMultiCache[K, T]) put(key K, id string, val ...T) {
+	c.addTran(key, val...)
+	c.changes.record(id, key, ChangeSet)
 }
 
 func (c *InMemoryTreeMultiCache[K, T]) addTran(key K, values ...T) {
@@ -257,124 +499,119 @@ func (c *InMemoryTreeMultiCache[K, T]) addTran(key K, values ...T) {
 		return
 	}
 
-	bucket := c.tryToGetBucket(keys)
-	lowKey := key.Keys()[len(keys)-1].Key()
+	node := c.root
+	node.invalidate()
+	for len(keys) > 0 {
+		child := node.child(keys[0].Key())
+		if child == nil {
+			child = c.arena.newNode(keys)
+			node.addChild(child)
+			node = child
+			break
+		}
+
+		l := child.matchPrefix(keys)
+		if l < len(child.path) {
+			child = node.split(child, l, c.arena)
+		}
+		child.invalidate()
+		node = child
+		keys = keys[l:]
+	}
 
+	if node.pairs == nil {
+		node.pairs = c.arena.pairs.take(len(values))[:0]
+	}
 	for _, value := range values {
-		if ind, _ := uarray.ContainsPredicate(bucket[lowKey], func(v *uarray.Pair[K, T]) bool {
+		if ind, _ := uarray.ContainsPredicate(node.pairs, func(v *uarray.Pair[K, T]) bool {
 			return v.Right.Equals(value)
 		}); ind > -1 {
-			bucket[lowKey][ind] = *uarray.NewPair[K, T](key, value)
+			node.pairs[ind] = *uarray.NewPair[K, T](key, value)
 		} else {
-			bucket[lowKey] = append(bucket[lowKey], *uarray.NewPair[K, T](key, value))
+			node.pairs = append(node.pairs, *uarray.NewPair[K, T](key, value))
 		}
 	}
 }
 
-func (c *InMemoryTreeMultiCache[K, T]) dropKeyRecursively(keys []uconst.Unique, n int, bucket map[int64]any) {
-	key := keys[n].Key()
-	interBucket := bucket[key]
-	if interBucket != nil {
-		switch b := interBucket.(type) {
-		case container[K, T]:
-			if n+1 == len(keys) {
-				delete(bucket, key)
-			} else {
-				c.dropKeyRecursively(keys, n+1, b.node)
-			}
-		default:
-			delete(bucket, key)
-		}
+// dropKeyRecursively removes the node addressed by keys together with its subtree.
+// If a broader key without any descendants is met on the way, it is removed as well,
+// because more specific keys take precedence over their parents.
+// Keys that end in the middle of a compressed edge address the node below the edge.
+func (c *InMemoryTreeMultiCache[K, T]) dropKeyRecursively(keys []uconst.Unique) {
+	if len(keys) == 0 {
+		return
 	}
-}
-
-func (c *InMemoryTreeMultiCache[K, T]) tryToGetBucket(keys []uconst.Unique) map[int64][]uarray.Pair[K, T] {
-	return c.getBucket(keys, 0, c.values)
-}
 
-func (c *InMemoryTreeMultiCache[K, T]) getBucket(keys []uconst.Unique, n int, interBucket map[int64]any) map[int64][]uarray.Pair[K, T] {
-	if keys == nil || n >= len(keys) {
-		return nil
-	}
+	parent := c.root
+	path := []*treeNode[K, T]{parent}
+	for {
+		node := parent.child(keys[0].Key())
+		if node == nil {
+			return
+		}
 
-	hash := keys[n].Key()
-	if bucket, ok := interBucket[hash]; ok {
-		switch b := bucket.(type) {
-		case map[int64][]uarray.Pair[K, T]:
-			if n+1 < len(keys) {
-				interBucket[hash] = container[K, T]{
-					node:  make(map[int64]any),
-					pairs: b,
-				}
-				return c.getBucket(keys, n+1, interBucket[hash].(container[K, T]).node)
-			} else {
-				return b
+		for i, hash := range node.path {
+			if keys[i].Key() != hash {
+				return
 			}
-		case container[K, T]:
-			if n+1 == len(keys) {
-				result := make(map[int64][]uarray.Pair[K, T])
-				for k, e := range b.pairs {
-					result[k] = append(result[k], e...)
-				}
-				if b.node != nil {
-					result = c.getNodePairsFlat(b.node, result)
+			if i+1 == len(keys) || (i+1 == len(node.path) && len(node.children) == 0) {
+				delete(parent.children, node.path[0])
+				c.arena.release(node)
+				for _, p := range path {
+					p.invalidate()
 				}
+				c.prune(path)
 
-				return result
-			}
-
-			return c.getBucket(keys, n+1, b.node)
-		}
-	} else {
-		if n+1 == len(keys) {
-			interBucket[hash] = map[int64][]uarray.Pair[K, T]{
-				hash: nil,
-			}
-			return interBucket[hash].(map[int64][]uarray.Pair[K, T])
-		} else {
-			if entry, ok := interBucket[hash]; !ok {
-				interBucket[hash] = container[K, T]{
-					node:  make(map[int64]any),
-					pairs: make(map[int64][]uarray.Pair[K, T]),
-				}
-				return c.getBucket(keys, n+1, interBucket[hash].(container[K, T]).node)
-			} else {
-				switch e := entry.(type) {
-				case map[int64][]uarray.Pair[K, T]:
-					interBucket[hash] = container[K, T]{
-						node:  make(map[int64]any),
-						pairs: e,
-					}
-					return c.getBucket(keys, n+1, interBucket[hash].(container[K, T]).node)
-				case container[K, T]:
-					interBucket[hash] = container[K, T]{
-						pairs: e.pairs,
-					}
-					return c.getBucket(keys, n+1, e.node)
-				}
+				return
 			}
 		}
+
+		keys = keys[len(node.path):]
+		path = append(path, node)
+		parent = node
 	}
+}
 
-	return nil
+// prune removes intermediate nodes that hold neither values nor children, starting from the deepest one,
+// and compresses the first remaining node if it's left with a single descendant.
+func (c *InMemoryTreeMultiCache[K, T]) prune(path []*treeNode[K, T]) {
+	i := len(path) - 1
+	for ; i > 0; i-- {
+		node := path[i]
+		if len(node.pairs) > 0 || len(node.children) > 0 {
+			break
+		}
+		delete(path[i-1].children, node.path[0])
+		c.arena.recycle(node)
+	}
+	if i > 0 {
+		path[i].compress(c.arena)
+	}
 }
 
-func (c *InMemoryTreeMultiCache[K, T]) getNodePairsFlat(node map[int64]any, result map[int64][]uarray.Pair[K, T]) map[int64][]uarray.Pair[K, T] {
-	for _, entry := range node {
-		switch e := entry.(type) {
-		case map[int64][]uarray.Pair[K, T]:
-			for hash, pair := range e {
-				result[hash] = append(result[hash], pair...)
-			}
-		case container[K, T]:
-			for hash, pair := range e.pairs {
-				result[hash] = append(result[hash], pair...)
-			}
-			result = c.getNodePairsFlat(e.node, result)
+func (c *InMemoryTreeMultiCache[K, T]) findNode(keys []uconst.Unique) *treeNode[K, T] {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	node := c.root
+	for len(keys) > 0 {
+		node = node.child(keys[0].Key())
+		if node == nil {
+			return nil
+		}
+
+		l := node.matchPrefix(keys)
+		if l == len(keys) {
+			return node
 		}
+		if l < len(node.path) {
+			return nil
+		}
+		keys = keys[l:]
 	}
 
-	return result
+	return node
 }
 
 // InMemoryHashMapMultiCache provides an in-memory caching mechanism using hashmaps.
@@ -397,63 +634,105 @@ func (c *InMemoryTreeMultiCache[K, T]) getNodePairsFlat(node map[int64]any, resu
 // Use ManagedMultiCache wrapper to automatically manage outdated keys.
 type InMemoryHashMapMultiCache[K CompositeKey, T any, H comparable] struct {
 	values  map[H][]T
-	changes map[H]K
+	changes *changeLog[H, K]
 
-	lastUpdatedKeys map[string]keyContainer[K]
+	lastUpdatedKeys map[string]keyContainer[K, H] // the fingerprint is the values hash computed on write
 	lastUpdated     time.Time
 	ttl             *time.Duration
 
 	toHash func(keys []uconst.Unique) H
 	vMtx   sync.Mutex
+
+	limits    multiCacheOptions
+	order     *expiryQueue[H] // write order of the entries, tracked only when MaxEntries is set
+	entryKeys map[H]string    // the lastUpdatedKeys ids of the entries, tracked only when MaxEntries is set
+}
+
+// LimitStrategy defines what happens to the writes that exceed the MultiCache limits.
+type LimitStrategy int
+
+const (
+	// LimitReject discards the new values that don't fit into the limits, the stored values are kept.
+	LimitReject LimitStrategy = iota
+	// LimitDropOldest removes the oldest values (or the least recently written entry) to make room for the new ones.
+	LimitDropOldest
+)
+
+type multiCacheOptions struct {
+	maxValuesPerKey int
+	maxEntries      int
+	strategy        LimitStrategy
+}
+
+// MultiCacheOption configures InMemoryHashMapMultiCache.
+type MultiCacheOption func(o *multiCacheOptions)
+
+// WithMaxValuesPerKey limits the number of values stored per key. There is no limit by default.
+func WithMaxValuesPerKey(limit int) MultiCacheOption {
+	return func(o *multiCacheOptions) {
+		o.maxValuesPerKey = limit
+	}
+}
+
+// WithMaxEntries limits the number of keys stored in the cache. There is no limit by default.
+func WithMaxEntries(limit int) MultiCacheOption {
+	return func(o *multiCacheOptions) {
+		o.maxEntries = limit
+	}
+}
+
+// WithLimitStrategy sets the strategy applied when a limit is exceeded, LimitReject by default.
+func WithLimitStrategy(strategy LimitStrategy) MultiCacheOption {
+	return func(o *multiCacheOptions) {
+		o.strategy = strategy
+	}
 }
 
 // NewInMemoryHashMapMultiCache creates a new instance of the InMemoryHashMapMultiCache.
 // It takes a hashing function to translate the composite keys to a desired hash type,
 // and an optional time-to-live duration for the cache entries.
-func NewInMemoryHashMapMultiCache[K CompositeKey, T any, H comparable](toHash func(keys []uconst.Unique) H, ttl uopt.Opt[time.Duration]) MultiCache[K, T] {
+// Values are appended unboundedly by default, use WithMaxValuesPerKey and WithMaxEntries options to limit the cache size.
+func NewInMemoryHashMapMultiCache[K CompositeKey, T any, H comparable](toHash func(keys []uconst.Unique) H, ttl uopt.Opt[time.Duration], opts ...MultiCacheOption) MultiCache[K, T] {
 	c := &InMemoryHashMapMultiCache[K, T, H]{
 		values:          make(map[H][]T),
-		changes:         make(map[H]K, 0),
-		lastUpdatedKeys: make(map[string]keyContainer[K]),
+		changes:         newChangeLog[H, K](),
+		lastUpdatedKeys: make(map[string]keyContainer[K, H]),
 		toHash:          toHash,
 	}
 	ttl.IfPresent(func(t time.Duration) {
 		c.ttl = &t
 	})
+	for _, opt := range opts {
+		opt(&c.limits)
+	}
+	if c.limits.maxEntries > 0 {
+		c.order = newExpiryQueue[H]()
+		c.entryKeys = make(map[H]string)
+	}
 
 	return c
 }
 
 // NewDefaultHashMapMultiCache creates a new instance of the InMemoryHashMapMultiCache using SHA256 as the hashing algorithm.
-func NewDefaultHashMapMultiCache[K CompositeKey, T uconst.Comparable](ttl uopt.Opt[time.Duration]) MultiCache[K, T] {
-	return NewFarmHashMapMultiCache[K, T](ttl)
+func NewDefaultHashMapMultiCache[K CompositeKey, T uconst.Comparable](ttl uopt.Opt[time.Duration], opts ...MultiCacheOption) MultiCache[K, T] {
+	return NewFarmHashMapMultiCache[K, T](ttl, opts...)
 }
 
-func NewFarmHashMapMultiCache[K CompositeKey, T uconst.Comparable](ttl uopt.Opt[time.Duration]) MultiCache[K, T] {
+func NewFarmHashMapMultiCache[K CompositeKey, T uconst.Comparable](ttl uopt.Opt[time.Duration], opts ...MultiCacheOption) MultiCache[K, T] {
 	return NewInMemoryHashMapMultiCache[K, T, uint64](func(keys []uconst.Unique) uint64 {
-		buffer := new(bytes.Buffer)
-		arr := make([]byte, 0)
-		for _, hash := range keys {
-			arr = append(arr, intToBytes(buffer, hash.Key())...)
-		}
+		var buf [keyBufferSize]byte
 
-		return farm.Hash64(arr)
-	}, ttl)
+		return farm.Hash64(appendKeyBytes(buf[:0], keys))
+	}, ttl, opts...)
 }
 
-func NewSha256HashMapMultiCache[K CompositeKey, T uconst.Comparable](ttl uopt.Opt[time.Duration]) MultiCache[K, T] {
+func NewSha256HashMapMultiCache[K CompositeKey, T uconst.Comparable](ttl uopt.Opt[time.Duration], opts ...MultiCacheOption) MultiCache[K, T] {
 	return NewInMemoryHashMapMultiCache[K, T, string](func(keys []uconst.Unique) string {
-		buffer := new(bytes.Buffer)
-		arr := make([]byte, 0)
-		for _, hash := range keys {
-			arr = append(arr, intToBytes(buffer, hash.Key())...)
-		}
-
-		h := sha256.New()
-		h.Write(arr)
+		var buf [keyBufferSize]byte
+		sum := sha256.Sum256(appendKeyBytes(buf[:0], keys))
 
-		return string(h.Sum(nil))
-	}, ttl)
+		return string(sum[:])
+	}, ttl, opts...)
 }
 
 // Put adds the given values to the cache associated with the provided key.
@@ -464,13 +743,11 @@ func (c *InMemoryHashMapMultiCache[K, T, H]) Put(key K, values ...T) {
 	}
 	c.vMtx.Lock()
 	defer c.vMtx.Unlock()
-	c.put(key, values...)
-	n := time.Now()
-	c.lastUpdatedKeys[keysAsString(key.Keys())] = keyContainer[K]{
-		key:       key,
-		updatedAt: n,
+	keys := key.Keys()
+	id := keysAsString(keys)
+	if hash, ok := c.put(key, keys, id, values...); ok {
+		c.touch(key, id, hash)
 	}
-	c.lastUpdated = n
 }
 
 // Set updates the cache values for the provided key. If the key already exists,
@@ -478,14 +755,12 @@ func (c *InMemoryHashMapMultiCache[K, T, H]) Put(key K, values ...T) {
 func (c *InMemoryHashMapMultiCache[K, T, H]) Set(key K, values ...T) {
 	c.vMtx.Lock()
 	defer c.vMtx.Unlock()
-	c.dropKey(key.Keys())
-	c.put(key, values...)
-	n := time.Now()
-	c.lastUpdatedKeys[keysAsString(key.Keys())] = keyContainer[K]{
-		key:       key,
-		updatedAt: n,
+	keys := key.Keys()
+	c.dropEntry(c.toHash(keys))
+	id := keysAsString(keys)
+	if hash, ok := c.put(key, keys, id, values...); ok {
+		c.touch(key, id, hash)
 	}
-	c.lastUpdated = n
 }
 
 // PutQuietly adds values to the cache for the provided key but does so without
@@ -493,13 +768,11 @@ func (c *InMemoryHashMapMultiCache[K, T, H]) Set(key K, values ...T) {
 func (c *InMemoryHashMapMultiCache[K, T, H]) PutQuietly(key K, values ...T) {
 	c.vMtx.Lock()
 	defer c.vMtx.Unlock()
-	c.addTran(key, values...)
-	n := time.Now()
-	c.lastUpdatedKeys[keysAsString(key.Keys())] = keyContainer[K]{
-		key:       key,
-		updatedAt: n,
+	keys := key.Keys()
+	id := keysAsString(keys)
+	if hash, ok := c.addTran(keys, id, values...); ok {
+		c.touch(key, id, hash)
 	}
-	c.lastUpdated = n
 }
 
 // Get retrieves the values associated with the provided key from the cache.
@@ -511,9 +784,32 @@ func (c *InMemoryHashMapMultiCache[K, T, H]) Get(key K) []T {
 	return c.values[c.toHash(key.Keys())]
 }
 
+// ChangeLog returns the latest change of every modified key in the order they were made. The operation is thread-safe.
+func (c *InMemoryHashMapMultiCache[K, T, H]) ChangeLog() []ChangeEvent[K] {
+	c.vMtx.Lock()
+	defer c.vMtx.Unlock()
+	return c.changes.events()
+}
+
 // Changes returns a list of keys that have experienced changes in the cache since the last reset.
 func (c *InMemoryHashMapMultiCache[K, T, H]) Changes() []K {
-	return umap.Values(c.changes)
+	c.vMtx.Lock()
+	defer c.vMtx.Unlock()
+	return c.changes.keys()
+}
+
+// ChangesCount returns the number of modified keys. The operation is thread-safe.
+func (c *InMemoryHashMapMultiCache[K, T, H]) ChangesCount() int {
+	c.vMtx.Lock()
+	defer c.vMtx.Unlock()
+	return c.changes.count()
+}
+
+// ResetChanges atomically returns the modified keys and clears the change history. The operation is thread-safe.
+func (c *InMemoryHashMapMultiCache[K, T, H]) ResetChanges() []K {
+	c.vMtx.Lock()
+	defer c.vMtx.Unlock()
+	return c.changes.reset()
 }
 
 // Drop completely clears the cache, removing all entries. The operation is thread-safe.
@@ -521,16 +817,42 @@ func (c *InMemoryHashMapMultiCache[K, T, H]) Drop() {
 	c.vMtx.Lock()
 	defer c.vMtx.Unlock()
 	c.dropAll()
-	c.lastUpdatedKeys = make(map[string]keyContainer[K])
+	c.changes.recordClear()
+	c.lastUpdatedKeys = make(map[string]keyContainer[K, H])
 }
 
 // DropKey removes the values associated with the provided key from the cache. The operation is thread-safe.
 func (c *InMemoryHashMapMultiCache[K, T, H]) DropKey(key K) {
 	c.vMtx.Lock()
 	defer c.vMtx.Unlock()
-	hash := c.dropKey(key.Keys())
-	delete(c.lastUpdatedKeys, keysAsString(key.Keys()))
-	delete(c.changes, hash)
+	c.remove(key, ChangeDelete)
+}
+
+func (c *InMemoryHashMapMultiCache[K, T, H]) expireKeys(keys []K) {
+	c.vMtx.Lock()
+	defer c.vMtx.Unlock()
+	for _, key := range keys {
+		c.remove(key, ChangeExpire)
+	}
+}
+
+// expireOutdated removes the outdated entries by the hashes computed on write.
+func (c *InMemoryHashMapMultiCache[K, T, H]) expireOutdated() []K {
+	c.vMtx.Lock()
+	defer c.vMtx.Unlock()
+
+	return removeOutdated(c.lastUpdatedKeys, c.ttl, func(_ string, lu keyContainer[K, H]) {
+		c.dropEntry(lu.fingerprint)
+		c.changes.record(lu.fingerprint, lu.key, ChangeExpire)
+	})
+}
+
+func (c *InMemoryHashMapMultiCache[K, T, H]) remove(key K, kind ChangeKind) {
+	keys := key.Keys()
+	hash := c.toHash(keys)
+	c.dropEntry(hash)
+	delete(c.lastUpdatedKeys, keysAsString(keys))
+	c.changes.record(hash, key, kind)
 }
 
 // Outdated checks if the provided key or the entire cache (if no key is provided)
@@ -541,68 +863,146 @@ func (c *InMemoryHashMapMultiCache[K, T, H]) Outdated(key uopt.Opt[K]) bool {
 
 	if c.ttl == nil {
 		return false
-	} else {
-		if key.Present() {
-			k := key.Get()
-			if lu, ok := c.lastUpdatedKeys[keysAsString((*k).Keys())]; ok {
-				return time.Since(lu.updatedAt) > *c.ttl
-			} else {
-				return true
-			}
-		} else {
-			return time.Since(c.lastUpdated) > *c.ttl
+	}
+
+	if k := key.Get(); k != nil {
+		var buf [keyBufferSize]byte
+		if lu, ok := c.lastUpdatedKeys[string(appendKeysDecimal(buf[:0], (*k).Keys()))]; ok {
+			return time.Since(lu.updatedAt) > *c.ttl
 		}
+		return true
 	}
+
+	return time.Since(c.lastUpdated) > *c.ttl
+}
+
+// OutdatedKeys returns the keys that are outdated based on the set TTL.
+// If no TTL is set returns an empty slice. The operation is thread-safe.
+func (c *InMemoryHashMapMultiCache[K, T, H]) OutdatedKeys() []K {
+	c.vMtx.Lock()
+	defer c.vMtx.Unlock()
+
+	return outdatedKeys(c.lastUpdatedKeys, c.ttl)
+}
+
+// Close is a no-op, since the cache doesn't own any resources.
+func (c *InMemoryHashMapMultiCache[K, T, H]) Close() error {
+	return nil
 }
 
 func (c *InMemoryHashMapMultiCache[K, T, H]) dropAll() {
 	c.values = make(map[H][]T)
-	c.changes = nil
-}
-
-func (c *InMemoryHashMapMultiCache[K, T, H]) put(key K, values ...T) {
-	hash := c.addTran(key, values...)
-	changes := len(c.changes) == 0
-	found := false
-	for _, diff := range c.changes {
-		if uarray.EqualsWithOrder(diff.Keys(), key.Keys()) {
-			if !diff.Equals(key) {
-				changes = true
-				break
+	if c.order != nil {
+		c.order.clear()
+		c.entryKeys = make(map[H]string)
+	}
+}
+
+// put adds the values and records the change. Keys and their id are passed along with the key,
+// so they are computed only once per operation.
+func (c *InMemoryHashMapMultiCache[K, T, H]) put(key K, keys []uconst.Unique, id string, values ...T) (H, bool) {
+	hash, ok := c.addTran(keys, id, values...)
+	if !ok {
+		return hash, false
+	}
+	// Keys with equal hashes are the same keys, so the latest one simply replaces the previous change.
+	c.changes.record(hash, key, ChangeSet)
+
+	return hash, true
+}
+
+// touch records the key update time along with the values hash computed on write.
+func (c *InMemoryHashMapMultiCache[K, T, H]) touch(key K, id string, hash H) {
+	n := time.Now()
+	c.lastUpdatedKeys[id] = keyContainer[K, H]{
+		key:         key,
+		fingerprint: hash,
+		updatedAt:   n,
+	}
+	c.lastUpdated = n
+}
+
+// addTran appends the values according to the limits. Returns false if the write was rejected completely.
+func (c *InMemoryHashMapMultiCache[K, T, H]) addTran(keys []uconst.Unique, id string, values ...T) (H, bool) {
+	hash := c.toHash(keys)
+	existing, exists := c.values[hash]
+	if !exists && c.limits.maxEntries > 0 && len(c.values) >= c.limits.maxEntries {
+		if c.limits.strategy == LimitReject {
+			return hash, false
+		}
+		c.evictOldest()
+	}
+
+	stored := append(existing, values...)
+	if limit := c.limits.maxValuesPerKey; limit > 0 && len(stored) > limit {
+		if c.limits.strategy == LimitReject {
+			if len(existing) >= limit {
+				return hash, false
 			}
-			found = true
-			continue
+			stored = stored[:limit]
+		} else {
+			stored = slices.Clone(stored[len(stored)-limit:]) // don't keep the dropped values reachable
 		}
 	}
-	if changes || !found {
-		c.changes[hash] = key
+	c.values[hash] = stored
+	if c.order != nil {
+		c.order.touch(hash, time.Now())
+		c.entryKeys[hash] = id
 	}
-}
 
-func (c *InMemoryHashMapMultiCache[K, T, H]) addTran(key K, values ...T) H {
-	hash := c.toHash(key.Keys())
-	c.values[hash] = append(c.values[hash], values...)
+	return hash, true
+}
 
-	return hash
+// evictOldest removes the least recently written entry.
+func (c *InMemoryHashMapMultiCache[K, T, H]) evictOldest() {
+	if len(c.order.heap) == 0 {
+		return
+	}
+	hash := c.order.heap[0].key
+	if id, ok := c.entryKeys[hash]; ok {
+		if lu, ok := c.lastUpdatedKeys[id]; ok {
+			delete(c.lastUpdatedKeys, id)
+			c.changes.record(hash, lu.key, ChangeDelete)
+		}
+	}
+	c.dropEntry(hash)
 }
 
-func (c *InMemoryHashMapMultiCache[K, T, H]) dropKey(keys []uconst.Unique) H {
-	hash := c.toHash(keys)
-	delete(c.values, c.toHash(keys))
-	return hash
+func (c *InMemoryHashMapMultiCache[K, T, H]) dropEntry(hash H) {
+	delete(c.values, hash)
+	if c.order != nil {
+		c.order.remove(hash)
+		delete(c.entryKeys, hash)
+	}
 }
 
-func intToBytes(buffer *bytes.Buffer, num int64) []byte {
-	buffer.Reset()
-	_ = binary.Write(buffer, binary.LittleEndian, num)
+// keyBufferSize is the size of the stack buffers used to encode composite keys,
+// it fits keys up to 8 levels deep, deeper keys spill to the heap.
+const keyBufferSize = 64
 
-	return buffer.Bytes()
+// appendKeyBytes appends the little-endian encoding of the keys to dst.
+func appendKeyBytes(dst []byte, keys []uconst.Unique) []byte {
+	for _, key := range keys {
+		dst = binary.LittleEndian.AppendUint64(dst, uint64(key.Key()))
+	}
+
+	return dst
 }
 
+// keysAsString returns the canonical string of the keys identifying the entries in the update time maps.
 func keysAsString(keys []uconst.Unique) string {
-	var sb strings.Builder
-	for _, key := range keys {
-		sb.WriteString(strconv.FormatInt(key.Key(), 10))
+	var buf [keyBufferSize]byte
+	return string(appendKeysDecimal(buf[:0], keys))
+}
+
+// appendKeysDecimal appends the decimal hashes of the keys separated by colons, so [1 23] and [12 3] don't collide.
+func appendKeysDecimal(dst []byte, keys []uconst.Unique) []byte {
+	for i, key := range keys {
+		if i > 0 {
+			dst = append(dst, ':')
+		}
+		dst = strconv.AppendInt(dst, key.Key(), 10)
 	}
-	return sb.String()
+
+	return dst
 }
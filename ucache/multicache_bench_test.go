@@ -469,6 +469,37 @@ func BenchmarkTreeMultiCacheGetIntKeySingle10xItems(b *testing.B) {
 	}
 }
 
+func BenchmarkTreeMultiCacheGetBroadPrefix(b *testing.B) {
+	c := NewInMemoryTreeMultiCache[IntCompositeKey, uconst.Comparable](uopt.Null[time.Duration]())
+	for i := int64(0); i < numItems; i++ {
+		c.Put(NewIntCompositeKey(1, i%100, i), NewInt64Value(i))
+	}
+	broad := NewIntCompositeKey(1)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		c.Get(broad)
+	}
+}
+
+func BenchmarkTreeMultiCacheGetBroadPrefixWithWrites(b *testing.B) {
+	c := NewInMemoryTreeMultiCache[IntCompositeKey, uconst.Comparable](uopt.Null[time.Duration]())
+	for i := int64(0); i < numItems; i++ {
+		c.Put(NewIntCompositeKey(1, i%100, i), NewInt64Value(i))
+	}
+	broad := NewIntCompositeKey(1)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := int64(0); i < int64(b.N); i++ {
+		if i%10 == 0 {
+			c.Put(NewIntCompositeKey(1, i%100, i%numItems), NewInt64Value(i))
+		}
+		c.Get(broad)
+	}
+}
+
 func BenchmarkMemoryFarmHashMapMultiCache(b *testing.B) {
 	c := NewFarmHashMapMultiCache[IntCompositeKey, uconst.Comparable](uopt.Null[time.Duration]())
 	benchmarkMemoryUsage(b, c)
@@ -500,3 +531,106 @@ func benchmarkMemoryUsage(b *testing.B, c MultiCache[IntCompositeKey, uconst.Com
 func bToKb(b uint64) uint64 {
 	return b / 1024
 }
+
+func BenchmarkHashMapMultiCacheAllocs(b *testing.B) {
+	caches := map[string]func() MultiCache[IntCompositeKey, uconst.Comparable]{
+		"farm": func() MultiCache[IntCompositeKey, uconst.Comparable] {
+			return NewFarmHashMapMultiCache[IntCompositeKey, uconst.Comparable](uopt.Null[time.Duration]())
+		},
+		"sha256": func() MultiCache[IntCompositeKey, uconst.Comparable] {
+			return NewSha256HashMapMultiCache[IntCompositeKey, uconst.Comparable](uopt.Null[time.Duration]())
+		},
+	}
+
+	for name, ctor := range caches {
+		c := ctor()
+		keys := prepareCacheIntKeyWithDepth(c, numItems, stdDepth)
+		value := NewInt64Value(1)
+
+		b.Run(name+"/Get", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				c.Get(keys[i%len(keys)])
+			}
+		})
+		b.Run(name+"/Set", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				c.Set(keys[i%len(keys)], value)
+			}
+		})
+	}
+}
+
+func BenchmarkKeyHashing(b *testing.B) {
+	keys := NewIntCompositeKey(1, 2, 3).Keys()
+
+	b.Run("appendKeyBytes", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var buf [keyBufferSize]byte
+			_ = appendKeyBytes(buf[:0], keys)
+		}
+	})
+}
+
+// deepKeys generates num keys of the given depth that share no prefix, which is the worst case for a non-compressed tree.
+func deepKeys(num, depth int64) []IntCompositeKey {
+	keys := make([]IntCompositeKey, num)
+	for i := int64(0); i < num; i++ {
+		hashes := make([]int64, depth)
+		for h := int64(0); h < depth; h++ {
+			hashes[h] = i*depth + h
+		}
+		keys[i] = NewIntCompositeKey(hashes...)
+	}
+
+	return keys
+}
+
+func BenchmarkTreeMultiCachePutDeepKeys(b *testing.B) {
+	keys := deepKeys(numItems, 32)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		c := NewInMemoryTreeMultiCache[IntCompositeKey, uconst.Comparable](uopt.Null[time.Duration]())
+		for j, key := range keys {
+			c.PutQuietly(key, NewInt64Value(int64(j)))
+		}
+	}
+}
+
+func BenchmarkTreeMultiCacheGetDeepKeys(b *testing.B) {
+	keys := deepKeys(numItems, 32)
+	c := NewInMemoryTreeMultiCache[IntCompositeKey, uconst.Comparable](uopt.Null[time.Duration]())
+	for j, key := range keys {
+		c.PutQuietly(key, NewInt64Value(int64(j)))
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		c.Get(keys[i%len(keys)])
+	}
+}
+
+func BenchmarkTreeMultiCacheMemoryDeepKeys(b *testing.B) {
+	keys := deepKeys(numItems, 32)
+	var before, after runtime.MemStats
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		runtime.GC()
+		runtime.ReadMemStats(&before)
+		c := NewInMemoryTreeMultiCache[IntCompositeKey, uconst.Comparable](uopt.Null[time.Duration]())
+		for j, key := range keys {
+			c.PutQuietly(key, NewInt64Value(int64(j)))
+		}
+		runtime.GC()
+		runtime.ReadMemStats(&after)
+		b.ReportMetric(float64(after.HeapAlloc-before.HeapAlloc)/1024, "heap-KB")
+		runtime.KeepAlive(c)
+	}
+}
@@ -2,9 +2,11 @@ package ucache_test
 
 import (
 	"math/rand"
+	"runtime"
 	"sort"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -12,6 +14,7 @@ import (
 	"github.com/kordax/basic-utils/uconst"
 	"github.com/kordax/basic-utils/uopt"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 type DummyComparable struct {
@@ -569,3 +572,403 @@ func TestHashMapMultiCacheHighCollisionProbability(t *testing.T) {
 		assert.Contains(t, values, ucache.NewInt64Value(int64(i))) // Check if the expected value is present in the retrieved values
 	}
 }
+
+func TestTreeMultiCache_PutBroaderKeyWithDescendants(t *testing.T) {
+	c := ucache.NewInMemoryTreeMultiCache[ucache.IntCompositeKey, DummyComparable](uopt.Null[time.Duration]())
+	c.Put(ucache.NewIntCompositeKey(1, 2, 3), DummyComparable{Val: 3})
+	c.Put(ucache.NewIntCompositeKey(1, 2), DummyComparable{Val: 2})
+
+	assert.ElementsMatch(t, []DummyComparable{{Val: 2}, {Val: 3}}, c.Get(ucache.NewIntCompositeKey(1, 2)))
+	assert.ElementsMatch(t, []DummyComparable{{Val: 2}, {Val: 3}}, c.Get(ucache.NewIntCompositeKey(1)))
+	assert.Equal(t, []DummyComparable{{Val: 3}}, c.Get(ucache.NewIntCompositeKey(1, 2, 3)))
+}
+
+func TestTreeMultiCache_CachedViewInvalidation(t *testing.T) {
+	c := ucache.NewInMemoryTreeMultiCache[ucache.IntCompositeKey, DummyComparable](uopt.Null[time.Duration]())
+	broad := ucache.NewIntCompositeKey(1)
+	for i := 0; i < 10; i++ {
+		c.Put(ucache.NewIntCompositeKey(1, int64(i)), DummyComparable{Val: i})
+	}
+	assert.Len(t, c.Get(broad), 10)
+
+	result := c.Get(broad)
+	result[0] = DummyComparable{Val: -1}
+	assert.NotContains(t, c.Get(broad), DummyComparable{Val: -1}, "Get must not expose the cached view")
+
+	c.Put(ucache.NewIntCompositeKey(1, 100), DummyComparable{Val: 100})
+	assert.Len(t, c.Get(broad), 11)
+
+	c.DropKey(ucache.NewIntCompositeKey(1, 0))
+	assert.Len(t, c.Get(broad), 10)
+	assert.NotContains(t, c.Get(broad), DummyComparable{Val: 0})
+
+	c.Set(ucache.NewIntCompositeKey(1, 1), DummyComparable{Val: 111})
+	assert.Len(t, c.Get(broad), 10)
+	assert.Contains(t, c.Get(broad), DummyComparable{Val: 111})
+
+	c.Drop()
+	assert.Empty(t, c.Get(broad))
+}
+
+func TestTreeMultiCache_GetMissingKey(t *testing.T) {
+	c := ucache.NewInMemoryTreeMultiCache[ucache.IntCompositeKey, DummyComparable](uopt.Null[time.Duration]())
+	c.Put(ucache.NewIntCompositeKey(1, 2), DummyComparable{Val: 1})
+
+	result := c.Get(ucache.NewIntCompositeKey(1, 2, 3))
+	assert.NotNil(t, result)
+	assert.Empty(t, result)
+	assert.Empty(t, c.Get(ucache.NewIntCompositeKey(5)))
+
+	// reading a missing, more specific key must not turn the existing key into a broader one
+	c.Set(ucache.NewIntCompositeKey(1, 2, 3, 4), DummyComparable{Val: 2})
+	assert.Equal(t, []DummyComparable{{Val: 2}}, c.Get(ucache.NewIntCompositeKey(1, 2)))
+}
+
+func TestTreeMultiCache_CompressedPaths(t *testing.T) {
+	c := ucache.NewInMemoryTreeMultiCache[ucache.IntCompositeKey, DummyComparable](uopt.Null[time.Duration]())
+	key := func(hashes ...int64) ucache.IntCompositeKey {
+		return ucache.NewIntCompositeKey(hashes...)
+	}
+
+	c.Put(key(1, 2, 3, 4, 5, 6), DummyComparable{Val: 1})
+	assert.Equal(t, []DummyComparable{{Val: 1}}, c.Get(key(1, 2, 3)), "prefix inside a compressed edge")
+	assert.Empty(t, c.Get(key(1, 2, 4)))
+	assert.Empty(t, c.Get(key(1, 2, 3, 4, 5, 6, 7)))
+
+	// splits the edge in the middle
+	c.Put(key(1, 2, 3, 9), DummyComparable{Val: 2})
+	assert.ElementsMatch(t, []DummyComparable{{Val: 1}, {Val: 2}}, c.Get(key(1, 2)))
+	assert.Equal(t, []DummyComparable{{Val: 1}}, c.Get(key(1, 2, 3, 4)))
+	assert.Equal(t, []DummyComparable{{Val: 2}}, c.Get(key(1, 2, 3, 9)))
+
+	// puts a value right at the split point
+	c.Put(key(1, 2, 3), DummyComparable{Val: 3})
+	assert.ElementsMatch(t, []DummyComparable{{Val: 1}, {Val: 2}, {Val: 3}}, c.Get(key(1)))
+
+	// dropping a key inside a compressed edge drops the whole edge
+	c.DropKey(key(1, 2, 3, 4, 5))
+	assert.Empty(t, c.Get(key(1, 2, 3, 4, 5, 6)))
+	assert.ElementsMatch(t, []DummyComparable{{Val: 2}, {Val: 3}}, c.Get(key(1)))
+
+	c.Set(key(1, 2, 3, 9, 10), DummyComparable{Val: 4})
+	assert.ElementsMatch(t, []DummyComparable{{Val: 3}, {Val: 4}}, c.Get(key(1, 2, 3)))
+	c.DropKey(key(1, 2, 3))
+	assert.Empty(t, c.Get(key(1)))
+
+	// the remaining branch is compressed back after its sibling is dropped
+	c.Put(key(5, 6, 7, 8), DummyComparable{Val: 5})
+	c.Put(key(5, 6, 9), DummyComparable{Val: 6})
+	c.DropKey(key(5, 6, 9))
+	assert.Equal(t, []DummyComparable{{Val: 5}}, c.Get(key(5, 6, 7)))
+	assert.Empty(t, c.Get(key(5, 6, 9)))
+	c.Put(key(5, 6, 9), DummyComparable{Val: 7})
+	assert.ElementsMatch(t, []DummyComparable{{Val: 5}, {Val: 7}}, c.Get(key(5)))
+}
+
+type payload struct {
+	data *[1 << 10]byte
+}
+
+func (p payload) Equals(other uconst.Comparable) bool {
+	o, ok := other.(payload)
+	return ok && o.data == p.data
+}
+
+func TestTreeMultiCache_DroppedValuesAreCollected(t *testing.T) {
+	c := ucache.NewInMemoryTreeMultiCache[ucache.IntCompositeKey, payload](uopt.Null[time.Duration]())
+	var collected atomic.Int32
+	put := func(hashes ...int64) {
+		p := payload{data: new([1 << 10]byte)}
+		runtime.SetFinalizer(p.data, func(*[1 << 10]byte) { collected.Add(1) })
+		c.Put(ucache.NewIntCompositeKey(hashes...), p)
+	}
+
+	// The nodes and pairs of the siblings are carved from the same slab chunks.
+	for i := int64(0); i < 8; i++ {
+		put(i, 1)
+		put(i, 2, 3)
+	}
+	for i := int64(0); i < 8; i++ {
+		c.DropKey(ucache.NewIntCompositeKey(i, 2))
+	}
+	c.DropKey(ucache.NewIntCompositeKey(0))
+
+	require.Eventually(t, func() bool {
+		runtime.GC()
+		return collected.Load() == 9
+	}, time.Second, 10*time.Millisecond, "dropped values must be collectable while the siblings are alive")
+	assert.Len(t, c.Get(ucache.NewIntCompositeKey(1)), 1)
+
+	// The released nodes are reused.
+	for i := int64(0); i < 8; i++ {
+		put(i, 2, 3)
+	}
+	for i := int64(1); i < 8; i++ {
+		assert.Len(t, c.Get(ucache.NewIntCompositeKey(i)), 2)
+	}
+}
+
+func TestMultiCache_OutdatedNoTTL(t *testing.T) {
+	caches := map[string]ucache.MultiCache[ucache.IntCompositeKey, ucache.StringValue]{
+		"tree": ucache.NewInMemoryTreeMultiCache[ucache.IntCompositeKey, ucache.StringValue](uopt.Null[time.Duration]()),
+		"hash": ucache.NewFarmHashMapMultiCache[ucache.IntCompositeKey, ucache.StringValue](uopt.Null[time.Duration]()),
+	}
+
+	for name, c := range caches {
+		t.Run(name, func(t *testing.T) {
+			key := ucache.NewIntCompositeKey(1, 2)
+			assert.False(t, c.Outdated(uopt.Null[ucache.IntCompositeKey]()))
+			assert.False(t, c.Outdated(uopt.Of(key)))
+			c.Put(key, ucache.NewStringValue("value"))
+			assert.False(t, c.Outdated(uopt.Null[ucache.IntCompositeKey]()))
+			assert.False(t, c.Outdated(uopt.Of(key)))
+			assert.Empty(t, c.(ucache.OutdatedKeysLister[ucache.IntCompositeKey]).OutdatedKeys())
+		})
+	}
+}
+
+func TestMultiCache_OutdatedKeys(t *testing.T) {
+	ttl := 20 * time.Millisecond
+	caches := map[string]ucache.MultiCache[ucache.IntCompositeKey, ucache.StringValue]{
+		"tree": ucache.NewInMemoryTreeMultiCache[ucache.IntCompositeKey, ucache.StringValue](uopt.Of(ttl)),
+		"hash": ucache.NewFarmHashMapMultiCache[ucache.IntCompositeKey, ucache.StringValue](uopt.Of(ttl)),
+	}
+
+	for name, c := range caches {
+		t.Run(name, func(t *testing.T) {
+			outdatedKeys := c.(ucache.OutdatedKeysLister[ucache.IntCompositeKey]).OutdatedKeys
+			stale1 := ucache.NewIntCompositeKey(1, 2)
+			stale2 := ucache.NewIntCompositeKey(3)
+			fresh := ucache.NewIntCompositeKey(4, 5)
+
+			c.Put(stale1, ucache.NewStringValue("a"))
+			c.PutQuietly(stale2, ucache.NewStringValue("b"))
+			assert.Empty(t, outdatedKeys())
+
+			time.Sleep(ttl + 10*time.Millisecond)
+			c.Set(fresh, ucache.NewStringValue("c"))
+
+			assert.ElementsMatch(t, []ucache.IntCompositeKey{stale1, stale2}, outdatedKeys())
+
+			c.DropKey(stale1)
+			assert.ElementsMatch(t, []ucache.IntCompositeKey{stale2}, outdatedKeys())
+		})
+	}
+}
+
+func TestMultiCache_OutdatedKeysAmbiguousIds(t *testing.T) {
+	ttl := 20 * time.Millisecond
+	caches := map[string]ucache.MultiCache[ucache.IntCompositeKey, ucache.StringValue]{
+		"tree": ucache.NewInMemoryTreeMultiCache[ucache.IntCompositeKey, ucache.StringValue](uopt.Of(ttl)),
+		"hash": ucache.NewFarmHashMapMultiCache[ucache.IntCompositeKey, ucache.StringValue](uopt.Of(ttl)),
+	}
+
+	for name, c := range caches {
+		t.Run(name, func(t *testing.T) {
+			stale := ucache.NewIntCompositeKey(1, 23)
+			c.Put(stale, ucache.NewStringValue("a"))
+			time.Sleep(ttl + 10*time.Millisecond)
+			c.Put(ucache.NewIntCompositeKey(12, 3), ucache.NewStringValue("b"))
+
+			assert.Equal(t, []ucache.IntCompositeKey{stale}, c.(ucache.OutdatedKeysLister[ucache.IntCompositeKey]).OutdatedKeys())
+		})
+	}
+}
+
+func TestManagedMultiCache_MutatedKeys(t *testing.T) {
+	ttl := 20 * time.Millisecond
+	type key = SimpleCompositeKey[ucache.IntKey]
+	caches := map[string]ucache.MultiCache[key, DummyComparable]{
+		"tree": ucache.NewInMemoryTreeMultiCache[key, DummyComparable](uopt.Of(ttl)),
+		"hash": ucache.NewFarmHashMapMultiCache[key, DummyComparable](uopt.Of(ttl), ucache.WithMaxEntries(10)),
+	}
+
+	for name, c := range caches {
+		t.Run(name, func(t *testing.T) {
+			managed := ucache.NewManagedMultiCache(c, 5*time.Millisecond)
+			defer managed.Stop()
+
+			mutable := NewSimpleCompositeKey[ucache.IntKey](1, 2)
+			managed.Put(mutable, DummyComparable{Val: 1})
+			mutable.keys[1] = 3 // the caller reuses the key buffer after the write
+
+			require.Eventually(t, func() bool {
+				return len(c.Get(NewSimpleCompositeKey[ucache.IntKey](1, 2))) == 0
+			}, time.Second, 5*time.Millisecond, "the entry must expire even though its key was mutated")
+			assert.Empty(t, c.(ucache.OutdatedKeysLister[key]).OutdatedKeys())
+		})
+	}
+}
+
+func TestMultiCache_ResetChanges(t *testing.T) {
+	caches := map[string]ucache.MultiCache[ucache.IntCompositeKey, ucache.StringValue]{
+		"tree": ucache.NewInMemoryTreeMultiCache[ucache.IntCompositeKey, ucache.StringValue](uopt.Null[time.Duration]()),
+		"hash": ucache.NewFarmHashMapMultiCache[ucache.IntCompositeKey, ucache.StringValue](uopt.Null[time.Duration]()),
+	}
+
+	for name, c := range caches {
+		t.Run(name, func(t *testing.T) {
+			reset := c.(ucache.ChangesResetter[ucache.IntCompositeKey]).ResetChanges
+			key1 := ucache.NewIntCompositeKey(1, 2)
+			key2 := ucache.NewIntCompositeKey(3)
+
+			c.Put(key1, ucache.NewStringValue("a"))
+			c.Set(key2, ucache.NewStringValue("b"))
+			c.PutQuietly(ucache.NewIntCompositeKey(4), ucache.NewStringValue("c"))
+
+			assert.ElementsMatch(t, []ucache.IntCompositeKey{key1, key2}, reset())
+			assert.Empty(t, c.Changes())
+			assert.Empty(t, reset())
+			assert.Len(t, c.Get(key1), 1, "ResetChanges must not drop values")
+
+			c.Drop()
+			c.Put(key2, ucache.NewStringValue("d"))
+			assert.ElementsMatch(t, []ucache.IntCompositeKey{key2}, c.Changes(), "changes must be tracked after Drop")
+		})
+	}
+}
+
+func TestMultiCache_ChangesCount(t *testing.T) {
+	caches := map[string]ucache.MultiCache[ucache.IntCompositeKey, ucache.StringValue]{
+		"tree": ucache.NewInMemoryTreeMultiCache[ucache.IntCompositeKey, ucache.StringValue](uopt.Null[time.Duration]()),
+		"hash": ucache.NewFarmHashMapMultiCache[ucache.IntCompositeKey, ucache.StringValue](uopt.Null[time.Duration]()),
+	}
+
+	for name, c := range caches {
+		t.Run(name, func(t *testing.T) {
+			count := c.(ucache.ChangesCounter).ChangesCount
+			key1 := ucache.NewIntCompositeKey(1, 2)
+			key2 := ucache.NewIntCompositeKey(3)
+
+			assert.Zero(t, count())
+			c.Put(key1, ucache.NewStringValue("a"))
+			c.Put(key2, ucache.NewStringValue("b"))
+			assert.Equal(t, 2, count())
+
+			changes := c.Changes()
+			changes[0] = ucache.NewIntCompositeKey(100)
+			assert.ElementsMatch(t, []ucache.IntCompositeKey{key1, key2}, c.Changes(), "Changes must return a copy")
+
+			c.(ucache.ChangesResetter[ucache.IntCompositeKey]).ResetChanges()
+			assert.Zero(t, count())
+		})
+	}
+}
+
+func TestMultiCache_ChangeLog(t *testing.T) {
+	caches := map[string]ucache.MultiCache[ucache.IntCompositeKey, ucache.StringValue]{
+		"tree": ucache.NewInMemoryTreeMultiCache[ucache.IntCompositeKey, ucache.StringValue](uopt.Of(100 * time.Millisecond)),
+		"hash": ucache.NewFarmHashMapMultiCache[ucache.IntCompositeKey, ucache.StringValue](uopt.Of(100 * time.Millisecond)),
+	}
+
+	for name, c := range caches {
+		t.Run(name, func(t *testing.T) {
+			managed := ucache.NewManagedMultiCache(c, 10*time.Millisecond)
+			defer managed.Stop()
+			key1 := ucache.NewIntCompositeKey(1, 2)
+			key2 := ucache.NewIntCompositeKey(3)
+
+			managed.Put(key1, ucache.NewStringValue("a"))
+			managed.Put(key2, ucache.NewStringValue("b"))
+			managed.DropKey(key1)
+			assert.Len(t, managed.Get(key2), 1)
+
+			log := managed.ChangeLog()
+			require.Len(t, log, 2, "Get must not alter the changes")
+			assert.Equal(t, key2, log[0].Key)
+			assert.Equal(t, ucache.ChangeSet, log[0].Kind)
+			assert.Equal(t, key1, log[1].Key)
+			assert.Equal(t, ucache.ChangeDelete, log[1].Kind)
+			assert.Equal(t, []ucache.IntCompositeKey{key2}, managed.Changes())
+
+			require.Eventually(t, func() bool {
+				return len(managed.Get(key2)) == 0
+			}, time.Second, 10*time.Millisecond)
+			log = managed.ChangeLog()
+			require.Len(t, log, 2)
+			assert.Equal(t, key2, log[1].Key)
+			assert.Equal(t, ucache.ChangeExpire, log[1].Kind)
+			assert.Empty(t, managed.Changes())
+
+			managed.Drop()
+			assert.Equal(t, []ucache.ChangeKind{ucache.ChangeClear}, changeKinds(managed.ChangeLog()))
+		})
+	}
+}
+
+func TestHashMapMultiCache_MaxValuesPerKey(t *testing.T) {
+	key := ucache.NewIntCompositeKey(1)
+	values := func(vs ...string) []ucache.StringValue {
+		result := make([]ucache.StringValue, len(vs))
+		for i, v := range vs {
+			result[i] = ucache.NewStringValue(v)
+		}
+		return result
+	}
+
+	t.Run("reject", func(t *testing.T) {
+		c := ucache.NewFarmHashMapMultiCache[ucache.IntCompositeKey, ucache.StringValue](uopt.Null[time.Duration](), ucache.WithMaxValuesPerKey(3))
+		c.Put(key, values("a", "b")...)
+		c.Put(key, values("c", "d")...)
+		assert.Equal(t, values("a", "b", "c"), c.Get(key))
+		c.PutQuietly(key, values("e")...)
+		assert.Equal(t, values("a", "b", "c"), c.Get(key))
+		c.Set(key, values("x", "y", "z", "w")...)
+		assert.Equal(t, values("x", "y", "z"), c.Get(key))
+	})
+
+	t.Run("drop oldest", func(t *testing.T) {
+		c := ucache.NewFarmHashMapMultiCache[ucache.IntCompositeKey, ucache.StringValue](uopt.Null[time.Duration](),
+			ucache.WithMaxValuesPerKey(3), ucache.WithLimitStrategy(ucache.LimitDropOldest))
+		c.Put(key, values("a", "b")...)
+		c.Put(key, values("c", "d")...)
+		assert.Equal(t, values("b", "c", "d"), c.Get(key))
+		c.PutQuietly(key, values("e")...)
+		assert.Equal(t, values("c", "d", "e"), c.Get(key))
+	})
+}
+
+func TestHashMapMultiCache_MaxEntries(t *testing.T) {
+	key1, key2, key3 := ucache.NewIntCompositeKey(1), ucache.NewIntCompositeKey(2), ucache.NewIntCompositeKey(3)
+	value := ucache.NewStringValue("v")
+
+	t.Run("reject", func(t *testing.T) {
+		c := ucache.NewFarmHashMapMultiCache[ucache.IntCompositeKey, ucache.StringValue](uopt.Of(time.Hour), ucache.WithMaxEntries(2))
+		c.Put(key1, value)
+		c.Put(key2, value)
+		c.Put(key3, value)
+		assert.Empty(t, c.Get(key3))
+		assert.ElementsMatch(t, []ucache.IntCompositeKey{key1, key2}, c.Changes())
+		assert.True(t, c.Outdated(uopt.Of(key3)), "rejected key must not be tracked")
+
+		c.Put(key1, value)
+		assert.Len(t, c.Get(key1), 2, "existing keys must accept values")
+		c.Set(key2, value)
+		assert.Len(t, c.Get(key2), 1, "existing keys must be replaceable")
+
+		c.DropKey(key1)
+		c.Put(key3, value)
+		assert.Len(t, c.Get(key3), 1)
+	})
+
+	t.Run("drop oldest", func(t *testing.T) {
+		c := ucache.NewFarmHashMapMultiCache[ucache.IntCompositeKey, ucache.StringValue](uopt.Of(time.Hour),
+			ucache.WithMaxEntries(2), ucache.WithLimitStrategy(ucache.LimitDropOldest))
+		c.Put(key1, value)
+		c.Put(key2, value)
+		c.Put(key1, value) // key2 becomes the oldest
+		c.Put(key3, value)
+
+		assert.Len(t, c.Get(key1), 2)
+		assert.Empty(t, c.Get(key2))
+		assert.Len(t, c.Get(key3), 1)
+		assert.ElementsMatch(t, []ucache.IntCompositeKey{key1, key3}, c.Changes())
+		assert.True(t, c.Outdated(uopt.Of(key2)), "evicted key must not be tracked")
+
+		c.Drop()
+		c.Put(key2, value)
+		c.Put(key3, value)
+		assert.Len(t, c.Get(key2), 1)
+		assert.Len(t, c.Get(key3), 1)
+	})
+}
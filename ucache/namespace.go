@@ -0,0 +1,239 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package ucache
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/kordax/basic-utils/uopt"
+	"github.com/kordax/basic-utils/uset"
+)
+
+// NamespacedKey is a key of the cache shared by NamespacedCache namespaces.
+type NamespacedKey[K comparable] struct {
+	Namespace string
+	Key       K
+}
+
+// NamespacedCache partitions one cache between several subsystems.
+// Every namespace is a ComparableCache view with its own keys, so Drop and Changes of one namespace
+// don't affect the others, while the storage and TTL are shared.
+//
+// Namespaces keep track of their keys, so all the modifications should be made through the namespaces.
+// To remove outdated entries wrap the namespaces, not the underlying cache, with ManagedCache.
+type NamespacedCache[K comparable, T any] struct {
+	cache ComparableCache[NamespacedKey[K], T]
+
+	mtx        sync.Mutex
+	namespaces map[string]*namespaceState[K]
+}
+
+type namespaceState[K comparable] struct {
+	keys    uset.Set[K]
+	changes *changeLog[K, K]
+}
+
+// NewNamespacedCache creates a new NamespacedCache on top of the provided cache.
+func NewNamespacedCache[K comparable, T any](cache ComparableCache[NamespacedKey[K], T]) *NamespacedCache[K, T] {
+	return &NamespacedCache[K, T]{
+		cache:      cache,
+		namespaces: make(map[string]*namespaceState[K]),
+	}
+}
+
+// Namespace returns a view of the cache scoped to the namespace. Views of the same namespace share their state.
+func (c *NamespacedCache[K, T]) Namespace(name string) ComparableCache[K, T] {
+	return &namespaceView[K, T]{parent: c, name: name}
+}
+
+// Namespaces returns the sorted names of the namespaces that contain keys. The operation is thread-safe.
+func (c *NamespacedCache[K, T]) Namespaces() []string {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	result := make([]string, 0, len(c.namespaces))
+	for name, state := range c.namespaces {
+		if state.keys.Size() > 0 {
+			result = append(result, name)
+		}
+	}
+	sort.Strings(result)
+
+	return result
+}
+
+// Drop clears all the namespaces. The operation is thread-safe.
+func (c *NamespacedCache[K, T]) Drop() {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.cache.Drop()
+	c.namespaces = make(map[string]*namespaceState[K])
+}
+
+// Close closes the underlying cache. The namespaces must not be used after Close.
+// Closing the cache more than once has no effect if the underlying cache allows it.
+func (c *NamespacedCache[K, T]) Close() error {
+	return closeCache(c.cache)
+}
+
+func (c *NamespacedCache[K, T]) state(name string) *namespaceState[K] {
+	state, ok := c.namespaces[name]
+	if !ok {
+		state = &namespaceState[K]{keys: uset.NewHashSet[K](), changes: newChangeLog[K, K]()}
+		c.namespaces[name] = state
+	}
+
+	return state
+}
+
+type namespaceView[K comparable, T any] struct {
+	parent *NamespacedCache[K, T]
+	name   string
+}
+
+func (v *namespaceView[K, T]) key(key K) NamespacedKey[K] {
+	return NamespacedKey[K]{Namespace: v.name, Key: key}
+}
+
+func (v *namespaceView[K, T]) Set(key K, value T) {
+	v.parent.mtx.Lock()
+	defer v.parent.mtx.Unlock()
+	v.parent.cache.Set(v.key(key), value)
+	state := v.parent.state(v.name)
+	state.keys.Add(key)
+	state.changes.record(key, key, ChangeSet)
+}
+
+func (v *namespaceView[K, T]) SetQuietly(key K, value T) {
+	v.parent.mtx.Lock()
+	defer v.parent.mtx.Unlock()
+	v.parent.cache.SetQuietly(v.key(key), value)
+	v.parent.state(v.name).keys.Add(key)
+}
+
+func (v *namespaceView[K, T]) Get(key K) (*T, bool) {
+	return v.parent.cache.Get(v.key(key))
+}
+
+func (v *namespaceView[K, T]) ChangeLog() []ChangeEvent[K] {
+	v.parent.mtx.Lock()
+	defer v.parent.mtx.Unlock()
+	if state, ok := v.parent.namespaces[v.name]; ok {
+		return state.changes.events()
+	}
+
+	return make([]ChangeEvent[K], 0)
+}
+
+func (v *namespaceView[K, T]) Changes() []K {
+	v.parent.mtx.Lock()
+	defer v.parent.mtx.Unlock()
+	if state, ok := v.parent.namespaces[v.name]; ok {
+		return state.changes.keys()
+	}
+
+	return make([]K, 0)
+}
+
+func (v *namespaceView[K, T]) ChangesCount() int {
+	v.parent.mtx.Lock()
+	defer v.parent.mtx.Unlock()
+	if state, ok := v.parent.namespaces[v.name]; ok {
+		return state.changes.count()
+	}
+
+	return 0
+}
+
+func (v *namespaceView[K, T]) ResetChanges() []K {
+	v.parent.mtx.Lock()
+	defer v.parent.mtx.Unlock()
+	state, ok := v.parent.namespaces[v.name]
+	if !ok {
+		return make([]K, 0)
+	}
+
+	return state.changes.reset()
+}
+
+func (v *namespaceView[K, T]) Drop() {
+	v.parent.mtx.Lock()
+	defer v.parent.mtx.Unlock()
+	state := v.parent.state(v.name)
+	for _, key := range state.keys.Values() {
+		v.parent.cache.DropKey(v.key(key))
+	}
+	state.keys.Clear()
+	state.changes.recordClear()
+}
+
+func (v *namespaceView[K, T]) DropKey(key K) {
+	v.parent.mtx.Lock()
+	defer v.parent.mtx.Unlock()
+	v.parent.cache.DropKey(v.key(key))
+	v.forget(key, ChangeDelete)
+}
+
+func (v *namespaceView[K, T]) expireKeys(keys []K) {
+	v.parent.mtx.Lock()
+	defer v.parent.mtx.Unlock()
+	scoped := make([]NamespacedKey[K], len(keys))
+	for i, key := range keys {
+		scoped[i] = v.key(key)
+	}
+	expireKeys(v.parent.cache, scoped)
+	for _, key := range keys {
+		v.forget(key, ChangeExpire)
+	}
+}
+
+// forget removes the key from the namespace. The namespace state is kept until NamespacedCache.Drop to preserve its changes.
+func (v *namespaceView[K, T]) forget(key K, kind ChangeKind) {
+	state := v.parent.state(v.name)
+	state.keys.Remove(key)
+	state.changes.record(key, key, kind)
+}
+
+// Outdated checks the key or, if no key is provided, the whole namespace.
+// A namespace is outdated if none of its keys was updated within the TTL.
+// An empty namespace is reported the same way as the underlying cache.
+func (v *namespaceView[K, T]) Outdated(key uopt.Opt[K]) bool {
+	if k := key.Get(); k != nil {
+		return v.parent.cache.Outdated(uopt.Of(v.key(*k)))
+	}
+
+	v.parent.mtx.Lock()
+	defer v.parent.mtx.Unlock()
+	state, ok := v.parent.namespaces[v.name]
+	if !ok || state.keys.Size() == 0 {
+		return v.parent.cache.Outdated(uopt.Null[NamespacedKey[K]]())
+	}
+	for _, k := range state.keys.Values() {
+		if !v.parent.cache.Outdated(uopt.Of(v.key(k))) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Close is a no-op, since the storage is shared with the other namespaces, see NamespacedCache.Close.
+func (v *namespaceView[K, T]) Close() error {
+	return nil
+}
+
+func (v *namespaceView[K, T]) OutdatedKeys() []K {
+	result := make([]K, 0)
+	for _, key := range outdatedKeysOf[NamespacedKey[K]](v.parent.cache) {
+		if key.Namespace == v.name {
+			result = append(result, key.Key)
+		}
+	}
+
+	return result
+}
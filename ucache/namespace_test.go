@@ -0,0 +1,107 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package ucache_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kordax/basic-utils/ucache"
+	"github.com/kordax/basic-utils/uopt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newNamespacedCache(ttl uopt.Opt[time.Duration]) *ucache.NamespacedCache[string, int] {
+	return ucache.NewNamespacedCache[string, int](ucache.NewInMemoryComparableMapCache[ucache.NamespacedKey[string], int](ttl))
+}
+
+func TestNamespacedCache_Isolation(t *testing.T) {
+	c := newNamespacedCache(uopt.Null[time.Duration]())
+	users := c.Namespace("users")
+	orders := c.Namespace("orders")
+
+	users.Set("1", 10)
+	users.SetQuietly("2", 20)
+	orders.Set("1", 100)
+
+	value, ok := users.Get("1")
+	require.True(t, ok)
+	assert.Equal(t, 10, *value)
+	value, ok = orders.Get("1")
+	require.True(t, ok)
+	assert.Equal(t, 100, *value)
+	_, ok = orders.Get("2")
+	assert.False(t, ok)
+
+	assert.ElementsMatch(t, []string{"1"}, users.Changes())
+	assert.ElementsMatch(t, []string{"1"}, orders.(ucache.ChangesResetter[string]).ResetChanges())
+	assert.Empty(t, orders.Changes())
+	assert.ElementsMatch(t, []string{"1"}, users.Changes(), "ResetChanges must not affect other namespaces")
+	assert.Equal(t, []string{"orders", "users"}, c.Namespaces())
+
+	users.Drop()
+	_, ok = users.Get("1")
+	assert.False(t, ok)
+	_, ok = users.Get("2")
+	assert.False(t, ok)
+	assert.Empty(t, users.Changes())
+	_, ok = orders.Get("1")
+	assert.True(t, ok, "Drop must not affect other namespaces")
+	assert.Equal(t, []string{"orders"}, c.Namespaces())
+
+	c.Namespace("orders").DropKey("1")
+	_, ok = orders.Get("1")
+	assert.False(t, ok, "views of the same namespace must share the state")
+	assert.Empty(t, c.Namespaces())
+
+	users.Set("3", 30)
+	c.Drop()
+	_, ok = users.Get("3")
+	assert.False(t, ok)
+	assert.Empty(t, users.Changes())
+}
+
+func TestNamespacedCache_Outdated(t *testing.T) {
+	ttl := 20 * time.Millisecond
+	c := newNamespacedCache(uopt.Of(ttl))
+	stale := c.Namespace("stale")
+	fresh := c.Namespace("fresh")
+
+	stale.Set("a", 1)
+	stale.Set("b", 2)
+	time.Sleep(ttl + 10*time.Millisecond)
+	fresh.Set("a", 3)
+
+	assert.True(t, stale.Outdated(uopt.Null[string]()))
+	assert.True(t, stale.Outdated(uopt.Of("a")))
+	assert.False(t, fresh.Outdated(uopt.Null[string]()))
+	assert.False(t, fresh.Outdated(uopt.Of("a")))
+	assert.True(t, fresh.Outdated(uopt.Of("missing")))
+	assert.ElementsMatch(t, []string{"a", "b"}, stale.(ucache.OutdatedKeysLister[string]).OutdatedKeys())
+	assert.Empty(t, fresh.(ucache.OutdatedKeysLister[string]).OutdatedKeys())
+
+	noTTL := newNamespacedCache(uopt.Null[time.Duration]()).Namespace("ns")
+	assert.False(t, noTTL.Outdated(uopt.Null[string]()))
+	noTTL.Set("a", 1)
+	assert.False(t, noTTL.Outdated(uopt.Null[string]()))
+}
+
+func TestNamespacedCache_Managed(t *testing.T) {
+	ttl := 10 * time.Millisecond
+	c := newNamespacedCache(uopt.Of(ttl))
+	managed := ucache.NewManagedCache[string, int](c.Namespace("ns"), time.Hour)
+	defer managed.Stop()
+
+	managed.Set("a", 1)
+	time.Sleep(ttl + 10*time.Millisecond)
+	managed.ForceCleanup()
+
+	_, ok := c.Namespace("ns").Get("a")
+	assert.False(t, ok)
+	assert.Empty(t, c.Namespaces())
+}
@@ -0,0 +1,127 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package ucache
+
+import (
+	"context"
+
+	"github.com/kordax/basic-utils/uopt"
+)
+
+// ObservableCache wraps a BaseCache and reports every modification made through it as a ChangeEvent.
+// Events can be consumed with listeners (OnChange) or channels (ChangesStream), e.g. to replicate the cache.
+// Set reports ChangeSet, DropKey reports ChangeDelete and Drop reports ChangeClear, SetQuietly is not reported.
+// Outdated keys removed by a wrapping ManagedCache are reported as ChangeExpire.
+// Events are published after the underlying cache was modified, concurrent modifications may be reported in any order.
+type ObservableCache[K, T any] struct {
+	cache BaseCache[K, T]
+	feed  changeFeed[K]
+}
+
+// NewObservableCache creates a new ObservableCache on top of the provided cache.
+func NewObservableCache[K, T any](cache BaseCache[K, T]) *ObservableCache[K, T] {
+	return &ObservableCache[K, T]{cache: cache}
+}
+
+// OnChange registers a listener that is called after every reported change.
+// Listeners are called synchronously in the goroutine that made the change, so they should return quickly.
+// The operation is thread-safe.
+func (c *ObservableCache[K, T]) OnChange(listener func(event ChangeEvent[K])) {
+	c.feed.subscribe(listener)
+}
+
+// ChangesStream returns a channel of change events. The channel is closed once the context is done.
+// By default, the stream buffers 64 events and blocks the cache writers when the buffer is full,
+// use WithStreamBuffer and WithBackpressure to change it. The operation is thread-safe.
+func (c *ObservableCache[K, T]) ChangesStream(ctx context.Context, opts ...StreamOption) <-chan ChangeEvent[K] {
+	return c.feed.stream(ctx, opts...)
+}
+
+// Set updates the cache value for the provided key and reports ChangeSet. The operation is thread-safe.
+func (c *ObservableCache[K, T]) Set(key K, value T) {
+	c.cache.Set(key, value)
+	c.feed.publish(key, ChangeSet)
+}
+
+// SetQuietly adds a value to the cache for the provided key without altering the change history.
+// No events are reported. The operation is thread-safe.
+func (c *ObservableCache[K, T]) SetQuietly(key K, value T) {
+	c.cache.SetQuietly(key, value)
+}
+
+// Get retrieves the value associated with the provided key from the cache. The operation is thread-safe.
+func (c *ObservableCache[K, T]) Get(key K) (*T, bool) {
+	return c.cache.Get(key)
+}
+
+// ChangeLog returns the latest change of every modified key in the order they were made. The operation is thread-safe.
+func (c *ObservableCache[K, T]) ChangeLog() []ChangeEvent[K] {
+	return changeLogOf[K](c.cache)
+}
+
+// Changes returns a slice of keys that have been modified in the cache. The operation is thread-safe.
+func (c *ObservableCache[K, T]) Changes() []K {
+	return c.cache.Changes()
+}
+
+// ChangesCount returns the number of modified keys. The operation is thread-safe.
+func (c *ObservableCache[K, T]) ChangesCount() int {
+	return changesCount(c.cache)
+}
+
+// ResetChanges atomically returns the modified keys and clears the change history. The operation is thread-safe.
+func (c *ObservableCache[K, T]) ResetChanges() []K {
+	return resetChanges[K](c.cache)
+}
+
+// Drop completely clears the cache and reports ChangeClear. The operation is thread-safe.
+func (c *ObservableCache[K, T]) Drop() {
+	c.cache.Drop()
+	var zero K
+	c.feed.publish(zero, ChangeClear)
+}
+
+// DropKey removes the value associated with the provided key from the cache and reports ChangeDelete.
+// The operation is thread-safe.
+func (c *ObservableCache[K, T]) DropKey(key K) {
+	c.cache.DropKey(key)
+	c.feed.publish(key, ChangeDelete)
+}
+
+func (c *ObservableCache[K, T]) expireKeys(keys []K) {
+	expireKeys(c.cache, keys)
+	for _, key := range keys {
+		c.feed.publish(key, ChangeExpire)
+	}
+}
+
+func (c *ObservableCache[K, T]) expireOutdated() []K {
+	keys := expireOutdated(c.cache)
+	for _, key := range keys {
+		c.feed.publish(key, ChangeExpire)
+	}
+
+	return keys
+}
+
+// Outdated checks if the provided key or the entire cache (if no key is provided)
+// is outdated based on the set TTL. Returns true if outdated, false otherwise.
+func (c *ObservableCache[K, T]) Outdated(key uopt.Opt[K]) bool {
+	return c.cache.Outdated(key)
+}
+
+// OutdatedKeys returns the keys that are outdated based on the set TTL. The operation is thread-safe.
+func (c *ObservableCache[K, T]) OutdatedKeys() []K {
+	return outdatedKeysOf[K](c.cache)
+}
+
+// Close closes all the change streams, unsubscribes the listeners and closes the wrapped cache.
+// Closing the cache more than once has no effect.
+func (c *ObservableCache[K, T]) Close() error {
+	c.feed.close()
+	return closeCache(c.cache)
+}
@@ -0,0 +1,157 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package ucache_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kordax/basic-utils/ucache"
+	"github.com/kordax/basic-utils/uopt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newObservableCache() *ucache.ObservableCache[string, int] {
+	return ucache.NewObservableCache[string, int](ucache.NewInMemoryComparableMapCache[string, int](uopt.Null[time.Duration]()))
+}
+
+func receive(t *testing.T, ch <-chan ucache.ChangeEvent[string]) ucache.ChangeEvent[string] {
+	t.Helper()
+	select {
+	case event, ok := <-ch:
+		require.True(t, ok, "stream must not be closed")
+		return event
+	case <-time.After(time.Second):
+		require.FailNow(t, "no event received")
+		return ucache.ChangeEvent[string]{}
+	}
+}
+
+func TestObservableCache_ChangesStream(t *testing.T) {
+	c := newObservableCache()
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := c.ChangesStream(ctx)
+
+	var listened []ucache.ChangeKind
+	c.OnChange(func(event ucache.ChangeEvent[string]) {
+		listened = append(listened, event.Kind)
+	})
+
+	c.Set("a", 1)
+	c.SetQuietly("b", 2)
+	c.DropKey("a")
+	c.Drop()
+
+	event := receive(t, stream)
+	assert.Equal(t, "a", event.Key)
+	assert.Equal(t, ucache.ChangeSet, event.Kind)
+	assert.False(t, event.At.IsZero())
+	event = receive(t, stream)
+	assert.Equal(t, "a", event.Key)
+	assert.Equal(t, ucache.ChangeDelete, event.Kind)
+	event = receive(t, stream)
+	assert.Equal(t, "", event.Key)
+	assert.Equal(t, ucache.ChangeClear, event.Kind)
+	assert.Equal(t, []ucache.ChangeKind{ucache.ChangeSet, ucache.ChangeDelete, ucache.ChangeClear}, listened)
+
+	cancel()
+	require.Eventually(t, func() bool {
+		_, ok := <-stream
+		return !ok
+	}, time.Second, time.Millisecond, "stream must be closed once the context is done")
+	c.Set("c", 3) // must not panic after the stream is closed
+}
+
+func TestObservableCache_BackpressureDropNewest(t *testing.T) {
+	c := newObservableCache()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream := c.ChangesStream(ctx, ucache.WithStreamBuffer(2), ucache.WithBackpressure(ucache.BackpressureDropNewest))
+
+	c.Set("a", 1)
+	c.Set("b", 2)
+	c.Set("c", 3)
+
+	assert.Equal(t, "a", receive(t, stream).Key)
+	assert.Equal(t, "b", receive(t, stream).Key)
+	assert.Empty(t, stream)
+}
+
+func TestObservableCache_BackpressureDropOldest(t *testing.T) {
+	c := newObservableCache()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream := c.ChangesStream(ctx, ucache.WithStreamBuffer(2), ucache.WithBackpressure(ucache.BackpressureDropOldest))
+
+	c.Set("a", 1)
+	c.Set("b", 2)
+	c.Set("c", 3)
+
+	assert.Equal(t, "b", receive(t, stream).Key)
+	assert.Equal(t, "c", receive(t, stream).Key)
+	assert.Empty(t, stream)
+}
+
+func TestObservableCache_BackpressureBlock(t *testing.T) {
+	c := newObservableCache()
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := c.ChangesStream(ctx, ucache.WithStreamBuffer(0))
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		c.Set("a", 1)
+	}()
+
+	select {
+	case <-done:
+		require.FailNow(t, "Set must block until the event is consumed")
+	case <-time.After(20 * time.Millisecond):
+	}
+	assert.Equal(t, "a", receive(t, stream).Key)
+	<-done
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		cancel()
+	}()
+	c.Set("b", 2) // must be unblocked by the stream closing
+}
+
+func TestObservableCache_ResetChanges(t *testing.T) {
+	c := newObservableCache()
+	c.Set("a", 1)
+	c.Set("b", 2)
+
+	assert.ElementsMatch(t, []string{"a", "b"}, c.ResetChanges())
+	assert.Empty(t, c.Changes())
+	assert.Empty(t, c.ResetChanges())
+}
+
+func TestObservableCache_Close(t *testing.T) {
+	c := newObservableCache()
+	stream := c.ChangesStream(context.Background())
+	calls := 0
+	c.OnChange(func(event ucache.ChangeEvent[string]) {
+		calls++
+	})
+
+	require.NoError(t, c.Close())
+	require.NoError(t, c.Close(), "closing twice must be safe")
+	_, ok := <-stream
+	assert.False(t, ok, "streams must be closed")
+	_, ok = <-c.ChangesStream(context.Background())
+	assert.False(t, ok, "streams opened after Close must be closed")
+
+	c.OnChange(func(event ucache.ChangeEvent[string]) {
+		calls++
+	})
+	c.Set("a", 1)
+	assert.Zero(t, calls, "listeners must be unsubscribed")
+}
@@ -0,0 +1,300 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package ucache
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/kordax/basic-utils/ulog"
+	"github.com/kordax/basic-utils/uopt"
+)
+
+// ReplicationEvent is a change made by one of the cache instances and delivered to the others.
+type ReplicationEvent[K, T any] struct {
+	Origin string     // Origin is the id of the instance that made the change, see WithInstanceID.
+	Kind   ChangeKind // Kind is ChangeSet, ChangeDelete or ChangeClear.
+	Key    K          // Key is the changed key, the zero value for ChangeClear.
+	Value  T          // Value is the new value for ChangeSet if the values are replicated, see WithValueReplication.
+}
+
+// Replicator delivers the changes between the cache instances, e.g. over NATS or Redis pub/sub.
+// Implementations handle the serialization of the events and must deliver every published event
+// to the handlers subscribed by all the instances, including the publishing one.
+type Replicator[K, T any] interface {
+	// Publish sends the event to all the instances.
+	Publish(event ReplicationEvent[K, T]) error
+	// Subscribe registers the handler of the events published by any instance and returns a function
+	// cancelling the subscription.
+	Subscribe(handler func(event ReplicationEvent[K, T])) (cancel func(), err error)
+}
+
+type replicationOptions struct {
+	instanceID      string
+	replicateValues bool
+}
+
+// ReplicationOption configures a ReplicatedCache.
+type ReplicationOption func(o *replicationOptions)
+
+// WithInstanceID sets the id identifying the events published by the instance, which must be unique among
+// the instances. A random id is generated by default.
+func WithInstanceID(id string) ReplicationOption {
+	return func(o *replicationOptions) {
+		o.instanceID = id
+	}
+}
+
+// WithValueReplication makes the instances store the values set by the other instances instead of invalidating
+// their entries. This saves the reloads of the invalidated entries at the cost of sending the values,
+// which must be supported by the Replicator serialization.
+func WithValueReplication() ReplicationOption {
+	return func(o *replicationOptions) {
+		o.replicateValues = true
+	}
+}
+
+/*
+ReplicatedCache wraps a BaseCache and keeps it coherent with the caches of the other application instances.
+Local Set, SetQuietly, DropKey and Drop are published with a Replicator, while the changes published by the other
+instances are applied to the wrapped cache:
+  - a remote Set invalidates the local entry of the key, or stores the value with WithValueReplication;
+  - a remote DropKey drops the key and a remote Drop clears the cache.
+
+Outdated keys are expired by every instance independently, so the expiration is not replicated.
+Remote changes are applied directly to the wrapped cache and are not published again.
+
+Replication is asynchronous and best effort: concurrent changes of the same key on several instances may be applied
+in different orders, and the events lost by the Replicator are never recovered, so entries should have a TTL
+bounding the staleness. Publish failures are reported to a ulog.Logger, which discards everything
+unless replaced with SetLogger.
+*/
+type ReplicatedCache[K, T any] struct {
+	cache      BaseCache[K, T]
+	replicator Replicator[K, T]
+	options    replicationOptions
+	cancel     func()
+	closeOnce  sync.Once
+	closeErr   error
+	logger     atomic.Pointer[ulog.Logger]
+}
+
+// NewReplicatedCache wraps the cache and subscribes to the changes of the other instances.
+// Returns the subscription error of the Replicator.
+func NewReplicatedCache[K, T any](cache BaseCache[K, T], replicator Replicator[K, T], opts ...ReplicationOption) (*ReplicatedCache[K, T], error) {
+	c := &ReplicatedCache[K, T]{
+		cache:      cache,
+		replicator: replicator,
+	}
+	for _, opt := range opts {
+		opt(&c.options)
+	}
+	if c.options.instanceID == "" {
+		c.options.instanceID = randomInstanceID()
+	}
+	c.SetLogger(ulog.Nop())
+
+	cancel, err := replicator.Subscribe(c.apply)
+	if err != nil {
+		return nil, fmt.Errorf("ucache: failed to subscribe to replication events: %w", err)
+	}
+	c.cancel = cancel
+
+	return c, nil
+}
+
+// InstanceID returns the id of the instance, which is the Origin of the events it publishes.
+func (c *ReplicatedCache[K, T]) InstanceID() string {
+	return c.options.instanceID
+}
+
+// SetLogger replaces the logger used to report replication failures. Passing nil disables logging.
+// The operation is thread-safe.
+func (c *ReplicatedCache[K, T]) SetLogger(logger ulog.Logger) {
+	logger = ulog.OrNop(logger)
+	c.logger.Store(&logger)
+}
+
+func (c *ReplicatedCache[K, T]) getLogger() ulog.Logger {
+	return *c.logger.Load()
+}
+
+// Set updates the cache value for the provided key and publishes the change. The operation is thread-safe.
+func (c *ReplicatedCache[K, T]) Set(key K, value T) {
+	c.cache.Set(key, value)
+	c.publishSet(key, value)
+}
+
+// SetQuietly adds a value to the cache for the provided key without altering the change history.
+// The change is still published, so the other instances stay coherent. The operation is thread-safe.
+func (c *ReplicatedCache[K, T]) SetQuietly(key K, value T) {
+	c.cache.SetQuietly(key, value)
+	c.publishSet(key, value)
+}
+
+// Get retrieves the value associated with the provided key from the local cache. The operation is thread-safe.
+func (c *ReplicatedCache[K, T]) Get(key K) (*T, bool) {
+	return c.cache.Get(key)
+}
+
+// ChangeLog returns the latest change of every modified key in the order they were made, including the remote changes.
+// The operation is thread-safe.
+func (c *ReplicatedCache[K, T]) ChangeLog() []ChangeEvent[K] {
+	return changeLogOf[K](c.cache)
+}
+
+// Changes returns a slice of keys that have been modified in the cache. The operation is thread-safe.
+func (c *ReplicatedCache[K, T]) Changes() []K {
+	return c.cache.Changes()
+}
+
+// ChangesCount returns the number of modified keys. The operation is thread-safe.
+func (c *ReplicatedCache[K, T]) ChangesCount() int {
+	return changesCount(c.cache)
+}
+
+// ResetChanges atomically returns the modified keys and clears the change history. The operation is thread-safe.
+func (c *ReplicatedCache[K, T]) ResetChanges() []K {
+	return resetChanges[K](c.cache)
+}
+
+// Drop completely clears the cache and publishes the change. The operation is thread-safe.
+func (c *ReplicatedCache[K, T]) Drop() {
+	c.cache.Drop()
+	c.publish(ReplicationEvent[K, T]{Kind: ChangeClear})
+}
+
+// DropKey removes the value associated with the provided key from the cache and publishes the change.
+// The operation is thread-safe.
+func (c *ReplicatedCache[K, T]) DropKey(key K) {
+	c.cache.DropKey(key)
+	c.publish(ReplicationEvent[K, T]{Kind: ChangeDelete, Key: key})
+}
+
+func (c *ReplicatedCache[K, T]) expireKeys(keys []K) {
+	expireKeys(c.cache, keys)
+}
+
+func (c *ReplicatedCache[K, T]) expireOutdated() []K {
+	return expireOutdated(c.cache)
+}
+
+// Outdated checks if the provided key or the entire cache (if no key is provided)
+// is outdated based on the set TTL. Returns true if outdated, false otherwise.
+func (c *ReplicatedCache[K, T]) Outdated(key uopt.Opt[K]) bool {
+	return c.cache.Outdated(key)
+}
+
+// OutdatedKeys returns the keys that are outdated based on the set TTL. The operation is thread-safe.
+func (c *ReplicatedCache[K, T]) OutdatedKeys() []K {
+	return outdatedKeysOf[K](c.cache)
+}
+
+// Close cancels the subscription to the remote changes and closes the wrapped cache.
+// Closing the cache more than once has no effect.
+func (c *ReplicatedCache[K, T]) Close() error {
+	c.closeOnce.Do(func() {
+		if c.cancel != nil {
+			c.cancel()
+		}
+		c.closeErr = closeCache(c.cache)
+	})
+
+	return c.closeErr
+}
+
+func (c *ReplicatedCache[K, T]) publishSet(key K, value T) {
+	event := ReplicationEvent[K, T]{Kind: ChangeSet, Key: key}
+	if c.options.replicateValues {
+		event.Value = value
+	}
+	c.publish(event)
+}
+
+func (c *ReplicatedCache[K, T]) publish(event ReplicationEvent[K, T]) {
+	event.Origin = c.options.instanceID
+	if err := c.replicator.Publish(event); err != nil {
+		c.getLogger().Error("failed to publish cache change",
+			ulog.F("kind", event.Kind), ulog.F("key", event.Key), ulog.F("error", err))
+	}
+}
+
+// apply applies a change made by another instance to the wrapped cache.
+func (c *ReplicatedCache[K, T]) apply(event ReplicationEvent[K, T]) {
+	if event.Origin == c.options.instanceID {
+		return
+	}
+
+	switch event.Kind {
+	case ChangeSet:
+		if c.options.replicateValues {
+			c.cache.Set(event.Key, event.Value)
+		} else {
+			c.cache.DropKey(event.Key)
+		}
+	case ChangeDelete:
+		c.cache.DropKey(event.Key)
+	case ChangeClear:
+		c.cache.Drop()
+	default:
+		c.getLogger().Warn("ignoring unsupported replication event", ulog.F("kind", event.Kind), ulog.F("origin", event.Origin))
+	}
+}
+
+func randomInstanceID() string {
+	var id [8]byte
+	_, _ = rand.Read(id[:])
+	return hex.EncodeToString(id[:])
+}
+
+// InMemoryReplicator is a Replicator delivering the events to the caches of the same process synchronously.
+// It's meant for tests and for keeping several local caches coherent. The operations are thread-safe.
+type InMemoryReplicator[K, T any] struct {
+	mtx      sync.RWMutex
+	handlers map[int]func(event ReplicationEvent[K, T])
+	nextID   int
+}
+
+// NewInMemoryReplicator creates a new InMemoryReplicator.
+func NewInMemoryReplicator[K, T any]() *InMemoryReplicator[K, T] {
+	return &InMemoryReplicator[K, T]{handlers: make(map[int]func(event ReplicationEvent[K, T]))}
+}
+
+// Publish calls all the subscribed handlers in the calling goroutine. It never fails.
+func (r *InMemoryReplicator[K, T]) Publish(event ReplicationEvent[K, T]) error {
+	r.mtx.RLock()
+	handlers := make([]func(event ReplicationEvent[K, T]), 0, len(r.handlers))
+	for _, handler := range r.handlers {
+		handlers = append(handlers, handler)
+	}
+	r.mtx.RUnlock()
+
+	for _, handler := range handlers {
+		handler(event)
+	}
+
+	return nil
+}
+
+// Subscribe registers the handler. It never fails.
+func (r *InMemoryReplicator[K, T]) Subscribe(handler func(event ReplicationEvent[K, T])) (func(), error) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	id := r.nextID
+	r.nextID++
+	r.handlers[id] = handler
+
+	return func() {
+		r.mtx.Lock()
+		defer r.mtx.Unlock()
+		delete(r.handlers, id)
+	}, nil
+}
@@ -0,0 +1,111 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package ucache_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kordax/basic-utils/ucache"
+	"github.com/kordax/basic-utils/uopt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newReplicatedPair(t *testing.T, opts ...ucache.ReplicationOption) (*ucache.ReplicatedCache[string, int], *ucache.ReplicatedCache[string, int]) {
+	replicator := ucache.NewInMemoryReplicator[string, int]()
+	first, err := ucache.NewReplicatedCache[string, int](
+		ucache.NewInMemoryComparableMapCache[string, int](uopt.Null[time.Duration]()), replicator, opts...,
+	)
+	require.NoError(t, err)
+	second, err := ucache.NewReplicatedCache[string, int](
+		ucache.NewInMemoryComparableMapCache[string, int](uopt.Null[time.Duration]()), replicator, opts...,
+	)
+	require.NoError(t, err)
+	require.NotEqual(t, first.InstanceID(), second.InstanceID())
+
+	return first, second
+}
+
+func TestReplicatedCache_Invalidation(t *testing.T) {
+	first, second := newReplicatedPair(t)
+
+	first.Set("a", 1)
+	second.Set("a", 2)
+	_, ok := first.Get("a")
+	assert.False(t, ok, "a remote Set must invalidate the local entry")
+	v, ok := second.Get("a")
+	require.True(t, ok)
+	assert.Equal(t, 2, *v)
+
+	first.Set("b", 1)
+	second.SetQuietly("b", 2)
+	_, ok = first.Get("b")
+	assert.False(t, ok, "SetQuietly must be replicated too")
+
+	first.Set("c", 3)
+	second.DropKey("c")
+	_, ok = first.Get("c")
+	assert.False(t, ok)
+
+	second.Set("d", 4)
+	first.Drop()
+	_, ok = second.Get("d")
+	assert.False(t, ok)
+}
+
+func TestReplicatedCache_ValueReplication(t *testing.T) {
+	first, second := newReplicatedPair(t, ucache.WithValueReplication())
+
+	first.Set("a", 1)
+	v, ok := second.Get("a")
+	require.True(t, ok)
+	assert.Equal(t, 1, *v)
+
+	require.NoError(t, second.Close())
+	require.NoError(t, second.Close(), "closing twice must be safe")
+	first.Set("a", 2)
+	v, ok = second.Get("a")
+	require.True(t, ok)
+	assert.Equal(t, 1, *v, "a closed cache must not receive the changes")
+}
+
+type failingReplicator struct {
+	subscribeErr error
+}
+
+func (r failingReplicator) Publish(ucache.ReplicationEvent[string, int]) error {
+	return errors.New("publish failed")
+}
+
+func (r failingReplicator) Subscribe(func(ucache.ReplicationEvent[string, int])) (func(), error) {
+	return func() {}, r.subscribeErr
+}
+
+func TestReplicatedCache_Failures(t *testing.T) {
+	errSubscribe := errors.New("subscribe failed")
+	_, err := ucache.NewReplicatedCache[string, int](
+		ucache.NewInMemoryComparableMapCache[string, int](uopt.Null[time.Duration]()), failingReplicator{subscribeErr: errSubscribe},
+	)
+	assert.ErrorIs(t, err, errSubscribe)
+
+	c, err := ucache.NewReplicatedCache[string, int](
+		ucache.NewInMemoryComparableMapCache[string, int](uopt.Null[time.Duration]()), failingReplicator{},
+		ucache.WithInstanceID("node-1"),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "node-1", c.InstanceID())
+	logger := &recordingLogger{}
+	c.SetLogger(logger)
+
+	c.Set("a", 1)
+	v, ok := c.Get("a")
+	require.True(t, ok, "publish failures must not affect the local cache")
+	assert.Equal(t, 1, *v)
+	assert.Equal(t, []string{"failed to publish cache change"}, logger.Messages())
+}
@@ -0,0 +1,155 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package ucache
+
+import (
+	"encoding/binary"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/dgryski/go-farm"
+	"github.com/kordax/basic-utils/uconst"
+	"github.com/kordax/basic-utils/uopt"
+)
+
+// DefaultRingReplicas is the number of virtual nodes created per node when no explicit value is provided.
+const DefaultRingReplicas = 128
+
+/*
+Ring implements consistent hashing with virtual nodes and can be used to partition cache keys
+across multiple cache instances or processes. Adding or removing a node only remaps the keys
+owned by that node, the rest of the keys keep their owners.
+
+Keys are hashed with farm's 64-bit hash function: uconst.Unique keys use their Key value,
+strings and byte slices are hashed directly and all other keys are hashed via Hashed.
+Since the hashing is deterministic, every process sharing the same set of nodes and replicas
+locates keys on the same nodes. Pointer keys are hashed by their address and are therefore
+not stable across processes.
+
+Ring is safe for concurrent use.
+*/
+type Ring struct {
+	replicas int
+
+	mtx    sync.RWMutex
+	hashes []uint64
+	owners map[uint64]string
+	nodes  map[string]struct{}
+}
+
+// NewRing creates a new Ring with the specified number of virtual nodes per node (DefaultRingReplicas if absent)
+// and the initial set of nodes.
+func NewRing(replicas uopt.Opt[int], nodes ...string) *Ring {
+	r := &Ring{
+		replicas: replicas.OrElse(DefaultRingReplicas),
+		owners:   make(map[uint64]string),
+		nodes:    make(map[string]struct{}),
+	}
+	if r.replicas <= 0 {
+		r.replicas = DefaultRingReplicas
+	}
+	for _, node := range nodes {
+		r.AddNode(node)
+	}
+
+	return r
+}
+
+// AddNode adds the node to the ring. Adding an already present node is a no-op.
+func (r *Ring) AddNode(node string) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	if _, ok := r.nodes[node]; ok {
+		return
+	}
+	r.nodes[node] = struct{}{}
+
+	for i := 0; i < r.replicas; i++ {
+		h := farm.Hash64([]byte(node + "#" + strconv.Itoa(i)))
+		if _, taken := r.owners[h]; taken {
+			continue
+		}
+		r.owners[h] = node
+		r.hashes = append(r.hashes, h)
+	}
+	sort.Slice(r.hashes, func(i, j int) bool { return r.hashes[i] < r.hashes[j] })
+}
+
+// RemoveNode removes the node from the ring. Removing an absent node is a no-op.
+func (r *Ring) RemoveNode(node string) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	if _, ok := r.nodes[node]; !ok {
+		return
+	}
+	delete(r.nodes, node)
+
+	hashes := r.hashes[:0]
+	for _, h := range r.hashes {
+		if r.owners[h] == node {
+			delete(r.owners, h)
+			continue
+		}
+		hashes = append(hashes, h)
+	}
+	r.hashes = hashes
+}
+
+// Nodes returns the sorted list of nodes in the ring.
+func (r *Ring) Nodes() []string {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+
+	result := make([]string, 0, len(r.nodes))
+	for node := range r.nodes {
+		result = append(result, node)
+	}
+	sort.Strings(result)
+
+	return result
+}
+
+// Locate returns the node owning the key. The second value is false if the ring has no nodes.
+func (r *Ring) Locate(key any) (string, bool) {
+	return r.LocateHash(ringHash(key))
+}
+
+// LocateHash returns the node owning the precomputed key hash. The second value is false if the ring has no nodes.
+func (r *Ring) LocateHash(hash uint64) (string, bool) {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+
+	if len(r.hashes) == 0 {
+		return "", false
+	}
+
+	i := sort.Search(len(r.hashes), func(i int) bool { return r.hashes[i] >= hash })
+	if i == len(r.hashes) {
+		i = 0
+	}
+
+	return r.owners[r.hashes[i]], true
+}
+
+func ringHash(key any) uint64 {
+	switch k := key.(type) {
+	case string:
+		return farm.Hash64([]byte(k))
+	case []byte:
+		return farm.Hash64(k)
+	case uconst.Unique:
+		// Unique keys are often sequential, so they are rehashed to spread them over the ring.
+		var b [8]byte
+		binary.LittleEndian.PutUint64(b[:], uint64(k.Key()))
+		return farm.Hash64(b[:])
+	default:
+		return uint64(Hashed(key).Key())
+	}
+}
@@ -0,0 +1,96 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package ucache_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/kordax/basic-utils/ucache"
+	"github.com/kordax/basic-utils/uopt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRing_Empty(t *testing.T) {
+	r := ucache.NewRing(uopt.Null[int]())
+	_, ok := r.Locate("key")
+	assert.False(t, ok)
+	assert.Empty(t, r.Nodes())
+}
+
+func TestRing_LocateIsStable(t *testing.T) {
+	r1 := ucache.NewRing(uopt.Null[int](), "a", "b", "c")
+	r2 := ucache.NewRing(uopt.Null[int](), "c", "a", "b")
+	assert.Equal(t, []string{"a", "b", "c"}, r1.Nodes())
+
+	for i := 0; i < 1000; i++ {
+		key := fmt.Sprintf("key-%d", i)
+		n1, ok := r1.Locate(key)
+		require.True(t, ok)
+		n2, _ := r2.Locate(key)
+		assert.Equal(t, n1, n2)
+	}
+
+	n1, _ := r1.Locate(ucache.IntKey(42))
+	n2, _ := r2.Locate(ucache.IntKey(42))
+	assert.Equal(t, n1, n2)
+
+	type compound struct{ A, B int }
+	n1, _ = r1.Locate(compound{A: 1, B: 2})
+	n2, _ = r2.Locate(compound{A: 1, B: 2})
+	assert.Equal(t, n1, n2)
+}
+
+func TestRing_Distribution(t *testing.T) {
+	r := ucache.NewRing(uopt.Of(200), "a", "b", "c", "d")
+	counts := make(map[string]int)
+	for i := 0; i < 10000; i++ {
+		node, _ := r.Locate(ucache.IntKey(i))
+		counts[node]++
+	}
+
+	require.Len(t, counts, 4)
+	for node, c := range counts {
+		assert.InDelta(t, 2500, c, 1000, node)
+	}
+}
+
+func TestRing_AddRemoveNode(t *testing.T) {
+	r := ucache.NewRing(uopt.Null[int](), "a", "b", "c")
+	before := make(map[string]string)
+	for i := 0; i < 1000; i++ {
+		key := fmt.Sprintf("key-%d", i)
+		before[key], _ = r.Locate(key)
+	}
+
+	r.AddNode("d")
+	r.AddNode("d")
+	assert.Equal(t, []string{"a", "b", "c", "d"}, r.Nodes())
+	for key, owner := range before {
+		node, _ := r.Locate(key)
+		if node != owner {
+			assert.Equal(t, "d", node, "keys may only move to the new node")
+		}
+	}
+
+	r.RemoveNode("d")
+	r.RemoveNode("missing")
+	for key, owner := range before {
+		node, _ := r.Locate(key)
+		assert.Equal(t, owner, node)
+	}
+
+	r.RemoveNode("a")
+	for key, owner := range before {
+		node, _ := r.Locate(key)
+		assert.NotEqual(t, "a", node)
+		if owner != "a" {
+			assert.Equal(t, owner, node)
+		}
+	}
+}
@@ -0,0 +1,161 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package ucache
+
+import (
+	"hash/maphash"
+	"time"
+
+	"github.com/kordax/basic-utils/internal/uhash"
+	"github.com/kordax/basic-utils/uconst"
+	"github.com/kordax/basic-utils/uopt"
+)
+
+// SimpleCache is a ComparableCache built on top of InMemoryHashMapCache.
+// It accepts plain comparable keys (strings, numbers, structs etc.) and internally adapts them to the uconst.Unique
+// key machinery, so there is no need to implement Unique or CompositeKey for everyday use.
+// Keys are hashed with a per-cache random seed, collisions are resolved by the key equality.
+type SimpleCache[K comparable, T any] struct {
+	cache Cache[simpleKey[K], T]
+	seed  maphash.Seed
+}
+
+// NewSimpleCache creates a new instance of the SimpleCache.
+// It accepts an optional TTL (time-to-live) duration for cache entries.
+func NewSimpleCache[K comparable, T any](ttl uopt.Opt[time.Duration]) ComparableCache[K, T] {
+	return &SimpleCache[K, T]{
+		cache: NewInMemoryHashMapCache[simpleKey[K], T](ttl),
+		seed:  maphash.MakeSeed(),
+	}
+}
+
+// Set updates the cache value for the provided key. The operation is thread-safe.
+func (c *SimpleCache[K, T]) Set(key K, value T) {
+	c.cache.Set(c.wrap(key), value)
+}
+
+// SetQuietly adds a value to the cache for the provided key without altering the change history.
+// The operation is thread-safe.
+func (c *SimpleCache[K, T]) SetQuietly(key K, value T) {
+	c.cache.SetQuietly(c.wrap(key), value)
+}
+
+// Get retrieves the value associated with the provided key from the cache. The operation is thread-safe.
+func (c *SimpleCache[K, T]) Get(key K) (*T, bool) {
+	return c.cache.Get(c.wrap(key))
+}
+
+// ChangeLog returns the latest change of every modified key in the order they were made. The operation is thread-safe.
+func (c *SimpleCache[K, T]) ChangeLog() []ChangeEvent[K] {
+	events := changeLogOf[simpleKey[K]](c.cache)
+	result := make([]ChangeEvent[K], len(events))
+	for i, e := range events {
+		result[i] = ChangeEvent[K]{Key: e.Key.key, Kind: e.Kind, At: e.At}
+	}
+
+	return result
+}
+
+// Changes returns a slice of keys that have been modified in the cache. The operation is thread-safe.
+func (c *SimpleCache[K, T]) Changes() []K {
+	return unwrapKeys(c.cache.Changes())
+}
+
+// ChangesCount returns the number of modified keys. The operation is thread-safe.
+func (c *SimpleCache[K, T]) ChangesCount() int {
+	return changesCount(c.cache)
+}
+
+// ResetChanges atomically returns the modified keys and clears the change history. The operation is thread-safe.
+func (c *SimpleCache[K, T]) ResetChanges() []K {
+	return unwrapKeys(resetChanges[simpleKey[K]](c.cache))
+}
+
+// Drop completely clears the cache, removing all entries. The operation is thread-safe.
+func (c *SimpleCache[K, T]) Drop() {
+	c.cache.Drop()
+}
+
+// DropKey removes the value associated with the provided key from the cache. The operation is thread-safe.
+func (c *SimpleCache[K, T]) DropKey(key K) {
+	c.cache.DropKey(c.wrap(key))
+}
+
+func (c *SimpleCache[K, T]) expireKeys(keys []K) {
+	wrapped := make([]simpleKey[K], len(keys))
+	for i, key := range keys {
+		wrapped[i] = c.wrap(key)
+	}
+	expireKeys(c.cache, wrapped)
+}
+
+func (c *SimpleCache[K, T]) expireOutdated() []K {
+	expired := expireOutdated(c.cache)
+	result := make([]K, len(expired))
+	for i, key := range expired {
+		result[i] = key.key
+	}
+
+	return result
+}
+
+// Outdated checks if the provided key or the entire cache (if no key is provided)
+// is outdated based on the set TTL. Returns true if outdated, false otherwise.
+func (c *SimpleCache[K, T]) Outdated(key uopt.Opt[K]) bool {
+	if k := key.Get(); k != nil {
+		return c.cache.Outdated(uopt.Of(c.wrap(*k)))
+	}
+
+	return c.cache.Outdated(uopt.Null[simpleKey[K]]())
+}
+
+// OutdatedKeys returns the keys that are outdated based on the set TTL. The operation is thread-safe.
+func (c *SimpleCache[K, T]) OutdatedKeys() []K {
+	return unwrapKeys(outdatedKeysOf[simpleKey[K]](c.cache))
+}
+
+// Close closes the underlying cache.
+func (c *SimpleCache[K, T]) Close() error {
+	return closeCache(c.cache)
+}
+
+func (c *SimpleCache[K, T]) wrap(key K) simpleKey[K] {
+	return simpleKey[K]{key: key, hash: uhash.Comparable(c.seed, key)}
+}
+
+// simpleKey adapts a comparable key to the uconst.Unique interface with a precomputed hash.
+type simpleKey[K comparable] struct {
+	key  K
+	hash int64
+}
+
+func (k simpleKey[K]) Key() int64 {
+	return k.hash
+}
+
+func (k simpleKey[K]) Equals(other uconst.Comparable) bool {
+	switch o := other.(type) {
+	case simpleKey[K]:
+		return k.key == o.key
+	case *simpleKey[K]:
+		if o == nil {
+			return false
+		}
+		return k.key == o.key
+	default:
+		return false
+	}
+}
+
+func unwrapKeys[K comparable](keys []simpleKey[K]) []K {
+	result := make([]K, len(keys))
+	for i, key := range keys {
+		result[i] = key.key
+	}
+
+	return result
+}
@@ -0,0 +1,93 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package ucache_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kordax/basic-utils/ucache"
+	"github.com/kordax/basic-utils/uopt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type simpleCacheKey struct {
+	Tenant string
+	ID     int
+	Score  float64
+	Meta   any
+}
+
+func TestSimpleCache_StringKeys(t *testing.T) {
+	c := ucache.NewSimpleCache[string, int](uopt.Null[time.Duration]())
+
+	c.Set("one", 1)
+	c.Set("two", 2)
+	c.Set("one", 11)
+
+	v, ok := c.Get("one")
+	require.True(t, ok)
+	assert.Equal(t, 11, *v)
+	v, ok = c.Get("two")
+	require.True(t, ok)
+	assert.Equal(t, 2, *v)
+	_, ok = c.Get("three")
+	assert.False(t, ok)
+
+	assert.ElementsMatch(t, []string{"one", "two"}, c.Changes())
+
+	c.DropKey("one")
+	_, ok = c.Get("one")
+	assert.False(t, ok)
+	assert.ElementsMatch(t, []string{"two"}, c.Changes())
+
+	c.Drop()
+	_, ok = c.Get("two")
+	assert.False(t, ok)
+}
+
+func TestSimpleCache_StructKeys(t *testing.T) {
+	c := ucache.NewSimpleCache[simpleCacheKey, string](uopt.Null[time.Duration]())
+
+	k1 := simpleCacheKey{Tenant: "a", ID: 1, Score: 0, Meta: "x"}
+	k2 := simpleCacheKey{Tenant: "a", ID: 2, Score: 0.5, Meta: 7}
+	c.Set(k1, "first")
+	c.Set(k2, "second")
+
+	v, ok := c.Get(simpleCacheKey{Tenant: "a", ID: 1, Score: 0, Meta: "x"})
+	require.True(t, ok)
+	assert.Equal(t, "first", *v)
+
+	v, ok = c.Get(simpleCacheKey{Tenant: "a", ID: 1, Score: negativeZero(), Meta: "x"})
+	require.True(t, ok, "-0 and +0 are equal keys")
+	assert.Equal(t, "first", *v)
+
+	_, ok = c.Get(simpleCacheKey{Tenant: "a", ID: 2, Score: 0.5, Meta: int64(7)})
+	assert.False(t, ok, "interface values of different types must not match")
+
+	c.SetQuietly(simpleCacheKey{Tenant: "b"}, "quiet")
+	v, ok = c.Get(simpleCacheKey{Tenant: "b"})
+	require.True(t, ok)
+	assert.Equal(t, "quiet", *v)
+	assert.ElementsMatch(t, []simpleCacheKey{k1, k2}, c.Changes())
+}
+
+func TestSimpleCache_Outdated(t *testing.T) {
+	c := ucache.NewSimpleCache[int, int](uopt.Of(10 * time.Millisecond))
+	c.Set(1, 1)
+
+	assert.False(t, c.Outdated(uopt.Of(1)))
+	assert.True(t, c.Outdated(uopt.Of(2)))
+	time.Sleep(20 * time.Millisecond)
+	assert.True(t, c.Outdated(uopt.Of(1)))
+}
+
+func negativeZero() float64 {
+	zero := 0.0
+	return -zero
+}
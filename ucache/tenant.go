@@ -0,0 +1,486 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package ucache
+
+import (
+	"container/list"
+	"errors"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"github.com/kordax/basic-utils/uopt"
+)
+
+// TenantQuota limits the entries stored by a tenant of a TenantCache. Zero fields mean no limit.
+type TenantQuota struct {
+	MaxEntries int   // MaxEntries is the maximum number of entries.
+	MaxBytes   int64 // MaxBytes is the maximum total size of the entries measured by the sizer of the TenantCache.
+}
+
+// TenantStats holds the usage and the counters of a tenant of a TenantCache.
+type TenantStats struct {
+	Entries    int   // Entries is the number of stored entries.
+	Bytes      int64 // Bytes is the total size of the stored entries, zero without a sizer.
+	Hits       int64 // Hits is the number of Get calls that found the key.
+	Misses     int64 // Misses is the number of Get calls that didn't find the key.
+	Evictions  int64 // Evictions is the number of entries removed to fit the quota.
+	Rejections int64 // Rejections is the number of values not stored because they exceed MaxBytes on their own.
+}
+
+type tenantOptions struct {
+	quota TenantQuota
+}
+
+// TenantOption configures a TenantCache.
+type TenantOption func(o *tenantOptions)
+
+// WithTenantQuota sets the quota of the tenants that have no quota set with TenantCache.SetQuota.
+func WithTenantQuota(quota TenantQuota) TenantOption {
+	return func(o *tenantOptions) {
+		o.quota = quota
+	}
+}
+
+/*
+TenantCache partitions the entries of a process-wide cache between tenants, e.g. the customers of a SaaS service.
+Every tenant is a ComparableCache view backed by a separate cache created with the factory on the first write,
+so the keys, changes and TTL of the tenants are isolated and a whole tenant is dropped in O(1) with DropTenant.
+
+Tenants are limited by their quotas: when a write exceeds the maximum number of entries or bytes of the tenant,
+its least recently written entries are evicted, which is reported to the change history as ChangeDelete.
+The bytes are measured by the sizer passed to NewTenantCache, so MaxBytes is not enforced without it.
+
+Tenants keep track of their keys, so all the modifications should be made through the tenant views.
+To remove outdated entries wrap the tenant views, not the TenantCache, with ManagedCache.
+*/
+type TenantCache[K comparable, T any] struct {
+	factory func(tenant string) ComparableCache[K, T]
+	sizer   func(key K, value T) int64
+	options tenantOptions
+
+	mtx     sync.RWMutex
+	tenants map[string]*tenantState[K, T]
+	quotas  map[string]TenantQuota
+}
+
+type tenantState[K comparable, T any] struct {
+	mtx     sync.Mutex
+	cache   ComparableCache[K, T]
+	quota   TenantQuota
+	order   *list.List // *tenantEntry in the write order, the oldest first
+	entries map[K]*list.Element
+	bytes   int64
+	dropped bool
+
+	hits, misses, evictions, rejections atomic.Int64
+}
+
+type tenantEntry[K any] struct {
+	key  K
+	size int64
+}
+
+// NewTenantCache creates a new TenantCache creating the caches of the tenants with the factory.
+// The sizer measures the entries for TenantQuota.MaxBytes and may be nil if the bytes are not limited.
+func NewTenantCache[K comparable, T any](
+	factory func(tenant string) ComparableCache[K, T], sizer func(key K, value T) int64, opts ...TenantOption,
+) *TenantCache[K, T] {
+	c := &TenantCache[K, T]{
+		factory: factory,
+		sizer:   sizer,
+		tenants: make(map[string]*tenantState[K, T]),
+		quotas:  make(map[string]TenantQuota),
+	}
+	for _, opt := range opts {
+		opt(&c.options)
+	}
+
+	return c
+}
+
+// Tenant returns a view of the cache scoped to the tenant. Views of the same tenant share their state.
+// Close of the view is a no-op, use DropTenant to release the tenant.
+func (c *TenantCache[K, T]) Tenant(id string) ComparableCache[K, T] {
+	return &tenantView[K, T]{parent: c, id: id}
+}
+
+// Tenants returns the sorted ids of the tenants that have been written to since their creation or drop.
+// The operation is thread-safe.
+func (c *TenantCache[K, T]) Tenants() []string {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+
+	result := make([]string, 0, len(c.tenants))
+	for id := range c.tenants {
+		result = append(result, id)
+	}
+	sort.Strings(result)
+
+	return result
+}
+
+// SetQuota sets the quota of the tenant, evicting its entries if they don't fit the new one.
+// The quota persists when the tenant is dropped. The operation is thread-safe.
+func (c *TenantCache[K, T]) SetQuota(id string, quota TenantQuota) {
+	c.mtx.Lock()
+	c.quotas[id] = quota
+	state, ok := c.tenants[id]
+	c.mtx.Unlock()
+	if !ok {
+		return
+	}
+
+	state.mtx.Lock()
+	defer state.mtx.Unlock()
+	state.quota = quota
+	state.evict()
+}
+
+// Quota returns the quota of the tenant, which is the one set with WithTenantQuota unless replaced with SetQuota.
+// The operation is thread-safe.
+func (c *TenantCache[K, T]) Quota(id string) TenantQuota {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+
+	return c.quota(id)
+}
+
+// Stats returns the usage and the counters of the tenant, which are reset when the tenant is dropped.
+// The operation is thread-safe.
+func (c *TenantCache[K, T]) Stats(id string) TenantStats {
+	state := c.lookup(id)
+	if state == nil {
+		return TenantStats{}
+	}
+
+	state.mtx.Lock()
+	defer state.mtx.Unlock()
+
+	return TenantStats{
+		Entries:    len(state.entries),
+		Bytes:      state.bytes,
+		Hits:       state.hits.Load(),
+		Misses:     state.misses.Load(),
+		Evictions:  state.evictions.Load(),
+		Rejections: state.rejections.Load(),
+	}
+}
+
+// DropTenant removes the tenant with all its entries, changes and stats in O(1) and closes its cache.
+// The views of the tenant remain usable and start over with a new cache. The operation is thread-safe.
+func (c *TenantCache[K, T]) DropTenant(id string) error {
+	c.mtx.Lock()
+	state, ok := c.tenants[id]
+	delete(c.tenants, id)
+	c.mtx.Unlock()
+	if !ok {
+		return nil
+	}
+
+	return state.close()
+}
+
+// Drop removes all the tenants and closes their caches. The operation is thread-safe.
+func (c *TenantCache[K, T]) Drop() {
+	_ = c.drop()
+}
+
+// Close removes all the tenants and closes their caches, returning the joined errors of the caches.
+// The cache remains usable after Close.
+func (c *TenantCache[K, T]) Close() error {
+	return c.drop()
+}
+
+func (c *TenantCache[K, T]) drop() error {
+	c.mtx.Lock()
+	tenants := c.tenants
+	c.tenants = make(map[string]*tenantState[K, T])
+	c.mtx.Unlock()
+
+	var errs []error
+	for _, state := range tenants {
+		errs = append(errs, state.close())
+	}
+
+	return errors.Join(errs...)
+}
+
+func (c *TenantCache[K, T]) quota(id string) TenantQuota {
+	if quota, ok := c.quotas[id]; ok {
+		return quota
+	}
+
+	return c.options.quota
+}
+
+func (c *TenantCache[K, T]) lookup(id string) *tenantState[K, T] {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+
+	return c.tenants[id]
+}
+
+func (c *TenantCache[K, T]) getOrCreate(id string) *tenantState[K, T] {
+	if state := c.lookup(id); state != nil {
+		return state
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	state, ok := c.tenants[id]
+	if !ok {
+		state = &tenantState[K, T]{
+			cache:   c.factory(id),
+			quota:   c.quota(id),
+			order:   list.New(),
+			entries: make(map[K]*list.Element),
+		}
+		c.tenants[id] = state
+	}
+
+	return state
+}
+
+// write runs fn on the locked state of the tenant, creating it if needed.
+// A state dropped concurrently is replaced, so the write is never lost in a closed cache.
+func (c *TenantCache[K, T]) write(id string, fn func(state *tenantState[K, T])) {
+	for {
+		state := c.getOrCreate(id)
+		state.mtx.Lock()
+		if !state.dropped {
+			fn(state)
+			state.mtx.Unlock()
+			return
+		}
+		state.mtx.Unlock()
+	}
+}
+
+// read runs fn on the locked state of the tenant if it exists.
+func (c *TenantCache[K, T]) read(id string, fn func(state *tenantState[K, T])) {
+	state := c.lookup(id)
+	if state == nil {
+		return
+	}
+
+	state.mtx.Lock()
+	defer state.mtx.Unlock()
+	if !state.dropped {
+		fn(state)
+	}
+}
+
+func (c *TenantCache[K, T]) size(key K, value T) int64 {
+	if c.sizer == nil {
+		return 0
+	}
+
+	return max(c.sizer(key, value), 0)
+}
+
+func (s *tenantState[K, T]) close() error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.dropped = true
+
+	return closeCache(s.cache)
+}
+
+// put accounts the written entry. Returns false if the entry doesn't fit the quota on its own.
+func (s *tenantState[K, T]) put(key K, size int64) bool {
+	if s.quota.MaxBytes > 0 && size > s.quota.MaxBytes {
+		return false
+	}
+	if elem, ok := s.entries[key]; ok {
+		entry := elem.Value.(*tenantEntry[K])
+		s.bytes += size - entry.size
+		entry.size = size
+		s.order.MoveToBack(elem)
+	} else {
+		s.entries[key] = s.order.PushBack(&tenantEntry[K]{key: key, size: size})
+		s.bytes += size
+	}
+
+	return true
+}
+
+func (s *tenantState[K, T]) forget(key K) {
+	if elem, ok := s.entries[key]; ok {
+		s.bytes -= elem.Value.(*tenantEntry[K]).size
+		s.order.Remove(elem)
+		delete(s.entries, key)
+	}
+}
+
+func (s *tenantState[K, T]) reset() {
+	s.order.Init()
+	s.entries = make(map[K]*list.Element)
+	s.bytes = 0
+}
+
+// evict removes the oldest entries until the tenant fits its quota.
+// The last written entry is never evicted, since put rejects the entries exceeding the quota on their own.
+func (s *tenantState[K, T]) evict() {
+	for s.exceeds() && s.order.Len() > 0 {
+		key := s.order.Front().Value.(*tenantEntry[K]).key
+		s.cache.DropKey(key)
+		s.forget(key)
+		s.evictions.Add(1)
+	}
+}
+
+func (s *tenantState[K, T]) exceeds() bool {
+	return (s.quota.MaxEntries > 0 && len(s.entries) > s.quota.MaxEntries) ||
+		(s.quota.MaxBytes > 0 && s.bytes > s.quota.MaxBytes)
+}
+
+type tenantView[K comparable, T any] struct {
+	parent *TenantCache[K, T]
+	id     string
+}
+
+func (v *tenantView[K, T]) Set(key K, value T) {
+	v.set(key, value, false)
+}
+
+func (v *tenantView[K, T]) SetQuietly(key K, value T) {
+	v.set(key, value, true)
+}
+
+func (v *tenantView[K, T]) set(key K, value T, quietly bool) {
+	size := v.parent.size(key, value)
+	v.parent.write(v.id, func(state *tenantState[K, T]) {
+		if !state.put(key, size) {
+			state.rejections.Add(1)
+			if _, ok := state.entries[key]; ok {
+				state.cache.DropKey(key) // the stored value is outdated by the rejected one
+				state.forget(key)
+			}
+			return
+		}
+		if quietly {
+			state.cache.SetQuietly(key, value)
+		} else {
+			state.cache.Set(key, value)
+		}
+		state.evict()
+	})
+}
+
+func (v *tenantView[K, T]) Get(key K) (*T, bool) {
+	state := v.parent.lookup(v.id)
+	if state == nil {
+		return nil, false
+	}
+
+	value, ok := state.cache.Get(key)
+	if ok {
+		state.hits.Add(1)
+	} else {
+		state.misses.Add(1)
+	}
+
+	return value, ok
+}
+
+func (v *tenantView[K, T]) ChangeLog() []ChangeEvent[K] {
+	result := make([]ChangeEvent[K], 0)
+	v.parent.read(v.id, func(state *tenantState[K, T]) {
+		result = changeLogOf[K](state.cache)
+	})
+
+	return result
+}
+
+func (v *tenantView[K, T]) Changes() []K {
+	result := make([]K, 0)
+	v.parent.read(v.id, func(state *tenantState[K, T]) {
+		result = state.cache.Changes()
+	})
+
+	return result
+}
+
+func (v *tenantView[K, T]) ChangesCount() int {
+	var result int
+	v.parent.read(v.id, func(state *tenantState[K, T]) {
+		result = changesCount(state.cache)
+	})
+
+	return result
+}
+
+func (v *tenantView[K, T]) ResetChanges() []K {
+	result := make([]K, 0)
+	v.parent.read(v.id, func(state *tenantState[K, T]) {
+		result = resetChanges[K](state.cache)
+	})
+
+	return result
+}
+
+// Drop clears the tenant, keeping its cache and stats, unlike TenantCache.DropTenant.
+func (v *tenantView[K, T]) Drop() {
+	v.parent.read(v.id, func(state *tenantState[K, T]) {
+		state.cache.Drop()
+		state.reset()
+	})
+}
+
+func (v *tenantView[K, T]) DropKey(key K) {
+	v.parent.read(v.id, func(state *tenantState[K, T]) {
+		state.cache.DropKey(key)
+		state.forget(key)
+	})
+}
+
+func (v *tenantView[K, T]) expireKeys(keys []K) {
+	v.parent.read(v.id, func(state *tenantState[K, T]) {
+		expireKeys(state.cache, keys)
+		for _, key := range keys {
+			state.forget(key)
+		}
+	})
+}
+
+func (v *tenantView[K, T]) expireOutdated() []K {
+	result := make([]K, 0)
+	v.parent.read(v.id, func(state *tenantState[K, T]) {
+		result = expireOutdated(state.cache)
+		for _, key := range result {
+			state.forget(key)
+		}
+	})
+
+	return result
+}
+
+// Outdated checks the key or the whole tenant in the cache of the tenant.
+// A tenant without a cache, i.e. never written to or dropped, is reported as outdated.
+func (v *tenantView[K, T]) Outdated(key uopt.Opt[K]) bool {
+	state := v.parent.lookup(v.id)
+	if state == nil {
+		return true
+	}
+
+	return state.cache.Outdated(key)
+}
+
+func (v *tenantView[K, T]) OutdatedKeys() []K {
+	state := v.parent.lookup(v.id)
+	if state == nil {
+		return make([]K, 0)
+	}
+
+	return outdatedKeysOf[K](state.cache)
+}
+
+// Close is a no-op, since the tenant is owned by the TenantCache, see TenantCache.DropTenant.
+func (v *tenantView[K, T]) Close() error {
+	return nil
+}
@@ -0,0 +1,213 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package ucache_test
+
+import (
+	"errors"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kordax/basic-utils/ucache"
+	"github.com/kordax/basic-utils/uopt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTenantCache(ttl uopt.Opt[time.Duration], opts ...ucache.TenantOption) *ucache.TenantCache[string, string] {
+	return ucache.NewTenantCache(func(string) ucache.ComparableCache[string, string] {
+		return ucache.NewInMemoryComparableMapCache[string, string](ttl)
+	}, func(key string, value string) int64 {
+		return int64(len(key) + len(value))
+	}, opts...)
+}
+
+func TestTenantCache_Isolation(t *testing.T) {
+	c := newTenantCache(uopt.Null[time.Duration]())
+	acme := c.Tenant("acme")
+	globex := c.Tenant("globex")
+
+	acme.Set("k", "acme")
+	globex.Set("k", "globex")
+	globex.SetQuietly("q", "quiet")
+
+	value, ok := acme.Get("k")
+	require.True(t, ok)
+	assert.Equal(t, "acme", *value)
+	value, ok = c.Tenant("globex").Get("k")
+	require.True(t, ok, "views of the same tenant must share the state")
+	assert.Equal(t, "globex", *value)
+	_, ok = acme.Get("q")
+	assert.False(t, ok)
+	_, ok = c.Tenant("initech").Get("k")
+	assert.False(t, ok)
+
+	assert.Equal(t, []string{"k"}, acme.Changes())
+	assert.Equal(t, []string{"k"}, globex.(ucache.ChangesResetter[string]).ResetChanges())
+	assert.Equal(t, []string{"k"}, acme.Changes(), "ResetChanges must not affect other tenants")
+	assert.Equal(t, []string{"acme", "globex"}, c.Tenants())
+
+	acme.Drop()
+	_, ok = acme.Get("k")
+	assert.False(t, ok)
+	_, ok = globex.Get("k")
+	assert.True(t, ok, "Drop must not affect other tenants")
+	assert.Equal(t, ucache.TenantStats{Hits: 1, Misses: 2}, c.Stats("acme"))
+
+	globex.DropKey("q")
+	assert.Equal(t, ucache.TenantStats{Entries: 1, Bytes: 7, Hits: 2}, c.Stats("globex"))
+	require.NoError(t, acme.(io.Closer).Close())
+	_, ok = globex.Get("k")
+	assert.True(t, ok, "closing a view must not affect the tenant")
+}
+
+func TestTenantCache_MaxEntries(t *testing.T) {
+	c := newTenantCache(uopt.Null[time.Duration](), ucache.WithTenantQuota(ucache.TenantQuota{MaxEntries: 2}))
+	acme := c.Tenant("acme")
+	acme.Set("a", "1")
+	acme.Set("b", "2")
+	acme.Set("a", "3") // rewriting makes "a" the most recent entry
+	acme.Set("c", "4")
+
+	_, ok := acme.Get("b")
+	assert.False(t, ok, "the least recently written entry must be evicted")
+	value, ok := acme.Get("a")
+	require.True(t, ok)
+	assert.Equal(t, "3", *value)
+	_, ok = acme.Get("c")
+	assert.True(t, ok)
+
+	stats := c.Stats("acme")
+	assert.Equal(t, 2, stats.Entries)
+	assert.Equal(t, int64(1), stats.Evictions)
+	changes := acme.(ucache.ChangeLogReader[string]).ChangeLog()
+	require.Len(t, changes, 3)
+	assert.Equal(t, "b", changes[2].Key)
+	assert.Equal(t, ucache.ChangeDelete, changes[2].Kind, "the eviction must be reported to the change history")
+
+	c.Tenant("globex").Set("x", "1")
+	c.Tenant("globex").Set("y", "2")
+	c.Tenant("globex").Set("z", "3")
+	assert.Equal(t, 2, c.Stats("globex").Entries, "the default quota must apply to every tenant")
+}
+
+func TestTenantCache_MaxBytes(t *testing.T) {
+	c := newTenantCache(uopt.Null[time.Duration]())
+	c.SetQuota("acme", ucache.TenantQuota{MaxBytes: 10})
+	assert.Equal(t, ucache.TenantQuota{MaxBytes: 10}, c.Quota("acme"))
+	assert.Equal(t, ucache.TenantQuota{}, c.Quota("globex"))
+	acme := c.Tenant("acme")
+
+	acme.Set("a", "1234") // 5 bytes
+	acme.Set("b", "1234") // 10 bytes
+	assert.Equal(t, int64(10), c.Stats("acme").Bytes)
+	acme.Set("c", "12") // 13 bytes, "a" must be evicted
+	_, ok := acme.Get("a")
+	assert.False(t, ok)
+	assert.Equal(t, int64(8), c.Stats("acme").Bytes)
+
+	acme.Set("b", "too large value")
+	_, ok = acme.Get("b")
+	assert.False(t, ok, "a value exceeding the quota must not be stored and must drop the previous one")
+	stats := c.Stats("acme")
+	assert.Equal(t, ucache.TenantStats{Entries: 1, Bytes: 3, Misses: 2, Evictions: 1, Rejections: 1}, stats)
+
+	acme.Set("a", "1234")
+	c.SetQuota("acme", ucache.TenantQuota{MaxEntries: 1})
+	assert.Equal(t, 1, c.Stats("acme").Entries, "a new quota must be enforced immediately")
+	_, ok = acme.Get("a")
+	assert.True(t, ok)
+}
+
+func TestTenantCache_DropTenant(t *testing.T) {
+	c := newTenantCache(uopt.Null[time.Duration]())
+	acme := c.Tenant("acme")
+	acme.Set("a", "1")
+	acme.Get("a")
+	c.Tenant("globex").Set("a", "1")
+
+	require.NoError(t, c.DropTenant("acme"))
+	require.NoError(t, c.DropTenant("unknown"))
+	assert.Equal(t, []string{"globex"}, c.Tenants())
+	assert.Equal(t, ucache.TenantStats{}, c.Stats("acme"))
+	assert.Empty(t, acme.Changes())
+	assert.True(t, acme.Outdated(uopt.Null[string]()))
+	assert.Empty(t, acme.(ucache.OutdatedKeysLister[string]).OutdatedKeys())
+	_, ok := acme.Get("a")
+	assert.False(t, ok)
+
+	acme.Set("b", "2")
+	_, ok = acme.Get("b")
+	assert.True(t, ok, "a dropped tenant must start over")
+	assert.Equal(t, []string{"b"}, acme.Changes())
+
+	c.Drop()
+	assert.Empty(t, c.Tenants())
+	_, ok = c.Tenant("globex").Get("a")
+	assert.False(t, ok)
+}
+
+type failingCloseCache struct {
+	ucache.ComparableCache[string, string]
+}
+
+func (failingCloseCache) Close() error {
+	return errors.New("close failed")
+}
+
+func TestTenantCache_Close(t *testing.T) {
+	c := ucache.NewTenantCache(func(string) ucache.ComparableCache[string, string] {
+		return failingCloseCache{ucache.NewInMemoryComparableMapCache[string, string](uopt.Null[time.Duration]())}
+	}, nil)
+	c.Tenant("acme").Set("a", "1")
+	c.Tenant("globex").Set("a", "1")
+
+	assert.EqualError(t, c.DropTenant("acme"), "close failed")
+	err := c.Close()
+	assert.EqualError(t, err, "close failed")
+	assert.Empty(t, c.Tenants())
+	assert.NoError(t, c.Close())
+}
+
+func TestTenantCache_Managed(t *testing.T) {
+	c := newTenantCache(uopt.Of(time.Millisecond), ucache.WithTenantQuota(ucache.TenantQuota{MaxEntries: 10}))
+	acme := c.Tenant("acme")
+	managed := ucache.NewManagedCache[string, string](acme, time.Millisecond)
+	defer managed.Stop()
+
+	managed.Set("a", "1")
+	require.Eventually(t, func() bool {
+		return c.Stats("acme").Entries == 0
+	}, time.Second, time.Millisecond, "expired entries must not count against the quota")
+	assert.Equal(t, ucache.ChangeExpire, acme.(ucache.ChangeLogReader[string]).ChangeLog()[0].Kind)
+}
+
+func TestTenantCache_Concurrency(t *testing.T) {
+	c := newTenantCache(uopt.Null[time.Duration](), ucache.WithTenantQuota(ucache.TenantQuota{MaxEntries: 5}))
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			tenant := c.Tenant([]string{"acme", "globex"}[i%2])
+			for j := range 200 {
+				key := string(rune('a' + j%10))
+				tenant.Set(key, key)
+				tenant.Get(key)
+				if j%50 == 0 {
+					_ = c.DropTenant("acme")
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	for _, id := range c.Tenants() {
+		assert.LessOrEqual(t, c.Stats(id).Entries, 5)
+	}
+}
@@ -0,0 +1,131 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package ucache
+
+import (
+	"context"
+	"fmt"
+)
+
+// Span is the subset of an OpenTelemetry trace.Span used by TracingHooks.
+type Span interface {
+	// SetAttribute sets the attribute of the span, the value is a string, bool, int64 or float64.
+	SetAttribute(key string, value any)
+	// End completes the span.
+	End()
+}
+
+/*
+Tracer is the subset of an OpenTelemetry trace.Tracer used by TracingHooks, so the package doesn't depend on the
+OpenTelemetry SDK. An adapter takes a few lines:
+
+	type otelTracer struct{ tracer trace.Tracer }
+
+	func (t otelTracer) Start(ctx context.Context, name string) (context.Context, ucache.Span) {
+		ctx, span := t.tracer.Start(ctx, name, trace.WithSpanKind(trace.SpanKindInternal))
+		return ctx, otelSpan{span}
+	}
+
+	type otelSpan struct{ trace.Span }
+
+	func (s otelSpan) SetAttribute(key string, value any) {
+		switch v := value.(type) {
+		case bool:
+			s.SetAttributes(attribute.Bool(key, v))
+		case int64:
+			s.SetAttributes(attribute.Int64(key, v))
+		case float64:
+			s.SetAttributes(attribute.Float64(key, v))
+		default:
+			s.SetAttributes(attribute.String(key, fmt.Sprint(v)))
+		}
+	}
+
+	func (s otelSpan) End() { s.Span.End() }
+*/
+type Tracer interface {
+	// Start creates a span named name as a child of the span in ctx, if any.
+	Start(ctx context.Context, name string) (context.Context, Span)
+}
+
+// Tracing span names and attributes set by TracingHooks.
+const (
+	SpanGet = "ucache.get"
+	SpanSet = "ucache.set"
+
+	AttrCacheName  = "cache.name"
+	AttrCacheKey   = "cache.key"
+	AttrCacheHit   = "cache.hit"
+	AttrDurationNs = "cache.duration_ns"
+)
+
+type tracingOptions struct {
+	recordKeys bool
+}
+
+// TracingOption configures TracingHooks.
+type TracingOption func(o *tracingOptions)
+
+// WithKeyAttribute records the keys formatted with fmt.Sprint as the AttrCacheKey span attribute.
+// Keys are not recorded by default, since they may be sensitive or have a high cardinality.
+func WithKeyAttribute() TracingOption {
+	return func(o *tracingOptions) {
+		o.recordKeys = true
+	}
+}
+
+/*
+TracingHooks returns the Hooks creating a span per Get and Set of an InstrumentedCache, so the cache latency and
+hit-rate appear in the distributed traces. The spans are named SpanGet and SpanSet and have the attributes:
+  - AttrCacheName: the cacheName, unless empty;
+  - AttrCacheHit: whether Get found the key;
+  - AttrDurationNs: the duration of the operation in nanoseconds, excluding the tracing overhead;
+  - AttrCacheKey: the key, only with WithKeyAttribute.
+
+The spans are children of the span in the context passed to GetCtx or SetCtx.
+*/
+func TracingHooks[K any](tracer Tracer, cacheName string, opts ...TracingOption) Hooks[K] {
+	var options tracingOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	start := func(name string) func(op *Operation[K]) {
+		return func(op *Operation[K]) {
+			ctx := op.Context
+			if ctx == nil {
+				ctx = context.Background()
+			}
+			_, span := tracer.Start(ctx, name)
+			if cacheName != "" {
+				span.SetAttribute(AttrCacheName, cacheName)
+			}
+			if options.recordKeys {
+				span.SetAttribute(AttrCacheKey, fmt.Sprint(op.Key))
+			}
+			op.Data = span
+		}
+	}
+	end := func(op *Operation[K]) {
+		span, ok := op.Data.(Span)
+		if !ok {
+			return
+		}
+		if op.Kind == OperationGet {
+			span.SetAttribute(AttrCacheHit, op.Hit)
+		}
+		span.SetAttribute(AttrDurationNs, op.Elapsed.Nanoseconds())
+		span.End()
+	}
+
+	return Hooks[K]{
+		BeforeGet: start(SpanGet),
+		AfterGet:  end,
+		BeforeSet: start(SpanSet),
+		AfterSet:  end,
+	}
+}
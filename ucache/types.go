@@ -18,13 +18,74 @@ type UIntKey uint64
 type IntKey int64
 type StringKey string
 
-type keyContainer[K any] struct {
-	key       K
-	updatedAt time.Time
+// keyContainer holds a written key along with its fingerprint: the hashes identifying the entry computed on write.
+// Caches locate the entries by fingerprints rather than by the keys, since the keys may be mutated by the callers
+// after the write, e.g. by changing a slice the key was built from, and would no longer lead to their entries.
+type keyContainer[K any, F any] struct {
+	key         K
+	fingerprint F
+	updatedAt   time.Time
+}
+
+func outdatedKeys[H comparable, K any, F any](lastUpdatedKeys map[H]keyContainer[K, F], ttl *time.Duration) []K {
+	result := make([]K, 0)
+	if ttl == nil {
+		return result
+	}
+	for _, lu := range lastUpdatedKeys {
+		if time.Since(lu.updatedAt) > *ttl {
+			result = append(result, lu.key)
+		}
+	}
+
+	return result
+}
+
+// removeOutdated deletes the outdated containers and calls remove for each of them, so the cache can drop the entry.
+func removeOutdated[H comparable, K any, F any](lastUpdatedKeys map[H]keyContainer[K, F], ttl *time.Duration, remove func(id H, lu keyContainer[K, F])) []K {
+	result := make([]K, 0)
+	if ttl == nil {
+		return result
+	}
+	for id, lu := range lastUpdatedKeys {
+		if time.Since(lu.updatedAt) > *ttl {
+			delete(lastUpdatedKeys, id)
+			remove(id, lu)
+			result = append(result, lu.key)
+		}
+	}
+
+	return result
+}
+
+// freezeKeys copies the hashes of the keys, so the copy is not affected if the keys are mutated later.
+func freezeKeys(keys []uconst.Unique) []uconst.Unique {
+	frozen := make([]uconst.Unique, len(keys))
+	for i, key := range keys {
+		frozen[i] = IntKey(key.Key())
+	}
+
+	return frozen
+}
+
+// shrinkMap moves the entries to a new map if most of them were removed since the peak, as maps never shrink.
+// It returns the map to use and its new peak, so the cost of copying is amortized over the removals.
+func shrinkMap[H comparable, V any](m map[H]V, peak int) (map[H]V, int) {
+	if len(m) >= peak/4 {
+		return m, max(peak, len(m))
+	}
+	shrunk := make(map[H]V, len(m))
+	for k, v := range m {
+		shrunk[k] = v
+	}
+
+	return shrunk, len(m)
 }
 
 /*
 CompositeKey specifies an abstract key with an ability to provide an ordered list of available keys.
+Caches fingerprint the keys on write, so mutating a key after passing it to a cache doesn't corrupt the cache,
+however the keys reported by the cache, e.g. by Changes or OutdatedKeys, are the instances passed by the caller.
 */
 type CompositeKey interface {
 	uconst.Comparable
@@ -44,3 +44,33 @@ func BenchmarkFarmHash64Entity(b *testing.B) {
 		cache.Get(keys[i%numItems])
 	}
 }
+
+func BenchmarkBuiltKeyGet(b *testing.B) {
+	cache := ucache.NewFarmHashMapMultiCache[ucache.BuiltKey, ucache.Int64Value](uopt.Null[time.Duration]())
+	keys := make([]ucache.BuiltKey, 1000)
+	for i := range keys {
+		keys[i] = ucache.Key().Str("user").Int(int64(i)).Build()
+		cache.Put(keys[i], ucache.NewInt64Value(int64(i)))
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		cache.Get(keys[i%len(keys)])
+	}
+}
+
+func BenchmarkGenericCompositeKeyGet(b *testing.B) {
+	cache := ucache.NewFarmHashMapMultiCache[ucache.GenericCompositeKey, ucache.Int64Value](uopt.Null[time.Duration]())
+	keys := make([]ucache.GenericCompositeKey, 1000)
+	for i := range keys {
+		keys[i] = ucache.NewGenericCompositeKey("user", int64(i))
+		cache.Put(keys[i], ucache.NewInt64Value(int64(i)))
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		cache.Get(keys[i%len(keys)])
+	}
+}
@@ -7,15 +7,18 @@
 package ucache
 
 import (
+	"io"
+	"slices"
 	"sync"
 	"time"
 
 	"github.com/kordax/basic-utils/uconst"
-	"github.com/kordax/basic-utils/umap"
 	"github.com/kordax/basic-utils/uopt"
-	"github.com/kordax/basic-utils/uset"
 )
 
+// BaseCache is the minimal set of methods of a single-value cache.
+// The caches of this package also implement the optional ChangeLogReader, ChangesCounter, ChangesResetter,
+// OutdatedKeysLister and io.Closer interfaces, which the wrappers forward to the wrapped cache if it implements them.
 type BaseCache[K, T any] interface {
 	// Set updates the cache value for the provided key. If the key already exists,
 	// its previous value is removed before adding the new value. This method should be thread-safe.
@@ -23,26 +26,28 @@ type BaseCache[K, T any] interface {
 
 	// Get retrieves the value associated with the provided key from the cache.
 	// It returns the value and a boolean indicating whether the key was found.
-	// This method should be thread-safe. Get operation drops down change state of the item, meaning that item becomes
-	// actual after Get operation.
+	// This method should be thread-safe. Get never alters the change history.
 	Get(key K) (*T, bool)
 
-	// Changes returns a slice of keys that have been modified in the cache.
+	// Changes returns the keys whose latest change is ChangeSet, in the order they were set.
+	// It's a compatibility view of ChangeLogReader.ChangeLog: deleted, expired and dropped keys are not returned.
 	// This method provides a way to track changes made to the cache, useful for scenarios like cache syncing.
-	// Cache changes will be updated only on modifying operations, but not on Drop() call, meaning that in-fact, changes contain all the present keys.
+	// The returned slice is a copy owned by the caller and can be safely modified.
 	Changes() []K
 
 	// Drop completely clears the cache, removing all entries. This method should be thread-safe.
 	Drop()
 
 	// DropKey removes the value associated with the provided key from the cache. This method should be thread-safe.
-	// This method also clears up changes associated with this key.
+	// The key is reported as ChangeDelete, so it's no longer returned by Changes.
 	DropKey(key K)
 
 	// Outdated checks if the provided key or the entire cache (if no key is provided)
 	// is outdated based on the set TTL (time-to-live). Returns true if outdated, false otherwise.
 	// This method should be thread-safe.
-	// If key was not found returns false.
+	// If no TTL is set, nothing is ever outdated and false is returned.
+	// Otherwise, a key that was not found is considered outdated, and the entire cache is outdated
+	// if it wasn't updated within the TTL.
 	Outdated(key uopt.Opt[K]) bool
 
 	// SetQuietly is an optimized method adds a value to the cache for the provided key but does so without
@@ -64,9 +69,28 @@ type ComparableCache[K comparable, T any] interface {
 	BaseCache[K, T]
 }
 
+// closeCache closes the cache if it implements io.Closer.
+// Close releases the resources owned by the cache, e.g. stops its background goroutines and closes its change streams,
+// and closes the wrapped cache if there is one. Closing a cache more than once has no effect.
+func closeCache(cache any) error {
+	if c, ok := cache.(io.Closer); ok {
+		return c.Close()
+	}
+
+	return nil
+}
+
 type hashValueContainer[K uconst.Unique, T any] struct {
-	key   K
-	value T
+	key       K
+	value     T
+	updatedAt time.Time
+}
+
+// hashChangeID identifies a key in the change log of InMemoryHashMapCache. The hashes are not unique,
+// so the keys sharing a hash are told apart by Equals and get distinct slots.
+type hashChangeID struct {
+	hash int64
+	slot int
 }
 
 // InMemoryHashMapCache provides an in-memory caching mechanism using hashmaps for single-value entries.
@@ -77,12 +101,13 @@ type hashValueContainer[K uconst.Unique, T any] struct {
 // TTL parameter in cache doesn't automatically clean up all the entries.
 // Use ManagedCache wrapper to automatically manage outdated keys.
 type InMemoryHashMapCache[K uconst.Unique, T any] struct {
-	values  map[int64][]hashValueContainer[K, T]
-	changes map[int64]K
+	values      map[int64][]hashValueContainer[K, T] // the entries keep their update times, so colliding keys expire independently
+	changes     *changeLog[hashChangeID, K]
+	changeSlots map[int64]int // the number of change log slots of the hashes shared by several changed keys
 
-	lastUpdatedKeys map[int64]keyContainer[K]
-	lastUpdated     time.Time
-	ttl             *time.Duration
+	lastUpdated time.Time
+	ttl         *time.Duration
+	peak        int // the peak number of hashes, see shrinkMap
 
 	vMtx sync.Mutex
 }
@@ -92,9 +117,9 @@ type InMemoryHashMapCache[K uconst.Unique, T any] struct {
 // and an optional time-to-live duration for the cache entries.
 func NewInMemoryHashMapCache[K uconst.Unique, T any](ttl uopt.Opt[time.Duration]) Cache[K, T] {
 	c := &InMemoryHashMapCache[K, T]{
-		values:          make(map[int64][]hashValueContainer[K, T]),
-		changes:         make(map[int64]K),
-		lastUpdatedKeys: make(map[int64]keyContainer[K]),
+		values:      make(map[int64][]hashValueContainer[K, T]),
+		changes:     newChangeLog[hashChangeID, K](),
+		changeSlots: make(map[int64]int),
 	}
 	ttl.IfPresent(func(t time.Duration) {
 		c.ttl = &t
@@ -108,13 +133,8 @@ func NewInMemoryHashMapCache[K uconst.Unique, T any](ttl uopt.Opt[time.Duration]
 func (c *InMemoryHashMapCache[K, T]) Set(key K, value T) {
 	c.vMtx.Lock()
 	defer c.vMtx.Unlock()
-	c.put(key, value)
-	n := time.Now()
-	c.lastUpdatedKeys[key.Key()] = keyContainer[K]{
-		key:       key,
-		updatedAt: n,
-	}
-	c.lastUpdated = n
+	hash := c.addTran(key, value)
+	c.changes.record(c.changeID(hash, key), key, ChangeSet)
 }
 
 // SetQuietly is an optimized method that adds value to the cache for the provided key but does so without
@@ -124,12 +144,6 @@ func (c *InMemoryHashMapCache[K, T]) SetQuietly(key K, value T) {
 	c.vMtx.Lock()
 	defer c.vMtx.Unlock()
 	c.addTran(key, value)
-	n := time.Now()
-	c.lastUpdatedKeys[key.Key()] = keyContainer[K]{
-		key:       key,
-		updatedAt: n,
-	}
-	c.lastUpdated = n
 }
 
 // Get retrieves the value associated with the provided key from the cache.
@@ -138,30 +152,41 @@ func (c *InMemoryHashMapCache[K, T]) Get(key K) (*T, bool) {
 	c.vMtx.Lock()
 	defer c.vMtx.Unlock()
 
-	values, ok := c.values[key.Key()]
-	if !ok {
-		return nil, false
+	if v := c.find(key); v != nil {
+		return &v.value, true
 	}
 
-	if len(values) > 0 {
-		for _, v := range values {
-			if v.key.Equals(key) {
-				return &v.value, true
-			}
-		}
-
-		return nil, false
-	}
+	return nil, false
+}
 
-	return &values[0].value, ok
+// ChangeLog returns the latest change of every modified key in the order they were made. The operation is thread-safe.
+func (c *InMemoryHashMapCache[K, T]) ChangeLog() []ChangeEvent[K] {
+	c.vMtx.Lock()
+	defer c.vMtx.Unlock()
+	return c.changes.events()
 }
 
-// Changes returns a slice of keys that have been modified in the cache.
+// Changes returns the keys whose latest change is a Set, in the order they were set.
 // This method provides a way to track changes made to the cache, useful for scenarios like cache syncing.
 func (c *InMemoryHashMapCache[K, T]) Changes() []K {
 	c.vMtx.Lock()
 	defer c.vMtx.Unlock()
-	return umap.Values(c.changes)
+	return c.changes.keys()
+}
+
+// ChangesCount returns the number of modified keys. The operation is thread-safe.
+func (c *InMemoryHashMapCache[K, T]) ChangesCount() int {
+	c.vMtx.Lock()
+	defer c.vMtx.Unlock()
+	return c.changes.count()
+}
+
+// ResetChanges atomically returns the modified keys and clears the change history. The operation is thread-safe.
+func (c *InMemoryHashMapCache[K, T]) ResetChanges() []K {
+	c.vMtx.Lock()
+	defer c.vMtx.Unlock()
+	clear(c.changeSlots)
+	return c.changes.reset()
 }
 
 // Drop completely clears the cache, removing all entries. The operation is thread-safe.
@@ -169,18 +194,89 @@ func (c *InMemoryHashMapCache[K, T]) Drop() {
 	c.vMtx.Lock()
 	defer c.vMtx.Unlock()
 	c.dropAll()
-	c.changes = nil
-	c.lastUpdatedKeys = make(map[int64]keyContainer[K])
+	c.changes.recordClear()
+	clear(c.changeSlots)
 }
 
 // DropKey removes the value associated with the provided key from the cache. The operation is thread-safe.
 func (c *InMemoryHashMapCache[K, T]) DropKey(key K) {
 	c.vMtx.Lock()
 	defer c.vMtx.Unlock()
+	c.remove(key, ChangeDelete)
+}
+
+func (c *InMemoryHashMapCache[K, T]) expireKeys(keys []K) {
+	c.vMtx.Lock()
+	defer c.vMtx.Unlock()
+	for _, key := range keys {
+		c.remove(key, ChangeExpire)
+	}
+}
+
+// expireOutdated removes the outdated entries by the hashes computed on write.
+func (c *InMemoryHashMapCache[K, T]) expireOutdated() []K {
+	c.vMtx.Lock()
+	defer c.vMtx.Unlock()
+
+	keys := make([]K, 0)
+	if c.ttl == nil {
+		return keys
+	}
+	c.peak = max(c.peak, len(c.values)) // the removals are batched, so the peak has to be taken before them
+	for hash, values := range c.values {
+		kept := values[:0]
+		for _, v := range values {
+			if time.Since(v.updatedAt) <= *c.ttl {
+				kept = append(kept, v)
+				continue
+			}
+			keys = append(keys, v.key)
+			c.changes.record(c.changeID(hash, v.key), v.key, ChangeExpire)
+		}
+		clear(values[len(kept):])
+		if len(kept) == 0 {
+			delete(c.values, hash)
+		} else {
+			c.values[hash] = kept
+		}
+	}
+	c.shrink()
+
+	return keys
+}
+
+func (c *InMemoryHashMapCache[K, T]) remove(key K, kind ChangeKind) {
 	hash := key.Key()
-	c.dropKey(key.Key())
-	delete(c.changes, hash)
-	delete(c.lastUpdatedKeys, key.Key())
+	c.dropEntry(hash, key)
+	c.changes.record(c.changeID(hash, key), key, kind)
+	c.shrink()
+}
+
+func (c *InMemoryHashMapCache[K, T]) shrink() {
+	c.values, c.peak = shrinkMap(c.values, c.peak)
+}
+
+// changeID returns the change log slot of the key: the slot already holding a change of an equal key
+// or the first free one, which keeps the changes of the keys sharing the hash apart.
+func (c *InMemoryHashMapCache[K, T]) changeID(hash int64, key K) hashChangeID {
+	slots := max(c.changeSlots[hash], 1)
+	free := -1
+	for slot := 0; slot < slots; slot++ {
+		id := hashChangeID{hash: hash, slot: slot}
+		event, ok := c.changes.event(id)
+		if ok && event.Key.Equals(key) {
+			return id
+		}
+		if !ok && free < 0 {
+			free = slot
+		}
+	}
+	if free < 0 {
+		free = slots
+		c.changeSlots[hash] = slots + 1
+	}
+
+	return hashChangeID{hash: hash, slot: free}
 }
 
 // Outdated checks if the provided key or the entire cache (if no key is provided)
@@ -191,102 +287,144 @@ func (c *InMemoryHashMapCache[K, T]) Outdated(key uopt.Opt[K]) bool {
 
 	if c.ttl == nil {
 		return false
-	} else {
-		if key.Present() {
-			k := key.Get()
-			if lu, ok := c.lastUpdatedKeys[(*k).Key()]; ok {
-				return time.Since(lu.updatedAt) > *c.ttl
-			} else {
-				return true
+	}
+
+	if k := key.Get(); k != nil {
+		if v := c.find(*k); v != nil {
+			return time.Since(v.updatedAt) > *c.ttl
+		}
+		return true
+	}
+
+	return time.Since(c.lastUpdated) > *c.ttl
+}
+
+// OutdatedKeys returns the keys that are outdated based on the set TTL.
+// If no TTL is set returns an empty slice. The operation is thread-safe.
+func (c *InMemoryHashMapCache[K, T]) OutdatedKeys() []K {
+	c.vMtx.Lock()
+	defer c.vMtx.Unlock()
+
+	result := make([]K, 0)
+	if c.ttl == nil {
+		return result
+	}
+	for _, values := range c.values {
+		for _, v := range values {
+			if time.Since(v.updatedAt) > *c.ttl {
+				result = append(result, v.key)
 			}
-		} else {
-			return false
 		}
 	}
+
+	return result
+}
+
+// Close is a no-op, since the cache doesn't own any resources.
+func (c *InMemoryHashMapCache[K, T]) Close() error {
+	return nil
 }
 
 func (c *InMemoryHashMapCache[K, T]) dropAll() {
 	c.values = make(map[int64][]hashValueContainer[K, T])
 }
 
-func (c *InMemoryHashMapCache[K, T]) put(key K, value T) {
-	hash := c.addTran(key, value)
-	changes := len(c.changes) == 0
-	found := false
-	for _, diff := range c.changes {
-		if diff.Key() == key.Key() {
-			if !diff.Equals(key) {
-				changes = true
-				break
-			}
-			found = true
-			continue
+// find returns the entry equal to the key or nil if there is none.
+func (c *InMemoryHashMapCache[K, T]) find(key K) *hashValueContainer[K, T] {
+	values := c.values[key.Key()]
+	for i := range values {
+		if values[i].key.Equals(key) {
+			return &values[i]
 		}
 	}
-	if changes || !found {
-		c.changes[hash] = key
-	}
+
+	return nil
 }
 
 func (c *InMemoryHashMapCache[K, T]) addTran(key K, value T) int64 {
 	keyHash := key.Key()
-	values := c.values[keyHash]
-	if len(values) == 0 {
-		values = make([]hashValueContainer[K, T], 0)
-		values = append(values, hashValueContainer[K, T]{
-			key:   key,
-			value: value,
-		})
-		c.values[keyHash] = values
+	n := time.Now()
+	c.lastUpdated = n
+	container := hashValueContainer[K, T]{
+		key:       key,
+		value:     value,
+		updatedAt: n,
+	}
+	if v := c.find(key); v != nil {
+		*v = container
 	} else {
-		ind := -1
-		for i, v := range values {
-			if v.key.Equals(key) {
-				ind = i
-			}
-		}
-		if ind != -1 {
-			values[ind] = hashValueContainer[K, T]{
-				key:   key,
-				value: value,
-			}
-		} else {
-			values = append(values, hashValueContainer[K, T]{
-				key:   key,
-				value: value,
-			})
-			c.values[keyHash] = values
-		}
+		c.values[keyHash] = append(c.values[keyHash], container)
 	}
 
 	return keyHash
 }
 
-func (c *InMemoryHashMapCache[K, T]) dropKey(key int64) {
-	delete(c.values, key)
+// dropEntry removes only the entry equal to the key, so the other keys sharing the same hash stay intact.
+func (c *InMemoryHashMapCache[K, T]) dropEntry(hash int64, key K) {
+	values := c.values[hash]
+	for i, v := range values {
+		if v.key.Equals(key) {
+			values = slices.Delete(values, i, i+1)
+			break
+		}
+	}
+	if len(values) == 0 {
+		delete(c.values, hash)
+	} else {
+		c.values[hash] = values
+	}
 }
 
 // InMemoryComparableMapCache provides an in-memory caching mechanism using Go's native maps for single-value entries.
 // It supports optional TTL for entries and ensures concurrency-safe operations using a mutex.
 // It is very similiar to InMemoryHashMapCache by behaviour, and the only difference is a key type constraint.
+// Update times of the keys are kept in a min-heap, so OutdatedKeys visits only the expired keys instead of scanning
+// all the entries, which makes the ManagedCache cleanup cheap for large caches.
+// Besides the TTL, entries can expire after an idle timeout, see WithIdleTimeout.
 type InMemoryComparableMapCache[K comparable, T any] struct {
 	values  map[K]T
-	changes uset.Set[K]
+	changes *changeLog[K, K]
+
+	expiry      *expiryQueue[K]
+	lastUpdated time.Time
 
-	lastUpdatedKeys map[K]time.Time
-	lastUpdated     time.Time
+	access       *expiryQueue[K] // the last access times, tracked only if the idle timeout is set
+	lastAccessed time.Time
 
 	ttl  *time.Duration
+	idle *time.Duration
 	vMtx sync.Mutex
 }
 
+type cacheOptions struct {
+	idle *time.Duration
+}
+
+// CacheOption configures InMemoryComparableMapCache.
+type CacheOption func(o *cacheOptions)
+
+// WithIdleTimeout makes the entries outdated if they were neither read nor updated within the timeout,
+// independently of the TTL. This keeps caches with bursty access patterns small without shortening the TTL
+// of frequently used entries. Like with the TTL, outdated entries are removed by ManagedCache.
+func WithIdleTimeout(timeout time.Duration) CacheOption {
+	return func(o *cacheOptions) {
+		o.idle = &timeout
+	}
+}
+
 // NewInMemoryComparableMapCache creates a new instance of InMemoryComparableMapCache.
 // It accepts an optional TTL (time-to-live) duration for cache entries.
-func NewInMemoryComparableMapCache[K comparable, T any](ttl uopt.Opt[time.Duration]) ComparableCache[K, T] {
+func NewInMemoryComparableMapCache[K comparable, T any](ttl uopt.Opt[time.Duration], opts ...CacheOption) ComparableCache[K, T] {
+	var o cacheOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
 	c := &InMemoryComparableMapCache[K, T]{
-		values:          make(map[K]T),
-		changes:         uset.NewHashSet[K](),
-		lastUpdatedKeys: make(map[K]time.Time),
+		values:  make(map[K]T),
+		changes: newChangeLog[K, K](),
+		expiry:  newExpiryQueue[K](),
+		access:  newExpiryQueue[K](),
+		idle:    o.idle,
 	}
 	ttl.IfPresent(func(t time.Duration) {
 		c.ttl = &t
@@ -300,10 +438,8 @@ func (c *InMemoryComparableMapCache[K, T]) Set(key K, value T) {
 	c.vMtx.Lock()
 	defer c.vMtx.Unlock()
 	c.values[key] = value
-	c.changes.Add(key)
-	now := time.Now()
-	c.lastUpdatedKeys[key] = now
-	c.lastUpdated = now
+	c.changes.record(key, key, ChangeSet)
+	c.touch(key)
 }
 
 // SetQuietly adds a value to the cache for the provided key without altering the change history.
@@ -312,14 +448,12 @@ func (c *InMemoryComparableMapCache[K, T]) SetQuietly(key K, value T) {
 	c.vMtx.Lock()
 	defer c.vMtx.Unlock()
 	c.values[key] = value
-	now := time.Now()
-	c.lastUpdatedKeys[key] = now
-	c.lastUpdated = now
+	c.touch(key)
 }
 
 // Get retrieves the value associated with the provided key from the cache.
 // It returns a pointer to the value and a boolean indicating whether the key was found.
-// The operation is thread-safe.
+// Reading a value resets its idle timeout. The operation is thread-safe.
 func (c *InMemoryComparableMapCache[K, T]) Get(key K) (*T, bool) {
 	c.vMtx.Lock()
 	defer c.vMtx.Unlock()
@@ -328,16 +462,41 @@ func (c *InMemoryComparableMapCache[K, T]) Get(key K) (*T, bool) {
 	if !ok {
 		return nil, false
 	}
+	c.markAccessed(key, time.Now())
 	return &value, true
 }
 
-// Changes returns a slice of keys that have been modified in the cache since the last call to Changes.
+// ChangeLog returns the latest change of every modified key in the order they were made. The operation is thread-safe.
+func (c *InMemoryComparableMapCache[K, T]) ChangeLog() []ChangeEvent[K] {
+	c.vMtx.Lock()
+	defer c.vMtx.Unlock()
+
+	return c.changes.events()
+}
+
+// Changes returns the keys whose latest change is a Set, in the order they were set. Get doesn't alter the changes.
 // This method is thread-safe.
 func (c *InMemoryComparableMapCache[K, T]) Changes() []K {
 	c.vMtx.Lock()
 	defer c.vMtx.Unlock()
 
-	return c.changes.Values()
+	return c.changes.keys()
+}
+
+// ChangesCount returns the number of modified keys. The operation is thread-safe.
+func (c *InMemoryComparableMapCache[K, T]) ChangesCount() int {
+	c.vMtx.Lock()
+	defer c.vMtx.Unlock()
+
+	return c.changes.count()
+}
+
+// ResetChanges atomically returns the modified keys and clears the change history. The operation is thread-safe.
+func (c *InMemoryComparableMapCache[K, T]) ResetChanges() []K {
+	c.vMtx.Lock()
+	defer c.vMtx.Unlock()
+
+	return c.changes.reset()
 }
 
 // Drop completely clears the cache, removing all entries. The operation is thread-safe.
@@ -345,9 +504,11 @@ func (c *InMemoryComparableMapCache[K, T]) Drop() {
 	c.vMtx.Lock()
 	defer c.vMtx.Unlock()
 	c.values = make(map[K]T)
-	c.changes.Clear()
-	c.lastUpdatedKeys = make(map[K]time.Time)
+	c.changes.recordClear()
+	c.expiry.clear()
+	c.access.clear()
 	c.lastUpdated = time.Time{}
+	c.lastAccessed = time.Time{}
 }
 
 // DropKey removes the value associated with the provided key from the cache.
@@ -355,29 +516,123 @@ func (c *InMemoryComparableMapCache[K, T]) Drop() {
 func (c *InMemoryComparableMapCache[K, T]) DropKey(key K) {
 	c.vMtx.Lock()
 	defer c.vMtx.Unlock()
+	c.remove(key, ChangeDelete)
+}
+
+func (c *InMemoryComparableMapCache[K, T]) expireKeys(keys []K) {
+	c.vMtx.Lock()
+	defer c.vMtx.Unlock()
+	for _, key := range keys {
+		c.remove(key, ChangeExpire)
+	}
+}
+
+func (c *InMemoryComparableMapCache[K, T]) remove(key K, kind ChangeKind) {
 	delete(c.values, key)
-	c.changes.Remove(key)
-	delete(c.lastUpdatedKeys, key)
+	c.changes.record(key, key, kind)
+	c.expiry.remove(key)
+	c.access.remove(key)
 }
 
-// Outdated checks if the provided key is outdated based on the set TTL (time-to-live).
-// Returns true if outdated, false otherwise.
-// If no TTL is set or the key does not exist, it returns false.
+// Outdated checks if the provided key or the entire cache (if no key is provided)
+// is outdated based on the set TTL (time-to-live) or the idle timeout. Returns true if outdated, false otherwise.
+// If neither is set it returns false. If the key does not exist, it is considered outdated.
 func (c *InMemoryComparableMapCache[K, T]) Outdated(key uopt.Opt[K]) bool {
 	c.vMtx.Lock()
 	defer c.vMtx.Unlock()
 
-	if c.ttl == nil {
+	if c.ttl == nil && c.idle == nil {
 		return false
 	}
 
 	if k := key.Get(); k != nil {
-		lastUpdated, exists := c.lastUpdatedKeys[*k]
-		if !exists {
+		if _, exists := c.values[*k]; !exists {
 			return true
 		}
-		return time.Since(lastUpdated) > *c.ttl
+		if c.ttl != nil {
+			if lastUpdated, _ := c.expiry.updatedAt(*k); time.Since(lastUpdated) > *c.ttl {
+				return true
+			}
+		}
+		if c.idle != nil {
+			lastAccessed, _ := c.access.updatedAt(*k)
+			return time.Since(lastAccessed) > *c.idle
+		}
+		return false
 	}
 
-	return false
+	return (c.ttl != nil && time.Since(c.lastUpdated) > *c.ttl) || (c.idle != nil && time.Since(c.lastAccessed) > *c.idle)
+}
+
+// OutdatedKeys returns the keys that are outdated based on the set TTL or the idle timeout.
+// If neither is set returns an empty slice. The operation is thread-safe.
+func (c *InMemoryComparableMapCache[K, T]) OutdatedKeys() []K {
+	c.vMtx.Lock()
+	defer c.vMtx.Unlock()
+
+	return c.outdatedKeys()
+}
+
+// expireOutdated removes the outdated entries under the same lock they were found with,
+// so an entry updated in between is not removed.
+func (c *InMemoryComparableMapCache[K, T]) expireOutdated() []K {
+	c.vMtx.Lock()
+	defer c.vMtx.Unlock()
+
+	keys := c.outdatedKeys()
+	for _, key := range keys {
+		c.remove(key, ChangeExpire)
+	}
+
+	return keys
+}
+
+func (c *InMemoryComparableMapCache[K, T]) outdatedKeys() []K {
+	now := time.Now()
+	result := make([]K, 0)
+	if c.ttl != nil {
+		result = c.expiry.expired(now.Add(-*c.ttl))
+	}
+	if c.idle == nil {
+		return result
+	}
+
+	idle := c.access.expired(now.Add(-*c.idle))
+	if len(result) == 0 {
+		return idle
+	}
+	seen := make(map[K]struct{}, len(result))
+	for _, key := range result {
+		seen[key] = struct{}{}
+	}
+	for _, key := range idle {
+		if _, ok := seen[key]; !ok {
+			result = append(result, key)
+		}
+	}
+
+	return result
+}
+
+// Close is a no-op, since the cache doesn't own any resources.
+func (c *InMemoryComparableMapCache[K, T]) Close() error {
+	return nil
+}
+
+// touch updates the key expiration. Update times are not tracked if no TTL is set, since nothing can expire.
+func (c *InMemoryComparableMapCache[K, T]) touch(key K) {
+	now := time.Now()
+	if c.ttl != nil {
+		c.expiry.touch(key, now)
+	}
+	c.lastUpdated = now
+	c.markAccessed(key, now)
+}
+
+// markAccessed resets the key idle timeout. Access times are not tracked if no idle timeout is set.
+func (c *InMemoryComparableMapCache[K, T]) markAccessed(key K, at time.Time) {
+	if c.idle != nil {
+		c.access.touch(key, at)
+		c.lastAccessed = at
+	}
 }
@@ -77,3 +77,50 @@ func BenchmarkInMemoryHashMapCacheGetConcurrent(b *testing.B) {
 		}
 	})
 }
+
+// benchmarkOutdatedKeys measures listing of a few outdated keys among many fresh ones.
+// TTL must be longer than the benchmark run, otherwise the fresh keys expire during the measurement.
+func benchmarkOutdatedKeys(b *testing.B, c ucache.BaseCache[ucache.StringKey, int], ttl time.Duration) {
+	const total, outdated = 50_000, 100
+	lister := c.(ucache.OutdatedKeysLister[ucache.StringKey])
+	keys := make([]ucache.StringKey, total-outdated)
+	for i := range keys {
+		keys[i] = ucache.StringKey(fmt.Sprintf("key%d", i))
+	}
+	for i := 0; i < outdated; i++ {
+		c.SetQuietly(ucache.StringKey(fmt.Sprintf("old%d", i)), i)
+	}
+	time.Sleep(ttl + 10*time.Millisecond)
+	for i, key := range keys {
+		c.SetQuietly(key, i)
+	}
+	if keys := lister.OutdatedKeys(); len(keys) != outdated {
+		b.Fatalf("expected %d outdated keys, got %d", outdated, len(keys))
+	}
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_ = lister.OutdatedKeys()
+	}
+}
+
+// BenchmarkInMemoryHashMapCacheOutdatedKeys scans every entry.
+func BenchmarkInMemoryHashMapCacheOutdatedKeys(b *testing.B) {
+	ttl := 2 * time.Second
+	benchmarkOutdatedKeys(b, ucache.NewInMemoryHashMapCache[ucache.StringKey, int](uopt.Of(ttl)), ttl)
+}
+
+// BenchmarkInMemoryComparableMapCacheOutdatedKeys visits only the expired part of the heap.
+func BenchmarkInMemoryComparableMapCacheOutdatedKeys(b *testing.B) {
+	ttl := 2 * time.Second
+	benchmarkOutdatedKeys(b, ucache.NewInMemoryComparableMapCache[ucache.StringKey, int](uopt.Of(ttl)), ttl)
+}
+
+func BenchmarkInMemoryComparableMapCacheSetWithTTL(b *testing.B) {
+	cache := ucache.NewInMemoryComparableMapCache[int, int](uopt.Of(time.Hour))
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		cache.Set(i%10_000, i)
+	}
+}
@@ -336,6 +336,56 @@ func TestHashMapCacheHighCollisionProbability(t *testing.T) {
 	}
 }
 
+func TestHashMapCacheDropKeyWithCollisions(t *testing.T) {
+	c := ucache.NewInMemoryHashMapCache[CollisionTestKey, ucache.Int64Value](uopt.Null[time.Duration]())
+
+	keys := []CollisionTestKey{
+		{id: 1, hash: []int64{1, 2, 3}},
+		{id: 2, hash: []int64{1, 2, 3}},
+		{id: 3, hash: []int64{1, 2, 3}},
+	}
+	for i, key := range keys {
+		c.Set(key, ucache.NewInt64Value(int64(i)))
+	}
+
+	c.DropKey(keys[1])
+
+	_, ok := c.Get(keys[1])
+	assert.False(t, ok, "Dropped key must not be available")
+	for _, i := range []int{0, 2} {
+		value, ok := c.Get(keys[i])
+		require.True(t, ok, "Keys sharing the hash with the dropped key must stay intact")
+		assert.EqualValues(t, ucache.NewInt64Value(int64(i)), *value)
+	}
+}
+
+func TestHashMapCacheBookkeepingWithCollisions(t *testing.T) {
+	ttl := 50 * time.Millisecond
+	c := ucache.NewInMemoryHashMapCache[CollisionTestKey, ucache.Int64Value](uopt.Of(ttl))
+
+	keys := []CollisionTestKey{
+		{id: 1, hash: []int64{1, 2, 3}},
+		{id: 2, hash: []int64{1, 2, 3}},
+		{id: 3, hash: []int64{1, 2, 3}},
+	}
+	for i, key := range keys {
+		c.Set(key, ucache.NewInt64Value(int64(i)))
+	}
+	assert.Equal(t, keys, c.Changes(), "Every colliding key must have its own change")
+
+	c.DropKey(keys[1])
+	assert.Equal(t, []CollisionTestKey{keys[0], keys[2]}, c.Changes(), "DropKey must not clear the changes of the other keys")
+	assert.Equal(t, 2, c.(ucache.ChangesCounter).ChangesCount())
+	assert.False(t, c.Outdated(uopt.Of(keys[0])), "DropKey must not clear the update times of the other keys")
+
+	time.Sleep(ttl / 2)
+	c.Set(keys[1], ucache.NewInt64Value(1))
+	time.Sleep(ttl/2 + 10*time.Millisecond)
+	assert.True(t, c.Outdated(uopt.Of(keys[0])))
+	assert.False(t, c.Outdated(uopt.Of(keys[1])), "Keys sharing the hash must expire independently")
+	assert.ElementsMatch(t, []CollisionTestKey{keys[0], keys[2]}, c.(ucache.OutdatedKeysLister[CollisionTestKey]).OutdatedKeys())
+}
+
 func TestComparableMapCache_CompositeKey(t *testing.T) {
 	// Using string as a comparable key
 	c := ucache.NewInMemoryComparableMapCache[string, int](uopt.Null[time.Duration]())
@@ -1109,3 +1159,269 @@ func TestComparableMapCache_OverwriteSetQuietly(t *testing.T) {
 	require.True(t, ok, "Expected to retrieve value for key1")
 	assert.Equal(t, 3, *val, "Expected value for key1 to be 3")
 }
+
+func TestCache_OutdatedKeys(t *testing.T) {
+	ttl := 20 * time.Millisecond
+	hashCache := ucache.NewInMemoryHashMapCache[ucache.StringKey, int](uopt.Of(ttl))
+	comparableCache := ucache.NewInMemoryComparableMapCache[ucache.StringKey, int](uopt.Of(ttl))
+
+	for name, c := range map[string]ucache.BaseCache[ucache.StringKey, int]{"hash": hashCache, "comparable": comparableCache} {
+		t.Run(name, func(t *testing.T) {
+			outdatedKeys := c.(ucache.OutdatedKeysLister[ucache.StringKey]).OutdatedKeys
+			assert.True(t, c.Outdated(uopt.Null[ucache.StringKey]()), "Empty cache has never been updated")
+
+			c.Set("stale", 1)
+			c.SetQuietly("quiet", 2)
+			assert.Empty(t, outdatedKeys())
+			assert.False(t, c.Outdated(uopt.Null[ucache.StringKey]()))
+
+			time.Sleep(ttl + 10*time.Millisecond)
+			assert.True(t, c.Outdated(uopt.Null[ucache.StringKey]()))
+			c.Set("fresh", 3)
+			assert.False(t, c.Outdated(uopt.Null[ucache.StringKey]()))
+
+			assert.ElementsMatch(t, []ucache.StringKey{"stale", "quiet"}, outdatedKeys())
+		})
+	}
+}
+
+func TestCache_OutdatedKeysNoTTL(t *testing.T) {
+	c := ucache.NewInMemoryComparableMapCache[string, int](uopt.Null[time.Duration]())
+	c.Set("key", 1)
+
+	assert.False(t, c.Outdated(uopt.Null[string]()))
+	assert.Empty(t, c.(ucache.OutdatedKeysLister[string]).OutdatedKeys())
+}
+
+func TestComparableMapCache_OutdatedKeysHeap(t *testing.T) {
+	ttl := 30 * time.Millisecond
+	c := ucache.NewInMemoryComparableMapCache[int, int](uopt.Of(ttl))
+
+	for i := range 100 {
+		c.Set(i, i)
+	}
+	time.Sleep(ttl + 10*time.Millisecond)
+
+	// refresh every even key, drop some odd keys
+	for i := 0; i < 100; i += 2 {
+		c.SetQuietly(i, i)
+	}
+	for i := 1; i < 20; i += 2 {
+		c.DropKey(i)
+	}
+
+	var expected []int
+	for i := 21; i < 100; i += 2 {
+		expected = append(expected, i)
+	}
+	assert.ElementsMatch(t, expected, c.(ucache.OutdatedKeysLister[int]).OutdatedKeys())
+	for _, key := range expected {
+		assert.True(t, c.Outdated(uopt.Of(key)))
+	}
+	assert.False(t, c.Outdated(uopt.Of(2)))
+
+	c.Drop()
+	assert.Empty(t, c.(ucache.OutdatedKeysLister[int]).OutdatedKeys())
+}
+
+func TestComparableMapCache_IdleTimeout(t *testing.T) {
+	idle := 40 * time.Millisecond
+	c := ucache.NewInMemoryComparableMapCache[string, int](uopt.Null[time.Duration](), ucache.WithIdleTimeout(idle))
+	c.Set("read", 1)
+	c.Set("idle", 2)
+	assert.False(t, c.Outdated(uopt.Of("read")))
+	assert.Empty(t, c.(ucache.OutdatedKeysLister[string]).OutdatedKeys())
+
+	time.Sleep(idle / 2)
+	_, ok := c.Get("read")
+	assert.True(t, ok)
+	time.Sleep(idle/2 + 10*time.Millisecond)
+
+	assert.Equal(t, []string{"idle"}, c.(ucache.OutdatedKeysLister[string]).OutdatedKeys())
+	assert.True(t, c.Outdated(uopt.Of("idle")))
+	assert.False(t, c.Outdated(uopt.Of("read")), "reading must reset the idle timeout")
+	assert.False(t, c.Outdated(uopt.Null[string]()))
+	assert.True(t, c.Outdated(uopt.Of("missing")))
+
+	c.DropKey("idle")
+	assert.Empty(t, c.(ucache.OutdatedKeysLister[string]).OutdatedKeys())
+}
+
+func TestComparableMapCache_IdleTimeoutWithTTL(t *testing.T) {
+	ttl, idle := 80*time.Millisecond, 20*time.Millisecond
+	c := ucache.NewInMemoryComparableMapCache[string, int](uopt.Of(ttl), ucache.WithIdleTimeout(idle))
+	c.Set("hot", 1)
+	c.Set("cold", 2)
+
+	readFor := func(d time.Duration) {
+		for deadline := time.Now().Add(d); time.Now().Before(deadline); time.Sleep(2 * time.Millisecond) {
+			c.Get("hot")
+		}
+	}
+	readFor(idle + 10*time.Millisecond)
+	assert.Equal(t, []string{"cold"}, c.(ucache.OutdatedKeysLister[string]).OutdatedKeys())
+
+	readFor(ttl)
+	assert.ElementsMatch(t, []string{"hot", "cold"}, c.(ucache.OutdatedKeysLister[string]).OutdatedKeys(), "reads must not extend the TTL")
+	assert.True(t, c.Outdated(uopt.Of("hot")))
+}
+
+func TestCache_ResetChanges(t *testing.T) {
+	caches := map[string]ucache.BaseCache[ucache.StringKey, int]{
+		"hash":       ucache.NewInMemoryHashMapCache[ucache.StringKey, int](uopt.Null[time.Duration]()),
+		"comparable": ucache.NewInMemoryComparableMapCache[ucache.StringKey, int](uopt.Null[time.Duration]()),
+		"simple":     ucache.NewSimpleCache[ucache.StringKey, int](uopt.Null[time.Duration]()),
+	}
+
+	for name, c := range caches {
+		t.Run(name, func(t *testing.T) {
+			reset := c.(ucache.ChangesResetter[ucache.StringKey]).ResetChanges
+			c.Set("a", 1)
+			c.Set("b", 2)
+			c.SetQuietly("quiet", 3)
+
+			assert.ElementsMatch(t, []ucache.StringKey{"a", "b"}, reset())
+			assert.Empty(t, c.Changes())
+			assert.Empty(t, reset())
+
+			c.Set("c", 3)
+			assert.ElementsMatch(t, []ucache.StringKey{"c"}, reset())
+
+			value, ok := c.Get("a")
+			require.True(t, ok, "ResetChanges must not drop values")
+			assert.Equal(t, 1, *value)
+
+			c.Drop()
+			c.Set("d", 4)
+			assert.ElementsMatch(t, []ucache.StringKey{"d"}, c.Changes(), "changes must be tracked after Drop")
+		})
+	}
+}
+
+func TestCache_ChangesCount(t *testing.T) {
+	caches := map[string]ucache.BaseCache[ucache.StringKey, int]{
+		"hash":       ucache.NewInMemoryHashMapCache[ucache.StringKey, int](uopt.Null[time.Duration]()),
+		"comparable": ucache.NewInMemoryComparableMapCache[ucache.StringKey, int](uopt.Null[time.Duration]()),
+		"simple":     ucache.NewSimpleCache[ucache.StringKey, int](uopt.Null[time.Duration]()),
+	}
+
+	for name, c := range caches {
+		t.Run(name, func(t *testing.T) {
+			count := c.(ucache.ChangesCounter).ChangesCount
+			assert.Zero(t, count())
+			c.Set("a", 1)
+			c.Set("b", 2)
+			c.Set("a", 3)
+			assert.Equal(t, 2, count())
+
+			changes := c.Changes()
+			changes[0] = "mutated"
+			assert.ElementsMatch(t, []ucache.StringKey{"a", "b"}, c.Changes(), "Changes must return a copy")
+
+			c.DropKey("a")
+			assert.Equal(t, 1, count())
+			c.(ucache.ChangesResetter[ucache.StringKey]).ResetChanges()
+			assert.Zero(t, count())
+		})
+	}
+}
+
+func changeKinds[K any](events []ucache.ChangeEvent[K]) []ucache.ChangeKind {
+	result := make([]ucache.ChangeKind, len(events))
+	for i, e := range events {
+		result[i] = e.Kind
+	}
+
+	return result
+}
+
+func TestCache_ChangeLog(t *testing.T) {
+	caches := map[string]ucache.BaseCache[ucache.StringKey, int]{
+		"hash":       ucache.NewInMemoryHashMapCache[ucache.StringKey, int](uopt.Null[time.Duration]()),
+		"comparable": ucache.NewInMemoryComparableMapCache[ucache.StringKey, int](uopt.Null[time.Duration]()),
+		"simple":     ucache.NewSimpleCache[ucache.StringKey, int](uopt.Null[time.Duration]()),
+		"namespace": ucache.NewNamespacedCache[ucache.StringKey, int](
+			ucache.NewInMemoryComparableMapCache[ucache.NamespacedKey[ucache.StringKey], int](uopt.Null[time.Duration]()),
+		).Namespace("ns"),
+	}
+
+	for name, c := range caches {
+		t.Run(name, func(t *testing.T) {
+			changeLog := c.(ucache.ChangeLogReader[ucache.StringKey]).ChangeLog
+			c.Set("a", 1)
+			c.Set("b", 2)
+			c.SetQuietly("quiet", 3)
+			c.DropKey("a")
+			c.Set("b", 4)
+
+			_, ok := c.Get("b")
+			require.True(t, ok)
+			log := changeLog()
+			require.Len(t, log, 2, "Get must not alter the changes")
+			assert.Equal(t, ucache.StringKey("a"), log[0].Key)
+			assert.Equal(t, ucache.ChangeDelete, log[0].Kind)
+			assert.Equal(t, ucache.StringKey("b"), log[1].Key)
+			assert.Equal(t, ucache.ChangeSet, log[1].Kind)
+			assert.False(t, log[1].At.Before(log[0].At))
+
+			assert.Equal(t, []ucache.StringKey{"b"}, c.Changes(), "only the set keys are returned by Changes")
+			assert.Equal(t, 1, c.(ucache.ChangesCounter).ChangesCount())
+
+			c.Drop()
+			c.Set("c", 5)
+			assert.Equal(t, []ucache.ChangeKind{ucache.ChangeClear, ucache.ChangeSet}, changeKinds(changeLog()))
+			assert.Equal(t, []ucache.StringKey{"c"}, c.(ucache.ChangesResetter[ucache.StringKey]).ResetChanges())
+			assert.Empty(t, changeLog(), "ResetChanges must clear the change log")
+		})
+	}
+}
+
+func TestCache_ChangeLogExpire(t *testing.T) {
+	ttl := time.Millisecond
+	caches := map[string]ucache.BaseCache[ucache.StringKey, int]{
+		"hash":       ucache.NewInMemoryHashMapCache[ucache.StringKey, int](uopt.Of(ttl)),
+		"comparable": ucache.NewInMemoryComparableMapCache[ucache.StringKey, int](uopt.Of(ttl)),
+		"simple":     ucache.NewSimpleCache[ucache.StringKey, int](uopt.Of(ttl)),
+	}
+
+	for name, c := range caches {
+		t.Run(name, func(t *testing.T) {
+			observable := ucache.NewObservableCache(c)
+			var events []ucache.ChangeKind
+			observable.OnChange(func(event ucache.ChangeEvent[ucache.StringKey]) {
+				events = append(events, event.Kind)
+			})
+			managed := ucache.NewManagedCache[ucache.StringKey, int](observable, time.Hour)
+			defer managed.Stop()
+
+			managed.Set("a", 1)
+			time.Sleep(2 * ttl)
+			managed.ForceCleanup()
+
+			_, ok := managed.Get("a")
+			assert.False(t, ok)
+			log := managed.ChangeLog()
+			require.Len(t, log, 1)
+			assert.Equal(t, ucache.StringKey("a"), log[0].Key)
+			assert.Equal(t, ucache.ChangeExpire, log[0].Kind)
+			assert.Empty(t, managed.Changes())
+			assert.Equal(t, []ucache.ChangeKind{ucache.ChangeSet, ucache.ChangeExpire}, events)
+		})
+	}
+}
+
+func TestCache_ChangeLogBoundsRemovals(t *testing.T) {
+	c := ucache.NewInMemoryComparableMapCache[int, int](uopt.Null[time.Duration]())
+	for i := range 100000 {
+		c.Set(i, i)
+		c.DropKey(i)
+	}
+	c.Set(-1, 0)
+
+	log := c.(ucache.ChangeLogReader[int]).ChangeLog()
+	assert.Less(t, len(log), 100000, "removals must be bounded")
+	last := log[len(log)-1]
+	assert.Equal(t, -1, last.Key)
+	assert.Equal(t, ucache.ChangeSet, last.Kind)
+	assert.Equal(t, 99999, log[len(log)-2].Key, "the latest removals must be kept")
+}
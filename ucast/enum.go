@@ -0,0 +1,133 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package ucast
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ErrUnknownEnum is matched by the errors returned by Enum for inputs that don't match any allowed name.
+var ErrUnknownEnum = errors.New("unknown enum value")
+
+// maxSuggestions limits the number of "did you mean" candidates.
+const maxSuggestions = 3
+
+// EnumError is returned by Enum when the input doesn't match any allowed name.
+type EnumError struct {
+	Input       string
+	Allowed     []string // Allowed are the sorted allowed names.
+	Suggestions []string // Suggestions are the allowed names closest to the input, the closest first.
+}
+
+func (e *EnumError) Error() string {
+	if len(e.Suggestions) > 0 {
+		return fmt.Sprintf("unknown value %q, did you mean %s?", e.Input, quoteJoin(e.Suggestions, " or "))
+	}
+
+	return fmt.Sprintf("unknown value %q, allowed values are %s", e.Input, quoteJoin(e.Allowed, ", "))
+}
+
+func (e *EnumError) Unwrap() error {
+	return ErrUnknownEnum
+}
+
+// Enum maps the input to one of the allowed values by its name, which is handy for parsing configs and CLI flags.
+// Names are matched case-insensitively, with an exact match taking precedence. If nothing matches, an *EnumError is returned
+// suggesting the closest names by the Levenshtein distance.
+//
+// Example usage:
+//
+//	level, err := ucast.Enum("wran", map[string]Level{"debug": Debug, "info": Info, "warn": Warn})
+//	// err: unknown value "wran", did you mean "warn"?
+func Enum[T ~string | ~int](input string, allowed map[string]T) (T, error) {
+	if v, ok := allowed[input]; ok {
+		return v, nil
+	}
+
+	names := make([]string, 0, len(allowed))
+	for name := range allowed {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if strings.EqualFold(name, input) {
+			return allowed[name], nil
+		}
+	}
+
+	var zero T
+	return zero, &EnumError{Input: input, Allowed: names, Suggestions: suggest(input, names)}
+}
+
+// suggest returns up to maxSuggestions names within a third of the input length edits, the closest first.
+func suggest(input string, names []string) []string {
+	threshold := max(len([]rune(input))/3, 1)
+	lowered := strings.ToLower(input)
+
+	type candidate struct {
+		name     string
+		distance int
+	}
+	var candidates []candidate
+	for _, name := range names {
+		if d := levenshtein(lowered, strings.ToLower(name)); d <= threshold {
+			candidates = append(candidates, candidate{name: name, distance: d})
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].distance < candidates[j].distance
+	})
+
+	result := make([]string, 0, maxSuggestions)
+	for i := 0; i < len(candidates) && i < maxSuggestions; i++ {
+		result = append(result, candidates[i].name)
+	}
+
+	return result
+}
+
+// levenshtein returns the edit distance between the strings measured in runes.
+// Adjacent transpositions count as a single edit (the optimal string alignment variant), as they are common typos.
+func levenshtein(a, b string) int {
+	ar, br := []rune(a), []rune(b)
+	prevPrev := make([]int, len(br)+1)
+	prev := make([]int, len(br)+1)
+	curr := make([]int, len(br)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ar); i++ {
+		curr[0] = i
+		for j := 1; j <= len(br); j++ {
+			cost := 1
+			if ar[i-1] == br[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && ar[i-1] == br[j-2] && ar[i-2] == br[j-1] {
+				curr[j] = min(curr[j], prevPrev[j-2]+1)
+			}
+		}
+		prevPrev, prev, curr = prev, curr, prevPrev
+	}
+
+	return prev[len(br)]
+}
+
+func quoteJoin(values []string, sep string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = fmt.Sprintf("%q", v)
+	}
+
+	return strings.Join(quoted, sep)
+}
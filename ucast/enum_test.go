@@ -0,0 +1,65 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package ucast_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kordax/basic-utils/ucast"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type level int
+
+const (
+	levelDebug level = iota
+	levelInfo
+	levelWarn
+)
+
+func TestEnum(t *testing.T) {
+	levels := map[string]level{"debug": levelDebug, "info": levelInfo, "warn": levelWarn, "Warning": levelWarn}
+
+	v, err := ucast.Enum("info", levels)
+	require.NoError(t, err)
+	assert.Equal(t, levelInfo, v)
+
+	v, err = ucast.Enum("DEBUG", levels)
+	require.NoError(t, err)
+	assert.Equal(t, levelDebug, v)
+
+	v, err = ucast.Enum("warning", levels)
+	require.NoError(t, err)
+	assert.Equal(t, levelWarn, v)
+
+	type color string
+	c, err := ucast.Enum("Red", map[string]color{"red": "#f00"})
+	require.NoError(t, err)
+	assert.Equal(t, color("#f00"), c)
+}
+
+func TestEnum_Suggestions(t *testing.T) {
+	levels := map[string]level{"debug": levelDebug, "info": levelInfo, "warn": levelWarn}
+
+	_, err := ucast.Enum("wran", levels)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ucast.ErrUnknownEnum))
+	assert.EqualError(t, err, `unknown value "wran", did you mean "warn"?`)
+
+	var enumErr *ucast.EnumError
+	require.ErrorAs(t, err, &enumErr)
+	assert.Equal(t, "wran", enumErr.Input)
+	assert.Equal(t, []string{"debug", "info", "warn"}, enumErr.Allowed)
+
+	_, err = ucast.Enum("fatal", levels)
+	assert.EqualError(t, err, `unknown value "fatal", allowed values are "debug", "info", "warn"`)
+
+	_, err = ucast.Enum("bat", map[string]int{"cat": 1, "bar": 2, "hat": 3, "dog": 4, "bats": 5})
+	assert.EqualError(t, err, `unknown value "bat", did you mean "bar" or "bats" or "cat"?`)
+}
@@ -0,0 +1,133 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package ucast
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/kordax/basic-utils/uconst"
+)
+
+// Option relaxes the format accepted by String and StringOrDef for numeric target types.
+// Options are ignored for string and bool targets.
+type Option func(*options)
+
+type options struct {
+	thousands   byte
+	decimal     byte
+	underscores bool
+	percent     bool
+}
+
+// WithComma accepts commas as thousands separators, e.g. "1,234.56".
+// Digits must be grouped by three after the first group, so "1,23" or "12,34,5" are rejected.
+func WithComma() Option {
+	return func(o *options) {
+		o.thousands = ','
+		o.decimal = '.'
+	}
+}
+
+// WithDecimalComma accepts the continental notation with dots as thousands separators
+// and a comma as the decimal separator, e.g. "1.234,56". Digits are grouped like with WithComma.
+func WithDecimalComma() Option {
+	return func(o *options) {
+		o.thousands = '.'
+		o.decimal = ','
+	}
+}
+
+// WithUnderscores accepts underscores as digit separators, e.g. "1_000_000".
+// Unlike thousands separators, underscores may separate digit groups of any size, like in Go literals.
+func WithUnderscores() Option {
+	return func(o *options) {
+		o.underscores = true
+	}
+}
+
+// WithPercent accepts a trailing percent sign and returns the value as a fraction, like Unit does,
+// e.g. "45%" is parsed as 0.45. Integer targets accept only the percentages of whole numbers, e.g. "200%" is 2.
+// Values without the percent sign are parsed as is.
+func WithPercent() Option {
+	return func(o *options) {
+		o.percent = true
+	}
+}
+
+// normalize converts the numeric input to the plain format understood by strconv and reports whether it was a percentage.
+// Separators are removed only when they are placed between digits and thousands separators only between groups of three digits,
+// so malformed inputs like "1,,000" or "1,00" still fail to parse.
+func normalize[R uconst.BasicType](s string, opts []Option) (string, bool, error) {
+	var zero R
+	t := reflect.TypeOf(zero)
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() == reflect.String || t.Kind() == reflect.Bool {
+		return s, false, nil
+	}
+
+	o := options{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	s = strings.TrimSpace(s)
+	percent := false
+	if o.percent {
+		var cut bool
+		if s, cut = strings.CutSuffix(s, "%"); cut {
+			s, percent = strings.TrimSpace(s), true
+		}
+	}
+
+	var b strings.Builder
+	b.Grow(len(s))
+	groupStart := 0 // the start of the current group of digits
+	grouped := false
+	fraction := false
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		between := i > 0 && i < len(s)-1 && isDigit(s[i-1]) && isDigit(s[i+1])
+		switch {
+		case o.underscores && c == '_' && between:
+			continue
+		case o.thousands != 0 && c == o.thousands:
+			size := i - groupStart
+			if !between || fraction || digitsAt(s, i+1) != 3 || size > 3 || grouped && size != 3 {
+				return "", false, fmt.Errorf("misplaced thousands separator at position %d", i)
+			}
+			grouped = true
+			groupStart = i + 1
+			continue
+		case o.decimal != 0 && c == o.decimal:
+			fraction = true
+			c = '.'
+		case isDigit(c) && (i == 0 || !isDigit(s[i-1])):
+			groupStart = i
+		}
+		b.WriteByte(c)
+	}
+
+	return b.String(), percent, nil
+}
+
+// digitsAt returns the number of consecutive digits starting at i.
+func digitsAt(s string, i int) int {
+	n := 0
+	for i+n < len(s) && isDigit(s[i+n]) {
+		n++
+	}
+
+	return n
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
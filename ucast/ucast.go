@@ -3,6 +3,7 @@ package ucast
 import (
 	"fmt"
 	"reflect"
+	"strconv"
 
 	"github.com/kordax/basic-utils/uconst"
 )
@@ -11,6 +12,7 @@ import (
 // It returns the converted value and an error if the conversion fails.
 //
 // The type R must satisfy the uconst.BasicType constraint.
+// Options, e.g. WithComma, WithUnderscores or WithPercent, relax the accepted format of numeric inputs.
 //
 // Example usage:
 //
@@ -18,9 +20,11 @@ import (
 //	if err != nil {
 //	    // handle error
 //	}
-func String[R uconst.BasicType](str string) (R, error) {
+//
+//	price, err := ucast.String[float64]("1,234.56", ucast.WithComma())
+func String[R uconst.BasicType](str string, opts ...Option) (R, error) {
 	var zero R
-	return StringOrDef(str, zero)
+	return StringOrDef(str, zero, opts...)
 }
 
 // Type converts the input value v of type V to its string representation.
@@ -44,7 +48,21 @@ func Type[V uconst.BasicType](v V) string {
 //
 //	value, err := ucast.StringOrDef[int]("invalid", 42)
 //	// value == 42, err != nil
-func StringOrDef[R uconst.BasicType](str string, def R) (R, error) {
+func StringOrDef[R uconst.BasicType](str string, def R, opts ...Option) (R, error) {
+	if len(opts) > 0 {
+		normalized, percent, err := normalize[R](str, opts)
+		if err != nil {
+			return def, fmt.Errorf("failed to convert string to target type: %v", err)
+		}
+		if str = normalized; percent {
+			v, err := strconv.ParseFloat(str, 64)
+			if err != nil {
+				return def, fmt.Errorf("failed to convert string to target type: %v", err)
+			}
+			str = strconv.FormatFloat(v/100, 'f', -1, 64)
+		}
+	}
+
 	result, err := fromString[R](str)
 	if err != nil {
 		return def, fmt.Errorf("failed to convert string to target type: %v", err)
@@ -514,3 +514,74 @@ func TestStringOrDef(t *testing.T) {
 		assert.Equal(t, false, result)
 	})
 }
+
+func TestString_Options(t *testing.T) {
+	f, err := ucast.String[float64]("1,234.56", ucast.WithComma())
+	require.NoError(t, err)
+	assert.Equal(t, 1234.56, f)
+
+	f, err = ucast.String[float64]("1.234,56", ucast.WithDecimalComma())
+	require.NoError(t, err)
+	assert.Equal(t, 1234.56, f)
+
+	i, err := ucast.String[int]("1_000_000", ucast.WithUnderscores())
+	require.NoError(t, err)
+	assert.Equal(t, 1000000, i)
+
+	f, err = ucast.String[float64](" 45% ", ucast.WithPercent())
+	require.NoError(t, err)
+	assert.Equal(t, 0.45, f)
+	unit, err := ucast.Unit[float64]("45%")
+	require.NoError(t, err)
+	assert.Equal(t, unit, f, "WithPercent must be consistent with Unit")
+
+	f, err = ucast.String[float64]("12,345.5%", ucast.WithComma(), ucast.WithPercent())
+	require.NoError(t, err)
+	assert.Equal(t, 123.455, f)
+
+	f, err = ucast.String[float64]("0.5", ucast.WithPercent())
+	require.NoError(t, err)
+	assert.Equal(t, 0.5, f, "values without the percent sign are parsed as is")
+
+	i, err = ucast.String[int]("200%", ucast.WithPercent())
+	require.NoError(t, err)
+	assert.Equal(t, 2, i)
+	_, err = ucast.String[int]("45%", ucast.WithPercent())
+	assert.Error(t, err, "fractions can't be assigned to integers")
+
+	i, err = ucast.String[int]("-1,234,567", ucast.WithComma())
+	require.NoError(t, err)
+	assert.Equal(t, -1234567, i)
+	f, err = ucast.String[float64]("12.345.678,9", ucast.WithDecimalComma())
+	require.NoError(t, err)
+	assert.Equal(t, 12345678.9, f)
+	for _, input := range []string{"1,23", "12,34,5", "1234,567", "1,234,56", "1,2345", "1.234,5,6"} {
+		_, err = ucast.String[float64](input, ucast.WithComma())
+		assert.Error(t, err, "%q must not be parsed as a grouped number", input)
+	}
+	for _, input := range []string{"1.23", "1.", "1..234"} {
+		_, err = ucast.String[float64](input, ucast.WithDecimalComma())
+		assert.Error(t, err, "%q must not be parsed as a grouped number", input)
+	}
+
+	p, err := ucast.String[*uint64]("9,000", ucast.WithComma())
+	require.NoError(t, err)
+	assert.Equal(t, uint64(9000), *p)
+
+	s, err := ucast.String[string]("1,000%", ucast.WithComma(), ucast.WithPercent())
+	require.NoError(t, err)
+	assert.Equal(t, "1,000%", s)
+
+	_, err = ucast.String[int]("1,234")
+	assert.Error(t, err)
+	_, err = ucast.String[int]("1,,234", ucast.WithComma())
+	assert.Error(t, err)
+	_, err = ucast.String[int](",234", ucast.WithComma())
+	assert.Error(t, err)
+	_, err = ucast.String[int]("45%")
+	assert.Error(t, err)
+
+	v, err := ucast.StringOrDef[int]("1_0_", 7, ucast.WithUnderscores())
+	assert.Error(t, err)
+	assert.Equal(t, 7, v)
+}
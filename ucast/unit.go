@@ -0,0 +1,118 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package ucast
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidUnit is matched by the errors returned by Unit for malformed inputs.
+var ErrInvalidUnit = errors.New("invalid unit value")
+
+// byteUnits maps the lower-cased byte size suffixes to their multipliers.
+// Decimal prefixes are powers of 1000 and binary ones are powers of 1024, single letters are treated as binary.
+var byteUnits = map[string]int64{
+	"": 1, "b": 1,
+	"k": 1 << 10, "kb": 1e3, "ki": 1 << 10, "kib": 1 << 10,
+	"m": 1 << 20, "mb": 1e6, "mi": 1 << 20, "mib": 1 << 20,
+	"g": 1 << 30, "gb": 1e9, "gi": 1 << 30, "gib": 1 << 30,
+	"t": 1 << 40, "tb": 1e12, "ti": 1 << 40, "tib": 1 << 40,
+	"p": 1 << 50, "pb": 1e15, "pi": 1 << 50, "pib": 1 << 50,
+	"e": 1 << 60, "eb": 1e18, "ei": 1 << 60, "eib": 1 << 60,
+}
+
+// Unit parses a value with a unit, which is handy for human-friendly configs and CLI flags.
+// The unit kind is selected by the target type:
+//   - time.Duration accepts Go durations, e.g. "250ms" or "2h45m".
+//   - float64 accepts percents returned as fractions, e.g. "75%" is 0.75, a plain number is returned as is.
+//   - int64 accepts byte sizes, e.g. "512", "10KB" or "1.5GiB". Units are case-insensitive, KB, MB etc. are decimal,
+//     KiB, MiB etc. and single letters K, M etc. are binary. Fractional sizes must result in whole bytes.
+//
+// Errors match ErrInvalidUnit.
+//
+// Example usage:
+//
+//	timeout, err := ucast.Unit[time.Duration]("2h45m")
+//	ratio, err := ucast.Unit[float64]("75%")   // 0.75
+//	limit, err := ucast.Unit[int64]("1.5GiB") // 1610612736
+func Unit[T time.Duration | float64 | int64](input string) (T, error) {
+	s := strings.TrimSpace(input)
+	var result any
+	var err error
+	var zero T
+	switch any(zero).(type) {
+	case time.Duration:
+		result, err = time.ParseDuration(s)
+	case float64:
+		result, err = parsePercent(s)
+	default:
+		result, err = parseByteSize(s)
+	}
+	if err != nil {
+		return zero, fmt.Errorf("%w %q: %v", ErrInvalidUnit, input, err)
+	}
+
+	return result.(T), nil
+}
+
+func parsePercent(s string) (float64, error) {
+	number, percent := strings.CutSuffix(s, "%")
+	v, err := strconv.ParseFloat(strings.TrimSpace(number), 64)
+	if err != nil {
+		return 0, err
+	}
+	if percent {
+		v /= 100
+	}
+
+	return v, nil
+}
+
+func parseByteSize(s string) (int64, error) {
+	i := 0
+	for i < len(s) && (isDigit(s[i]) || s[i] == '.') {
+		i++
+	}
+	number, unit := s[:i], strings.ToLower(strings.TrimSpace(s[i:]))
+	if number == "" {
+		return 0, errors.New("missing number")
+	}
+	multiplier, ok := byteUnits[unit]
+	if !ok {
+		return 0, fmt.Errorf("unknown byte size unit %q", unit)
+	}
+
+	if !strings.Contains(number, ".") {
+		v, err := strconv.ParseInt(number, 10, 64)
+		if err != nil {
+			return 0, err
+		}
+		if v > math.MaxInt64/multiplier {
+			return 0, errors.New("byte size overflows int64")
+		}
+		return v * multiplier, nil
+	}
+
+	v, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return 0, err
+	}
+	v *= float64(multiplier)
+	if v >= math.MaxInt64 {
+		return 0, errors.New("byte size overflows int64")
+	}
+	if v != math.Trunc(v) {
+		return 0, errors.New("byte size must be a whole number of bytes")
+	}
+
+	return int64(v), nil
+}
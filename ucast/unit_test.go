@@ -0,0 +1,74 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package ucast_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kordax/basic-utils/ucast"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnit_Duration(t *testing.T) {
+	d, err := ucast.Unit[time.Duration]("250ms")
+	require.NoError(t, err)
+	assert.Equal(t, 250*time.Millisecond, d)
+
+	d, err = ucast.Unit[time.Duration](" 2h45m ")
+	require.NoError(t, err)
+	assert.Equal(t, 2*time.Hour+45*time.Minute, d)
+
+	_, err = ucast.Unit[time.Duration]("2 hours")
+	assert.ErrorIs(t, err, ucast.ErrInvalidUnit)
+}
+
+func TestUnit_Percent(t *testing.T) {
+	tests := map[string]float64{
+		"75%":    0.75,
+		"12.5 %": 0.125,
+		"0.3":    0.3,
+		"-10%":   -0.1,
+	}
+	for input, expected := range tests {
+		v, err := ucast.Unit[float64](input)
+		require.NoError(t, err, input)
+		assert.InDelta(t, expected, v, 1e-12, input)
+	}
+
+	for _, input := range []string{"", "%", "abc%", "75%%"} {
+		_, err := ucast.Unit[float64](input)
+		assert.ErrorIs(t, err, ucast.ErrInvalidUnit, input)
+	}
+}
+
+func TestUnit_ByteSize(t *testing.T) {
+	tests := map[string]int64{
+		"512":     512,
+		"512B":    512,
+		"10KB":    10_000,
+		"10kb":    10_000,
+		"10KiB":   10 * 1024,
+		"10K":     10 * 1024,
+		"1.5GiB":  1610612736,
+		"2 MB":    2_000_000,
+		"0.5KiB":  512,
+		"7EiB":    7 << 60,
+		"1.25 Mi": 1310720,
+	}
+	for input, expected := range tests {
+		v, err := ucast.Unit[int64](input)
+		require.NoError(t, err, input)
+		assert.Equal(t, expected, v, input)
+	}
+
+	for _, input := range []string{"", "KB", "10XB", "1.5B", "8EiB", "9.5EiB", "-1KB", "1..5KB"} {
+		_, err := ucast.Unit[int64](input)
+		assert.ErrorIs(t, err, ucast.ErrInvalidUnit, input)
+	}
+}
@@ -0,0 +1,111 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package ucircuit
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/kordax/basic-utils/uconst"
+)
+
+const (
+	// DefaultConsecutiveFailures is the number of consecutive failures tripping the Breaker by default.
+	DefaultConsecutiveFailures = 5
+	// DefaultOpenTimeout is the default duration the Breaker stays open before letting trial calls through.
+	DefaultOpenTimeout = 30 * time.Second
+)
+
+// Option configures a Breaker.
+type Option func(*options)
+
+type options struct {
+	consecutiveFailures int
+
+	rateThreshold   float64
+	rateMinRequests int
+	rateWindow      time.Duration
+
+	openTimeout      time.Duration
+	halfOpenRequests int
+	failureIf        func(err error) bool
+	onStateChange    func(from, to State)
+	clock            uconst.Clock
+}
+
+func defaultOptions() options {
+	return options{
+		consecutiveFailures: DefaultConsecutiveFailures,
+		openTimeout:         DefaultOpenTimeout,
+		halfOpenRequests:    1,
+		failureIf:           DefaultFailureIf,
+		clock:               uconst.SystemClock{},
+	}
+}
+
+// DefaultFailureIf considers all the errors failures, except context.Canceled, which is caused by the caller.
+func DefaultFailureIf(err error) bool {
+	return err != nil && !errors.Is(err, context.Canceled)
+}
+
+// WithConsecutiveFailures trips the Breaker after n consecutive failures. A non-positive n disables the policy,
+// which makes sense together with WithFailureRate.
+func WithConsecutiveFailures(n int) Option {
+	return func(o *options) {
+		o.consecutiveFailures = n
+	}
+}
+
+// WithFailureRate trips the Breaker once the failure rate within the sliding window reaches the threshold,
+// e.g. 0.5 for 50%, provided that at least minRequests calls have been made within the window.
+// It works together with the consecutive failures policy, disable the latter with WithConsecutiveFailures(0) if needed.
+func WithFailureRate(threshold float64, minRequests int, window time.Duration) Option {
+	return func(o *options) {
+		o.rateThreshold = threshold
+		o.rateMinRequests = max(minRequests, 1)
+		o.rateWindow = window
+	}
+}
+
+// WithOpenTimeout sets the duration the Breaker stays open before becoming half-open.
+func WithOpenTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.openTimeout = timeout
+	}
+}
+
+// WithHalfOpenRequests sets the number of trial calls let through in the half-open state, 1 by default.
+// The Breaker closes once all of them succeed.
+func WithHalfOpenRequests(n int) Option {
+	return func(o *options) {
+		o.halfOpenRequests = max(n, 1)
+	}
+}
+
+// WithFailureIf replaces the condition that decides whether the call result is a failure.
+// See DefaultFailureIf.
+func WithFailureIf(failureIf func(err error) bool) Option {
+	return func(o *options) {
+		o.failureIf = failureIf
+	}
+}
+
+// WithOnStateChange sets the callback called on every state change. The callback is called synchronously,
+// but outside the Breaker lock, so it may use the Breaker.
+func WithOnStateChange(onStateChange func(from, to State)) Option {
+	return func(o *options) {
+		o.onStateChange = onStateChange
+	}
+}
+
+// WithClock sets the time source, which is useful for tests.
+func WithClock(clock uconst.Clock) Option {
+	return func(o *options) {
+		o.clock = clock
+	}
+}
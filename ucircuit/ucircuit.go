@@ -0,0 +1,302 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+// Package ucircuit implements the circuit breaker pattern protecting callers from repeatedly invoking failing remote calls.
+package ucircuit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// State is a state of the Breaker.
+type State int
+
+const (
+	// Closed lets all the calls through and tracks their failures.
+	Closed State = iota
+	// Open rejects all the calls until the open timeout passes.
+	Open
+	// HalfOpen lets a limited number of trial calls through to check whether the remote side has recovered.
+	HalfOpen
+)
+
+func (s State) String() string {
+	switch s {
+	case Closed:
+		return "closed"
+	case Open:
+		return "open"
+	case HalfOpen:
+		return "half-open"
+	default:
+		return fmt.Sprintf("State(%d)", int(s))
+	}
+}
+
+// ErrOpen is returned, wrapped in OpenError, when the Breaker rejects a call.
+var ErrOpen = errors.New("ucircuit: circuit breaker is open")
+
+/*
+OpenError is returned when the Breaker rejects a call, either because it's open or because all the half-open trial
+calls are already in flight. It unwraps to ErrOpen and implements uerror.Retryable, reporting the call as retryable,
+so retry loops classifying errors with uerror.IsRetryable back off and try again instead of giving up.
+RetryAfter hints when the Breaker is going to let calls through again.
+*/
+type OpenError struct {
+	State      State
+	RetryAfter time.Duration
+}
+
+func (e *OpenError) Error() string {
+	return fmt.Sprintf("%s (%s, retry after %s)", ErrOpen, e.State, e.RetryAfter)
+}
+
+func (e *OpenError) Unwrap() error {
+	return ErrOpen
+}
+
+func (e *OpenError) Retryable() bool {
+	return true
+}
+
+/*
+Breaker is a circuit breaker. While closed, it records the results of the calls and trips open once the configured
+policies consider the remote side broken: after a number of consecutive failures and/or when the failure rate within
+a sliding window reaches the threshold. While open, calls are rejected with OpenError. Once the open timeout passes,
+the Breaker becomes half-open and lets a limited number of trial calls through: if all of them succeed it closes,
+while any failure opens it again. Results of the calls started before the last state change are ignored.
+
+Breaker is safe for concurrent use.
+*/
+type Breaker struct {
+	opts options
+
+	mtx         sync.Mutex
+	state       State
+	generation  uint64
+	openedAt    time.Time
+	consecutive int
+	window      *rateWindow
+	inFlight    int
+	successes   int
+}
+
+// New creates a Breaker in the Closed state. Without options it trips after 5 consecutive failures
+// and stays open for 30 seconds.
+func New(opts ...Option) *Breaker {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	b := &Breaker{opts: o}
+	if o.rateThreshold > 0 {
+		b.window = newRateWindow(o.rateWindow)
+	}
+
+	return b
+}
+
+// State returns the current state of the Breaker.
+func (b *Breaker) State() State {
+	b.mtx.Lock()
+	changes := b.refresh(b.opts.clock.Now())
+	state := b.state
+	b.mtx.Unlock()
+	b.notify(changes)
+
+	return state
+}
+
+// Reset forcibly closes the Breaker and forgets the recorded results.
+func (b *Breaker) Reset() {
+	b.mtx.Lock()
+	changes := b.setState(Closed, b.opts.clock.Now())
+	b.mtx.Unlock()
+	b.notify(changes)
+}
+
+/*
+Allow is a low-level alternative to Do for the calls that can't be wrapped into a function.
+If the call is permitted, it returns the done function, which must be called exactly once with the call result,
+otherwise it returns OpenError.
+*/
+func (b *Breaker) Allow() (done func(err error), err error) {
+	b.mtx.Lock()
+	now := b.opts.clock.Now()
+	changes := b.refresh(now)
+
+	switch b.state {
+	case Open:
+		err = &OpenError{State: Open, RetryAfter: b.openedAt.Add(b.opts.openTimeout).Sub(now)}
+	case HalfOpen:
+		if b.inFlight >= b.opts.halfOpenRequests {
+			err = &OpenError{State: HalfOpen}
+		} else {
+			b.inFlight++
+		}
+	}
+	generation := b.generation
+	b.mtx.Unlock()
+	b.notify(changes)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var once sync.Once
+	return func(err error) {
+		once.Do(func() {
+			b.record(generation, err)
+		})
+	}, nil
+}
+
+/*
+Do calls f if the Breaker permits it and records the result.
+The context is passed to f, and if it's already done, Do returns its error without calling f.
+Rejected calls return OpenError without calling f. A panic in f is recorded as a failure and propagated.
+By default, context.Canceled errors are not considered failures, see WithFailureIf.
+*/
+func (b *Breaker) Do(ctx context.Context, f func(ctx context.Context) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	done, err := b.Allow()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			done(fmt.Errorf("ucircuit: panic: %v", r))
+			panic(r)
+		}
+	}()
+	err = f(ctx)
+	done(err)
+
+	return err
+}
+
+/*
+Execute calls f through the Breaker and returns its result. If the call is rejected or fails, the fallback is called
+with the error and its result is returned instead, unless the fallback is nil.
+*/
+func Execute[T any](ctx context.Context, b *Breaker, f func(ctx context.Context) (T, error), fallback func(ctx context.Context, err error) (T, error)) (T, error) {
+	var result T
+	err := b.Do(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = f(ctx)
+		return err
+	})
+	if err != nil && fallback != nil {
+		return fallback(ctx, err)
+	}
+
+	return result, err
+}
+
+func (b *Breaker) record(generation uint64, err error) {
+	failure := b.opts.failureIf(err)
+
+	b.mtx.Lock()
+	now := b.opts.clock.Now()
+	changes := b.refresh(now)
+	if generation != b.generation {
+		b.mtx.Unlock()
+		b.notify(changes)
+		return
+	}
+
+	switch b.state {
+	case Closed:
+		if b.window != nil {
+			b.window.add(now, failure)
+		}
+		if !failure {
+			b.consecutive = 0
+			break
+		}
+		b.consecutive++
+		if b.shouldTrip(now) {
+			changes = append(changes, b.setState(Open, now)...)
+		}
+	case HalfOpen:
+		b.inFlight--
+		if failure {
+			changes = append(changes, b.setState(Open, now)...)
+			break
+		}
+		b.successes++
+		if b.successes >= b.opts.halfOpenRequests {
+			changes = append(changes, b.setState(Closed, now)...)
+		}
+	}
+	b.mtx.Unlock()
+	b.notify(changes)
+}
+
+func (b *Breaker) shouldTrip(now time.Time) bool {
+	if b.opts.consecutiveFailures > 0 && b.consecutive >= b.opts.consecutiveFailures {
+		return true
+	}
+	if b.window != nil {
+		requests, failures := b.window.totals(now)
+		if requests >= b.opts.rateMinRequests && float64(failures)/float64(requests) >= b.opts.rateThreshold {
+			return true
+		}
+	}
+
+	return false
+}
+
+// refresh moves an open Breaker to the half-open state once the open timeout passes.
+func (b *Breaker) refresh(now time.Time) []stateChange {
+	if b.state == Open && !now.Before(b.openedAt.Add(b.opts.openTimeout)) {
+		return b.setState(HalfOpen, now)
+	}
+
+	return nil
+}
+
+type stateChange struct {
+	from, to State
+}
+
+func (b *Breaker) setState(state State, now time.Time) []stateChange {
+	from := b.state
+	b.state = state
+	b.generation++
+	b.consecutive = 0
+	b.inFlight = 0
+	b.successes = 0
+	if b.window != nil {
+		b.window.reset()
+	}
+	if state == Open {
+		b.openedAt = now
+	}
+	if from == state {
+		return nil
+	}
+
+	return []stateChange{{from: from, to: state}}
+}
+
+// notify calls the state change callback outside the lock, so the callback may use the Breaker.
+func (b *Breaker) notify(changes []stateChange) {
+	if b.opts.onStateChange == nil {
+		return
+	}
+	for _, c := range changes {
+		b.opts.onStateChange(c.from, c.to)
+	}
+}
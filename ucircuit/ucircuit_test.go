@@ -0,0 +1,221 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package ucircuit_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kordax/basic-utils/ucircuit"
+	"github.com/kordax/basic-utils/uerror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errRemote = errors.New("remote failure")
+
+type fakeClock struct {
+	mtx sync.Mutex
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	panic("not used")
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func fail(context.Context) error {
+	return errRemote
+}
+
+func succeed(context.Context) error {
+	return nil
+}
+
+func TestBreaker_ConsecutiveFailures(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	var transitions []string
+	b := ucircuit.New(
+		ucircuit.WithConsecutiveFailures(3),
+		ucircuit.WithOpenTimeout(time.Second),
+		ucircuit.WithClock(clock),
+		ucircuit.WithOnStateChange(func(from, to ucircuit.State) {
+			transitions = append(transitions, from.String()+"->"+to.String())
+		}),
+	)
+	ctx := context.Background()
+
+	assert.ErrorIs(t, b.Do(ctx, fail), errRemote)
+	assert.ErrorIs(t, b.Do(ctx, fail), errRemote)
+	assert.NoError(t, b.Do(ctx, succeed), "a success resets the consecutive failures")
+	assert.ErrorIs(t, b.Do(ctx, fail), errRemote)
+	assert.ErrorIs(t, b.Do(ctx, fail), errRemote)
+	assert.Equal(t, ucircuit.Closed, b.State())
+	assert.ErrorIs(t, b.Do(ctx, fail), errRemote)
+	assert.Equal(t, ucircuit.Open, b.State())
+
+	called := false
+	err := b.Do(ctx, func(context.Context) error {
+		called = true
+		return nil
+	})
+	assert.False(t, called)
+	assert.ErrorIs(t, err, ucircuit.ErrOpen)
+	var openErr *ucircuit.OpenError
+	require.ErrorAs(t, err, &openErr)
+	assert.Equal(t, time.Second, openErr.RetryAfter)
+	assert.True(t, uerror.IsRetryable(err))
+
+	clock.Advance(time.Second)
+	assert.Equal(t, ucircuit.HalfOpen, b.State())
+	assert.ErrorIs(t, b.Do(ctx, fail), errRemote)
+	assert.Equal(t, ucircuit.Open, b.State(), "a half-open failure opens the breaker again")
+
+	clock.Advance(time.Second)
+	assert.NoError(t, b.Do(ctx, succeed))
+	assert.Equal(t, ucircuit.Closed, b.State())
+
+	assert.Equal(t, []string{"closed->open", "open->half-open", "half-open->open", "open->half-open", "half-open->closed"}, transitions)
+}
+
+func TestBreaker_FailureRate(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	b := ucircuit.New(
+		ucircuit.WithConsecutiveFailures(0),
+		ucircuit.WithFailureRate(0.5, 10, 10*time.Second),
+		ucircuit.WithClock(clock),
+	)
+	ctx := context.Background()
+
+	for i := 0; i < 8; i++ {
+		_ = b.Do(ctx, fail)
+	}
+	assert.Equal(t, ucircuit.Closed, b.State(), "not enough requests yet")
+
+	// the failures fall out of the window
+	clock.Advance(11 * time.Second)
+	for i := 0; i < 6; i++ {
+		_ = b.Do(ctx, succeed)
+	}
+	for i := 0; i < 3; i++ {
+		_ = b.Do(ctx, fail)
+	}
+	assert.Equal(t, ucircuit.Closed, b.State())
+	_ = b.Do(ctx, fail)
+	assert.Equal(t, ucircuit.Closed, b.State(), "4 of 10 failed")
+	_ = b.Do(ctx, fail)
+	_ = b.Do(ctx, fail)
+	assert.Equal(t, ucircuit.Open, b.State(), "6 of 12 failed")
+}
+
+func TestBreaker_HalfOpenRequests(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	b := ucircuit.New(ucircuit.WithConsecutiveFailures(1), ucircuit.WithHalfOpenRequests(2), ucircuit.WithClock(clock))
+	_ = b.Do(context.Background(), fail)
+	clock.Advance(ucircuit.DefaultOpenTimeout)
+
+	done1, err := b.Allow()
+	require.NoError(t, err)
+	done2, err := b.Allow()
+	require.NoError(t, err)
+	_, err = b.Allow()
+	var openErr *ucircuit.OpenError
+	require.ErrorAs(t, err, &openErr)
+	assert.Equal(t, ucircuit.HalfOpen, openErr.State)
+
+	done1(nil)
+	done1(errRemote) // subsequent calls are ignored
+	assert.Equal(t, ucircuit.HalfOpen, b.State())
+	done2(nil)
+	assert.Equal(t, ucircuit.Closed, b.State())
+}
+
+func TestBreaker_StaleResultsIgnored(t *testing.T) {
+	b := ucircuit.New(ucircuit.WithConsecutiveFailures(1))
+	done, err := b.Allow()
+	require.NoError(t, err)
+	_ = b.Do(context.Background(), fail)
+	assert.Equal(t, ucircuit.Open, b.State())
+
+	b.Reset()
+	done(errRemote)
+	assert.Equal(t, ucircuit.Closed, b.State(), "the result of the call started before reset must be ignored")
+}
+
+func TestBreaker_Context(t *testing.T) {
+	b := ucircuit.New(ucircuit.WithConsecutiveFailures(1))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	called := false
+	err := b.Do(ctx, func(context.Context) error {
+		called = true
+		return nil
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.False(t, called)
+
+	err = b.Do(context.Background(), func(context.Context) error {
+		return context.Canceled
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, ucircuit.Closed, b.State(), "cancellations are not failures")
+
+	_ = b.Do(context.Background(), func(context.Context) error {
+		return context.DeadlineExceeded
+	})
+	assert.Equal(t, ucircuit.Open, b.State(), "timeouts are failures")
+}
+
+func TestBreaker_Panic(t *testing.T) {
+	b := ucircuit.New(ucircuit.WithConsecutiveFailures(1))
+	assert.Panics(t, func() {
+		_ = b.Do(context.Background(), func(context.Context) error {
+			panic("boom")
+		})
+	})
+	assert.Equal(t, ucircuit.Open, b.State())
+}
+
+func TestExecute(t *testing.T) {
+	b := ucircuit.New(ucircuit.WithConsecutiveFailures(1))
+	ctx := context.Background()
+	fallback := func(_ context.Context, err error) (string, error) {
+		return "cached", nil
+	}
+
+	result, err := ucircuit.Execute(ctx, b, func(context.Context) (string, error) {
+		return "fresh", nil
+	}, fallback)
+	assert.NoError(t, err)
+	assert.Equal(t, "fresh", result)
+
+	result, err = ucircuit.Execute(ctx, b, func(context.Context) (string, error) {
+		return "", errRemote
+	}, fallback)
+	assert.NoError(t, err)
+	assert.Equal(t, "cached", result)
+
+	_, err = ucircuit.Execute(ctx, b, func(context.Context) (string, error) {
+		return "fresh", nil
+	}, nil)
+	assert.ErrorIs(t, err, ucircuit.ErrOpen)
+}
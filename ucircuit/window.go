@@ -0,0 +1,70 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package ucircuit
+
+import "time"
+
+// rateWindowBuckets is the number of buckets the failure rate window is split into.
+const rateWindowBuckets = 10
+
+type rateBucket struct {
+	requests int
+	failures int
+}
+
+// rateWindow counts requests and failures within a sliding window made of fixed buckets.
+type rateWindow struct {
+	buckets [rateWindowBuckets]rateBucket
+	width   time.Duration
+	head    int
+	start   time.Time
+}
+
+func newRateWindow(window time.Duration) *rateWindow {
+	return &rateWindow{width: max(window/rateWindowBuckets, 1)}
+}
+
+func (w *rateWindow) add(now time.Time, failure bool) {
+	w.advance(now)
+	w.buckets[w.head].requests++
+	if failure {
+		w.buckets[w.head].failures++
+	}
+}
+
+func (w *rateWindow) totals(now time.Time) (requests, failures int) {
+	w.advance(now)
+	for _, b := range w.buckets {
+		requests += b.requests
+		failures += b.failures
+	}
+
+	return requests, failures
+}
+
+func (w *rateWindow) reset() {
+	w.buckets = [rateWindowBuckets]rateBucket{}
+	w.start = time.Time{}
+}
+
+// advance moves the head to the bucket covering now, clearing the buckets that went out of the window.
+func (w *rateWindow) advance(now time.Time) {
+	if w.start.IsZero() {
+		w.start = now
+		return
+	}
+
+	steps := int(now.Sub(w.start) / w.width)
+	if steps <= 0 {
+		return
+	}
+	for i := 0; i < min(steps, rateWindowBuckets); i++ {
+		w.head = (w.head + 1) % rateWindowBuckets
+		w.buckets[w.head] = rateBucket{}
+	}
+	w.start = w.start.Add(time.Duration(steps) * w.width)
+}
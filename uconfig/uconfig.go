@@ -0,0 +1,539 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+/*
+Package uconfig loads a configuration struct from layered sources: the `default` struct tags,
+JSON/YAML files and environment variables, each layer overriding the previous ones.
+
+	type Config struct {
+		Addr string        `conf:"addr,required"`
+		DB   *DBConfig     `conf:"db"`
+		TTL  time.Duration `conf:"ttl" default:"1m"`
+	}
+
+	var cfg Config
+	err := uconfig.Load(&cfg, uconfig.WithFile("config.yaml"), uconfig.WithEnvPrefix("APP"))
+
+String values of the files and the defaults may reference environment variables as ${NAME},
+e.g. `password: ${DB_PASSWORD}`, which keeps the secrets out of the files.
+*/
+package uconfig
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kordax/basic-utils/ucast"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	tagName    = "conf"
+	defaultTag = "default"
+	sliceSep   = ","
+)
+
+// Error is returned by Load when the configuration contains unknown keys or lacks required fields.
+// Both lists contain dotted field paths, e.g. "db.host".
+type Error struct {
+	UnknownKeys   []string
+	MissingFields []string
+}
+
+func (e *Error) Error() string {
+	var parts []string
+	if len(e.UnknownKeys) > 0 {
+		parts = append(parts, "unknown keys: "+strings.Join(e.UnknownKeys, ", "))
+	}
+	if len(e.MissingFields) > 0 {
+		parts = append(parts, "missing required fields: "+strings.Join(e.MissingFields, ", "))
+	}
+
+	return "invalid configuration: " + strings.Join(parts, "; ")
+}
+
+type options struct {
+	files         []string
+	envPrefix     string
+	lookupEnv     func(key string) (string, bool)
+	allowUnknowns bool
+}
+
+// Option configures the Load call.
+type Option func(o *options)
+
+// WithFile adds a configuration file layer. The format is detected by the extension: .json, .yaml or .yml.
+// Files are applied in the order they were provided, so the later files override the earlier ones.
+func WithFile(path string) Option {
+	return func(o *options) {
+		o.files = append(o.files, path)
+	}
+}
+
+// WithEnvPrefix sets the prefix for environment variable names, e.g. "APP" produces "APP_DB_HOST" for "db.host" field.
+func WithEnvPrefix(prefix string) Option {
+	return func(o *options) {
+		o.envPrefix = prefix
+	}
+}
+
+// WithEnvLookup replaces os.LookupEnv as the source of environment overrides.
+func WithEnvLookup(lookup func(key string) (string, bool)) Option {
+	return func(o *options) {
+		o.lookupEnv = lookup
+	}
+}
+
+// WithUnknownKeysAllowed disables reporting of file keys that don't match any field.
+func WithUnknownKeysAllowed() Option {
+	return func(o *options) {
+		o.allowUnknowns = true
+	}
+}
+
+// Load fills the cfg struct in layers:
+//  1. defaults, taken from the `default` tags (the values already present in cfg are kept otherwise);
+//  2. configuration files (JSON/YAML), see WithFile;
+//  3. environment variables, see WithEnvPrefix.
+//
+// Fields are matched by the `conf` tag: `conf:"name"` or `conf:"name,required"`, `conf:"-"` skips the field.
+// Untagged exported fields are matched by their lower-cased name. Nested structs produce dotted paths in files
+// and underscore separated upper-case names in environment, e.g. "db.host" and "DB_HOST".
+//
+// Basic types, time.Duration, slices (comma-separated in environment), pointers,
+// encoding.TextUnmarshaler and json.Unmarshaler implementations (including uopt.Opt) are supported.
+// Environment values for json.Unmarshaler fields are treated as JSON, falling back to a plain JSON string.
+//
+// Nested struct pointers are allocated if nil, so the nested fields can be set the same way as for the struct values.
+//
+// The ${NAME} references in the defaults and the file string values, including the ones nested in lists and objects,
+// are substituted with the values of the environment variables, looked up with the same function as the overrides,
+// but without the prefix. A reference to an undefined variable is an error, a "$" that doesn't start a reference is kept as is.
+// Values taken from environment variables are not substituted.
+//
+// Unknown file keys and required fields that were not set by any layer are reported with *Error.
+func Load[T any](cfg *T, opts ...Option) error {
+	o := &options{lookupEnv: os.LookupEnv}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	l := &loader{opts: o, set: make(map[string]bool)}
+	root := reflect.ValueOf(cfg).Elem()
+	if root.Kind() != reflect.Struct {
+		return fmt.Errorf("uconfig: config must be a struct, got %s", root.Type())
+	}
+
+	if err := l.applyDefaults(root, ""); err != nil {
+		return err
+	}
+	for _, file := range o.files {
+		data, err := readFile(file)
+		if err != nil {
+			return err
+		}
+		expanded, err := l.expandRaw(data)
+		if err != nil {
+			return fmt.Errorf("uconfig: failed to substitute variables in config file %s: %w", file, err)
+		}
+		if err := l.applyMap(root, "", expanded.(map[string]any)); err != nil {
+			return err
+		}
+	}
+	if err := l.applyEnv(root, ""); err != nil {
+		return err
+	}
+	l.checkRequired(root, "")
+
+	if len(l.unknown) > 0 || len(l.missing) > 0 {
+		sort.Strings(l.unknown)
+		return &Error{UnknownKeys: l.unknown, MissingFields: l.missing}
+	}
+
+	return nil
+}
+
+type field struct {
+	value    reflect.Value
+	name     string
+	required bool
+	def      *string
+}
+
+type loader struct {
+	opts    *options
+	set     map[string]bool
+	unknown []string
+	missing []string
+}
+
+func (l *loader) applyDefaults(v reflect.Value, prefix string) error {
+	for _, f := range fields(v) {
+		path := join(prefix, f.name)
+		if nested, ok := nestedStruct(f.value); ok {
+			if err := l.applyDefaults(nested, path); err != nil {
+				return err
+			}
+			continue
+		}
+		if f.def != nil {
+			def, err := l.expand(*f.def)
+			if err != nil {
+				return fmt.Errorf("uconfig: invalid default value for %s: %w", path, err)
+			}
+			if err := setString(f.value, def); err != nil {
+				return fmt.Errorf("uconfig: invalid default value for %s: %w", path, err)
+			}
+			l.set[path] = true
+		}
+	}
+
+	return nil
+}
+
+func (l *loader) applyMap(v reflect.Value, prefix string, data map[string]any) error {
+	known := make(map[string]bool)
+	for _, f := range fields(v) {
+		path := join(prefix, f.name)
+		raw, ok := data[f.name]
+		if !ok {
+			continue
+		}
+		known[f.name] = true
+
+		if values, isMap := raw.(map[string]any); isMap {
+			if nested, ok := nestedStruct(f.value); ok {
+				if err := l.applyMap(nested, path, values); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+		if err := setRaw(f.value, raw); err != nil {
+			return fmt.Errorf("uconfig: invalid value for %s: %w", path, err)
+		}
+		l.set[path] = true
+	}
+
+	if !l.opts.allowUnknowns {
+		for key := range data {
+			if !known[key] {
+				l.unknown = append(l.unknown, join(prefix, key))
+			}
+		}
+	}
+
+	return nil
+}
+
+func (l *loader) applyEnv(v reflect.Value, prefix string) error {
+	for _, f := range fields(v) {
+		path := join(prefix, f.name)
+		if nested, ok := nestedStruct(f.value); ok {
+			if err := l.applyEnv(nested, path); err != nil {
+				return err
+			}
+			continue
+		}
+		key := l.envKey(path)
+		if value, ok := l.opts.lookupEnv(key); ok {
+			if err := setString(f.value, value); err != nil {
+				return fmt.Errorf("uconfig: invalid value of %s environment variable: %w", key, err)
+			}
+			l.set[path] = true
+		}
+	}
+
+	return nil
+}
+
+func (l *loader) checkRequired(v reflect.Value, prefix string) {
+	for _, f := range fields(v) {
+		path := join(prefix, f.name)
+		if nested, ok := nestedStruct(f.value); ok {
+			l.checkRequired(nested, path)
+			continue
+		}
+		if f.required && !l.set[path] && f.value.IsZero() {
+			l.missing = append(l.missing, path)
+		}
+	}
+}
+
+func (l *loader) envKey(path string) string {
+	key := strings.ToUpper(strings.ReplaceAll(path, ".", "_"))
+	if l.opts.envPrefix != "" {
+		return l.opts.envPrefix + "_" + key
+	}
+
+	return key
+}
+
+func fields(v reflect.Value) []field {
+	t := v.Type()
+	result := make([]field, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+
+		f := field{value: v.Field(i), name: strings.ToLower(sf.Name)}
+		if tag, ok := sf.Tag.Lookup(tagName); ok {
+			if tag == "-" {
+				continue
+			}
+			name, flags, _ := strings.Cut(tag, ",")
+			if name != "" {
+				f.name = name
+			}
+			f.required = flags == "required"
+		}
+		if def, ok := sf.Tag.Lookup(defaultTag); ok {
+			f.def = &def
+		}
+		result = append(result, f)
+	}
+
+	return result
+}
+
+// expand substitutes the ${NAME} references in s with the values of the environment variables.
+func (l *loader) expand(s string) (string, error) {
+	if !strings.Contains(s, "${") {
+		return s, nil
+	}
+
+	var b strings.Builder
+	for {
+		start := strings.Index(s, "${")
+		if start < 0 {
+			break
+		}
+		end := strings.IndexByte(s[start:], '}')
+		if end < 0 {
+			break
+		}
+		name := s[start+2 : start+end]
+		value, ok := l.opts.lookupEnv(name)
+		if !ok {
+			return "", fmt.Errorf("undefined environment variable %q", name)
+		}
+		b.WriteString(s[:start])
+		b.WriteString(value)
+		s = s[start+end+1:]
+	}
+	b.WriteString(s)
+
+	return b.String(), nil
+}
+
+// expandRaw substitutes the environment variables in the string values of the decoded file content.
+func (l *loader) expandRaw(raw any) (any, error) {
+	switch r := raw.(type) {
+	case string:
+		return l.expand(r)
+	case []any:
+		for i, item := range r {
+			expanded, err := l.expandRaw(item)
+			if err != nil {
+				return nil, err
+			}
+			r[i] = expanded
+		}
+	case map[string]any:
+		for key, item := range r {
+			expanded, err := l.expandRaw(item)
+			if err != nil {
+				return nil, err
+			}
+			r[key] = expanded
+		}
+	}
+
+	return raw, nil
+}
+
+// nestedStruct returns the struct whose fields are configured separately: a struct field or the struct
+// a pointer field refers to, which is allocated if the pointer is nil. Unmarshalers are configured as a whole.
+func nestedStruct(v reflect.Value) (reflect.Value, bool) {
+	switch {
+	case v.Kind() == reflect.Struct && !isUnmarshaler(v):
+		return v, true
+	case v.Kind() == reflect.Pointer && v.Type().Elem().Kind() == reflect.Struct && !isUnmarshaler(reflect.New(v.Type().Elem()).Elem()):
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return v.Elem(), true
+	default:
+		return reflect.Value{}, false
+	}
+}
+
+func isUnmarshaler(v reflect.Value) bool {
+	if !v.CanAddr() {
+		return false
+	}
+	switch v.Addr().Interface().(type) {
+	case encoding.TextUnmarshaler, json.Unmarshaler:
+		return true
+	default:
+		return false
+	}
+}
+
+func setRaw(v reflect.Value, raw any) error {
+	if u, ok := v.Addr().Interface().(json.Unmarshaler); ok {
+		if _, isText := v.Addr().Interface().(encoding.TextUnmarshaler); !isText {
+			data, err := json.Marshal(raw)
+			if err != nil {
+				return err
+			}
+			return u.UnmarshalJSON(data)
+		}
+	}
+
+	switch r := raw.(type) {
+	case nil:
+		v.SetZero()
+		return nil
+	case []any:
+		if v.Kind() != reflect.Slice {
+			return fmt.Errorf("list can't be assigned to %s", v.Type())
+		}
+		result := reflect.MakeSlice(v.Type(), len(r), len(r))
+		for i, item := range r {
+			if err := setRaw(result.Index(i), item); err != nil {
+				return err
+			}
+		}
+		v.Set(result)
+		return nil
+	case map[string]any:
+		return fmt.Errorf("object can't be assigned to %s", v.Type())
+	case string:
+		return setString(v, r)
+	case float64:
+		return setString(v, strconv.FormatFloat(r, 'f', -1, 64))
+	default:
+		return setString(v, fmt.Sprint(r))
+	}
+}
+
+func setString(v reflect.Value, s string) error {
+	switch u := v.Addr().Interface().(type) {
+	case encoding.TextUnmarshaler:
+		return u.UnmarshalText([]byte(s))
+	case json.Unmarshaler:
+		if json.Valid([]byte(s)) && u.UnmarshalJSON([]byte(s)) == nil {
+			return nil
+		}
+		quoted, _ := json.Marshal(s)
+		return u.UnmarshalJSON(quoted)
+	case *time.Duration:
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return err
+		}
+		*u = d
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := ucast.StringToBool(&s)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := ucast.StringToInt64(&s)
+		if err != nil {
+			return err
+		}
+		if v.OverflowInt(i) {
+			return fmt.Errorf("value %d overflows %s", i, v.Type())
+		}
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := ucast.StringToUint64(&s)
+		if err != nil {
+			return err
+		}
+		if v.OverflowUint(u) {
+			return fmt.Errorf("value %d overflows %s", u, v.Type())
+		}
+		v.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := ucast.StringToFloat64(&s)
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	case reflect.Slice:
+		var parts []string
+		if s != "" {
+			parts = strings.Split(s, sliceSep)
+		}
+		result := reflect.MakeSlice(v.Type(), len(parts), len(parts))
+		for i, part := range parts {
+			if err := setString(result.Index(i), strings.TrimSpace(part)); err != nil {
+				return err
+			}
+		}
+		v.Set(result)
+	case reflect.Pointer:
+		ptr := reflect.New(v.Type().Elem())
+		if err := setString(ptr.Elem(), s); err != nil {
+			return err
+		}
+		v.Set(ptr)
+	default:
+		return fmt.Errorf("unsupported field type %s", v.Type())
+	}
+
+	return nil
+}
+
+func readFile(path string) (map[string]any, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("uconfig: failed to read config file: %w", err)
+	}
+
+	data := make(map[string]any)
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".json":
+		err = json.Unmarshal(content, &data)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(content, &data)
+	default:
+		return nil, fmt.Errorf("uconfig: unsupported config file format: %s", ext)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("uconfig: failed to parse config file %s: %w", path, err)
+	}
+
+	return data, nil
+}
+
+func join(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+
+	return prefix + "." + name
+}
@@ -0,0 +1,30 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package uconst
+
+import "time"
+
+/*
+Clock abstracts the time source, so time-dependent code can be driven by a fake clock in tests.
+*/
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+/*
+SystemClock is a Clock backed by the time package.
+*/
+type SystemClock struct{}
+
+func (SystemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (SystemClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
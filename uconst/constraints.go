@@ -6,26 +6,48 @@
 
 package uconst
 
+import "cmp"
+
+// Numeric is satisfied by all the integer and floating-point types, including the types derived from them.
 type Numeric interface {
 	Integer | Float
 }
 
+// Integer is satisfied by all the signed and unsigned integer types.
 type Integer interface {
 	~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64
 }
 
+// Signed is satisfied by the signed integer types.
+type Signed interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64
+}
+
+// Unsigned is satisfied by the unsigned integer types. It doesn't include uintptr, which holds addresses rather than numbers.
+type Unsigned interface {
+	~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64
+}
+
+// Float is satisfied by the floating-point types.
 type Float interface {
 	~float32 | ~float64
 }
 
+// SignedNumeric is satisfied by the numeric types able to hold negative values: signed integers and floats.
 type SignedNumeric interface {
-	~int | ~int8 | ~int16 | ~int32 | ~int64 | ~float32 | ~float64
+	Signed | Float
 }
 
+// Ordered is satisfied by the types supporting the < <= >= > operators. It's an alias of cmp.Ordered,
+// so the functions constrained by either one accept the same types.
+type Ordered = cmp.Ordered
+
+// Stringable is satisfied by the types having an obvious string representation: numbers, booleans and strings.
 type Stringable interface {
 	Numeric | ~bool | ~string
 }
 
+// BasicType is satisfied by the Stringable types and the pointers to the predeclared ones.
 type BasicType interface {
 	~string | ~bool | ~int | ~int8 | ~int16 | ~int32 | ~int64 |
 		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~float32 | ~float64 |
@@ -0,0 +1,256 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package ucron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxLookahead bounds the search in Next, so impossible schedules like "0 0 30 2 *" terminate.
+const maxLookahead = 5
+
+var macros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+var (
+	monthNames = map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}
+	dayNames = map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}
+)
+
+type field struct {
+	name     string
+	min, max int
+	names    map[string]int
+}
+
+var (
+	minuteField = field{name: "minute", min: 0, max: 59}
+	hourField   = field{name: "hour", min: 0, max: 23}
+	domField    = field{name: "day of month", min: 1, max: 31}
+	monthField  = field{name: "month", min: 1, max: 12, names: monthNames}
+	// Both 0 and 7 stand for Sunday.
+	dowField = field{name: "day of week", min: 0, max: 7, names: dayNames}
+)
+
+// Schedule is a parsed standard 5-field cron expression: minute, hour, day of month, month and day of week.
+//
+// Fields support wildcards (*), values, ranges (1-5), lists (1,3,5) and steps (*/15, 1-30/5),
+// months and days of week also accept three-letter English names (JAN, MON).
+// If both the day of month and the day of week are restricted, a time matches when either of them matches,
+// following the traditional cron behaviour.
+// The macros @yearly, @annually, @monthly, @weekly, @daily, @midnight and @hourly are supported as well.
+type Schedule struct {
+	minute, hour, dom, month, dow uint64
+	domAny, dowAny                bool
+}
+
+// Parse parses the cron expression.
+func Parse(expr string) (*Schedule, error) {
+	expr = strings.TrimSpace(expr)
+	if macro, ok := macros[strings.ToLower(expr)]; ok {
+		expr = macro
+	}
+
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("ucron: expected 5 fields in %q, got %d", expr, len(fields))
+	}
+
+	s := &Schedule{
+		domAny: fields[2] == "*" || fields[2] == "?",
+		dowAny: fields[4] == "*" || fields[4] == "?",
+	}
+	var err error
+	if s.minute, err = parseField(fields[0], minuteField); err != nil {
+		return nil, err
+	}
+	if s.hour, err = parseField(fields[1], hourField); err != nil {
+		return nil, err
+	}
+	if s.dom, err = parseField(fields[2], domField); err != nil {
+		return nil, err
+	}
+	if s.month, err = parseField(fields[3], monthField); err != nil {
+		return nil, err
+	}
+	if s.dow, err = parseField(fields[4], dowField); err != nil {
+		return nil, err
+	}
+	if s.dow&(1<<7) != 0 {
+		s.dow = s.dow&^(1<<7) | 1
+	}
+
+	return s, nil
+}
+
+// MustParse is the same as Parse, but panics if the expression is invalid.
+func MustParse(expr string) *Schedule {
+	s, err := Parse(expr)
+	if err != nil {
+		panic(err)
+	}
+
+	return s
+}
+
+// Next returns the earliest matching time strictly after t in t's location, truncated to a minute.
+// The zero time is returned if the schedule doesn't match anything within the next five years.
+func (s *Schedule) Next(t time.Time) time.Time {
+	t = t.Add(time.Minute - time.Duration(t.Second())*time.Second - time.Duration(t.Nanosecond()))
+	loc := t.Location()
+	limit := t.Year() + maxLookahead
+	added := false
+
+wrap:
+	if t.Year() > limit {
+		return time.Time{}
+	}
+
+	for !has(s.month, int(t.Month())) {
+		if !added {
+			added = true
+			t = time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, loc)
+		}
+		t = t.AddDate(0, 1, 0)
+		if t.Month() == time.January {
+			goto wrap
+		}
+	}
+
+	for !s.dayMatches(t) {
+		if !added {
+			added = true
+			t = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+		}
+		t = t.AddDate(0, 0, 1)
+		if t.Day() == 1 {
+			goto wrap
+		}
+	}
+
+	for !has(s.hour, t.Hour()) {
+		if !added {
+			added = true
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, loc)
+		}
+		t = t.Add(time.Hour)
+		if t.Hour() == 0 {
+			goto wrap
+		}
+	}
+
+	for !has(s.minute, t.Minute()) {
+		added = true
+		t = t.Add(time.Minute)
+		if t.Minute() == 0 {
+			goto wrap
+		}
+	}
+
+	return t
+}
+
+func (s *Schedule) dayMatches(t time.Time) bool {
+	dom := has(s.dom, t.Day())
+	dow := has(s.dow, int(t.Weekday()))
+	if s.domAny || s.dowAny {
+		return dom && dow
+	}
+
+	return dom || dow
+}
+
+func has(set uint64, v int) bool {
+	return set&(1<<uint(v)) != 0
+}
+
+func parseField(expr string, f field) (uint64, error) {
+	var set uint64
+	for _, part := range strings.Split(expr, ",") {
+		bitsSet, err := parsePart(part, f)
+		if err != nil {
+			return 0, fmt.Errorf("ucron: invalid %s field %q: %w", f.name, expr, err)
+		}
+		set |= bitsSet
+	}
+
+	return set, nil
+}
+
+func parsePart(part string, f field) (uint64, error) {
+	rangeExpr, stepExpr, hasStep := strings.Cut(part, "/")
+	step := 1
+	if hasStep {
+		var err error
+		if step, err = strconv.Atoi(stepExpr); err != nil || step <= 0 {
+			return 0, fmt.Errorf("invalid step %q", stepExpr)
+		}
+	}
+
+	var from, to int
+	switch {
+	case rangeExpr == "*" || rangeExpr == "?":
+		from, to = f.min, f.max
+	case strings.Contains(rangeExpr, "-"):
+		lo, hi, _ := strings.Cut(rangeExpr, "-")
+		var err error
+		if from, err = parseValue(lo, f); err != nil {
+			return 0, err
+		}
+		if to, err = parseValue(hi, f); err != nil {
+			return 0, err
+		}
+		if from > to {
+			return 0, fmt.Errorf("invalid range %q", rangeExpr)
+		}
+	default:
+		var err error
+		if from, err = parseValue(rangeExpr, f); err != nil {
+			return 0, err
+		}
+		to = from
+		if hasStep {
+			to = f.max
+		}
+	}
+
+	var set uint64
+	for v := from; v <= to; v += step {
+		set |= 1 << uint(v)
+	}
+	return set, nil
+}
+
+func parseValue(s string, f field) (int, error) {
+	if v, ok := f.names[strings.ToLower(s)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", s)
+	}
+	if v < f.min || v > f.max {
+		return 0, fmt.Errorf("value %d is out of range [%d, %d]", v, f.min, f.max)
+	}
+
+	return v, nil
+}
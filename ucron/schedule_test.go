@@ -0,0 +1,96 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package ucron_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kordax/basic-utils/ucron"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func date(s string) time.Time {
+	t, err := time.Parse("2006-01-02 15:04:05", s)
+	if err != nil {
+		panic(err)
+	}
+
+	return t
+}
+
+func TestParse_Invalid(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"a * * * *",
+		"* * * FOO *",
+		"1,,2 * * * *",
+	} {
+		_, err := ucron.Parse(expr)
+		assert.Error(t, err, expr)
+	}
+
+	assert.Panics(t, func() { ucron.MustParse("bad") })
+}
+
+func TestSchedule_Next(t *testing.T) {
+	tests := []struct {
+		expr     string
+		from     string
+		expected string
+	}{
+		{"* * * * *", "2026-03-10 10:00:00", "2026-03-10 10:01:00"},
+		{"* * * * *", "2026-03-10 10:00:30", "2026-03-10 10:01:00"},
+		{"*/15 * * * *", "2026-03-10 10:07:00", "2026-03-10 10:15:00"},
+		{"0 * * * *", "2026-03-10 23:59:00", "2026-03-11 00:00:00"},
+		{"30 9 * * *", "2026-03-10 09:30:00", "2026-03-11 09:30:00"},
+		{"0 0 1 1 *", "2026-03-10 00:00:00", "2027-01-01 00:00:00"},
+		{"@yearly", "2026-12-31 23:59:00", "2027-01-01 00:00:00"},
+		{"@hourly", "2026-03-10 10:59:59", "2026-03-10 11:00:00"},
+		{"@weekly", "2026-03-10 00:00:00", "2026-03-15 00:00:00"},
+		{"0 9 * * MON-FRI", "2026-03-13 10:00:00", "2026-03-16 09:00:00"},
+		{"0 0 * * 7", "2026-03-10 00:00:00", "2026-03-15 00:00:00"},
+		{"0 0 29 2 *", "2026-03-10 00:00:00", "2028-02-29 00:00:00"},
+		{"0 0 31 * *", "2026-04-01 00:00:00", "2026-05-31 00:00:00"},
+		{"5,10-12/2 * * jan *", "2026-03-10 00:00:00", "2027-01-01 00:05:00"},
+		{"10/20 * * * *", "2026-03-10 00:11:00", "2026-03-10 00:30:00"},
+		// Restricted day of month and day of week match when either of them matches.
+		{"0 0 13 * FRI", "2026-03-01 00:00:00", "2026-03-06 00:00:00"},
+		{"0 0 1 * FRI", "2026-03-28 00:00:00", "2026-04-01 00:00:00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr+" from "+tt.from, func(t *testing.T) {
+			s, err := ucron.Parse(tt.expr)
+			require.NoError(t, err)
+			assert.Equal(t, date(tt.expected), s.Next(date(tt.from)))
+		})
+	}
+}
+
+func TestSchedule_NextImpossible(t *testing.T) {
+	s := ucron.MustParse("0 0 30 2 *")
+	assert.True(t, s.Next(date("2026-01-01 00:00:00")).IsZero())
+}
+
+func TestSchedule_NextLocation(t *testing.T) {
+	loc := time.FixedZone("UTC+3", 3*60*60)
+	s := ucron.MustParse("0 9 * * *")
+	next := s.Next(time.Date(2026, 3, 10, 10, 0, 0, 0, loc))
+	assert.Equal(t, time.Date(2026, 3, 11, 9, 0, 0, 0, loc), next)
+	assert.Equal(t, loc, next.Location())
+}
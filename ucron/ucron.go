@@ -0,0 +1,219 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+// Package ucron provides cron expression parsing and a lightweight scheduler running jobs on cron schedules.
+package ucron
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/kordax/basic-utils/uconst"
+	"github.com/kordax/basic-utils/ulog"
+)
+
+// ErrDuplicateJob is returned by Scheduler.Add when a job with the same name is already registered.
+var ErrDuplicateJob = errors.New("ucron: job is already registered")
+
+// Job is a unit of work run by the Scheduler. The context is cancelled when the scheduler stops.
+type Job func(ctx context.Context) error
+
+// Option configures the Scheduler.
+type Option func(*Scheduler)
+
+// WithClock sets the clock used to compute and wait for the schedules, uconst.SystemClock is used by default.
+func WithClock(clock uconst.Clock) Option {
+	return func(s *Scheduler) {
+		s.clock = clock
+	}
+}
+
+// WithLogger sets the logger that receives job failures and panics, which are discarded by default.
+func WithLogger(logger ulog.Logger) Option {
+	return func(s *Scheduler) {
+		s.logger = ulog.OrNop(logger)
+	}
+}
+
+type entry struct {
+	schedule *Schedule
+	job      Job
+	next     time.Time
+}
+
+/*
+Scheduler runs registered jobs on their cron schedules.
+
+Jobs are started in separate goroutines, so a slow job doesn't delay the others,
+and a job can overlap with its own previous run if it takes longer than the interval between runs.
+Job errors and panics are recovered and reported to the logger.
+Jobs can be added and removed at any time, including while the scheduler is running.
+*/
+type Scheduler struct {
+	clock  uconst.Clock
+	logger ulog.Logger
+
+	mtx     sync.Mutex
+	entries map[string]*entry
+	wake    chan struct{}
+}
+
+// New creates a new Scheduler.
+func New(opts ...Option) *Scheduler {
+	s := &Scheduler{
+		clock:   uconst.SystemClock{},
+		logger:  ulog.Nop(),
+		entries: make(map[string]*entry),
+		wake:    make(chan struct{}, 1),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// Add registers the job under the unique name to run on the cron expression schedule.
+func (s *Scheduler) Add(name, expr string, job Job) error {
+	schedule, err := Parse(expr)
+	if err != nil {
+		return err
+	}
+
+	return s.AddSchedule(name, schedule, job)
+}
+
+// AddSchedule registers the job under the unique name to run on the parsed schedule.
+func (s *Scheduler) AddSchedule(name string, schedule *Schedule, job Job) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if _, ok := s.entries[name]; ok {
+		return fmt.Errorf("%w: %s", ErrDuplicateJob, name)
+	}
+	s.entries[name] = &entry{schedule: schedule, job: job, next: schedule.Next(s.clock.Now())}
+	s.notify()
+
+	return nil
+}
+
+// Remove unregisters the job. Runs that are already in progress are not interrupted.
+// Returns false if there is no such job.
+func (s *Scheduler) Remove(name string) bool {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if _, ok := s.entries[name]; !ok {
+		return false
+	}
+	delete(s.entries, name)
+	s.notify()
+
+	return true
+}
+
+// Jobs returns the sorted names of the registered jobs.
+func (s *Scheduler) Jobs() []string {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	result := make([]string, 0, len(s.entries))
+	for name := range s.entries {
+		result = append(result, name)
+	}
+	sort.Strings(result)
+
+	return result
+}
+
+// Next returns the next run time of the job. The second value is false if there is no such job
+// or its schedule doesn't match anything in the foreseeable future.
+func (s *Scheduler) Next(name string) (time.Time, bool) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	e, ok := s.entries[name]
+	if !ok || e.next.IsZero() {
+		return time.Time{}, false
+	}
+
+	return e.next, true
+}
+
+// Run runs the jobs until the context is cancelled, then waits for the running jobs to finish and returns the context error.
+func (s *Scheduler) Run(ctx context.Context) error {
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	// Changes made before Run are picked up by the first dispatch anyway.
+	select {
+	case <-s.wake:
+	default:
+	}
+
+	for {
+		now := s.clock.Now()
+		earliest := s.dispatch(ctx, now, &wg)
+
+		var timer <-chan time.Time
+		if !earliest.IsZero() {
+			timer = s.clock.After(earliest.Sub(now))
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer:
+		case <-s.wake:
+		}
+	}
+}
+
+// dispatch starts the due jobs and returns the earliest upcoming run time.
+func (s *Scheduler) dispatch(ctx context.Context, now time.Time, wg *sync.WaitGroup) time.Time {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	var earliest time.Time
+	for name, e := range s.entries {
+		if e.next.IsZero() {
+			continue
+		}
+		if !e.next.After(now) {
+			wg.Add(1)
+			go s.run(ctx, wg, name, e.job)
+			e.next = e.schedule.Next(now)
+			if e.next.IsZero() {
+				continue
+			}
+		}
+		if earliest.IsZero() || e.next.Before(earliest) {
+			earliest = e.next
+		}
+	}
+
+	return earliest
+}
+
+func (s *Scheduler) run(ctx context.Context, wg *sync.WaitGroup, name string, job Job) {
+	defer wg.Done()
+	defer func() {
+		if r := recover(); r != nil {
+			s.logger.Error("cron job panicked", ulog.F("job", name), ulog.F("panic", r))
+		}
+	}()
+
+	if err := job(ctx); err != nil {
+		s.logger.Error("cron job failed", ulog.F("job", name), ulog.F("error", err))
+	}
+}
+
+func (s *Scheduler) notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
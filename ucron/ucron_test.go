@@ -0,0 +1,214 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package ucron_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kordax/basic-utils/ucron"
+	"github.com/kordax/basic-utils/ulog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type waiter struct {
+	at time.Time
+	ch chan time.Time
+}
+
+// fakeClock signals every After call, so tests advance the time only when the scheduler is waiting.
+type fakeClock struct {
+	mtx     sync.Mutex
+	now     time.Time
+	waiters []waiter
+	waiting chan struct{}
+}
+
+func newFakeClock(now time.Time) *fakeClock {
+	return &fakeClock{now: now, waiting: make(chan struct{}, 100)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	ch := make(chan time.Time, 1)
+	c.waiters = append(c.waiters, waiter{at: c.now.Add(d), ch: ch})
+	c.waiting <- struct{}{}
+
+	return ch
+}
+
+func (c *fakeClock) Set(now time.Time) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.now = now
+	pending := c.waiters[:0]
+	for _, w := range c.waiters {
+		if !w.at.After(now) {
+			w.ch <- now
+			continue
+		}
+		pending = append(pending, w)
+	}
+	c.waiters = pending
+}
+
+func (c *fakeClock) awaitWaiting(t *testing.T) {
+	select {
+	case <-c.waiting:
+	case <-time.After(time.Second):
+		t.Fatal("scheduler is not waiting")
+	}
+}
+
+type recordingLogger struct {
+	mtx      sync.Mutex
+	messages []string
+}
+
+func (l *recordingLogger) Debug(string, ...ulog.Field) {}
+func (l *recordingLogger) Info(string, ...ulog.Field)  {}
+func (l *recordingLogger) Warn(string, ...ulog.Field)  {}
+func (l *recordingLogger) Error(msg string, _ ...ulog.Field) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	l.messages = append(l.messages, msg)
+}
+
+func (l *recordingLogger) Messages() []string {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	return append([]string(nil), l.messages...)
+}
+
+func receive[T any](t *testing.T, ch <-chan T) T {
+	select {
+	case v := <-ch:
+		return v
+	case <-time.After(time.Second):
+		t.Fatal("timed out")
+		var zero T
+		return zero
+	}
+}
+
+func TestScheduler_Run(t *testing.T) {
+	clock := newFakeClock(date("2026-03-10 10:00:00"))
+	logger := &recordingLogger{}
+	s := ucron.New(ucron.WithClock(clock), ucron.WithLogger(logger))
+
+	runs := make(chan time.Time, 10)
+	require.NoError(t, s.Add("every5", "*/5 * * * *", func(ctx context.Context) error {
+		runs <- clock.Now()
+		return nil
+	}))
+	require.NoError(t, s.Add("failing", "0 11 * * *", func(ctx context.Context) error {
+		return errors.New("boom")
+	}))
+	assert.ErrorIs(t, s.Add("every5", "* * * * *", nil), ucron.ErrDuplicateJob)
+	assert.Error(t, s.Add("invalid", "bad", nil))
+	assert.Equal(t, []string{"every5", "failing"}, s.Jobs())
+
+	next, ok := s.Next("every5")
+	require.True(t, ok)
+	assert.Equal(t, date("2026-03-10 10:05:00"), next)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- s.Run(ctx) }()
+
+	clock.awaitWaiting(t)
+	clock.Set(date("2026-03-10 10:05:00"))
+	assert.Equal(t, date("2026-03-10 10:05:00"), receive(t, runs))
+
+	clock.awaitWaiting(t)
+	next, _ = s.Next("every5")
+	assert.Equal(t, date("2026-03-10 10:10:00"), next)
+
+	clock.Set(date("2026-03-10 11:00:00"))
+	assert.Equal(t, date("2026-03-10 11:00:00"), receive(t, runs))
+	assert.Eventually(t, func() bool {
+		return len(logger.Messages()) == 1
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, []string{"cron job failed"}, logger.Messages())
+
+	cancel()
+	assert.ErrorIs(t, receive(t, done), context.Canceled)
+}
+
+func TestScheduler_AddRemoveWhileRunning(t *testing.T) {
+	clock := newFakeClock(date("2026-03-10 10:00:00"))
+	logger := &recordingLogger{}
+	s := ucron.New(ucron.WithClock(clock), ucron.WithLogger(logger))
+
+	runs := make(chan string, 10)
+	require.NoError(t, s.Add("hourly", "@hourly", func(ctx context.Context) error {
+		runs <- "hourly"
+		return nil
+	}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() { _ = s.Run(ctx) }()
+	clock.awaitWaiting(t)
+
+	require.NoError(t, s.Add("panicking", "30 10 * * *", func(ctx context.Context) error {
+		panic("oops")
+	}))
+	clock.awaitWaiting(t)
+
+	assert.True(t, s.Remove("hourly"))
+	assert.False(t, s.Remove("hourly"))
+	clock.awaitWaiting(t)
+
+	clock.Set(date("2026-03-10 11:00:00"))
+	assert.Eventually(t, func() bool {
+		return len(logger.Messages()) == 1
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, []string{"cron job panicked"}, logger.Messages())
+	assert.Empty(t, runs)
+}
+
+func TestScheduler_WaitsForJobs(t *testing.T) {
+	clock := newFakeClock(date("2026-03-10 10:00:00"))
+	s := ucron.New(ucron.WithClock(clock))
+
+	started := make(chan struct{})
+	finished := make(chan struct{})
+	require.NoError(t, s.Add("slow", "* * * * *", func(ctx context.Context) error {
+		close(started)
+		<-ctx.Done()
+		close(finished)
+		return ctx.Err()
+	}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- s.Run(ctx) }()
+
+	clock.awaitWaiting(t)
+	clock.Set(date("2026-03-10 10:01:00"))
+	receive(t, started)
+
+	cancel()
+	receive(t, done)
+	select {
+	case <-finished:
+	default:
+		t.Fatal("Run must wait for the running jobs")
+	}
+}
@@ -0,0 +1,384 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package ucsv
+
+import (
+	"encoding"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/kordax/basic-utils/ucast"
+)
+
+const tagName = "csv"
+
+// Error describes a value that couldn't be converted to or from the struct field.
+type Error struct {
+	Line   int
+	Column string
+	Err    error
+}
+
+func (e *Error) Error() string {
+	if e.Line > 0 {
+		return fmt.Sprintf("ucsv: line %d, column %q: %v", e.Line, e.Column, e.Err)
+	}
+
+	return fmt.Sprintf("ucsv: column %q: %v", e.Column, e.Err)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+type options struct {
+	comma      rune
+	comment    rune
+	noHeader   bool
+	timeLayout string
+}
+
+// Option configures reading and writing.
+type Option func(o *options)
+
+// WithDelimiter sets the field delimiter, comma by default.
+func WithDelimiter(delimiter rune) Option {
+	return func(o *options) {
+		o.comma = delimiter
+	}
+}
+
+// WithComment sets the comment character, lines starting with it are skipped while reading. Disabled by default.
+func WithComment(comment rune) Option {
+	return func(o *options) {
+		o.comment = comment
+	}
+}
+
+// WithoutHeader disables the header row. Columns are mapped to the struct fields by their order.
+func WithoutHeader() Option {
+	return func(o *options) {
+		o.noHeader = true
+	}
+}
+
+// WithTimeLayout sets the layout for time.Time fields, time.RFC3339 by default.
+func WithTimeLayout(layout string) Option {
+	return func(o *options) {
+		o.timeLayout = layout
+	}
+}
+
+// Read returns an iterator over the rows of the CSV data decoded into T, which must be a struct.
+// Columns are matched by the header with the `csv` struct tags: `csv:"name"`, `csv:"-"` skips the field,
+// untagged exported fields are matched by their name. Unknown columns are ignored, missing columns leave the fields zero.
+//
+// Basic types are converted with ucast, time.Time, time.Duration, pointers (an empty value produces nil)
+// and encoding.TextUnmarshaler implementations are supported as well.
+// The iteration stops after the first error.
+func Read[T any](r io.Reader, opts ...Option) iter.Seq2[T, error] {
+	o := newOptions(opts)
+	return func(yield func(T, error) bool) {
+		var zero T
+		fields, err := structFields(reflect.TypeOf(zero))
+		if err != nil {
+			yield(zero, err)
+			return
+		}
+
+		reader := csv.NewReader(r)
+		reader.Comma = o.comma
+		reader.Comment = o.comment
+		reader.FieldsPerRecord = -1
+		reader.ReuseRecord = true
+
+		columns := make([]int, len(fields)) // field index for every column, -1 for unknown columns
+		for i := range columns {
+			columns[i] = i
+		}
+		if !o.noHeader {
+			header, err := reader.Read()
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			columns = mapColumns(header, fields)
+		}
+
+		for {
+			record, err := reader.Read()
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+
+			var value T
+			v := reflect.ValueOf(&value).Elem()
+			for col, raw := range record {
+				if col >= len(columns) || columns[col] < 0 {
+					continue
+				}
+				f := fields[columns[col]]
+				if err := decode(v.FieldByIndex(f.index), raw, o); err != nil {
+					line, _ := reader.FieldPos(col)
+					yield(zero, &Error{Line: line, Column: f.name, Err: err})
+					return
+				}
+			}
+			if !yield(value, nil) {
+				return
+			}
+		}
+	}
+}
+
+// ReadAll reads all the rows of the CSV data, see Read for the mapping rules.
+func ReadAll[T any](r io.Reader, opts ...Option) ([]T, error) {
+	result := make([]T, 0)
+	for value, err := range Read[T](r, opts...) {
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, value)
+	}
+
+	return result, nil
+}
+
+// Write encodes the values as CSV rows preceded by the header row, see Read for the mapping rules.
+// Nil pointers are written as empty values.
+func Write[T any](w io.Writer, values []T, opts ...Option) error {
+	return WriteSeq(w, func(yield func(T) bool) {
+		for _, v := range values {
+			if !yield(v) {
+				return
+			}
+		}
+	}, opts...)
+}
+
+// WriteSeq is the same as Write, but takes the values from an iterator, so they don't have to be kept in memory.
+func WriteSeq[T any](w io.Writer, values iter.Seq[T], opts ...Option) error {
+	o := newOptions(opts)
+	var zero T
+	fields, err := structFields(reflect.TypeOf(zero))
+	if err != nil {
+		return err
+	}
+
+	writer := csv.NewWriter(w)
+	writer.Comma = o.comma
+	record := make([]string, len(fields))
+	if !o.noHeader {
+		for i, f := range fields {
+			record[i] = f.name
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	for value := range values {
+		v := reflect.ValueOf(&value).Elem()
+		for i, f := range fields {
+			s, err := encode(v.FieldByIndex(f.index), o)
+			if err != nil {
+				return &Error{Column: f.name, Err: err}
+			}
+			record[i] = s
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+
+	return writer.Error()
+}
+
+type field struct {
+	name  string
+	index []int
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{comma: ',', timeLayout: time.RFC3339}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return o
+}
+
+func structFields(t reflect.Type) ([]field, error) {
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("ucsv: struct type expected, got %v", t)
+	}
+
+	result := make([]field, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		name := sf.Name
+		if tag, ok := sf.Tag.Lookup(tagName); ok {
+			if tag == "-" {
+				continue
+			}
+			if tag != "" {
+				name = tag
+			}
+		}
+		result = append(result, field{name: name, index: sf.Index})
+	}
+
+	return result, nil
+}
+
+func mapColumns(header []string, fields []field) []int {
+	columns := make([]int, len(header))
+	for i, name := range header {
+		columns[i] = -1
+		for j, f := range fields {
+			if f.name == name {
+				columns[i] = j
+				break
+			}
+		}
+	}
+
+	return columns
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+func decode(v reflect.Value, s string, o *options) error {
+	if v.Kind() == reflect.Pointer {
+		if s == "" {
+			v.SetZero()
+			return nil
+		}
+		ptr := reflect.New(v.Type().Elem())
+		if err := decode(ptr.Elem(), s, o); err != nil {
+			return err
+		}
+		v.Set(ptr)
+		return nil
+	}
+
+	switch u := v.Addr().Interface().(type) {
+	case *time.Time:
+		t, err := time.Parse(o.timeLayout, s)
+		if err != nil {
+			return err
+		}
+		*u = t
+		return nil
+	case *time.Duration:
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return err
+		}
+		*u = d
+		return nil
+	case encoding.TextUnmarshaler:
+		return u.UnmarshalText([]byte(s))
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := ucast.StringToBool(&s)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := ucast.StringToInt64(&s)
+		if err != nil {
+			return err
+		}
+		if v.OverflowInt(i) {
+			return fmt.Errorf("value %d overflows %s", i, v.Type())
+		}
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := ucast.StringToUint64(&s)
+		if err != nil {
+			return err
+		}
+		if v.OverflowUint(u) {
+			return fmt.Errorf("value %d overflows %s", u, v.Type())
+		}
+		v.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := ucast.StringToFloat64(&s)
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	default:
+		return fmt.Errorf("unsupported field type %s", v.Type())
+	}
+
+	return nil
+}
+
+func encode(v reflect.Value, o *options) (string, error) {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return "", nil
+		}
+		return encode(v.Elem(), o)
+	}
+
+	if v.Type() == timeType {
+		return v.Interface().(time.Time).Format(o.timeLayout), nil
+	}
+	if d, ok := v.Interface().(time.Duration); ok {
+		return d.String(), nil
+	}
+	target := v.Interface()
+	if v.CanAddr() {
+		target = v.Addr().Interface() // pointer receivers are included
+	}
+	if m, ok := target.(encoding.TextMarshaler); ok {
+		text, err := m.MarshalText()
+		return string(text), err
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Float32:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 32), nil
+	case reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 64), nil
+	default:
+		return "", fmt.Errorf("unsupported field type %s", v.Type())
+	}
+}
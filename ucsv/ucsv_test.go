@@ -0,0 +1,136 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package ucsv_test
+
+import (
+	"bytes"
+	"net/netip"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kordax/basic-utils/ucsv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type record struct {
+	ID       int           `csv:"id"`
+	Name     string        `csv:"name"`
+	Score    float64       `csv:"score"`
+	Active   bool          `csv:"active"`
+	Created  time.Time     `csv:"created"`
+	Timeout  time.Duration `csv:"timeout"`
+	Addr     netip.Addr    `csv:"addr"`
+	Parent   *uint8        `csv:"parent"`
+	Ignored  string        `csv:"-"`
+	Untagged string
+	internal string
+}
+
+const data = `id,name,score,active,created,timeout,addr,parent,Untagged,extra
+1,alice,9.5,true,2026-01-02T03:04:05Z,1m30s,10.0.0.1,7,u1,x
+2,"bob, jr",0,false,2026-02-03T00:00:00Z,0s,::1,,u2,y
+`
+
+func TestReadAll(t *testing.T) {
+	records, err := ucsv.ReadAll[record](strings.NewReader(data))
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+
+	parent := uint8(7)
+	assert.Equal(t, record{
+		ID:       1,
+		Name:     "alice",
+		Score:    9.5,
+		Active:   true,
+		Created:  time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		Timeout:  90 * time.Second,
+		Addr:     netip.MustParseAddr("10.0.0.1"),
+		Parent:   &parent,
+		Untagged: "u1",
+	}, records[0])
+	assert.Equal(t, "bob, jr", records[1].Name)
+	assert.Nil(t, records[1].Parent)
+	assert.Equal(t, netip.MustParseAddr("::1"), records[1].Addr)
+}
+
+func TestReadAll_Options(t *testing.T) {
+	type pair struct {
+		Key   string
+		Value int
+		Day   time.Time
+	}
+
+	input := "# comment\na;1;2026-01-02\nb;2;2026-01-03\n"
+	records, err := ucsv.ReadAll[pair](strings.NewReader(input),
+		ucsv.WithDelimiter(';'), ucsv.WithComment('#'), ucsv.WithoutHeader(), ucsv.WithTimeLayout(time.DateOnly))
+	require.NoError(t, err)
+	assert.Equal(t, []pair{
+		{Key: "a", Value: 1, Day: time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{Key: "b", Value: 2, Day: time.Date(2026, 1, 3, 0, 0, 0, 0, time.UTC)},
+	}, records)
+}
+
+func TestReadAll_Errors(t *testing.T) {
+	_, err := ucsv.ReadAll[record](strings.NewReader("id,name\n1,a\nx,b\n"))
+	var csvErr *ucsv.Error
+	require.ErrorAs(t, err, &csvErr)
+	assert.Equal(t, 3, csvErr.Line)
+	assert.Equal(t, "id", csvErr.Column)
+
+	_, err = ucsv.ReadAll[record](strings.NewReader("parent\n300\n"))
+	assert.ErrorContains(t, err, "overflows")
+
+	_, err = ucsv.ReadAll[int](strings.NewReader("1\n"))
+	assert.ErrorContains(t, err, "struct type expected")
+
+	records, err := ucsv.ReadAll[record](strings.NewReader(""))
+	require.NoError(t, err)
+	assert.Empty(t, records)
+}
+
+func TestRead_Streaming(t *testing.T) {
+	count := 0
+	for r, err := range ucsv.Read[record](strings.NewReader(data)) {
+		require.NoError(t, err)
+		count++
+		if r.ID == 1 {
+			break
+		}
+	}
+	assert.Equal(t, 1, count, "iteration must stop when the consumer breaks")
+}
+
+func TestWrite(t *testing.T) {
+	records, err := ucsv.ReadAll[record](strings.NewReader(data))
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, ucsv.Write(&buf, records))
+	assert.Equal(t, `id,name,score,active,created,timeout,addr,parent,Untagged
+1,alice,9.5,true,2026-01-02T03:04:05Z,1m30s,10.0.0.1,7,u1
+2,"bob, jr",0,false,2026-02-03T00:00:00Z,0s,::1,,u2
+`, buf.String())
+
+	decoded, err := ucsv.ReadAll[record](&buf)
+	require.NoError(t, err)
+	assert.Equal(t, records, decoded)
+}
+
+func TestWrite_Options(t *testing.T) {
+	type pair struct {
+		Key   string
+		Value float32
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, ucsv.Write(&buf, []pair{{"a", 1.5}, {"b", 2}}, ucsv.WithDelimiter('\t'), ucsv.WithoutHeader()))
+	assert.Equal(t, "a\t1.5\nb\t2\n", buf.String())
+
+	assert.ErrorContains(t, ucsv.Write(&buf, []string{"a"}), "struct type expected")
+}
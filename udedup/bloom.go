@@ -0,0 +1,79 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package udedup
+
+import (
+	"hash/maphash"
+	"math"
+	"time"
+
+	"github.com/kordax/basic-utils/internal/uhash"
+	"github.com/kordax/basic-utils/ubitset"
+)
+
+// bloomStore keeps keys in two generations of bloom filters.
+// New keys are added to the current generation, while lookups check both of them.
+// Generations are rotated once per window, so a key outlives its last sighting by one to two windows.
+type bloomStore[K comparable] struct {
+	seed   maphash.Seed
+	bits   uint64
+	hashes int
+
+	current   *ubitset.BitSet[uint64]
+	previous  *ubitset.BitSet[uint64]
+	rotatedAt time.Time
+	window    time.Duration
+}
+
+func newBloomStore[K comparable](window time.Duration, expectedItems int, falsePositiveRate float64) *bloomStore[K] {
+	bits := math.Ceil(-float64(expectedItems) * math.Log(falsePositiveRate) / (math.Ln2 * math.Ln2))
+	hashes := max(int(math.Round(bits/float64(expectedItems)*math.Ln2)), 1)
+
+	return &bloomStore[K]{
+		seed:      maphash.MakeSeed(),
+		bits:      uint64(bits),
+		hashes:    hashes,
+		current:   ubitset.New[uint64](),
+		previous:  ubitset.New[uint64](),
+		rotatedAt: time.Now(),
+		window:    window,
+	}
+}
+
+func (s *bloomStore[K]) seen(key K, now time.Time) bool {
+	if elapsed := now.Sub(s.rotatedAt); elapsed >= s.window {
+		if elapsed >= 2*s.window {
+			s.previous = ubitset.New[uint64]()
+		} else {
+			s.previous = s.current
+		}
+		s.current = ubitset.New[uint64]()
+		s.rotatedAt = now
+	}
+
+	// double hashing: the i-th index is h1 + i*h2, which is as good as k independent hashes for a bloom filter
+	h1 := uhash.Mix(uint64(uhash.Comparable(s.seed, key)))
+	h2 := uhash.Mix(h1) | 1
+	inCurrent, inPrevious := true, true
+	for i := 0; i < s.hashes; i++ {
+		idx := (h1 + uint64(i)*h2) % s.bits
+		if !s.current.Test(idx) {
+			inCurrent = false
+			s.current.Set(idx)
+		}
+		if inPrevious && !s.previous.Test(idx) {
+			inPrevious = false
+		}
+	}
+
+	return inCurrent || inPrevious
+}
+
+func (s *bloomStore[K]) reset() {
+	s.current = ubitset.New[uint64]()
+	s.previous = ubitset.New[uint64]()
+}
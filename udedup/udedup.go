@@ -0,0 +1,137 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+// Package udedup provides streaming deduplication of events within a sliding time window.
+package udedup
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/kordax/basic-utils/ucache"
+	"github.com/kordax/basic-utils/uopt"
+)
+
+// Option configures a Deduplicator.
+type Option func(*options)
+
+type options struct {
+	bloom             bool
+	expectedItems     int
+	falsePositiveRate float64
+}
+
+// WithBloomFilter switches the Deduplicator to the probabilistic mode for memory-constrained, high-volume streams.
+// Instead of remembering every key, it keeps two generations of bloom filters sized for the expected number of keys
+// per window, so the memory usage is constant. Keys seen within the window are never reported as unseen,
+// but an unseen key may be reported as seen with the given false positive rate, and a key is remembered
+// for at least one window and at most two windows since the last time it's seen.
+// It panics if expectedItems is not positive or falsePositiveRate is not within (0, 1).
+func WithBloomFilter(expectedItems int, falsePositiveRate float64) Option {
+	if expectedItems <= 0 {
+		panic(fmt.Sprintf("udedup: expected items must be positive, got %d", expectedItems))
+	}
+	if falsePositiveRate <= 0 || falsePositiveRate >= 1 {
+		panic(fmt.Sprintf("udedup: false positive rate must be within (0, 1), got %v", falsePositiveRate))
+	}
+
+	return func(o *options) {
+		o.bloom = true
+		o.expectedItems = expectedItems
+		o.falsePositiveRate = falsePositiveRate
+	}
+}
+
+// store remembers keys for a Deduplicator. Implementations are not thread-safe.
+type store[K comparable] interface {
+	// seen reports whether the key is remembered and remembers it for another window.
+	seen(key K, now time.Time) bool
+	reset()
+}
+
+// Deduplicator answers whether a key has been seen within a sliding time window:
+// every sighting of a key keeps it remembered for another window.
+// By default keys are tracked exactly with a TTL cache, which is periodically swept from the expired keys.
+// Use WithBloomFilter to trade exactness for constant memory usage.
+// Deduplicator is safe for concurrent use.
+type Deduplicator[K comparable] struct {
+	store store[K]
+	mtx   sync.Mutex
+}
+
+// New creates a Deduplicator remembering keys for the given window.
+// It panics if the window is not positive.
+func New[K comparable](window time.Duration, opts ...Option) *Deduplicator[K] {
+	if window <= 0 {
+		panic(fmt.Sprintf("udedup: window must be positive, got %s", window))
+	}
+
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	d := &Deduplicator[K]{}
+	if o.bloom {
+		d.store = newBloomStore[K](window, o.expectedItems, o.falsePositiveRate)
+	} else {
+		d.store = newExactStore[K](window)
+	}
+
+	return d
+}
+
+// Seen reports whether the key has been seen within the window and marks it as seen,
+// so the first call for a key returns false and the subsequent calls within the window return true.
+func (d *Deduplicator[K]) Seen(key K) bool {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	return d.store.seen(key, time.Now())
+}
+
+// Reset forgets all the seen keys.
+func (d *Deduplicator[K]) Reset() {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	d.store.reset()
+}
+
+// exactStore keeps every key in a TTL cache and drops the expired ones at most once per window.
+type exactStore[K comparable] struct {
+	cache     ucache.ComparableCache[K, struct{}]
+	window    time.Duration
+	lastSweep time.Time
+}
+
+func newExactStore[K comparable](window time.Duration) *exactStore[K] {
+	return &exactStore[K]{
+		cache:     ucache.NewInMemoryComparableMapCache[K, struct{}](uopt.Of(window)),
+		window:    window,
+		lastSweep: time.Now(),
+	}
+}
+
+func (s *exactStore[K]) seen(key K, now time.Time) bool {
+	_, ok := s.cache.Get(key)
+	seen := ok && !s.cache.Outdated(uopt.Of(key))
+	s.cache.SetQuietly(key, struct{}{})
+
+	if now.Sub(s.lastSweep) >= s.window {
+		for _, k := range s.cache.(ucache.OutdatedKeysLister[K]).OutdatedKeys() {
+			s.cache.DropKey(k)
+		}
+		s.lastSweep = now
+	}
+
+	return seen
+}
+
+func (s *exactStore[K]) reset() {
+	s.cache.Drop()
+}
@@ -0,0 +1,96 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package udedup_test
+
+import (
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kordax/basic-utils/udedup"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeduplicator(t *testing.T) {
+	for name, opts := range map[string][]udedup.Option{
+		"exact": nil,
+		"bloom": {udedup.WithBloomFilter(1000, 0.001)},
+	} {
+		t.Run(name, func(t *testing.T) {
+			d := udedup.New[string](50*time.Millisecond, opts...)
+
+			assert.False(t, d.Seen("a"))
+			assert.True(t, d.Seen("a"))
+			assert.False(t, d.Seen("b"))
+			assert.True(t, d.Seen("b"))
+
+			d.Reset()
+			assert.False(t, d.Seen("a"))
+
+			// two windows are enough to forget a key in both modes
+			time.Sleep(120 * time.Millisecond)
+			assert.False(t, d.Seen("a"))
+			assert.True(t, d.Seen("a"))
+		})
+	}
+}
+
+func TestDeduplicator_Sliding(t *testing.T) {
+	d := udedup.New[int](60 * time.Millisecond)
+	assert.False(t, d.Seen(1))
+	for i := 0; i < 4; i++ {
+		time.Sleep(30 * time.Millisecond)
+		assert.True(t, d.Seen(1), "every sighting must extend the window")
+	}
+}
+
+func TestDeduplicator_BloomFalsePositives(t *testing.T) {
+	const n = 10000
+	d := udedup.New[string](time.Minute, udedup.WithBloomFilter(2*n, 0.01))
+	for i := 0; i < n; i++ {
+		d.Seen("seen-" + strconv.Itoa(i))
+	}
+	for i := 0; i < n; i++ {
+		assert.True(t, d.Seen("seen-"+strconv.Itoa(i)), "bloom filter must not produce false negatives")
+	}
+
+	falsePositives := 0
+	for i := 0; i < n; i++ {
+		if d.Seen("unseen-" + strconv.Itoa(i)) {
+			falsePositives++
+		}
+	}
+	assert.Less(t, falsePositives, n*3/100)
+}
+
+func TestDeduplicator_Concurrent(t *testing.T) {
+	d := udedup.New[int](time.Minute)
+	var unseen atomic.Int32
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				if !d.Seen(i) {
+					unseen.Add(1)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.EqualValues(t, 1000, unseen.Load(), "every key must be reported as unseen exactly once")
+}
+
+func TestNew_Panics(t *testing.T) {
+	assert.Panics(t, func() { udedup.New[int](0) })
+	assert.Panics(t, func() { udedup.WithBloomFilter(0, 0.1) })
+	assert.Panics(t, func() { udedup.WithBloomFilter(10, 1) })
+}
@@ -0,0 +1,388 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+// Package udiff computes the deep differences between two values, e.g. for audit logs or cache change introspection.
+package udiff
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Tag is the default struct tag customizing the field paths: `diff:"-"` ignores the field
+// and `diff:"name"` renames its path segment.
+const Tag = "diff"
+
+// Kind describes how a value differs.
+type Kind int
+
+const (
+	// Modified means that the value was changed.
+	Modified Kind = iota
+	// Added means that the map key or the slice element appeared in the new value.
+	Added
+	// Removed means that the map key or the slice element is missing in the new value.
+	Removed
+)
+
+func (k Kind) String() string {
+	switch k {
+	case Modified:
+		return "modified"
+	case Added:
+		return "added"
+	case Removed:
+		return "removed"
+	default:
+		return fmt.Sprintf("Kind(%d)", int(k))
+	}
+}
+
+// Change is a difference between two values.
+type Change struct {
+	Path string // Path is the path of the value, e.g. "Server.Port", "Users[2].Name" or "Labels[env]", empty for the root.
+	Kind Kind
+	Old  any // Old is the old value, nil if the value was added.
+	New  any // New is the new value, nil if the value was removed.
+}
+
+func (c Change) String() string {
+	path := c.Path
+	if path == "" {
+		path = "<root>"
+	}
+
+	switch c.Kind {
+	case Added:
+		return fmt.Sprintf("%s: added %v", path, c.New)
+	case Removed:
+		return fmt.Sprintf("%s: removed %v", path, c.Old)
+	default:
+		return fmt.Sprintf("%s: %v -> %v", path, c.Old, c.New)
+	}
+}
+
+type options struct {
+	tag       string
+	ignored   map[string]struct{}
+	accessors bool
+}
+
+// Option configures Diff.
+type Option func(o *options)
+
+// WithTagName reads the field paths from the struct tag with the provided name instead of Tag,
+// e.g. "json" to reuse the JSON field names. Only the part of the tag before the first comma is used.
+func WithTagName(name string) Option {
+	return func(o *options) {
+		o.tag = name
+	}
+}
+
+// IgnorePaths skips the values with the provided paths along with their nested values.
+func IgnorePaths(paths ...string) Option {
+	return func(o *options) {
+		for _, path := range paths {
+			o.ignored[path] = struct{}{}
+		}
+	}
+}
+
+// WithAccessors compares the unexported struct fields using their accessors: a field named "name" is compared
+// by the results of the Name or GetName method taking no arguments and returning a single value.
+// Unexported fields without accessors, and all of them by default, are skipped.
+func WithAccessors() Option {
+	return func(o *options) {
+		o.accessors = true
+	}
+}
+
+/*
+Diff returns the differences between a and b in the order of the struct fields, slice indices and sorted map keys.
+Returns nil if the values are deeply equal.
+
+Structs, pointers, interfaces, slices, arrays and maps are compared recursively. Values of different types are
+reported as Modified, as well as nil and non-nil pointers and types with an Equal(T) bool method, like time.Time,
+which are compared with it as a whole. Slice elements and map keys present in only one of the values are reported
+as Added or Removed, nil and empty slices and maps are equal. NaN floats are equal to each other,
+functions and channels are compared by their pointers. Cyclic references are compared once.
+*/
+func Diff(a, b any, opts ...Option) []Change {
+	o := options{tag: Tag, ignored: make(map[string]struct{})}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	d := &differ{options: o, visited: make(map[visit]struct{})}
+	d.diff("", reflect.ValueOf(a), reflect.ValueOf(b))
+
+	return d.changes
+}
+
+type visit struct {
+	a, b uintptr
+	len  int
+	typ  reflect.Type
+}
+
+type differ struct {
+	options options
+	visited map[visit]struct{}
+	changes []Change
+}
+
+func (d *differ) add(path string, kind Kind, a, b reflect.Value) {
+	d.changes = append(d.changes, Change{Path: path, Kind: kind, Old: value(a), New: value(b)})
+}
+
+func (d *differ) diff(path string, a, b reflect.Value) {
+	if _, ok := d.options.ignored[path]; ok {
+		return
+	}
+
+	switch {
+	case !a.IsValid() && !b.IsValid():
+		return
+	case !a.IsValid():
+		d.add(path, Added, a, b)
+		return
+	case !b.IsValid():
+		d.add(path, Removed, a, b)
+		return
+	case a.Type() != b.Type():
+		d.add(path, Modified, a, b)
+		return
+	}
+
+	if equal, ok := callEqual(a, b); ok {
+		if !equal {
+			d.add(path, Modified, a, b)
+		}
+		return
+	}
+
+	switch a.Kind() {
+	case reflect.Pointer:
+		if a.IsNil() || b.IsNil() {
+			if a.IsNil() != b.IsNil() {
+				d.add(path, Modified, a, b)
+			}
+			return
+		}
+		if !d.enter(a, b) {
+			return
+		}
+		d.diff(path, a.Elem(), b.Elem())
+	case reflect.Interface:
+		if a.IsNil() || b.IsNil() {
+			if a.IsNil() != b.IsNil() {
+				d.add(path, Modified, a, b)
+			}
+			return
+		}
+		d.diff(path, a.Elem(), b.Elem())
+	case reflect.Struct:
+		d.diffStruct(path, a, b)
+	case reflect.Slice:
+		if a.Len() > 0 && b.Len() > 0 && !d.enter(a, b) {
+			return
+		}
+		d.diffElements(path, a, b)
+	case reflect.Array:
+		d.diffElements(path, a, b)
+	case reflect.Map:
+		if a.Len() > 0 && b.Len() > 0 && !d.enter(a, b) {
+			return
+		}
+		d.diffMap(path, a, b)
+	default:
+		if !scalarEqual(a, b) {
+			d.add(path, Modified, a, b)
+		}
+	}
+}
+
+// enter reports whether the pair of references hasn't been compared yet and marks it as visited.
+func (d *differ) enter(a, b reflect.Value) bool {
+	key := visit{a: a.Pointer(), b: b.Pointer(), typ: a.Type()}
+	if a.Kind() == reflect.Slice {
+		key.len = max(a.Len(), b.Len())
+	}
+	if _, ok := d.visited[key]; ok {
+		return false
+	}
+	d.visited[key] = struct{}{}
+
+	return true
+}
+
+func (d *differ) diffStruct(path string, a, b reflect.Value) {
+	t := a.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		name := sf.Name
+		if tag, ok := sf.Tag.Lookup(d.options.tag); ok {
+			tag, _, _ = strings.Cut(tag, ",")
+			if tag == "-" {
+				continue
+			}
+			if tag != "" {
+				name = tag
+			}
+		}
+		if path != "" {
+			name = path + "." + name
+		}
+
+		if sf.IsExported() {
+			d.diff(name, a.Field(i), b.Field(i))
+			continue
+		}
+		if !d.options.accessors {
+			continue
+		}
+		if av, ok := access(a, sf.Name); ok {
+			bv, _ := access(b, sf.Name)
+			d.diff(name, av, bv)
+		}
+	}
+}
+
+func (d *differ) diffElements(path string, a, b reflect.Value) {
+	for i := 0; i < max(a.Len(), b.Len()); i++ {
+		elemPath := fmt.Sprintf("%s[%d]", path, i)
+		switch {
+		case i >= b.Len():
+			d.removed(elemPath, a.Index(i))
+		case i >= a.Len():
+			d.added(elemPath, b.Index(i))
+		default:
+			d.diff(elemPath, a.Index(i), b.Index(i))
+		}
+	}
+}
+
+func (d *differ) diffMap(path string, a, b reflect.Value) {
+	type mapKey struct {
+		name  string
+		value reflect.Value
+	}
+	keys := make([]mapKey, 0, max(a.Len(), b.Len()))
+	for _, key := range a.MapKeys() {
+		keys = append(keys, mapKey{name: fmt.Sprint(value(key)), value: key})
+	}
+	for _, key := range b.MapKeys() {
+		if !a.MapIndex(key).IsValid() {
+			keys = append(keys, mapKey{name: fmt.Sprint(value(key)), value: key})
+		}
+	}
+	sort.SliceStable(keys, func(i, j int) bool {
+		return keys[i].name < keys[j].name
+	})
+
+	for _, key := range keys {
+		elemPath := path + "[" + key.name + "]"
+		av, bv := a.MapIndex(key.value), b.MapIndex(key.value)
+		switch {
+		case !bv.IsValid():
+			d.removed(elemPath, av)
+		case !av.IsValid():
+			d.added(elemPath, bv)
+		default:
+			d.diff(elemPath, av, bv)
+		}
+	}
+}
+
+func (d *differ) added(path string, v reflect.Value) {
+	if _, ok := d.options.ignored[path]; !ok {
+		d.add(path, Added, reflect.Value{}, v)
+	}
+}
+
+func (d *differ) removed(path string, v reflect.Value) {
+	if _, ok := d.options.ignored[path]; !ok {
+		d.add(path, Removed, v, reflect.Value{})
+	}
+}
+
+// value returns the value as any, or nil if it's invalid or can't be retrieved.
+func value(v reflect.Value) any {
+	if !v.IsValid() || !v.CanInterface() {
+		return nil
+	}
+
+	return v.Interface()
+}
+
+// callEqual compares the values with their Equal method if they have one with signature func(T) bool.
+func callEqual(a, b reflect.Value) (equal bool, ok bool) {
+	if !a.CanInterface() || !b.CanInterface() || (a.Kind() == reflect.Pointer && (a.IsNil() || b.IsNil())) {
+		return false, false
+	}
+	method := a.MethodByName("Equal")
+	if !method.IsValid() {
+		return false, false
+	}
+	mt := method.Type()
+	if mt.NumIn() != 1 || mt.In(0) != a.Type() || mt.NumOut() != 1 || mt.Out(0).Kind() != reflect.Bool {
+		return false, false
+	}
+
+	return method.Call([]reflect.Value{b})[0].Bool(), true
+}
+
+// access returns the result of the accessor of the unexported field of the struct.
+func access(v reflect.Value, field string) (reflect.Value, bool) {
+	if !v.CanInterface() {
+		return reflect.Value{}, false
+	}
+	ptr := reflect.New(v.Type()) // the pointer also exposes the methods with pointer receivers
+	ptr.Elem().Set(v)
+
+	r, size := utf8.DecodeRuneInString(field)
+	exported := string(unicode.ToUpper(r)) + field[size:]
+	for _, name := range []string{exported, "Get" + exported} {
+		method := ptr.MethodByName(name)
+		if method.IsValid() && method.Type().NumIn() == 0 && method.Type().NumOut() == 1 {
+			return method.Call(nil)[0], true
+		}
+	}
+
+	return reflect.Value{}, false
+}
+
+func scalarEqual(a, b reflect.Value) bool {
+	switch a.Kind() {
+	case reflect.Bool:
+		return a.Bool() == b.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return a.Int() == b.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return a.Uint() == b.Uint()
+	case reflect.Float32, reflect.Float64:
+		x, y := a.Float(), b.Float()
+		return x == y || (math.IsNaN(x) && math.IsNaN(y))
+	case reflect.Complex64, reflect.Complex128:
+		x, y := a.Complex(), b.Complex()
+		return x == y || (isNaN(x) && isNaN(y))
+	case reflect.String:
+		return a.String() == b.String()
+	case reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		return a.Pointer() == b.Pointer()
+	default:
+		return true
+	}
+}
+
+func isNaN(c complex128) bool {
+	return math.IsNaN(real(c)) || math.IsNaN(imag(c))
+}
@@ -0,0 +1,157 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package udiff_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/kordax/basic-utils/udiff"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type address struct {
+	City   string
+	Street string `diff:"street"`
+}
+
+type user struct {
+	Name     string
+	Age      int
+	Address  *address
+	Tags     []string
+	Labels   map[string]string
+	Password string `diff:"-"`
+	Updated  time.Time
+	Extra    any
+	balance  int
+}
+
+func (u user) Balance() int {
+	return u.balance
+}
+
+func TestDiff_Equal(t *testing.T) {
+	u := user{Name: "john", Address: &address{City: "Paris"}, Tags: []string{"a"}, Labels: map[string]string{"k": "v"}}
+	copied := u
+	copied.Address = &address{City: "Paris"}
+	copied.Tags = []string{"a"}
+	copied.Labels = map[string]string{"k": "v"}
+
+	assert.Empty(t, udiff.Diff(u, copied))
+	assert.Empty(t, udiff.Diff(&u, &copied))
+	assert.Empty(t, udiff.Diff(nil, nil))
+	assert.Empty(t, udiff.Diff(user{Tags: []string{}}, user{}), "nil and empty slices must be equal")
+	assert.Empty(t, udiff.Diff(math.NaN(), math.NaN()))
+}
+
+func TestDiff_Struct(t *testing.T) {
+	now := time.Now()
+	old := user{
+		Name:     "john",
+		Age:      30,
+		Address:  &address{City: "Paris", Street: "Main"},
+		Tags:     []string{"a", "b", "c"},
+		Labels:   map[string]string{"env": "dev", "team": "core"},
+		Password: "old",
+		Updated:  now,
+		Extra:    1,
+		balance:  10,
+	}
+	updated := user{
+		Name:     "john",
+		Age:      31,
+		Address:  &address{City: "Berlin", Street: "Side"},
+		Tags:     []string{"a", "x"},
+		Labels:   map[string]string{"env": "prod", "zone": "eu"},
+		Password: "new",
+		Updated:  now.In(time.UTC),
+		Extra:    "1",
+		balance:  20,
+	}
+
+	assert.Equal(t, []udiff.Change{
+		{Path: "Age", Kind: udiff.Modified, Old: 30, New: 31},
+		{Path: "Address.City", Kind: udiff.Modified, Old: "Paris", New: "Berlin"},
+		{Path: "Address.street", Kind: udiff.Modified, Old: "Main", New: "Side"},
+		{Path: "Tags[1]", Kind: udiff.Modified, Old: "b", New: "x"},
+		{Path: "Tags[2]", Kind: udiff.Removed, Old: "c"},
+		{Path: "Labels[env]", Kind: udiff.Modified, Old: "dev", New: "prod"},
+		{Path: "Labels[team]", Kind: udiff.Removed, Old: "core"},
+		{Path: "Labels[zone]", Kind: udiff.Added, New: "eu"},
+		{Path: "Extra", Kind: udiff.Modified, Old: 1, New: "1"},
+	}, udiff.Diff(old, updated), "time.Time must be compared with Equal and unexported fields must be skipped")
+
+	changes := udiff.Diff(old, updated, udiff.WithAccessors(), udiff.IgnorePaths("Address", "Tags[2]", "Labels[zone]"))
+	assert.Equal(t, []udiff.Change{
+		{Path: "Age", Kind: udiff.Modified, Old: 30, New: 31},
+		{Path: "Tags[1]", Kind: udiff.Modified, Old: "b", New: "x"},
+		{Path: "Labels[env]", Kind: udiff.Modified, Old: "dev", New: "prod"},
+		{Path: "Labels[team]", Kind: udiff.Removed, Old: "core"},
+		{Path: "Extra", Kind: udiff.Modified, Old: 1, New: "1"},
+		{Path: "balance", Kind: udiff.Modified, Old: 10, New: 20},
+	}, changes)
+}
+
+func TestDiff_Pointers(t *testing.T) {
+	a := &address{City: "Paris"}
+	changes := udiff.Diff(user{Address: a}, user{})
+	require.Len(t, changes, 1)
+	assert.Equal(t, "Address", changes[0].Path)
+	assert.Equal(t, a, changes[0].Old)
+	assert.Equal(t, (*address)(nil), changes[0].New)
+
+	assert.Equal(t, []udiff.Change{{Kind: udiff.Modified, Old: 1, New: int64(1)}}, udiff.Diff(1, int64(1)))
+	assert.Equal(t, []udiff.Change{{Kind: udiff.Added, New: 1}}, udiff.Diff(nil, 1))
+	assert.Equal(t, []udiff.Change{{Kind: udiff.Removed, Old: 1}}, udiff.Diff(1, nil))
+}
+
+type node struct {
+	Value int
+	Next  *node
+}
+
+func TestDiff_Cycles(t *testing.T) {
+	a := &node{Value: 1}
+	a.Next = a
+	b := &node{Value: 2}
+	b.Next = b
+
+	assert.Equal(t, []udiff.Change{{Path: "Value", Kind: udiff.Modified, Old: 1, New: 2}}, udiff.Diff(a, b))
+}
+
+func TestDiff_WithTagName(t *testing.T) {
+	type item struct {
+		ID    int    `json:"id"`
+		Name  string `json:"name,omitempty"`
+		Cache string `json:"-"`
+	}
+
+	changes := udiff.Diff(item{ID: 1, Name: "a", Cache: "x"}, item{ID: 2, Name: "b", Cache: "y"}, udiff.WithTagName("json"))
+	assert.Equal(t, []udiff.Change{
+		{Path: "id", Kind: udiff.Modified, Old: 1, New: 2},
+		{Path: "name", Kind: udiff.Modified, Old: "a", New: "b"},
+	}, changes)
+}
+
+func TestDiff_MapsOfStructs(t *testing.T) {
+	old := map[int]address{1: {City: "Paris"}, 2: {City: "Rome"}}
+	updated := map[int]address{1: {City: "Lyon"}, 2: {City: "Rome"}}
+
+	assert.Equal(t, []udiff.Change{
+		{Path: "[1].City", Kind: udiff.Modified, Old: "Paris", New: "Lyon"},
+	}, udiff.Diff(old, updated))
+}
+
+func TestChange_String(t *testing.T) {
+	assert.Equal(t, "Age: 30 -> 31", udiff.Change{Path: "Age", Kind: udiff.Modified, Old: 30, New: 31}.String())
+	assert.Equal(t, "Tags[2]: added x", udiff.Change{Path: "Tags[2]", Kind: udiff.Added, New: "x"}.String())
+	assert.Equal(t, "<root>: removed 1", udiff.Change{Kind: udiff.Removed, Old: 1}.String())
+	assert.Equal(t, "removed", udiff.Removed.String())
+}
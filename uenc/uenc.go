@@ -0,0 +1,193 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package uenc
+
+import (
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"unsafe"
+)
+
+// Bytes is a constraint for the binary data accepted by the helpers: strings, byte slices and their derived types.
+type Bytes interface {
+	~string | ~[]byte
+}
+
+// EncodeBase64 encodes data with the standard padded base64 encoding.
+func EncodeBase64[T Bytes](data T) string {
+	return encodeBase64(base64.StdEncoding, data)
+}
+
+// DecodeBase64 decodes a standard padded base64 string.
+func DecodeBase64[T Bytes](data T) ([]byte, error) {
+	return decodeBase64(base64.StdEncoding, data)
+}
+
+// EncodeBase64URL encodes data with the URL-safe base64 encoding without padding,
+// so the result can be used in URLs and file names as is.
+func EncodeBase64URL[T Bytes](data T) string {
+	return encodeBase64(base64.RawURLEncoding, data)
+}
+
+// DecodeBase64URL decodes a URL-safe base64 string. Both padded and unpadded inputs are accepted.
+func DecodeBase64URL[T Bytes](data T) ([]byte, error) {
+	b := asBytes(data)
+	if len(b) > 0 && rune(b[len(b)-1]) == base64.StdPadding {
+		return decodeBase64(base64.URLEncoding, b)
+	}
+
+	return decodeBase64(base64.RawURLEncoding, b)
+}
+
+// EncodeHex encodes data as a lower-case hex string.
+func EncodeHex[T Bytes](data T) string {
+	b := asBytes(data)
+	dst := make([]byte, hex.EncodedLen(len(b)))
+	hex.Encode(dst, b)
+
+	return toString(dst)
+}
+
+// DecodeHex decodes a hex string, both lower and upper case digits are accepted.
+func DecodeHex[T Bytes](data T) ([]byte, error) {
+	b := asBytes(data)
+	dst := make([]byte, hex.DecodedLen(len(b)))
+	n, err := hex.Decode(dst, b)
+
+	return dst[:n], err
+}
+
+// Equal reports whether a and b are equal in constant time, so it is safe to compare secrets, tokens and signatures.
+// The time depends on the length of the inputs only, not on their contents.
+func Equal[T Bytes](a, b T) bool {
+	return subtle.ConstantTimeCompare(asBytes(a), asBytes(b)) == 1
+}
+
+// Codec is a reversible transformation of binary data.
+type Codec interface {
+	Encode(data []byte) []byte
+	Decode(data []byte) ([]byte, error)
+}
+
+var (
+	// Base64 is the standard padded base64 Codec.
+	Base64 Codec = base64Codec{enc: base64.StdEncoding}
+	// Base64URL is the URL-safe unpadded base64 Codec.
+	Base64URL Codec = base64Codec{enc: base64.RawURLEncoding}
+	// Hex is the lower-case hex Codec.
+	Hex Codec = hexCodec{}
+)
+
+// Chain applies several codecs one after another.
+// Encode runs the codecs in the order they were added, Decode runs them in reverse order.
+//
+//	chain := uenc.NewChain(uenc.Hex).Then(uenc.Base64URL)
+//	encoded := chain.EncodeToString(data)
+//	decoded, err := chain.Decode([]byte(encoded))
+type Chain struct {
+	codecs []Codec
+}
+
+// NewChain creates a new Chain of the provided codecs.
+func NewChain(codecs ...Codec) *Chain {
+	return &Chain{codecs: append([]Codec(nil), codecs...)}
+}
+
+// Then returns a new Chain with the codec appended, the original chain is not modified.
+func (c *Chain) Then(codec Codec) *Chain {
+	codecs := make([]Codec, len(c.codecs), len(c.codecs)+1)
+	copy(codecs, c.codecs)
+
+	return &Chain{codecs: append(codecs, codec)}
+}
+
+// Encode applies all the codecs to the data in order. An empty chain returns the data as is.
+func (c *Chain) Encode(data []byte) []byte {
+	for _, codec := range c.codecs {
+		data = codec.Encode(data)
+	}
+
+	return data
+}
+
+// EncodeToString is the same as Encode, but returns a string.
+func (c *Chain) EncodeToString(data []byte) string {
+	return string(c.Encode(data))
+}
+
+// Decode reverts Encode by decoding the data with all the codecs in reverse order.
+func (c *Chain) Decode(data []byte) ([]byte, error) {
+	var err error
+	for i := len(c.codecs) - 1; i >= 0; i-- {
+		if data, err = c.codecs[i].Decode(data); err != nil {
+			return nil, err
+		}
+	}
+
+	return data, nil
+}
+
+type base64Codec struct {
+	enc *base64.Encoding
+}
+
+func (c base64Codec) Encode(data []byte) []byte {
+	dst := make([]byte, c.enc.EncodedLen(len(data)))
+	c.enc.Encode(dst, data)
+
+	return dst
+}
+
+func (c base64Codec) Decode(data []byte) ([]byte, error) {
+	return decodeBase64(c.enc, data)
+}
+
+type hexCodec struct{}
+
+func (hexCodec) Encode(data []byte) []byte {
+	dst := make([]byte, hex.EncodedLen(len(data)))
+	hex.Encode(dst, data)
+
+	return dst
+}
+
+func (hexCodec) Decode(data []byte) ([]byte, error) {
+	return DecodeHex(data)
+}
+
+func encodeBase64[T Bytes](enc *base64.Encoding, data T) string {
+	dst := make([]byte, enc.EncodedLen(len(data)))
+	enc.Encode(dst, asBytes(data))
+
+	return toString(dst)
+}
+
+func decodeBase64[T Bytes](enc *base64.Encoding, data T) ([]byte, error) {
+	b := asBytes(data)
+	dst := make([]byte, enc.DecodedLen(len(b)))
+	n, err := enc.Decode(dst, b)
+
+	return dst[:n], err
+}
+
+// asBytes returns the data as a byte slice without copying. The result must not be modified.
+func asBytes[T Bytes](data T) []byte {
+	switch v := any(data).(type) {
+	case string:
+		return unsafe.Slice(unsafe.StringData(v), len(v))
+	case []byte:
+		return v
+	}
+
+	return []byte(data) // derived types are copied
+}
+
+// toString converts a freshly allocated buffer to a string without copying. The buffer must not be used afterwards.
+func toString(b []byte) string {
+	return unsafe.String(unsafe.SliceData(b), len(b))
+}
@@ -0,0 +1,93 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package uenc_test
+
+import (
+	"testing"
+
+	"github.com/kordax/basic-utils/uenc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type token string
+
+func TestBase64(t *testing.T) {
+	data := []byte{0xfb, 0xff, 0x01, 'a'}
+
+	assert.Equal(t, "+/8BYQ==", uenc.EncodeBase64(data))
+	assert.Equal(t, "aGVsbG8=", uenc.EncodeBase64("hello"))
+	assert.Equal(t, "aGVsbG8=", uenc.EncodeBase64(token("hello")))
+	assert.Equal(t, "", uenc.EncodeBase64(""))
+
+	decoded, err := uenc.DecodeBase64("+/8BYQ==")
+	require.NoError(t, err)
+	assert.Equal(t, data, decoded)
+
+	_, err = uenc.DecodeBase64("not base64!")
+	assert.Error(t, err)
+}
+
+func TestBase64URL(t *testing.T) {
+	data := []byte{0xfb, 0xff, 0x01, 'a'}
+
+	assert.Equal(t, "-_8BYQ", uenc.EncodeBase64URL(data))
+
+	decoded, err := uenc.DecodeBase64URL("-_8BYQ")
+	require.NoError(t, err)
+	assert.Equal(t, data, decoded)
+
+	decoded, err = uenc.DecodeBase64URL([]byte("-_8BYQ=="))
+	require.NoError(t, err)
+	assert.Equal(t, data, decoded)
+
+	_, err = uenc.DecodeBase64URL("+/8BYQ")
+	assert.Error(t, err)
+}
+
+func TestHex(t *testing.T) {
+	assert.Equal(t, "00ff10", uenc.EncodeHex([]byte{0x00, 0xff, 0x10}))
+	assert.Equal(t, "6869", uenc.EncodeHex("hi"))
+
+	decoded, err := uenc.DecodeHex("00FF10")
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x00, 0xff, 0x10}, decoded)
+
+	_, err = uenc.DecodeHex("0g")
+	assert.Error(t, err)
+	_, err = uenc.DecodeHex("abc")
+	assert.Error(t, err)
+}
+
+func TestEqual(t *testing.T) {
+	assert.True(t, uenc.Equal("secret", "secret"))
+	assert.True(t, uenc.Equal([]byte{}, nil))
+	assert.True(t, uenc.Equal(token("a"), token("a")))
+	assert.False(t, uenc.Equal("secret", "secreT"))
+	assert.False(t, uenc.Equal("secret", "secret1"))
+}
+
+func TestChain(t *testing.T) {
+	data := []byte("hello, world")
+
+	chain := uenc.NewChain(uenc.Hex).Then(uenc.Base64URL)
+	encoded := chain.EncodeToString(data)
+	assert.Equal(t, uenc.EncodeBase64URL(uenc.EncodeHex(data)), encoded)
+
+	decoded, err := chain.Decode([]byte(encoded))
+	require.NoError(t, err)
+	assert.Equal(t, data, decoded)
+
+	_, err = chain.Decode([]byte("!!"))
+	assert.Error(t, err)
+
+	base := uenc.NewChain(uenc.Base64)
+	_ = base.Then(uenc.Hex)
+	assert.Equal(t, uenc.EncodeBase64(data), base.EncodeToString(data), "Then must not modify the original chain")
+
+	assert.Equal(t, data, uenc.NewChain().Encode(data))
+}
@@ -0,0 +1,98 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package uerror
+
+import (
+	"errors"
+	"fmt"
+)
+
+// CodedError attaches a code of an arbitrary comparable type to an error, e.g. an HTTP status or an application code.
+type CodedError[C comparable] struct {
+	Code C
+	Err  error
+}
+
+func (e *CodedError[C]) Error() string {
+	return fmt.Sprintf("%v (code: %v)", e.Err, e.Code)
+}
+
+func (e *CodedError[C]) Unwrap() error {
+	return e.Err
+}
+
+// WrapWithCode wraps the error with the code. Returns nil if err is nil.
+func WrapWithCode[C comparable](err error, code C) error {
+	if err == nil {
+		return nil
+	}
+
+	return &CodedError[C]{Code: code, Err: err}
+}
+
+// CodeOf returns the code of the first CodedError with the code type C in the error chain.
+func CodeOf[C comparable](err error) (C, bool) {
+	var coded *CodedError[C]
+	if errors.As(err, &coded) {
+		return coded.Code, true
+	}
+
+	var zero C
+	return zero, false
+}
+
+// Retryable is implemented by errors that know whether the failed operation may succeed if retried.
+type Retryable interface {
+	Retryable() bool
+}
+
+type retryableError struct {
+	err       error
+	retryable bool
+}
+
+func (e *retryableError) Error() string {
+	return e.err.Error()
+}
+
+func (e *retryableError) Unwrap() error {
+	return e.err
+}
+
+func (e *retryableError) Retryable() bool {
+	return e.retryable
+}
+
+// MarkRetryable wraps the error, so IsRetryable reports true for it. Returns nil if err is nil.
+func MarkRetryable(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return &retryableError{err: err, retryable: true}
+}
+
+// MarkPermanent wraps the error, so IsRetryable reports false for it, even if the wrapped error is retryable.
+// Returns nil if err is nil.
+func MarkPermanent(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return &retryableError{err: err, retryable: false}
+}
+
+// IsRetryable reports whether the first error implementing Retryable in the error chain allows a retry.
+// Errors that don't implement Retryable are considered permanent.
+func IsRetryable(err error) bool {
+	var r Retryable
+	if errors.As(err, &r) {
+		return r.Retryable()
+	}
+
+	return false
+}
@@ -0,0 +1,88 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package uerror
+
+import (
+	"fmt"
+	"strings"
+)
+
+// IndexedError is an error associated with the index of the item that caused it, e.g. an element of a batch.
+type IndexedError struct {
+	Index int
+	Err   error
+}
+
+func (e *IndexedError) Error() string {
+	return fmt.Sprintf("#%d: %v", e.Index, e.Err)
+}
+
+func (e *IndexedError) Unwrap() error {
+	return e.Err
+}
+
+// Multi aggregates several errors into one. Unlike errors.Join it can be filled incrementally,
+// keeps the indices of failed items (see AppendAt) and returns nil when nothing was collected (see ErrorOrNil).
+// errors.Is and errors.As check every aggregated error.
+// The zero value is ready to use. Multi is not thread-safe.
+type Multi struct {
+	errs []error
+}
+
+// Append adds the error to the aggregate. Nil errors are ignored.
+func (m *Multi) Append(err error) {
+	if err != nil {
+		m.errs = append(m.errs, err)
+	}
+}
+
+// AppendAt adds the error wrapped into IndexedError with the provided index. Nil errors are ignored.
+func (m *Multi) AppendAt(index int, err error) {
+	if err != nil {
+		m.errs = append(m.errs, &IndexedError{Index: index, Err: err})
+	}
+}
+
+// Len returns the number of collected errors.
+func (m *Multi) Len() int {
+	return len(m.errs)
+}
+
+// Errors returns a copy of the collected errors.
+func (m *Multi) Errors() []error {
+	return append([]error(nil), m.errs...)
+}
+
+// ErrorOrNil returns the Multi as an error if it contains any errors and nil otherwise.
+func (m *Multi) ErrorOrNil() error {
+	if m == nil || len(m.errs) == 0 {
+		return nil
+	}
+
+	return m
+}
+
+func (m *Multi) Error() string {
+	switch len(m.errs) {
+	case 0:
+		return "no errors"
+	case 1:
+		return m.errs[0].Error()
+	}
+
+	msgs := make([]string, len(m.errs))
+	for i, err := range m.errs {
+		msgs[i] = err.Error()
+	}
+
+	return fmt.Sprintf("%d errors occurred: %s", len(m.errs), strings.Join(msgs, "; "))
+}
+
+// Unwrap returns the collected errors, so they are visible to errors.Is and errors.As.
+func (m *Multi) Unwrap() []error {
+	return m.errs
+}
@@ -30,3 +30,15 @@ func Must(args ...any) {
 		panic(err)
 	}
 }
+
+// MustValue returns the value if err is nil and panics with err otherwise.
+// It is a typed counterpart of Must for functions returning a single value and an error:
+//
+//	data := MustValue(os.ReadFile("./myfile.txt"))
+func MustValue[T any](value T, err error) T {
+	if err != nil {
+		panic(err)
+	}
+
+	return value
+}
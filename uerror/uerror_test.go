@@ -8,10 +8,14 @@ package uerror_test
 
 import (
 	"errors"
+	"fmt"
+	"io"
+	"net/http"
 	"testing"
 
 	"github.com/kordax/basic-utils/uerror"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestMust(t *testing.T) {
@@ -61,3 +65,78 @@ func successfulOperation() (string, error) {
 func failingOperation() (string, error) {
 	return "", errors.New("operation failed")
 }
+
+func TestMustValue(t *testing.T) {
+	assert.Equal(t, 42, uerror.MustValue(42, nil))
+	assert.PanicsWithError(t, "test error", func() {
+		uerror.MustValue(0, errors.New("test error"))
+	})
+}
+
+func TestMulti(t *testing.T) {
+	var m uerror.Multi
+	m.Append(nil)
+	m.AppendAt(0, nil)
+	assert.NoError(t, m.ErrorOrNil())
+	assert.Equal(t, 0, m.Len())
+
+	m.Append(io.EOF)
+	assert.EqualError(t, m.ErrorOrNil(), "EOF")
+
+	m.AppendAt(3, io.ErrUnexpectedEOF)
+	err := m.ErrorOrNil()
+	require.Error(t, err)
+	assert.Equal(t, 2, m.Len())
+	assert.EqualError(t, err, "2 errors occurred: EOF; #3: unexpected EOF")
+	assert.ErrorIs(t, err, io.EOF)
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+
+	var indexed *uerror.IndexedError
+	require.ErrorAs(t, err, &indexed)
+	assert.Equal(t, 3, indexed.Index)
+
+	errs := m.Errors()
+	errs[0] = nil
+	assert.ErrorIs(t, m.ErrorOrNil(), io.EOF, "Errors must return a copy")
+
+	var nilMulti *uerror.Multi
+	assert.NoError(t, nilMulti.ErrorOrNil())
+}
+
+func TestWrapWithCode(t *testing.T) {
+	assert.NoError(t, uerror.WrapWithCode(nil, 1))
+
+	base := errors.New("not found")
+	err := fmt.Errorf("lookup: %w", uerror.WrapWithCode(base, http.StatusNotFound))
+	assert.EqualError(t, err, "lookup: not found (code: 404)")
+	assert.ErrorIs(t, err, base)
+
+	code, ok := uerror.CodeOf[int](err)
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusNotFound, code)
+
+	_, ok = uerror.CodeOf[string](err)
+	assert.False(t, ok, "codes of different types must not match")
+	_, ok = uerror.CodeOf[int](base)
+	assert.False(t, ok)
+}
+
+type temporaryError struct{}
+
+func (temporaryError) Error() string   { return "temporary" }
+func (temporaryError) Retryable() bool { return true }
+
+func TestIsRetryable(t *testing.T) {
+	base := errors.New("failure")
+
+	assert.False(t, uerror.IsRetryable(nil))
+	assert.False(t, uerror.IsRetryable(base))
+	assert.True(t, uerror.IsRetryable(temporaryError{}))
+	assert.True(t, uerror.IsRetryable(fmt.Errorf("wrapped: %w", temporaryError{})))
+	assert.True(t, uerror.IsRetryable(uerror.MarkRetryable(base)))
+	assert.False(t, uerror.IsRetryable(uerror.MarkPermanent(temporaryError{})))
+	assert.ErrorIs(t, uerror.MarkRetryable(base), base)
+	assert.EqualError(t, uerror.MarkPermanent(base), "failure")
+	assert.NoError(t, uerror.MarkRetryable(nil))
+	assert.NoError(t, uerror.MarkPermanent(nil))
+}
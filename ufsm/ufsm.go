@@ -0,0 +1,267 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+// Package ufsm implements a generic finite state machine with guarded transitions and state callbacks.
+package ufsm
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ErrInvalidTransition is returned, wrapped in TransitionError, when no transition is defined for the event in the current state.
+var ErrInvalidTransition = errors.New("ufsm: invalid transition")
+
+// Transition describes a transition from one state to another triggered by an event.
+type Transition[S, E comparable] struct {
+	From  S
+	Event E
+	To    S
+}
+
+// TransitionError is returned by Fire when the event can't be handled. It unwraps to ErrInvalidTransition
+// if no transition is defined for the event or to the error of the guard that rejected the transition.
+type TransitionError[S, E comparable] struct {
+	State S
+	Event E
+	Err   error
+}
+
+func (e *TransitionError[S, E]) Error() string {
+	return fmt.Sprintf("ufsm: event %v in state %v: %v", e.Event, e.State, e.Err)
+}
+
+func (e *TransitionError[S, E]) Unwrap() error {
+	return e.Err
+}
+
+// TransitionOption configures a transition defined with Machine.Permit.
+type TransitionOption[S, E comparable] func(t *transition[S, E])
+
+// WithGuard makes the transition conditional: Fire calls the guard before changing the state
+// and fails with the guard error, wrapped in TransitionError, if it isn't nil.
+// Multiple guards are called in order until the first error.
+func WithGuard[S, E comparable](guard func(t Transition[S, E]) error) TransitionOption[S, E] {
+	return func(t *transition[S, E]) {
+		t.guards = append(t.guards, guard)
+	}
+}
+
+// WithAction sets the callback called when the transition is made, after the exit callbacks of the source state
+// and before the enter callbacks of the target state.
+func WithAction[S, E comparable](action func(t Transition[S, E])) TransitionOption[S, E] {
+	return func(t *transition[S, E]) {
+		t.actions = append(t.actions, action)
+	}
+}
+
+type transition[S, E comparable] struct {
+	Transition[S, E]
+	guards  []func(t Transition[S, E]) error
+	actions []func(t Transition[S, E])
+}
+
+/*
+Machine is a finite state machine over the states of type S changed by the events of type E.
+Transitions are defined with Permit, typically right after New, and the events are handled with Fire:
+
+	m := ufsm.New[OrderState, OrderEvent](Created)
+	m.Permit(Created, Pay, Paid, ufsm.WithGuard(func(t ufsm.Transition[OrderState, OrderEvent]) error {
+		return checkBalance(order)
+	}))
+	m.Permit(Paid, Ship, Shipped)
+	m.OnEnter(Shipped, func(t ufsm.Transition[OrderState, OrderEvent]) {
+		notify(order)
+	})
+
+	if err := m.Fire(Pay); err != nil {
+		return err
+	}
+
+Callbacks are called synchronously by Fire. They can inspect the Machine, but must not call Fire, which would deadlock.
+
+Machine is safe for concurrent use, Fire calls are serialized.
+*/
+type Machine[S, E comparable] struct {
+	fireMtx sync.Mutex
+
+	mtx         sync.RWMutex
+	state       S
+	transitions map[S]map[E]*transition[S, E]
+	order       []*transition[S, E]
+	onEnter     map[S][]func(t Transition[S, E])
+	onExit      map[S][]func(t Transition[S, E])
+	onChange    []func(t Transition[S, E])
+}
+
+// New creates a Machine in the initial state without any transitions.
+func New[S, E comparable](initial S) *Machine[S, E] {
+	return &Machine[S, E]{
+		state:       initial,
+		transitions: make(map[S]map[E]*transition[S, E]),
+		onEnter:     make(map[S][]func(t Transition[S, E])),
+		onExit:      make(map[S][]func(t Transition[S, E])),
+	}
+}
+
+// Permit defines the transition from one state to another triggered by the event and returns the Machine for chaining.
+// A state can transition to itself, in which case its exit and enter callbacks are still called.
+// Permit panics if a transition for the event in the source state is already defined.
+func (m *Machine[S, E]) Permit(from S, event E, to S, opts ...TransitionOption[S, E]) *Machine[S, E] {
+	t := &transition[S, E]{Transition: Transition[S, E]{From: from, Event: event, To: to}}
+	for _, opt := range opts {
+		opt(t)
+	}
+
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	events, ok := m.transitions[from]
+	if !ok {
+		events = make(map[E]*transition[S, E])
+		m.transitions[from] = events
+	}
+	if _, exists := events[event]; exists {
+		panic(fmt.Sprintf("ufsm: duplicate transition for event %v in state %v", event, from))
+	}
+	events[event] = t
+	m.order = append(m.order, t)
+
+	return m
+}
+
+// OnEnter adds the callback called whenever the Machine enters the state.
+func (m *Machine[S, E]) OnEnter(state S, f func(t Transition[S, E])) *Machine[S, E] {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	m.onEnter[state] = append(m.onEnter[state], f)
+
+	return m
+}
+
+// OnExit adds the callback called whenever the Machine leaves the state.
+func (m *Machine[S, E]) OnExit(state S, f func(t Transition[S, E])) *Machine[S, E] {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	m.onExit[state] = append(m.onExit[state], f)
+
+	return m
+}
+
+// OnTransition adds the callback called after every transition, which is handy for logging and auditing.
+func (m *Machine[S, E]) OnTransition(f func(t Transition[S, E])) *Machine[S, E] {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	m.onChange = append(m.onChange, f)
+
+	return m
+}
+
+// State returns the current state.
+func (m *Machine[S, E]) State() S {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	return m.state
+}
+
+// Can reports whether a transition for the event is defined in the current state. Guards are not evaluated.
+func (m *Machine[S, E]) Can(event E) bool {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+	_, ok := m.transitions[m.state][event]
+
+	return ok
+}
+
+// Events returns the events having transitions defined in the current state, in the order they were defined.
+func (m *Machine[S, E]) Events() []E {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+	result := make([]E, 0, len(m.transitions[m.state]))
+	for _, t := range m.order {
+		if t.From == m.state {
+			result = append(result, t.Event)
+		}
+	}
+
+	return result
+}
+
+// Fire handles the event: checks the transition guards, calls the exit callbacks of the current state,
+// the transition actions, changes the state and calls the enter callbacks of the new state and the transition callbacks.
+// Returns TransitionError if no transition is defined for the event in the current state or a guard rejects it,
+// in which case the state is left unchanged.
+func (m *Machine[S, E]) Fire(event E) error {
+	m.fireMtx.Lock()
+	defer m.fireMtx.Unlock()
+
+	m.mtx.RLock()
+	state := m.state
+	t, ok := m.transitions[state][event]
+	m.mtx.RUnlock()
+	if !ok {
+		return &TransitionError[S, E]{State: state, Event: event, Err: ErrInvalidTransition}
+	}
+	for _, guard := range t.guards {
+		if err := guard(t.Transition); err != nil {
+			return &TransitionError[S, E]{State: state, Event: event, Err: err}
+		}
+	}
+
+	m.call(m.callbacks(m.onExit, t.From), t.Transition)
+	m.call(t.actions, t.Transition)
+	m.mtx.Lock()
+	m.state = t.To
+	onEnter, onChange := m.onEnter[t.To], m.onChange
+	m.mtx.Unlock()
+	m.call(onEnter, t.Transition)
+	m.call(onChange, t.Transition)
+
+	return nil
+}
+
+// Transitions returns the transition table in the order the transitions were defined.
+func (m *Machine[S, E]) Transitions() []Transition[S, E] {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+	result := make([]Transition[S, E], len(m.order))
+	for i, t := range m.order {
+		result[i] = t.Transition
+	}
+
+	return result
+}
+
+// DOT renders the transition table in the Graphviz DOT format, the current state is drawn bold.
+func (m *Machine[S, E]) DOT() string {
+	current := fmt.Sprint(m.State())
+	var b strings.Builder
+	b.WriteString("digraph fsm {\n")
+	fmt.Fprintf(&b, "\t%s [style=bold];\n", strconv.Quote(current))
+	for _, t := range m.Transitions() {
+		fmt.Fprintf(&b, "\t%s -> %s [label=%s];\n",
+			strconv.Quote(fmt.Sprint(t.From)), strconv.Quote(fmt.Sprint(t.To)), strconv.Quote(fmt.Sprint(t.Event)))
+	}
+	b.WriteString("}\n")
+
+	return b.String()
+}
+
+func (m *Machine[S, E]) callbacks(callbacks map[S][]func(t Transition[S, E]), state S) []func(t Transition[S, E]) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	return callbacks[state]
+}
+
+func (m *Machine[S, E]) call(callbacks []func(t Transition[S, E]), t Transition[S, E]) {
+	for _, f := range callbacks {
+		f(t)
+	}
+}
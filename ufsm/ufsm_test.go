@@ -0,0 +1,167 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package ufsm_test
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/kordax/basic-utils/ufsm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type orderState string
+type orderEvent int
+
+const (
+	created   orderState = "created"
+	paid      orderState = "paid"
+	shipped   orderState = "shipped"
+	cancelled orderState = "cancelled"
+)
+
+const (
+	pay orderEvent = iota
+	ship
+	cancel
+)
+
+type orderTransition = ufsm.Transition[orderState, orderEvent]
+
+func newOrderMachine() *ufsm.Machine[orderState, orderEvent] {
+	return ufsm.New[orderState, orderEvent](created).
+		Permit(created, pay, paid).
+		Permit(created, cancel, cancelled).
+		Permit(paid, ship, shipped).
+		Permit(paid, cancel, cancelled)
+}
+
+func TestMachine_Fire(t *testing.T) {
+	m := newOrderMachine()
+	assert.Equal(t, created, m.State())
+	assert.True(t, m.Can(pay))
+	assert.False(t, m.Can(ship))
+	assert.Equal(t, []orderEvent{pay, cancel}, m.Events())
+
+	require.NoError(t, m.Fire(pay))
+	assert.Equal(t, paid, m.State())
+
+	err := m.Fire(pay)
+	assert.ErrorIs(t, err, ufsm.ErrInvalidTransition)
+	var transitionErr *ufsm.TransitionError[orderState, orderEvent]
+	require.ErrorAs(t, err, &transitionErr)
+	assert.Equal(t, paid, transitionErr.State)
+	assert.Equal(t, pay, transitionErr.Event)
+	assert.Equal(t, paid, m.State())
+
+	require.NoError(t, m.Fire(ship))
+	assert.Equal(t, shipped, m.State())
+	assert.Empty(t, m.Events())
+}
+
+func TestMachine_Guard(t *testing.T) {
+	errNoFunds := errors.New("no funds")
+	funds := 0
+	m := ufsm.New[orderState, orderEvent](created).
+		Permit(created, pay, paid, ufsm.WithGuard(func(orderTransition) error {
+			if funds == 0 {
+				return errNoFunds
+			}
+			return nil
+		}))
+
+	err := m.Fire(pay)
+	assert.ErrorIs(t, err, errNoFunds)
+	assert.NotErrorIs(t, err, ufsm.ErrInvalidTransition)
+	assert.Equal(t, created, m.State())
+
+	funds = 10
+	require.NoError(t, m.Fire(pay))
+	assert.Equal(t, paid, m.State())
+}
+
+func TestMachine_Callbacks(t *testing.T) {
+	var calls []string
+	record := func(name string) func(t orderTransition) {
+		return func(orderTransition) {
+			calls = append(calls, name)
+		}
+	}
+
+	m := ufsm.New[orderState, orderEvent](created)
+	m.Permit(created, pay, paid, ufsm.WithAction(record("action")))
+	m.Permit(paid, pay, paid)
+	m.OnExit(created, record("exit created"))
+	m.OnEnter(paid, record("enter paid"))
+	m.OnExit(paid, record("exit paid"))
+	m.OnEnter(paid, func(tr orderTransition) {
+		assert.Equal(t, orderTransition{From: created, Event: pay, To: paid}, tr)
+		assert.Equal(t, paid, m.State(), "the state must be changed before entering")
+	})
+	m.OnTransition(record("transition"))
+
+	require.NoError(t, m.Fire(pay))
+	assert.Equal(t, []string{"exit created", "action", "enter paid", "transition"}, calls)
+
+	calls = nil
+	m = ufsm.New[orderState, orderEvent](paid).Permit(paid, pay, paid)
+	m.OnExit(paid, record("exit paid")).OnEnter(paid, record("enter paid"))
+	require.NoError(t, m.Fire(pay))
+	assert.Equal(t, []string{"exit paid", "enter paid"}, calls, "self transitions must call the callbacks")
+
+	calls = nil
+	assert.Error(t, m.Fire(cancel))
+	assert.Empty(t, calls)
+}
+
+func TestMachine_Transitions(t *testing.T) {
+	m := newOrderMachine()
+	assert.Equal(t, []orderTransition{
+		{From: created, Event: pay, To: paid},
+		{From: created, Event: cancel, To: cancelled},
+		{From: paid, Event: ship, To: shipped},
+		{From: paid, Event: cancel, To: cancelled},
+	}, m.Transitions())
+
+	assert.Equal(t, `digraph fsm {
+	"created" [style=bold];
+	"created" -> "paid" [label="0"];
+	"created" -> "cancelled" [label="2"];
+	"paid" -> "shipped" [label="1"];
+	"paid" -> "cancelled" [label="2"];
+}
+`, m.DOT())
+
+	assert.Panics(t, func() {
+		m.Permit(created, pay, shipped)
+	})
+}
+
+func TestMachine_Concurrent(t *testing.T) {
+	m := ufsm.New[int, string](0)
+	for i := range 100 {
+		m.Permit(i, "next", i+1)
+	}
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 10 {
+				assert.NoError(t, m.Fire("next"))
+				m.State()
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 100, m.State())
+	assert.False(t, m.Can("next"))
+}
@@ -0,0 +1,203 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package uheap
+
+import "cmp"
+
+// IntervalHeap is a double-ended heap providing access to both the smallest and the largest elements in O(1)
+// and removing them in O(log n).
+//
+// Every node of the heap holds an interval of two elements: the left ones form a min heap and the right ones
+// form a max heap, with every interval containing the intervals of its children.
+// The zero value is not usable, use NewInterval or NewIntervalOrdered.
+type IntervalHeap[T any] struct {
+	data []T
+	less func(a, b T) bool
+}
+
+// NewInterval creates an empty IntervalHeap ordered by the less function.
+func NewInterval[T any](less func(a, b T) bool) *IntervalHeap[T] {
+	return &IntervalHeap[T]{less: less}
+}
+
+// NewIntervalOrdered creates an empty IntervalHeap using the natural order of the elements.
+func NewIntervalOrdered[T cmp.Ordered]() *IntervalHeap[T] {
+	return NewInterval(cmp.Less[T])
+}
+
+// Len returns the number of elements in the heap.
+func (h *IntervalHeap[T]) Len() int {
+	return len(h.data)
+}
+
+// Push adds the value to the heap.
+func (h *IntervalHeap[T]) Push(value T) {
+	h.data = append(h.data, value)
+	n := len(h.data) - 1
+	node := n / 2
+
+	if n%2 == 1 {
+		// The node is complete now, its elements must be ordered.
+		if h.less(h.data[n], h.data[n-1]) {
+			h.data[n], h.data[n-1] = h.data[n-1], h.data[n]
+			h.upMin(node)
+		} else {
+			h.upMax(node)
+		}
+		return
+	}
+
+	if node == 0 {
+		return
+	}
+	parent := (node - 1) / 2
+	switch {
+	case h.less(value, h.data[2*parent]):
+		h.upMin(node)
+	case h.less(h.data[2*parent+1], value):
+		h.upMax(node)
+	}
+}
+
+// Min returns the smallest element. The second value is false if the heap is empty.
+func (h *IntervalHeap[T]) Min() (T, bool) {
+	if len(h.data) == 0 {
+		var zero T
+		return zero, false
+	}
+
+	return h.data[0], true
+}
+
+// Max returns the largest element. The second value is false if the heap is empty.
+func (h *IntervalHeap[T]) Max() (T, bool) {
+	if len(h.data) == 0 {
+		var zero T
+		return zero, false
+	}
+
+	return h.data[h.maxIndex(0)], true
+}
+
+// PopMin removes and returns the smallest element. The second value is false if the heap is empty.
+func (h *IntervalHeap[T]) PopMin() (T, bool) {
+	top, ok := h.Min()
+	if !ok {
+		return top, false
+	}
+
+	h.removeAt(0)
+	if len(h.data) > 0 {
+		h.downMin(0)
+	}
+
+	return top, true
+}
+
+// PopMax removes and returns the largest element. The second value is false if the heap is empty.
+func (h *IntervalHeap[T]) PopMax() (T, bool) {
+	top, ok := h.Max()
+	if !ok {
+		return top, false
+	}
+
+	i := h.maxIndex(0)
+	h.removeAt(i)
+	if i < len(h.data) {
+		h.downMax(0)
+	}
+
+	return top, true
+}
+
+// removeAt replaces the element at i with the last one and shrinks the heap.
+func (h *IntervalHeap[T]) removeAt(i int) {
+	var zero T
+	last := len(h.data) - 1
+	h.data[i] = h.data[last]
+	h.data[last] = zero
+	h.data = h.data[:last]
+}
+
+// maxIndex returns the index of the right element of the node, single element nodes use their only element.
+func (h *IntervalHeap[T]) maxIndex(node int) int {
+	if i := 2*node + 1; i < len(h.data) {
+		return i
+	}
+
+	return 2 * node
+}
+
+func (h *IntervalHeap[T]) upMin(node int) {
+	for node > 0 {
+		parent := (node - 1) / 2
+		if !h.less(h.data[2*node], h.data[2*parent]) {
+			return
+		}
+		h.data[2*node], h.data[2*parent] = h.data[2*parent], h.data[2*node]
+		node = parent
+	}
+}
+
+func (h *IntervalHeap[T]) upMax(node int) {
+	for node > 0 {
+		parent := (node - 1) / 2
+		i, p := h.maxIndex(node), 2*parent+1
+		if !h.less(h.data[p], h.data[i]) {
+			return
+		}
+		h.data[i], h.data[p] = h.data[p], h.data[i]
+		node = parent
+	}
+}
+
+func (h *IntervalHeap[T]) downMin(node int) {
+	nodes := (len(h.data) + 1) / 2
+	for {
+		child := -1
+		for _, c := range []int{2*node + 1, 2*node + 2} {
+			if c < nodes && (child < 0 || h.less(h.data[2*c], h.data[2*child])) {
+				child = c
+			}
+		}
+		if child < 0 || !h.less(h.data[2*child], h.data[2*node]) {
+			h.fix(node)
+			return
+		}
+		h.data[2*child], h.data[2*node] = h.data[2*node], h.data[2*child]
+		h.fix(child)
+		node = child
+	}
+}
+
+func (h *IntervalHeap[T]) downMax(node int) {
+	nodes := (len(h.data) + 1) / 2
+	for {
+		child := -1
+		for _, c := range []int{2*node + 1, 2*node + 2} {
+			if c < nodes && (child < 0 || h.less(h.data[h.maxIndex(child)], h.data[h.maxIndex(c)])) {
+				child = c
+			}
+		}
+		i := h.maxIndex(node)
+		if child < 0 || !h.less(h.data[i], h.data[h.maxIndex(child)]) {
+			h.fix(node)
+			return
+		}
+		ci := h.maxIndex(child)
+		h.data[i], h.data[ci] = h.data[ci], h.data[i]
+		h.fix(child)
+		node = child
+	}
+}
+
+// fix restores the order of the node elements.
+func (h *IntervalHeap[T]) fix(node int) {
+	if i := 2*node + 1; i < len(h.data) && h.less(h.data[i], h.data[i-1]) {
+		h.data[i], h.data[i-1] = h.data[i-1], h.data[i]
+	}
+}
@@ -0,0 +1,212 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+// Package uheap provides generic binary heap containers: a Heap ordered by an arbitrary less function,
+// a double-ended IntervalHeap and a k-way merge of sorted slices built on top of them.
+//
+// Unlike uqueue, the containers are not thread-safe and carry no priority queue semantics,
+// which makes them cheap building blocks for TopN selections, schedulers and expiry queues.
+package uheap
+
+import "cmp"
+
+// Heap is a binary heap which pops the smallest element according to the less function first.
+// The zero value is not usable, use New, NewMin, NewMax or From.
+type Heap[T any] struct {
+	data []T
+	less func(a, b T) bool
+}
+
+// New creates an empty Heap ordered by the less function.
+func New[T any](less func(a, b T) bool) *Heap[T] {
+	return &Heap[T]{less: less}
+}
+
+// NewMin creates an empty Heap popping the smallest elements first.
+func NewMin[T cmp.Ordered]() *Heap[T] {
+	return New(cmp.Less[T])
+}
+
+// NewMax creates an empty Heap popping the largest elements first.
+func NewMax[T cmp.Ordered]() *Heap[T] {
+	return New(func(a, b T) bool { return cmp.Less(b, a) })
+}
+
+// From creates a Heap from the values in O(n). The values are copied.
+func From[T any](values []T, less func(a, b T) bool) *Heap[T] {
+	h := &Heap[T]{data: append([]T(nil), values...), less: less}
+	for i := len(h.data)/2 - 1; i >= 0; i-- {
+		h.down(i)
+	}
+
+	return h
+}
+
+// Len returns the number of elements in the heap.
+func (h *Heap[T]) Len() int {
+	return len(h.data)
+}
+
+// Push adds the value to the heap.
+func (h *Heap[T]) Push(value T) {
+	h.data = append(h.data, value)
+	h.up(len(h.data) - 1)
+}
+
+// Peek returns the top element without removing it. The second value is false if the heap is empty.
+func (h *Heap[T]) Peek() (T, bool) {
+	if len(h.data) == 0 {
+		var zero T
+		return zero, false
+	}
+
+	return h.data[0], true
+}
+
+// Pop removes and returns the top element. The second value is false if the heap is empty.
+func (h *Heap[T]) Pop() (T, bool) {
+	var zero T
+	if len(h.data) == 0 {
+		return zero, false
+	}
+
+	top := h.data[0]
+	last := len(h.data) - 1
+	h.data[0] = h.data[last]
+	h.data[last] = zero
+	h.data = h.data[:last]
+	if last > 0 {
+		h.down(0)
+	}
+
+	return top, true
+}
+
+// PushPop pushes the value and pops the top element, which is more efficient than calling Push and Pop separately.
+// It is handy for keeping the N largest elements in a min heap of size N.
+func (h *Heap[T]) PushPop(value T) T {
+	if len(h.data) == 0 || !h.less(h.data[0], value) {
+		return value
+	}
+
+	top := h.data[0]
+	h.data[0] = value
+	h.down(0)
+
+	return top
+}
+
+// Clear removes all the elements.
+func (h *Heap[T]) Clear() {
+	clear(h.data)
+	h.data = h.data[:0]
+}
+
+// Values returns a copy of the heap elements in no particular order.
+func (h *Heap[T]) Values() []T {
+	return append([]T(nil), h.data...)
+}
+
+func (h *Heap[T]) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !h.less(h.data[i], h.data[parent]) {
+			return
+		}
+		h.data[i], h.data[parent] = h.data[parent], h.data[i]
+		i = parent
+	}
+}
+
+func (h *Heap[T]) down(i int) {
+	n := len(h.data)
+	for {
+		smallest := i
+		if l := 2*i + 1; l < n && h.less(h.data[l], h.data[smallest]) {
+			smallest = l
+		}
+		if r := 2*i + 2; r < n && h.less(h.data[r], h.data[smallest]) {
+			smallest = r
+		}
+		if smallest == i {
+			return
+		}
+		h.data[i], h.data[smallest] = h.data[smallest], h.data[i]
+		i = smallest
+	}
+}
+
+// TopN returns the n largest values according to the less function in descending order.
+func TopN[T any](values []T, n int, less func(a, b T) bool) []T {
+	if n <= 0 {
+		return []T{}
+	}
+
+	h := New(less)
+	for _, v := range values {
+		if h.Len() < n {
+			h.Push(v)
+			continue
+		}
+		h.PushPop(v)
+	}
+
+	result := make([]T, h.Len())
+	for i := len(result) - 1; i >= 0; i-- {
+		result[i], _ = h.Pop()
+	}
+
+	return result
+}
+
+// MergeK merges the sorted slices into a single sorted slice in O(n log k).
+func MergeK[T cmp.Ordered](sorted ...[]T) []T {
+	return MergeKFunc(cmp.Less[T], sorted...)
+}
+
+// MergeKFunc merges the slices sorted by the less function into a single sorted slice in O(n log k).
+// The merge is stable: equal elements keep the order of the slices they come from.
+func MergeKFunc[T any](less func(a, b T) bool, sorted ...[]T) []T {
+	type cursor struct {
+		slice int
+		pos   int
+	}
+
+	total := 0
+	for _, s := range sorted {
+		total += len(s)
+	}
+
+	h := New(func(a, b cursor) bool {
+		av, bv := sorted[a.slice][a.pos], sorted[b.slice][b.pos]
+		if less(av, bv) {
+			return true
+		}
+		if less(bv, av) {
+			return false
+		}
+		return a.slice < b.slice
+	})
+	for i, s := range sorted {
+		if len(s) > 0 {
+			h.Push(cursor{slice: i})
+		}
+	}
+
+	result := make([]T, 0, total)
+	for h.Len() > 0 {
+		c := h.data[0]
+		result = append(result, sorted[c.slice][c.pos])
+		if c.pos+1 < len(sorted[c.slice]) {
+			h.data[0].pos++
+			h.down(0)
+		} else {
+			h.Pop()
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,175 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package uheap_test
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/kordax/basic-utils/uheap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func drain[T any](h *uheap.Heap[T]) []T {
+	var result []T
+	for h.Len() > 0 {
+		v, _ := h.Pop()
+		result = append(result, v)
+	}
+
+	return result
+}
+
+func TestHeap(t *testing.T) {
+	h := uheap.NewMin[int]()
+	_, ok := h.Pop()
+	assert.False(t, ok)
+	_, ok = h.Peek()
+	assert.False(t, ok)
+
+	for _, v := range []int{5, 1, 4, 1, 3} {
+		h.Push(v)
+	}
+	top, ok := h.Peek()
+	require.True(t, ok)
+	assert.Equal(t, 1, top)
+	assert.Len(t, h.Values(), 5)
+	assert.Equal(t, []int{1, 1, 3, 4, 5}, drain(h))
+
+	h = uheap.NewMax[int]()
+	for _, v := range []int{5, 1, 4} {
+		h.Push(v)
+	}
+	assert.Equal(t, []int{5, 4, 1}, drain(h))
+
+	type task struct {
+		name     string
+		priority int
+	}
+	tasks := uheap.From([]task{{"a", 2}, {"b", 1}, {"c", 3}}, func(a, b task) bool { return a.priority < b.priority })
+	first, _ := tasks.Pop()
+	assert.Equal(t, "b", first.name)
+	tasks.Clear()
+	assert.Zero(t, tasks.Len())
+}
+
+func TestHeap_Random(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	values := make([]int, 1000)
+	for i := range values {
+		values[i] = r.Intn(100)
+	}
+	expected := slices.Clone(values)
+	slices.Sort(expected)
+
+	assert.Equal(t, expected, drain(uheap.From(values, func(a, b int) bool { return a < b })))
+
+	h := uheap.NewMin[int]()
+	for _, v := range values {
+		h.Push(v)
+	}
+	assert.Equal(t, expected, drain(h))
+}
+
+func TestHeap_PushPop(t *testing.T) {
+	h := uheap.NewMin[int]()
+	assert.Equal(t, 5, h.PushPop(5))
+	h.Push(3)
+	assert.Equal(t, 2, h.PushPop(2))
+	assert.Equal(t, 3, h.PushPop(7))
+	top, _ := h.Peek()
+	assert.Equal(t, 7, top)
+}
+
+func TestTopN(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	assert.Equal(t, []int{9, 8, 7}, uheap.TopN([]int{3, 9, 1, 7, 8, 2}, 3, less))
+	assert.Equal(t, []int{2, 1}, uheap.TopN([]int{1, 2}, 5, less))
+	assert.Empty(t, uheap.TopN([]int{1, 2}, 0, less))
+}
+
+func TestMergeK(t *testing.T) {
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 6, 7}, uheap.MergeK([]int{1, 4, 7}, []int{}, []int{2, 5}, []int{3, 6}))
+	assert.Empty(t, uheap.MergeK[int]())
+
+	type item struct {
+		key    int
+		source string
+	}
+	merged := uheap.MergeKFunc(func(a, b item) bool { return a.key < b.key },
+		[]item{{1, "a"}, {2, "a"}},
+		[]item{{1, "b"}, {3, "b"}},
+	)
+	assert.Equal(t, []item{{1, "a"}, {1, "b"}, {2, "a"}, {3, "b"}}, merged, "MergeKFunc must be stable")
+}
+
+func TestIntervalHeap(t *testing.T) {
+	h := uheap.NewIntervalOrdered[int]()
+	_, ok := h.PopMin()
+	assert.False(t, ok)
+	_, ok = h.PopMax()
+	assert.False(t, ok)
+
+	for _, v := range []int{5, 1, 9, 3, 7} {
+		h.Push(v)
+	}
+	minValue, _ := h.Min()
+	maxValue, _ := h.Max()
+	assert.Equal(t, 1, minValue)
+	assert.Equal(t, 9, maxValue)
+
+	v, _ := h.PopMax()
+	assert.Equal(t, 9, v)
+	v, _ = h.PopMin()
+	assert.Equal(t, 1, v)
+	v, _ = h.PopMax()
+	assert.Equal(t, 7, v)
+	assert.Equal(t, 2, h.Len())
+}
+
+func TestIntervalHeap_Random(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	for round := 0; round < 50; round++ {
+		h := uheap.NewInterval(func(a, b int) bool { return a < b })
+		var reference []int
+
+		for op := 0; op < 300; op++ {
+			switch r.Intn(3) {
+			case 0, 1:
+				v := r.Intn(50)
+				h.Push(v)
+				reference = append(reference, v)
+			case 2:
+				slices.Sort(reference)
+				if r.Intn(2) == 0 {
+					v, ok := h.PopMin()
+					require.Equal(t, len(reference) > 0, ok)
+					if ok {
+						require.Equal(t, reference[0], v)
+						reference = reference[1:]
+					}
+				} else {
+					v, ok := h.PopMax()
+					require.Equal(t, len(reference) > 0, ok)
+					if ok {
+						require.Equal(t, reference[len(reference)-1], v)
+						reference = reference[:len(reference)-1]
+					}
+				}
+			}
+			require.Equal(t, len(reference), h.Len())
+			if len(reference) > 0 {
+				minValue, _ := h.Min()
+				maxValue, _ := h.Max()
+				require.Equal(t, slices.Min(reference), minValue)
+				require.Equal(t, slices.Max(reference), maxValue)
+			}
+		}
+	}
+}
@@ -0,0 +1,324 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package uhttputil
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/dgryski/go-farm"
+	"github.com/kordax/basic-utils/ubackoff"
+	"github.com/kordax/basic-utils/ucache"
+	"github.com/kordax/basic-utils/uopt"
+)
+
+// CachedResponse is a snapshot of a successful response stored in the response cache.
+type CachedResponse struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+// ResponseCache stores the responses of idempotent requests, see WithCache.
+type ResponseCache = ucache.Cache[*ucache.FarmHash64Entity, CachedResponse]
+
+// Client wraps http.Client with retries, per-attempt timeouts and an optional cache for idempotent requests.
+// Client is safe for concurrent use if the underlying http.Client and cache are.
+type Client struct {
+	client     *http.Client
+	retries    int
+	newBackoff func() ubackoff.Backoff
+	timeout    time.Duration
+	retryIf    func(req *http.Request, resp *http.Response, err error) bool
+	cache      ResponseCache
+}
+
+// Option configures the Client.
+type Option func(c *Client)
+
+// WithHTTPClient sets the underlying http.Client. http.DefaultClient is used by default.
+func WithHTTPClient(client *http.Client) Option {
+	return func(c *Client) {
+		c.client = client
+	}
+}
+
+// WithRetries sets the number of retries after the first failed attempt. No retries are made by default.
+func WithRetries(retries int) Option {
+	return func(c *Client) {
+		c.retries = retries
+	}
+}
+
+// WithBackoff sets the delay strategy between attempts. Backoffs are stateful, so newBackoff is called
+// for every request to create its own one. ubackoff.NewExponential(100ms, 5s) is used by default.
+func WithBackoff(newBackoff func() ubackoff.Backoff) Option {
+	return func(c *Client) {
+		c.newBackoff = newBackoff
+	}
+}
+
+// WithTimeout sets the timeout of every single attempt, including reading of the response body.
+// The overall time is still limited by the request context.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.timeout = timeout
+	}
+}
+
+// WithRetryIf replaces the condition that decides whether the attempt should be retried, see DefaultRetryIf.
+func WithRetryIf(retryIf func(req *http.Request, resp *http.Response, err error) bool) Option {
+	return func(c *Client) {
+		c.retryIf = retryIf
+	}
+}
+
+// WithCache enables caching of successful (2xx) GET and HEAD responses.
+// Entries are keyed by the method, URL, body hash and the values of the request headers listed in the response
+// Vary header. Outdated entries according to the cache TTL are not used.
+//
+// Requests with credentials, i.e. Authorization or Cookie headers, bypass the cache, so the responses
+// are never shared between the users. Responses with "Cache-Control: no-store" or "private" and "Vary: *"
+// are not stored.
+func WithCache(cache ResponseCache) Option {
+	return func(c *Client) {
+		c.cache = cache
+	}
+}
+
+// NewClient creates a new Client.
+func NewClient(opts ...Option) *Client {
+	c := &Client{
+		client: http.DefaultClient,
+		newBackoff: func() ubackoff.Backoff {
+			return ubackoff.NewExponential(100*time.Millisecond, 5*time.Second)
+		},
+		retryIf: DefaultRetryIf,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// DefaultRetryIf retries transport errors, 429 Too Many Requests and 5xx responses of idempotent requests:
+// GET, HEAD, PUT, DELETE and OPTIONS, or requests with the Idempotency-Key or X-Idempotency-Key header,
+// since the failed attempt of any other request could have been applied by the server.
+func DefaultRetryIf(req *http.Request, resp *http.Response, err error) bool {
+	if !isIdempotent(req) {
+		return false
+	}
+	if err != nil {
+		return !errors.Is(err, context.Canceled)
+	}
+
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError
+}
+
+// Do sends the request with the configured retries and timeouts.
+// The request body is buffered, so it can be sent again on retry.
+// The caller must close the response body as usual.
+func (c *Client) Do(ctx context.Context, req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read request body: %w", err)
+		}
+	}
+
+	cacheable := c.cache != nil && (req.Method == http.MethodGet || req.Method == http.MethodHead) &&
+		req.Header.Get("Authorization") == "" && req.Header.Get("Cookie") == ""
+	reqKey := requestKey{Method: req.Method, URL: req.URL.String(), BodyHash: farm.Hash64(body)}
+	if cacheable {
+		if cached, ok := c.cached(reqKey, req); ok {
+			return cached.toResponse(req), nil
+		}
+	}
+
+	resp, err := c.doWithRetries(ctx, req, body)
+	if err != nil || !cacheable || resp.StatusCode < 200 || resp.StatusCode > 299 || !storable(resp) {
+		return resp, err
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	cached := CachedResponse{StatusCode: resp.StatusCode, Header: resp.Header.Clone(), Body: data}
+	// The entry under the key without the varying headers tells which headers the lookups have to add to the key.
+	c.cache.Set(ucache.Hashed(reqKey), cached)
+	if vary := varyHeaders(resp.Header); len(vary) > 0 {
+		c.cache.Set(ucache.Hashed(reqKey.withHeaders(req, vary)), cached)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(data))
+
+	return resp, nil
+}
+
+// cached returns the cached response to the request, looking up the variant matching the request headers
+// if the response varies by them.
+func (c *Client) cached(reqKey requestKey, req *http.Request) (CachedResponse, bool) {
+	cached, ok := c.get(ucache.Hashed(reqKey))
+	if !ok {
+		return CachedResponse{}, false
+	}
+	if vary := varyHeaders(cached.Header); len(vary) > 0 {
+		return c.get(ucache.Hashed(reqKey.withHeaders(req, vary)))
+	}
+
+	return cached, true
+}
+
+func (c *Client) get(key *ucache.FarmHash64Entity) (CachedResponse, bool) {
+	cached, ok := c.cache.Get(key)
+	if !ok || c.cache.Outdated(uopt.Of(key)) {
+		return CachedResponse{}, false
+	}
+
+	return *cached, true
+}
+
+func (c *Client) doWithRetries(ctx context.Context, req *http.Request, body []byte) (*http.Response, error) {
+	var backoff ubackoff.Backoff
+	for attempt := 0; ; attempt++ {
+		resp, err := c.attempt(ctx, req, body)
+		if attempt >= c.retries || !c.retryIf(req, resp, err) {
+			return resp, err
+		}
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+
+		if backoff == nil {
+			backoff = c.newBackoff()
+		}
+		if err := ubackoff.Wait(ctx, backoff); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (c *Client) attempt(ctx context.Context, req *http.Request, body []byte) (*http.Response, error) {
+	cancel := context.CancelFunc(func() {})
+	if c.timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+	}
+
+	r := req.Clone(ctx)
+	if body != nil {
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		r.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(body)), nil
+		}
+		r.ContentLength = int64(len(body))
+	}
+
+	resp, err := c.client.Do(r)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+
+	return resp, nil
+}
+
+type requestKey struct {
+	Method   string
+	URL      string
+	BodyHash uint64
+	Headers  string // the values of the request headers the response varies by
+}
+
+func (k requestKey) withHeaders(req *http.Request, names []string) requestKey {
+	var b strings.Builder
+	for _, name := range names {
+		b.WriteString(name)
+		b.WriteByte(':')
+		b.WriteString(strings.Join(req.Header.Values(name), ","))
+		b.WriteByte('\n')
+	}
+	k.Headers = b.String()
+
+	return k
+}
+
+func isIdempotent(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete, http.MethodOptions:
+		return true
+	}
+
+	return req.Header.Get("Idempotency-Key") != "" || req.Header.Get("X-Idempotency-Key") != ""
+}
+
+// storable reports whether the response may be stored in a shared cache.
+func storable(resp *http.Response) bool {
+	for _, value := range resp.Header.Values("Cache-Control") {
+		for _, directive := range strings.Split(value, ",") {
+			name, _, _ := strings.Cut(strings.TrimSpace(directive), "=")
+			if strings.EqualFold(name, "no-store") || strings.EqualFold(name, "private") {
+				return false
+			}
+		}
+	}
+
+	return !slices.Contains(varyHeaders(resp.Header), "*")
+}
+
+// varyHeaders returns the sorted canonical names of the request headers listed in the Vary header.
+func varyHeaders(header http.Header) []string {
+	var names []string
+	for _, value := range header.Values("Vary") {
+		for _, name := range strings.Split(value, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				names = append(names, http.CanonicalHeaderKey(name))
+			}
+		}
+	}
+	slices.Sort(names)
+
+	return slices.Compact(names)
+}
+
+func (r CachedResponse) toResponse(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", r.StatusCode, http.StatusText(r.StatusCode)),
+		StatusCode:    r.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        r.Header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(r.Body)),
+		ContentLength: int64(len(r.Body)),
+		Request:       req,
+	}
+}
+
+// cancelOnClose releases the attempt context once the response body is closed.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnClose) Close() error {
+	defer b.cancel()
+	return b.ReadCloser.Close()
+}
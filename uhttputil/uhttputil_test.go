@@ -0,0 +1,288 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package uhttputil_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kordax/basic-utils/ubackoff"
+	"github.com/kordax/basic-utils/ucache"
+	"github.com/kordax/basic-utils/uhttputil"
+	"github.com/kordax/basic-utils/uopt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func noDelay() ubackoff.Backoff {
+	return ubackoff.NewConstant(0)
+}
+
+func TestClient_Retries(t *testing.T) {
+	var calls atomic.Int32
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	client := uhttputil.NewClient(uhttputil.WithRetries(3), uhttputil.WithBackoff(noDelay))
+	req, err := http.NewRequest(http.MethodPost, server.URL, strings.NewReader("payload"))
+	require.NoError(t, err)
+	req.Header.Set("Idempotency-Key", "key")
+
+	resp, err := client.Do(context.Background(), req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "ok", string(body))
+	assert.EqualValues(t, 3, calls.Load())
+	assert.Equal(t, []string{"payload", "payload", "payload"}, bodies, "Body must be resent on every attempt")
+}
+
+func TestClient_RetriesExhausted(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	client := uhttputil.NewClient(uhttputil.WithRetries(2), uhttputil.WithBackoff(noDelay))
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	resp, err := client.Do(context.Background(), req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusBadGateway, resp.StatusCode)
+	assert.EqualValues(t, 3, calls.Load())
+}
+
+func TestClient_NoRetryOnClientError(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := uhttputil.NewClient(uhttputil.WithRetries(5), uhttputil.WithBackoff(noDelay))
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	resp, err := client.Do(context.Background(), req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	assert.EqualValues(t, 1, calls.Load())
+}
+
+func TestClient_Timeout(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(time.Second):
+			}
+			return
+		}
+		_, _ = w.Write([]byte("late ok"))
+	}))
+	defer server.Close()
+
+	client := uhttputil.NewClient(uhttputil.WithTimeout(50*time.Millisecond), uhttputil.WithRetries(1), uhttputil.WithBackoff(noDelay))
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	resp, err := client.Do(context.Background(), req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "late ok", string(body))
+}
+
+func TestClient_ContextCancelledDuringBackoff(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	client := uhttputil.NewClient(uhttputil.WithRetries(10), uhttputil.WithBackoff(func() ubackoff.Backoff {
+		return ubackoff.NewConstant(time.Hour)
+	}))
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	_, err := client.Do(ctx, req)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestClient_Cache(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("X-Path", r.URL.Path)
+		_, _ = w.Write([]byte("body " + r.URL.Path))
+	}))
+	defer server.Close()
+
+	cache := ucache.NewInMemoryHashMapCache[*ucache.FarmHash64Entity, uhttputil.CachedResponse](uopt.Of(time.Hour))
+	client := uhttputil.NewClient(uhttputil.WithCache(cache))
+
+	get := func(path string) string {
+		req, _ := http.NewRequest(http.MethodGet, server.URL+path, nil)
+		resp, err := client.Do(context.Background(), req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, path, resp.Header.Get("X-Path"))
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return string(body)
+	}
+
+	assert.Equal(t, "body /a", get("/a"))
+	assert.Equal(t, "body /a", get("/a"))
+	assert.Equal(t, "body /b", get("/b"))
+	assert.EqualValues(t, 2, calls.Load())
+
+	req, _ := http.NewRequest(http.MethodPost, server.URL+"/a", nil)
+	resp, err := client.Do(context.Background(), req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.EqualValues(t, 3, calls.Load(), "POST requests are never cached")
+}
+
+func TestClient_NoRetryOfNonIdempotentRequests(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := uhttputil.NewClient(uhttputil.WithRetries(3), uhttputil.WithBackoff(noDelay))
+	for _, method := range []string{http.MethodPost, http.MethodPatch} {
+		calls.Store(0)
+		req, _ := http.NewRequest(method, server.URL, strings.NewReader("payload"))
+		resp, err := client.Do(context.Background(), req)
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+		assert.EqualValues(t, 1, calls.Load(), "%s without an idempotency key must not be retried", method)
+	}
+
+	calls.Store(0)
+	req, _ := http.NewRequest(http.MethodPut, server.URL, strings.NewReader("payload"))
+	resp, err := client.Do(context.Background(), req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.EqualValues(t, 4, calls.Load())
+}
+
+func TestClient_CacheCredentials(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		_, _ = w.Write([]byte(r.Header.Get("Authorization") + r.Header.Get("Cookie")))
+	}))
+	defer server.Close()
+
+	cache := ucache.NewInMemoryHashMapCache[*ucache.FarmHash64Entity, uhttputil.CachedResponse](uopt.Of(time.Hour))
+	client := uhttputil.NewClient(uhttputil.WithCache(cache))
+
+	for _, header := range []string{"Authorization", "Cookie"} {
+		for _, user := range []string{"alice", "bob"} {
+			req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+			req.Header.Set(header, user)
+			resp, err := client.Do(context.Background(), req)
+			require.NoError(t, err)
+			body, _ := io.ReadAll(resp.Body)
+			_ = resp.Body.Close()
+			assert.Equal(t, user, string(body), "responses to requests with %s must not be shared", header)
+		}
+	}
+	assert.EqualValues(t, 4, calls.Load())
+	assert.Empty(t, cache.Changes())
+}
+
+func TestClient_CacheControl(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		switch r.URL.Path {
+		case "/no-store":
+			w.Header().Set("Cache-Control", "max-age=60, no-store")
+		case "/private":
+			w.Header().Set("Cache-Control", "private")
+		case "/vary-all":
+			w.Header().Set("Vary", "*")
+		}
+	}))
+	defer server.Close()
+
+	cache := ucache.NewInMemoryHashMapCache[*ucache.FarmHash64Entity, uhttputil.CachedResponse](uopt.Of(time.Hour))
+	client := uhttputil.NewClient(uhttputil.WithCache(cache))
+
+	for _, path := range []string{"/no-store", "/private", "/vary-all"} {
+		calls.Store(0)
+		for range 2 {
+			req, _ := http.NewRequest(http.MethodGet, server.URL+path, nil)
+			resp, err := client.Do(context.Background(), req)
+			require.NoError(t, err)
+			_ = resp.Body.Close()
+		}
+		assert.EqualValues(t, 2, calls.Load(), "%s response must not be stored", path)
+	}
+}
+
+func TestClient_CacheVary(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Vary", "accept, Accept-Language")
+		_, _ = w.Write([]byte(r.Header.Get("Accept") + "/" + r.Header.Get("Accept-Language")))
+	}))
+	defer server.Close()
+
+	cache := ucache.NewInMemoryHashMapCache[*ucache.FarmHash64Entity, uhttputil.CachedResponse](uopt.Of(time.Hour))
+	client := uhttputil.NewClient(uhttputil.WithCache(cache))
+
+	get := func(accept, lang string) string {
+		req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+		req.Header.Set("Accept", accept)
+		req.Header.Set("Accept-Language", lang)
+		resp, err := client.Do(context.Background(), req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return string(body)
+	}
+
+	assert.Equal(t, "application/json/en", get("application/json", "en"))
+	assert.Equal(t, "text/xml/en", get("text/xml", "en"))
+	assert.Equal(t, "text/xml/de", get("text/xml", "de"))
+	assert.EqualValues(t, 3, calls.Load())
+
+	assert.Equal(t, "application/json/en", get("application/json", "en"))
+	assert.Equal(t, "text/xml/de", get("text/xml", "de"))
+	assert.EqualValues(t, 3, calls.Load(), "every variant must be served from the cache")
+}
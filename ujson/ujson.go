@@ -0,0 +1,199 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package ujson
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/kordax/basic-utils/uconst"
+)
+
+// ErrNotFound is returned by Get when the pointer doesn't match any value in the document.
+var ErrNotFound = errors.New("ujson: value not found")
+
+var pointerUnescaper = strings.NewReplacer("~1", "/", "~0", "~")
+
+// MustMarshal returns the JSON encoding of v and panics if it can't be encoded.
+// It is meant for values that are known to be encodable, e.g. static payloads and test fixtures.
+func MustMarshal(v any) []byte {
+	data, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+
+	return data
+}
+
+// Unmarshal decodes the JSON data into a new value of type T.
+func Unmarshal[T any](data []byte) (T, error) {
+	var v T
+	err := json.Unmarshal(data, &v)
+
+	return v, err
+}
+
+// Decode reads the next JSON value from the reader and decodes it into a new value of type T.
+func Decode[T any](r io.Reader) (T, error) {
+	var v T
+	err := json.NewDecoder(r).Decode(&v)
+
+	return v, err
+}
+
+// Number is a numeric value that tolerates string-quoted numbers while unmarshalling,
+// so both 42 and "42" are decoded into 42. Null and an empty string produce zero.
+// Number is always marshalled as a plain JSON number.
+type Number[T uconst.Numeric] struct {
+	Value T
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for the Number type.
+func (n *Number[T]) UnmarshalJSON(data []byte) error {
+	s := string(bytes.TrimSpace(data))
+	if s == "null" {
+		n.Value = 0
+		return nil
+	}
+	if strings.HasPrefix(s, `"`) {
+		var err error
+		if s, err = strconv.Unquote(s); err != nil {
+			return fmt.Errorf("ujson: invalid number %s: %w", data, err)
+		}
+		s = strings.TrimSpace(s)
+		if s == "" {
+			n.Value = 0
+			return nil
+		}
+	}
+
+	v := reflect.ValueOf(&n.Value).Elem()
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("ujson: invalid number %s: %w", data, err)
+		}
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(s, 10, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("ujson: invalid number %s: %w", data, err)
+		}
+		v.SetUint(u)
+	default:
+		f, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("ujson: invalid number %s: %w", data, err)
+		}
+		v.SetFloat(f)
+	}
+
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface for the Number type.
+func (n Number[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(n.Value)
+}
+
+// Merge deep-merges the patch document into the target document following JSON Merge Patch (RFC 7386) rules:
+// objects are merged recursively, null values in the patch remove the keys, and all other values,
+// including arrays, replace the target values. The inputs are not modified.
+func Merge(target, patch []byte) ([]byte, error) {
+	var t, p any
+	if err := json.Unmarshal(target, &t); err != nil {
+		return nil, fmt.Errorf("ujson: invalid target document: %w", err)
+	}
+	if err := json.Unmarshal(patch, &p); err != nil {
+		return nil, fmt.Errorf("ujson: invalid patch document: %w", err)
+	}
+
+	return json.Marshal(mergeValues(t, p))
+}
+
+func mergeValues(target, patch any) any {
+	patchObj, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+	targetObj, ok := target.(map[string]any)
+	if !ok {
+		targetObj = make(map[string]any)
+	}
+
+	for key, value := range patchObj {
+		if value == nil {
+			delete(targetObj, key)
+			continue
+		}
+		targetObj[key] = mergeValues(targetObj[key], value)
+	}
+
+	return targetObj
+}
+
+// Get returns the raw value referenced by the JSON Pointer (RFC 6901), e.g. "/a/b/0/c".
+// An empty pointer references the whole document. ErrNotFound is returned if there is no such value.
+func Get(data []byte, pointer string) (json.RawMessage, error) {
+	if pointer == "" {
+		return json.RawMessage(data), nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("ujson: invalid pointer %q: must start with /", pointer)
+	}
+
+	current := json.RawMessage(data)
+	for _, token := range strings.Split(pointer[1:], "/") {
+		token = pointerUnescaper.Replace(token)
+
+		trimmed := bytes.TrimSpace(current)
+		switch {
+		case len(trimmed) > 0 && trimmed[0] == '{':
+			var obj map[string]json.RawMessage
+			if err := json.Unmarshal(trimmed, &obj); err != nil {
+				return nil, err
+			}
+			next, ok := obj[token]
+			if !ok {
+				return nil, fmt.Errorf("%w: %s", ErrNotFound, pointer)
+			}
+			current = next
+		case len(trimmed) > 0 && trimmed[0] == '[':
+			var arr []json.RawMessage
+			if err := json.Unmarshal(trimmed, &arr); err != nil {
+				return nil, err
+			}
+			index, err := strconv.Atoi(token)
+			if err != nil || index < 0 || index >= len(arr) || (len(token) > 1 && token[0] == '0') {
+				return nil, fmt.Errorf("%w: %s", ErrNotFound, pointer)
+			}
+			current = arr[index]
+		default:
+			return nil, fmt.Errorf("%w: %s", ErrNotFound, pointer)
+		}
+	}
+
+	return current, nil
+}
+
+// GetAs is the same as Get, but decodes the referenced value into T.
+func GetAs[T any](data []byte, pointer string) (T, error) {
+	raw, err := Get(data, pointer)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+
+	return Unmarshal[T](raw)
+}
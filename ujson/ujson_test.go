@@ -0,0 +1,116 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package ujson_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/kordax/basic-utils/ujson"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type payload struct {
+	ID    ujson.Number[int64]   `json:"id"`
+	Price ujson.Number[float64] `json:"price"`
+	Name  string                `json:"name"`
+}
+
+func TestMustMarshal(t *testing.T) {
+	assert.Equal(t, `{"a":1}`, string(ujson.MustMarshal(map[string]int{"a": 1})))
+	assert.Panics(t, func() { ujson.MustMarshal(make(chan int)) })
+}
+
+func TestDecode(t *testing.T) {
+	v, err := ujson.Decode[payload](strings.NewReader(`{"id":"42","price":1.5,"name":"x"}`))
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), v.ID.Value)
+	assert.Equal(t, 1.5, v.Price.Value)
+	assert.Equal(t, "x", v.Name)
+
+	_, err = ujson.Decode[payload](strings.NewReader(`{`))
+	assert.Error(t, err)
+}
+
+func TestNumber(t *testing.T) {
+	var n ujson.Number[int]
+	require.NoError(t, json.Unmarshal([]byte(`42`), &n))
+	assert.Equal(t, 42, n.Value)
+	require.NoError(t, json.Unmarshal([]byte(`" 17 "`), &n))
+	assert.Equal(t, 17, n.Value)
+	require.NoError(t, json.Unmarshal([]byte(`null`), &n))
+	assert.Equal(t, 0, n.Value)
+	assert.Error(t, json.Unmarshal([]byte(`"abc"`), &n))
+	assert.Error(t, json.Unmarshal([]byte(`1.5`), &n))
+
+	var u ujson.Number[uint8]
+	assert.Error(t, json.Unmarshal([]byte(`"300"`), &u))
+
+	var f ujson.Number[float32]
+	require.NoError(t, json.Unmarshal([]byte(`"2.5"`), &f))
+	assert.Equal(t, float32(2.5), f.Value)
+
+	data, err := json.Marshal(ujson.Number[int]{Value: 5})
+	require.NoError(t, err)
+	assert.Equal(t, `5`, string(data))
+}
+
+func TestMerge(t *testing.T) {
+	target := []byte(`{"a":{"b":1,"c":2},"d":[1,2],"e":"x"}`)
+	patch := []byte(`{"a":{"c":3,"f":4},"d":[3],"e":null,"g":true}`)
+
+	merged, err := ujson.Merge(target, patch)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"a":{"b":1,"c":3,"f":4},"d":[3],"g":true}`, string(merged))
+	assert.JSONEq(t, `{"a":{"b":1,"c":2},"d":[1,2],"e":"x"}`, string(target))
+
+	merged, err = ujson.Merge([]byte(`[1]`), []byte(`{"a":1}`))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"a":1}`, string(merged))
+
+	_, err = ujson.Merge([]byte(`{`), patch)
+	assert.Error(t, err)
+	_, err = ujson.Merge(target, []byte(`{`))
+	assert.Error(t, err)
+}
+
+func TestGet(t *testing.T) {
+	doc := []byte(`{"a":{"b":[{"c":"found"},{"c":2}]},"x/y":1,"m~n":2}`)
+
+	raw, err := ujson.Get(doc, "/a/b/0/c")
+	require.NoError(t, err)
+	assert.Equal(t, `"found"`, string(raw))
+
+	raw, err = ujson.Get(doc, "/x~1y")
+	require.NoError(t, err)
+	assert.Equal(t, `1`, string(raw))
+
+	raw, err = ujson.Get(doc, "/m~0n")
+	require.NoError(t, err)
+	assert.Equal(t, `2`, string(raw))
+
+	raw, err = ujson.Get(doc, "")
+	require.NoError(t, err)
+	assert.Equal(t, string(doc), string(raw))
+
+	for _, pointer := range []string{"/missing", "/a/b/5", "/a/b/-1", "/a/b/01", "/a/b/0/c/d"} {
+		_, err = ujson.Get(doc, pointer)
+		assert.ErrorIs(t, err, ujson.ErrNotFound, pointer)
+	}
+
+	_, err = ujson.Get(doc, "a")
+	assert.Error(t, err)
+
+	c, err := ujson.GetAs[int](doc, "/a/b/1/c")
+	require.NoError(t, err)
+	assert.Equal(t, 2, c)
+
+	_, err = ujson.GetAs[int](doc, "/nope")
+	assert.ErrorIs(t, err, ujson.ErrNotFound)
+}
@@ -0,0 +1,56 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package ulog
+
+import (
+	"context"
+	"log/slog"
+)
+
+// SlogLogger adapts the standard library slog.Logger to the Logger interface.
+type SlogLogger struct {
+	l *slog.Logger
+}
+
+// NewSlogLogger creates a new Logger backed by the provided slog.Logger.
+// If logger is nil, slog.Default() is used.
+func NewSlogLogger(logger *slog.Logger) *SlogLogger {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &SlogLogger{l: logger}
+}
+
+func (s *SlogLogger) Debug(msg string, fields ...Field) {
+	s.log(slog.LevelDebug, msg, fields)
+}
+
+func (s *SlogLogger) Info(msg string, fields ...Field) {
+	s.log(slog.LevelInfo, msg, fields)
+}
+
+func (s *SlogLogger) Warn(msg string, fields ...Field) {
+	s.log(slog.LevelWarn, msg, fields)
+}
+
+func (s *SlogLogger) Error(msg string, fields ...Field) {
+	s.log(slog.LevelError, msg, fields)
+}
+
+func (s *SlogLogger) log(level slog.Level, msg string, fields []Field) {
+	ctx := context.Background()
+	if !s.l.Enabled(ctx, level) {
+		return
+	}
+
+	attrs := make([]slog.Attr, len(fields))
+	for i, f := range fields {
+		attrs[i] = slog.Any(f.Key, f.Value)
+	}
+	s.l.LogAttrs(ctx, level, msg, attrs...)
+}
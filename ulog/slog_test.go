@@ -0,0 +1,64 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package ulog_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/kordax/basic-utils/ulog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSlogLogger_Levels(t *testing.T) {
+	buf := new(bytes.Buffer)
+	logger := ulog.NewSlogLogger(slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+
+	logger.Debug("debug message", ulog.F("n", 1))
+	logger.Info("info message")
+	logger.Warn("warn message", ulog.F("key", "value"))
+	logger.Error("error message")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 4)
+
+	expected := []struct {
+		level string
+		msg   string
+	}{
+		{"DEBUG", "debug message"},
+		{"INFO", "info message"},
+		{"WARN", "warn message"},
+		{"ERROR", "error message"},
+	}
+	for i, line := range lines {
+		var record map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &record))
+		assert.Equal(t, expected[i].level, record["level"])
+		assert.Equal(t, expected[i].msg, record["msg"])
+	}
+
+	var first map[string]any
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &first))
+	assert.EqualValues(t, 1, first["n"])
+}
+
+func TestSlogLogger_DisabledLevel(t *testing.T) {
+	buf := new(bytes.Buffer)
+	logger := ulog.NewSlogLogger(slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{Level: slog.LevelWarn})))
+
+	logger.Debug("skipped")
+	logger.Info("skipped")
+	assert.Empty(t, buf.String())
+
+	logger.Warn("written")
+	assert.Contains(t, buf.String(), "written")
+}
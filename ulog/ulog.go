@@ -0,0 +1,53 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package ulog
+
+// Field is a single structured logging attribute.
+type Field struct {
+	Key   string
+	Value any
+}
+
+// F is a short constructor for Field.
+func F(key string, value any) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Logger is a minimal leveled logging facade used by the library internals.
+// It allows internal events to be observed without forcing any particular logging dependency on the user.
+// Implementations must be safe for concurrent use.
+type Logger interface {
+	Debug(msg string, fields ...Field)
+	Info(msg string, fields ...Field)
+	Warn(msg string, fields ...Field)
+	Error(msg string, fields ...Field)
+}
+
+type nopLogger struct{}
+
+// Nop returns a Logger that discards all the messages.
+// This is the default logger for all the components that accept a Logger.
+func Nop() Logger {
+	return nopLogger{}
+}
+
+func (nopLogger) Debug(string, ...Field) {}
+
+func (nopLogger) Info(string, ...Field) {}
+
+func (nopLogger) Warn(string, ...Field) {}
+
+func (nopLogger) Error(string, ...Field) {}
+
+// OrNop returns the provided logger or a Nop logger if it is nil.
+func OrNop(logger Logger) Logger {
+	if logger == nil {
+		return Nop()
+	}
+
+	return logger
+}
@@ -0,0 +1,37 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package ulog_test
+
+import (
+	"testing"
+
+	"github.com/kordax/basic-utils/ulog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestF(t *testing.T) {
+	f := ulog.F("key", 42)
+	assert.Equal(t, "key", f.Key)
+	assert.Equal(t, 42, f.Value)
+}
+
+func TestNop(t *testing.T) {
+	logger := ulog.Nop()
+	assert.NotPanics(t, func() {
+		logger.Debug("debug", ulog.F("k", 1))
+		logger.Info("info")
+		logger.Warn("warn", ulog.F("k", "v"))
+		logger.Error("error", ulog.F("err", nil))
+	})
+}
+
+func TestOrNop(t *testing.T) {
+	assert.Equal(t, ulog.Nop(), ulog.OrNop(nil))
+
+	custom := ulog.NewSlogLogger(nil)
+	assert.Same(t, custom, ulog.OrNop(custom))
+}
@@ -6,6 +6,15 @@
 
 package umap
 
+import (
+	"fmt"
+	"iter"
+	"slices"
+
+	"github.com/kordax/basic-utils/uconst"
+	"github.com/kordax/basic-utils/uopt"
+)
+
 // Contains checks if map contains specified element.
 // Returns value if found, nil otherwise.
 func Contains[K comparable, T comparable](e T, values map[K]T) *T {
@@ -60,6 +69,36 @@ func EqualsP[K comparable, T any](m1 map[K]T, m2 map[K]T, equals func(t1 T, t2 T
 	return true
 }
 
+// Diff compares two versions of a map and returns the minimal update set turning the old map into the new one:
+// keys present only in the new map with their values, keys present only in the old map with their old values,
+// and keys present in both maps whose values differ according to the equal func, with their new values.
+// All the returned maps are non-nil.
+//
+// Example Usage:
+//
+//	added, removed, changed := umap.Diff(oldConfig, newConfig, func(a, b string) bool { return a == b })
+func Diff[K comparable, V any](old, new map[K]V, equal func(a, b V) bool) (added, removed, changed map[K]V) {
+	added = make(map[K]V)
+	removed = make(map[K]V)
+	changed = make(map[K]V)
+	for k, v := range new {
+		ov, ok := old[k]
+		switch {
+		case !ok:
+			added[k] = v
+		case !equal(ov, v):
+			changed[k] = v
+		}
+	}
+	for k, v := range old {
+		if _, ok := new[k]; !ok {
+			removed[k] = v
+		}
+	}
+
+	return added, removed, changed
+}
+
 // Copy returns a copy of a map
 func Copy[K comparable, T any](m1 map[K]T) map[K]T {
 	r := make(map[K]T)
@@ -92,6 +131,82 @@ func Merge[K comparable, T any](src map[K]T, add map[K]T) map[K]T {
 	return result
 }
 
+// SortedKeys returns the keys of the map in ascending order.
+func SortedKeys[K uconst.Ordered, T any](m map[K]T) []K {
+	result := Keys(m)
+	slices.Sort(result)
+
+	return result
+}
+
+// Sorted returns an iterator over the entries of the map in the ascending order of the keys.
+// The keys are collected when the iteration starts, the values are read as the iteration goes.
+//
+// Example Usage:
+//
+//	for name, port := range umap.Sorted(ports) {
+//		fmt.Printf("%s: %d\n", name, port)
+//	}
+func Sorted[K uconst.Ordered, T any](m map[K]T) iter.Seq2[K, T] {
+	return func(yield func(K, T) bool) {
+		for _, k := range SortedKeys(m) {
+			v, ok := m[k]
+			if ok && !yield(k, v) {
+				return
+			}
+		}
+	}
+}
+
+// Select returns a new map with the entries matching the predicate.
+func Select[K comparable, T any](m map[K]T, predicate func(k K, v *T) bool) map[K]T {
+	result := make(map[K]T)
+	for k, v := range m {
+		if predicate(k, &v) {
+			result[k] = v
+		}
+	}
+
+	return result
+}
+
+// Reject returns a new map with the entries not matching the predicate.
+func Reject[K comparable, T any](m map[K]T, predicate func(k K, v *T) bool) map[K]T {
+	return Select(m, func(k K, v *T) bool {
+		return !predicate(k, v)
+	})
+}
+
+// Any returns true if at least one entry of the map matches the predicate. Returns false for an empty map.
+func Any[K comparable, T any](m map[K]T, predicate func(k K, v *T) bool) bool {
+	for k, v := range m {
+		if predicate(k, &v) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// All returns true if all the entries of the map match the predicate. Returns true for an empty map.
+func All[K comparable, T any](m map[K]T, predicate func(k K, v *T) bool) bool {
+	return !Any(m, func(k K, v *T) bool {
+		return !predicate(k, v)
+	})
+}
+
+// Count returns the number of the map entries matching the predicate.
+func Count[K comparable, T any](m map[K]T, predicate func(k K, v *T) bool) int {
+	count := 0
+	for k, v := range m {
+		if predicate(k, &v) {
+			count++
+		}
+	}
+
+	return count
+}
+
 func Values[K comparable, T any](m map[K]T) []T {
 	result := make([]T, 0)
 	for _, v := range m {
@@ -188,3 +303,48 @@ func GetOrDef[K comparable, V any](m map[K]V, key K, def V) V {
 
 	return def
 }
+
+// GetOpt retrieves the value associated with the specified key from the map `m` wrapped into uopt.Opt.
+// An empty Opt is returned if the key is not present.
+//
+// Example Usage:
+//
+//	m := map[string]int{"apple": 1}
+//	umap.GetOpt(m, "apple").OrElse(0)  // 1
+//	umap.GetOpt(m, "orange").Present() // false
+func GetOpt[K comparable, V any](m map[K]V, key K) uopt.Opt[V] {
+	if v, ok := m[key]; ok {
+		return uopt.Of(v)
+	}
+
+	return uopt.Null[V]()
+}
+
+// GetOrCompute retrieves the value associated with the specified key from the map `m`.
+// If the key is not present, the value is computed with the `compute` func, stored in the map and returned.
+// The map must not be nil.
+//
+// Example Usage:
+//
+//	groups := make(map[string][]string)
+//	members := umap.GetOrCompute(groups, "admins", func() []string { return make([]string, 0, 8) })
+func GetOrCompute[K comparable, V any](m map[K]V, key K, compute func() V) V {
+	if v, ok := m[key]; ok {
+		return v
+	}
+	v := compute()
+	m[key] = v
+
+	return v
+}
+
+// MustGet retrieves the value associated with the specified key from the map `m` and panics if the key is not present.
+// It is meant for lookups that can't fail unless there is a programming error, e.g. in static registries.
+func MustGet[K comparable, V any](m map[K]V, key K) V {
+	v, ok := m[key]
+	if !ok {
+		panic(fmt.Sprintf("umap: key %v is not present", key))
+	}
+
+	return v
+}
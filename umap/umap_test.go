@@ -158,6 +158,28 @@ func TestEqualsP(t *testing.T) {
 	}
 }
 
+func TestDiff(t *testing.T) {
+	eq := func(a, b int) bool { return a == b }
+	old := map[string]int{"kept": 1, "changed": 2, "removed": 3}
+	updated := map[string]int{"kept": 1, "changed": 20, "added": 4}
+
+	added, removed, changed := umap.Diff(old, updated, eq)
+	assert.Equal(t, map[string]int{"added": 4}, added)
+	assert.Equal(t, map[string]int{"removed": 3}, removed)
+	assert.Equal(t, map[string]int{"changed": 20}, changed)
+
+	added, removed, changed = umap.Diff(nil, map[string]int{}, eq)
+	assert.NotNil(t, added)
+	assert.Empty(t, added)
+	assert.Empty(t, removed)
+	assert.Empty(t, changed)
+
+	_, _, changedSlices := umap.Diff(map[int][]int{1: {1}}, map[int][]int{1: {1}}, func(a, b []int) bool {
+		return uarray.EqualsWithOrder(a, b)
+	})
+	assert.Empty(t, changedSlices)
+}
+
 func TestCopy(t *testing.T) {
 	// Test case 1: Copying a non-empty map
 	m1 := map[int]string{1: "one", 2: "two", 3: "three"}
@@ -348,3 +370,96 @@ func TestGetValueOrDefault(t *testing.T) {
 		assert.Equal(t, defaultValue, value)
 	})
 }
+
+func TestGetOpt(t *testing.T) {
+	m := map[string]int{"apple": 1, "zero": 0}
+
+	assert.Equal(t, 1, *umap.GetOpt(m, "apple").Get())
+	assert.True(t, umap.GetOpt(m, "zero").Present(), "zero values must be present")
+	assert.False(t, umap.GetOpt(m, "orange").Present())
+	assert.False(t, umap.GetOpt[string, int](nil, "apple").Present())
+}
+
+func TestGetOrCompute(t *testing.T) {
+	m := map[string]int{"apple": 1}
+	calls := 0
+	compute := func() int {
+		calls++
+		return 42
+	}
+
+	assert.Equal(t, 1, umap.GetOrCompute(m, "apple", compute))
+	assert.Equal(t, 0, calls)
+	assert.Equal(t, 42, umap.GetOrCompute(m, "orange", compute))
+	assert.Equal(t, 42, umap.GetOrCompute(m, "orange", compute))
+	assert.Equal(t, 1, calls, "computed value must be stored")
+	assert.Equal(t, 42, m["orange"])
+}
+
+func TestMustGet(t *testing.T) {
+	m := map[string]int{"apple": 1}
+
+	assert.Equal(t, 1, umap.MustGet(m, "apple"))
+	assert.PanicsWithValue(t, "umap: key orange is not present", func() {
+		umap.MustGet(m, "orange")
+	})
+}
+
+func TestSortedKeys(t *testing.T) {
+	assert.Empty(t, umap.SortedKeys(map[string]int{}))
+	assert.Equal(t, []string{"apple", "banana", "cherry"}, umap.SortedKeys(map[string]int{"cherry": 3, "apple": 1, "banana": 2}))
+	assert.Equal(t, []float64{-1.5, 0, 2}, umap.SortedKeys(map[float64]bool{2: true, -1.5: false, 0: true}))
+}
+
+func TestSorted(t *testing.T) {
+	m := map[int]string{3: "c", 1: "a", 2: "b", 4: "d"}
+
+	var keys []int
+	var values []string
+	for k, v := range umap.Sorted(m) {
+		keys = append(keys, k)
+		values = append(values, v)
+		if k == 3 {
+			break
+		}
+	}
+	assert.Equal(t, []int{1, 2, 3}, keys)
+	assert.Equal(t, []string{"a", "b", "c"}, values)
+
+	keys = keys[:0]
+	for k := range umap.Sorted(m) {
+		delete(m, 2)
+		keys = append(keys, k)
+	}
+	assert.Equal(t, []int{1, 3, 4}, keys, "the keys deleted during the iteration must be skipped")
+}
+
+func TestSelectReject(t *testing.T) {
+	m := map[string]int{"a": 1, "b": 2, "c": 3, "d": 4}
+	even := func(_ string, v *int) bool { return *v%2 == 0 }
+
+	assert.Equal(t, map[string]int{"b": 2, "d": 4}, umap.Select(m, even))
+	assert.Equal(t, map[string]int{"a": 1, "c": 3}, umap.Reject(m, even))
+	assert.Equal(t, map[string]int{"a": 1}, umap.Select(m, func(k string, _ *int) bool { return k == "a" }))
+	assert.Len(t, m, 4, "the source map must not be modified")
+	assert.NotNil(t, umap.Select(map[string]int(nil), even))
+	assert.Empty(t, umap.Reject(map[string]int(nil), even))
+}
+
+func TestAnyAllCount(t *testing.T) {
+	m := map[int]MyStruct{1: {ID: 1, Name: "John"}, 2: {ID: 2, Name: "Jane"}, 3: {ID: 3, Name: "Bob"}}
+	startsWithJ := func(_ int, v *MyStruct) bool { return v.Name[0] == 'J' }
+	keyMatches := func(k int, v *MyStruct) bool { return k == v.ID }
+
+	assert.True(t, umap.Any(m, startsWithJ))
+	assert.False(t, umap.All(m, startsWithJ))
+	assert.True(t, umap.All(m, keyMatches))
+	assert.False(t, umap.Any(m, func(_ int, v *MyStruct) bool { return v.ID > 3 }))
+	assert.Equal(t, 2, umap.Count(m, startsWithJ))
+	assert.Equal(t, 3, umap.Count(m, keyMatches))
+
+	var empty map[int]MyStruct
+	assert.False(t, umap.Any(empty, startsWithJ))
+	assert.True(t, umap.All(empty, startsWithJ))
+	assert.Zero(t, umap.Count(empty, startsWithJ))
+}
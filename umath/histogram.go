@@ -0,0 +1,102 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package umath
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"sort"
+
+	basicutils "github.com/kordax/basic-utils/uconst"
+)
+
+// ErrInvalidBuckets is returned when bucket boundaries can't be generated from the provided parameters.
+var ErrInvalidBuckets = errors.New("umath: invalid bucket parameters")
+
+// LinearBuckets returns count upper bounds of buckets, the first one is start and every next one is width greater.
+// Returns ErrInvalidBuckets if width is not positive or count is negative.
+func LinearBuckets(start, width float64, count int) ([]float64, error) {
+	if width <= 0 || count < 0 {
+		return nil, ErrInvalidBuckets
+	}
+
+	buckets := make([]float64, count)
+	for i := range buckets {
+		buckets[i] = start + float64(i)*width
+	}
+
+	return buckets, nil
+}
+
+// ExponentialBuckets returns count upper bounds of buckets, the first one is start and every next one is factor times
+// greater, which suits latencies and sizes spanning several orders of magnitude.
+// Returns ErrInvalidBuckets if start is not positive, factor is not greater than 1 or count is negative.
+func ExponentialBuckets(start, factor float64, count int) ([]float64, error) {
+	if start <= 0 || factor <= 1 || count < 0 {
+		return nil, ErrInvalidBuckets
+	}
+
+	buckets := make([]float64, count)
+	for i := range buckets {
+		buckets[i] = start
+		start *= factor
+	}
+
+	return buckets, nil
+}
+
+// Histogram counts the values falling into the buckets defined by their upper bounds, which must be sorted
+// in ascending order. The i-th count is the number of values v such that buckets[i-1] < v <= buckets[i],
+// the result has an extra last count of the values greater than the last bound. NaN values are not counted.
+//
+//	umath.Histogram([]int{1, 5, 7, 12, 30}, []float64{5, 10, 20}) // [2 1 1 1]
+func Histogram[T basicutils.Numeric](values []T, buckets []float64) []int {
+	counts := make([]int, len(buckets)+1)
+	for _, v := range values {
+		f := float64(v)
+		if math.IsNaN(f) {
+			continue
+		}
+		counts[sort.SearchFloat64s(buckets, f)]++
+	}
+
+	return counts
+}
+
+// Quantiles returns the quantiles qs, each one in the [0, 1] range, of the values, e.g. 0.5 for the median
+// or 0.99 for the 99th percentile. Quantiles are interpolated linearly between the closest values.
+// The values are not modified and NaN values are ignored. NaN is returned for the quantiles out of range and for all the quantiles
+// if there are no values.
+//
+//	umath.Quantiles(latencies, 0.5, 0.9, 0.99)
+func Quantiles[T basicutils.Numeric](values []T, qs ...float64) []float64 {
+	sorted := make([]float64, 0, len(values))
+	for _, v := range values {
+		if f := float64(v); !math.IsNaN(f) {
+			sorted = append(sorted, f)
+		}
+	}
+	slices.Sort(sorted)
+
+	result := make([]float64, len(qs))
+	for i, q := range qs {
+		if len(sorted) == 0 || math.IsNaN(q) || q < 0 || q > 1 {
+			result[i] = math.NaN()
+			continue
+		}
+		pos := q * float64(len(sorted)-1)
+		lower := int(pos)
+		if lower == len(sorted)-1 {
+			result[i] = sorted[lower]
+			continue
+		}
+		result[i] = sorted[lower] + (pos-float64(lower))*(sorted[lower+1]-sorted[lower])
+	}
+
+	return result
+}
@@ -0,0 +1,67 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package umath_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/kordax/basic-utils/umath"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLinearBuckets(t *testing.T) {
+	buckets, err := umath.LinearBuckets(10, 5, 4)
+	require.NoError(t, err)
+	assert.Equal(t, []float64{10, 15, 20, 25}, buckets)
+
+	buckets, err = umath.LinearBuckets(0, 1, 0)
+	require.NoError(t, err)
+	assert.Empty(t, buckets)
+
+	_, err = umath.LinearBuckets(0, 0, 3)
+	assert.ErrorIs(t, err, umath.ErrInvalidBuckets)
+	_, err = umath.LinearBuckets(0, 1, -1)
+	assert.ErrorIs(t, err, umath.ErrInvalidBuckets)
+}
+
+func TestExponentialBuckets(t *testing.T) {
+	buckets, err := umath.ExponentialBuckets(1, 2, 5)
+	require.NoError(t, err)
+	assert.Equal(t, []float64{1, 2, 4, 8, 16}, buckets)
+
+	for _, args := range [][2]float64{{0, 2}, {-1, 2}, {1, 1}, {1, 0.5}} {
+		_, err = umath.ExponentialBuckets(args[0], args[1], 3)
+		assert.ErrorIs(t, err, umath.ErrInvalidBuckets, "start %v, factor %v", args[0], args[1])
+	}
+}
+
+func TestHistogram(t *testing.T) {
+	assert.Equal(t, []int{2, 1, 1, 1}, umath.Histogram([]int{1, 5, 7, 12, 30}, []float64{5, 10, 20}))
+	assert.Equal(t, []int{0, 0}, umath.Histogram([]float64{}, []float64{1}))
+	assert.Equal(t, []int{3}, umath.Histogram([]int{1, 2, 3}, nil))
+	assert.Equal(t, []int{1, 1}, umath.Histogram([]float64{0.5, math.NaN(), math.Inf(1)}, []float64{1}))
+}
+
+func TestQuantiles(t *testing.T) {
+	values := []int{5, 1, 4, 2, 3}
+	assert.Equal(t, []float64{1, 2, 3, 5}, umath.Quantiles(values, 0, 0.25, 0.5, 1))
+	assert.Equal(t, []int{5, 1, 4, 2, 3}, values, "values must not be modified")
+
+	q := umath.Quantiles([]float64{10, 20}, 0.5, 0.9)
+	assert.InDelta(t, 15, q[0], 1e-9)
+	assert.InDelta(t, 19, q[1], 1e-9)
+
+	assert.Equal(t, []float64{2}, umath.Quantiles([]float64{math.NaN(), 1, 3}, 0.5))
+
+	q = umath.Quantiles([]int{1, 2}, -0.1, 1.1)
+	assert.True(t, math.IsNaN(q[0]))
+	assert.True(t, math.IsNaN(q[1]))
+	assert.True(t, math.IsNaN(umath.Quantiles([]int{}, 0.5)[0]))
+	assert.Empty(t, umath.Quantiles([]int{1}))
+}
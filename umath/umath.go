@@ -7,6 +7,7 @@
 package umath
 
 import (
+	"errors"
 	"fmt"
 	"math"
 	"sort"
@@ -41,7 +42,12 @@ func AbsDiff[T basicutils.Numeric](one T, two T) T {
 	return one - two
 }
 
-// AbsVal returns absolute value of a numeric type
+// ErrOverflow is returned when the result of an integer operation can't be represented by the target type.
+var ErrOverflow = errors.New("integer overflow")
+
+// AbsVal returns absolute value of a numeric type.
+// Unsigned values are returned as is. Note that the minimum value of a signed integer type has no positive counterpart,
+// so AbsVal(math.MinInt64) overflows and returns math.MinInt64.
 func AbsVal[T basicutils.Numeric](val T) T {
 	if val < 0 {
 		return -val
@@ -50,6 +56,126 @@ func AbsVal[T basicutils.Numeric](val T) T {
 	return val
 }
 
+// Sign returns -1 if val is negative, 1 if val is positive and 0 otherwise.
+func Sign[T basicutils.SignedNumeric](val T) int {
+	switch {
+	case val < 0:
+		return -1
+	case val > 0:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// GCD returns the greatest common divisor of a and b using the Euclidean algorithm.
+// The result is non-negative except for GCD(MinValue, 0) and GCD(MinValue, MinValue) of signed types,
+// which overflow and return MinValue. Use GCDChecked to detect it. GCD(0, 0) is 0.
+func GCD[T basicutils.Integer](a, b T) T {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	if a < 0 {
+		a = -a
+	}
+
+	return a
+}
+
+// GCDChecked works like GCD, but returns ErrOverflow if the result doesn't fit into T.
+func GCDChecked[T basicutils.Integer](a, b T) (T, error) {
+	gcd := GCD(a, b)
+	if gcd < 0 {
+		return 0, ErrOverflow
+	}
+
+	return gcd, nil
+}
+
+// LCM returns the least common multiple of a and b, or 0 if any of them is 0.
+// The result is always non-negative. Use LCMChecked if the result may not fit into T.
+func LCM[T basicutils.Integer](a, b T) T {
+	lcm, _ := LCMChecked(a, b)
+	return lcm
+}
+
+// LCMChecked works like LCM, but returns ErrOverflow if the result doesn't fit into T.
+func LCMChecked[T basicutils.Integer](a, b T) (T, error) {
+	if a == 0 || b == 0 {
+		return 0, nil
+	}
+
+	gcd, err := GCDChecked(a, b)
+	if err != nil {
+		return 0, err
+	}
+	lcm, err := mulChecked(a/gcd, b)
+	if err != nil {
+		return 0, err
+	}
+	if lcm < 0 {
+		lcm = -lcm
+	}
+	if lcm < 0 {
+		return 0, ErrOverflow
+	}
+
+	return lcm, nil
+}
+
+// Pow raises an integer base to the power of exp using exponentiation by squaring.
+// ErrOverflow is returned if the result doesn't fit into T.
+func Pow[T basicutils.Integer](base T, exp uint) (T, error) {
+	result := T(1)
+	var err error
+	for exp > 0 {
+		if exp&1 == 1 {
+			if result, err = mulChecked(result, base); err != nil {
+				return 0, err
+			}
+		}
+		exp >>= 1
+		if exp > 0 {
+			if base, err = mulChecked(base, base); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// NextPowerOfTwo returns the smallest power of two that is greater than or equal to val.
+// Values less than or equal to 1 produce 1. ErrOverflow is returned if the result doesn't fit into T.
+func NextPowerOfTwo[T basicutils.Integer](val T) (T, error) {
+	limit := MaxValue[T]() / 2
+	p := T(1)
+	for p < val {
+		if p > limit {
+			return 0, ErrOverflow
+		}
+		p <<= 1
+	}
+
+	return p, nil
+}
+
+func mulChecked[T basicutils.Integer](a, b T) (T, error) {
+	r := a * b
+	if a == 0 || b == 0 {
+		return r, nil
+	}
+	if r/b != a {
+		return r, ErrOverflow
+	}
+	// the only case not caught by the division check: MinValue * -1 for signed types
+	if minusOne := T(0) - 1; minusOne < 0 && (a == minusOne && r == b || b == minusOne && r == a) {
+		return r, ErrOverflow
+	}
+
+	return r, nil
+}
+
 // ValOrMin returns value or mn if value is less than mn
 func ValOrMin(val int, mn int) int {
 	if val < mn {
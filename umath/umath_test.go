@@ -325,3 +325,113 @@ func TestMaxValue(t *testing.T) {
 		t.Errorf("Expected %v for uint64, got %v", ^uint64(0), val)
 	}
 }
+
+func TestSign(t *testing.T) {
+	assert.Equal(t, -1, umath.Sign(-5))
+	assert.Equal(t, 0, umath.Sign(0))
+	assert.Equal(t, 1, umath.Sign(int8(3)))
+	assert.Equal(t, -1, umath.Sign(-0.5))
+	assert.Equal(t, 1, umath.Sign(float32(0.1)))
+}
+
+func TestGCD(t *testing.T) {
+	assert.Equal(t, 6, umath.GCD(12, 18))
+	assert.Equal(t, 6, umath.GCD(-12, 18))
+	assert.Equal(t, 6, umath.GCD(12, -18))
+	assert.Equal(t, uint(1), umath.GCD(uint(17), uint(5)))
+	assert.Equal(t, 7, umath.GCD(0, 7))
+	assert.Equal(t, 0, umath.GCD(0, 0))
+	assert.Equal(t, int64(2), umath.GCD(int64(math.MinInt64), 6))
+	assert.Equal(t, int64(2), umath.GCD(6, int64(math.MinInt64)))
+	assert.Equal(t, int8(64), umath.GCD(int8(math.MinInt8), 64))
+
+	_, err := umath.GCDChecked(int64(math.MinInt64), 0)
+	assert.ErrorIs(t, err, umath.ErrOverflow)
+	_, err = umath.GCDChecked(int64(math.MinInt64), math.MinInt64)
+	assert.ErrorIs(t, err, umath.ErrOverflow)
+	gcd, err := umath.GCDChecked(int64(math.MinInt64), 6)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), gcd)
+}
+
+func TestLCM(t *testing.T) {
+	assert.Equal(t, 36, umath.LCM(12, 18))
+	assert.Equal(t, 36, umath.LCM(-12, 18))
+	assert.Equal(t, 0, umath.LCM(0, 18))
+	assert.Equal(t, uint8(15), umath.LCM(uint8(3), uint8(5)))
+
+	_, err := umath.LCMChecked(uint8(17), uint8(19))
+	assert.ErrorIs(t, err, umath.ErrOverflow)
+	lcm, err := umath.LCMChecked(int64(1<<20), int64(3))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3<<20), lcm)
+
+	_, err = umath.LCMChecked(int64(math.MinInt64), 1)
+	assert.ErrorIs(t, err, umath.ErrOverflow)
+	_, err = umath.LCMChecked(int64(1), math.MinInt64)
+	assert.ErrorIs(t, err, umath.ErrOverflow)
+	_, err = umath.LCMChecked(int64(math.MinInt64), 6)
+	assert.ErrorIs(t, err, umath.ErrOverflow)
+	_, err = umath.LCMChecked(int64(math.MinInt64), 0)
+	assert.NoError(t, err)
+	lcm8, err := umath.LCMChecked(int8(-64), 2)
+	assert.NoError(t, err)
+	assert.Equal(t, int8(64), lcm8)
+	_, err = umath.LCMChecked(int8(-64), 3)
+	assert.ErrorIs(t, err, umath.ErrOverflow)
+}
+
+func TestPow(t *testing.T) {
+	r, err := umath.Pow(2, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, 1024, r)
+
+	r, err = umath.Pow(-3, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, -27, r)
+
+	r, err = umath.Pow(5, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, r)
+
+	r8, err := umath.Pow(int8(-2), 7)
+	assert.NoError(t, err)
+	assert.Equal(t, int8(math.MinInt8), r8)
+
+	_, err = umath.Pow(int8(2), 7)
+	assert.ErrorIs(t, err, umath.ErrOverflow)
+
+	_, err = umath.Pow(uint8(16), 2)
+	assert.ErrorIs(t, err, umath.ErrOverflow)
+
+	u64, err := umath.Pow(uint64(2), 63)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1)<<63, u64)
+
+	_, err = umath.Pow(uint64(2), 64)
+	assert.ErrorIs(t, err, umath.ErrOverflow)
+
+	_, err = umath.Pow(int64(math.MinInt64), 1)
+	assert.NoError(t, err)
+	_, err = umath.Pow(int64(math.MinInt64), 2)
+	assert.ErrorIs(t, err, umath.ErrOverflow)
+}
+
+func TestNextPowerOfTwo(t *testing.T) {
+	for _, tc := range []struct {
+		in, expected int
+	}{{-4, 1}, {0, 1}, {1, 1}, {2, 2}, {3, 4}, {17, 32}, {1024, 1024}, {1025, 2048}} {
+		p, err := umath.NextPowerOfTwo(tc.in)
+		assert.NoError(t, err)
+		assert.Equal(t, tc.expected, p, "input %d", tc.in)
+	}
+
+	p, err := umath.NextPowerOfTwo(uint8(128))
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(128), p)
+
+	_, err = umath.NextPowerOfTwo(uint8(129))
+	assert.ErrorIs(t, err, umath.ErrOverflow)
+	_, err = umath.NextPowerOfTwo(int8(65))
+	assert.ErrorIs(t, err, umath.ErrOverflow)
+}
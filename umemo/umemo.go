@@ -0,0 +1,124 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+// Package umemo memoizes pure functions on top of the ucache infrastructure.
+package umemo
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/kordax/basic-utils/ucache"
+	"github.com/kordax/basic-utils/uopt"
+)
+
+type options struct {
+	ttl        uopt.Opt[time.Duration]
+	maxEntries int
+}
+
+// Option configures the memoized function.
+type Option func(o *options)
+
+// WithTTL makes the memoized results outdated after the ttl, so they are recomputed on the next call.
+// Results never expire by default.
+func WithTTL(ttl time.Duration) Option {
+	return func(o *options) {
+		o.ttl = uopt.Of(ttl)
+	}
+}
+
+// WithMaxEntries limits the number of memoized results. Once the limit is exceeded, the oldest results are evicted
+// in the order they were first computed. There is no limit by default.
+func WithMaxEntries(limit int) Option {
+	return func(o *options) {
+		o.maxEntries = limit
+	}
+}
+
+// Memoize returns a function that calls f once per argument and then returns the cached result.
+// f must be pure, as the result depends only on the argument. Concurrent calls with the same argument are deduplicated,
+// so f runs once and all the callers share the result. The returned function is thread-safe.
+//
+// Without options results are kept forever, use WithTTL and WithMaxEntries to bound them.
+// Outdated results are kept until they are recomputed, so WithMaxEntries is what bounds the memory.
+func Memoize[K comparable, V any](f func(K) V, opts ...Option) func(K) V {
+	memoized := MemoizeErr(func(key K) (V, error) {
+		return f(key), nil
+	}, opts...)
+
+	return func(key K) V {
+		value, _ := memoized(key)
+		return value
+	}
+}
+
+// MemoizeErr is the same as Memoize, but for functions that can fail. Errors are returned as is and are not memoized,
+// so the failed calls are retried.
+func MemoizeErr[K comparable, V any](f func(K) (V, error), opts ...Option) func(K) (V, error) {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	m := &memo[K, V]{
+		cache: ucache.NewLoadingCache(
+			ucache.NewInMemoryComparableMapCache[K, V](o.ttl),
+			func(_ context.Context, key K) (V, error) {
+				return f(key)
+			},
+		),
+		maxEntries: o.maxEntries,
+	}
+	if m.maxEntries > 0 {
+		m.known = make(map[K]struct{})
+	}
+
+	return m.call
+}
+
+type memo[K comparable, V any] struct {
+	cache      *ucache.LoadingCache[K, V]
+	maxEntries int
+
+	// The memoized keys in the order they were first computed, tracked only if maxEntries is set.
+	mtx   sync.Mutex
+	order []K
+	known map[K]struct{}
+}
+
+func (m *memo[K, V]) call(key K) (V, error) {
+	value, err := m.cache.GetOrLoad(context.Background(), key)
+	if err != nil {
+		var zero V
+		return zero, err
+	}
+	if m.maxEntries > 0 {
+		m.track(key)
+	}
+
+	return *value, nil
+}
+
+func (m *memo[K, V]) track(key K) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	if _, ok := m.known[key]; ok {
+		return
+	}
+	m.known[key] = struct{}{}
+	m.order = append(m.order, key)
+	for len(m.order) > m.maxEntries {
+		oldest := m.order[0]
+		var zero K
+		m.order[0] = zero
+		m.order = m.order[1:]
+		delete(m.known, oldest)
+		m.cache.DropKey(oldest)
+	}
+}
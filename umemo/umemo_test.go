@@ -0,0 +1,114 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package umemo_test
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kordax/basic-utils/umemo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoize(t *testing.T) {
+	var calls atomic.Int32
+	square := umemo.Memoize(func(n int) int {
+		calls.Add(1)
+		return n * n
+	})
+
+	assert.Equal(t, 4, square(2))
+	assert.Equal(t, 4, square(2))
+	assert.Equal(t, 9, square(3))
+	assert.EqualValues(t, 2, calls.Load())
+}
+
+func TestMemoize_Concurrent(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	slow := umemo.Memoize(func(s string) int {
+		calls.Add(1)
+		<-release
+		return len(s)
+	})
+
+	var wg sync.WaitGroup
+	results := make([]int, 10)
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = slow("hello")
+		}()
+	}
+	require.Eventually(t, func() bool { return calls.Load() == 1 }, time.Second, time.Millisecond)
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.EqualValues(t, 1, calls.Load())
+	for _, r := range results {
+		assert.Equal(t, 5, r)
+	}
+}
+
+func TestMemoize_TTL(t *testing.T) {
+	var calls atomic.Int32
+	f := umemo.Memoize(func(n int) int {
+		return n + int(calls.Add(1))
+	}, umemo.WithTTL(20*time.Millisecond))
+
+	assert.Equal(t, 1, f(0))
+	assert.Equal(t, 1, f(0))
+	time.Sleep(40 * time.Millisecond)
+	assert.Equal(t, 2, f(0), "the outdated result must be recomputed")
+}
+
+func TestMemoize_MaxEntries(t *testing.T) {
+	var calls []int
+	f := umemo.Memoize(func(n int) int {
+		calls = append(calls, n)
+		return n
+	}, umemo.WithMaxEntries(2))
+
+	f(1)
+	f(2)
+	f(1)
+	f(3) // evicts 1
+	f(2)
+	f(1)
+
+	assert.Equal(t, []int{1, 2, 3, 1}, calls)
+}
+
+func TestMemoizeErr(t *testing.T) {
+	errOdd := errors.New("odd")
+	var calls atomic.Int32
+	half := umemo.MemoizeErr(func(n int) (int, error) {
+		calls.Add(1)
+		if n%2 != 0 {
+			return 0, errOdd
+		}
+		return n / 2, nil
+	})
+
+	v, err := half(4)
+	require.NoError(t, err)
+	assert.Equal(t, 2, v)
+	_, _ = half(4)
+	assert.EqualValues(t, 1, calls.Load())
+
+	_, err = half(3)
+	assert.ErrorIs(t, err, errOdd)
+	_, err = half(3)
+	assert.ErrorIs(t, err, errOdd)
+	assert.EqualValues(t, 3, calls.Load(), "errors must not be memoized")
+}
@@ -0,0 +1,109 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+// Package umutex provides synchronization primitives complementing the sync package:
+// per-key mutexes, a context-aware weighted semaphore and a per-key once.
+package umutex
+
+import "sync"
+
+type keyedEntry struct {
+	mtx  sync.Mutex
+	refs int
+}
+
+/*
+KeyedMutex provides a separate mutual exclusion lock for every key, so operations on different keys don't block each other.
+Locks are allocated on demand and released as soon as no goroutine holds or waits for them,
+so the memory usage is bound by the number of keys in use rather than by the number of keys ever seen.
+
+The zero value is ready to use. A KeyedMutex must not be copied after first use.
+*/
+type KeyedMutex[K comparable] struct {
+	mtx     sync.Mutex
+	entries map[K]*keyedEntry
+}
+
+// Lock locks the key. If the key is already locked, the calling goroutine blocks until it's unlocked.
+func (m *KeyedMutex[K]) Lock(key K) {
+	e := m.acquire(key)
+	e.mtx.Lock()
+}
+
+// TryLock tries to lock the key without blocking and reports whether it succeeded.
+func (m *KeyedMutex[K]) TryLock(key K) bool {
+	e := m.acquire(key)
+	if e.mtx.TryLock() {
+		return true
+	}
+	m.release(key, e)
+
+	return false
+}
+
+// Unlock unlocks the key. It panics if the key is not locked.
+func (m *KeyedMutex[K]) Unlock(key K) {
+	m.mtx.Lock()
+	e, ok := m.entries[key]
+	m.mtx.Unlock()
+	if !ok {
+		panic("umutex: unlock of unlocked key")
+	}
+
+	e.mtx.Unlock()
+	m.release(key, e)
+}
+
+// Locker returns a sync.Locker locking the key.
+func (m *KeyedMutex[K]) Locker(key K) sync.Locker {
+	return keyLocker[K]{m: m, key: key}
+}
+
+// Len returns the number of keys that are currently locked or waited for.
+func (m *KeyedMutex[K]) Len() int {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	return len(m.entries)
+}
+
+func (m *KeyedMutex[K]) acquire(key K) *keyedEntry {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	if m.entries == nil {
+		m.entries = make(map[K]*keyedEntry)
+	}
+	e, ok := m.entries[key]
+	if !ok {
+		e = &keyedEntry{}
+		m.entries[key] = e
+	}
+	e.refs++
+
+	return e
+}
+
+func (m *KeyedMutex[K]) release(key K, e *keyedEntry) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	e.refs--
+	if e.refs == 0 {
+		delete(m.entries, key)
+	}
+}
+
+type keyLocker[K comparable] struct {
+	m   *KeyedMutex[K]
+	key K
+}
+
+func (l keyLocker[K]) Lock() {
+	l.m.Lock(l.key)
+}
+
+func (l keyLocker[K]) Unlock() {
+	l.m.Unlock(l.key)
+}
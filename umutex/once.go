@@ -0,0 +1,116 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package umutex
+
+import (
+	"sync"
+	"time"
+
+	"github.com/kordax/basic-utils/uopt"
+)
+
+type onceEntry struct {
+	once   sync.Once
+	doneAt time.Time
+}
+
+type onceExpiry[K comparable] struct {
+	key   K
+	entry *onceEntry
+}
+
+/*
+OncePerKey works like sync.Once, but for every dynamic key separately: Do runs the function only once per key,
+and concurrent callers for the same key wait until the first call returns.
+
+With a TTL, a key is forgotten once the TTL has passed since its function returned, so the function can run again
+and the memory is eventually reclaimed. Expired keys are cleaned up during Do calls.
+*/
+type OncePerKey[K comparable] struct {
+	ttl *time.Duration
+
+	mtx     sync.Mutex
+	entries map[K]*onceEntry
+	expiry  []onceExpiry[K]
+}
+
+// NewOncePerKey creates a new OncePerKey that forgets keys after the TTL if it's present, or keeps them forever otherwise.
+func NewOncePerKey[K comparable](ttl uopt.Opt[time.Duration]) *OncePerKey[K] {
+	return &OncePerKey[K]{
+		ttl:     ttl.Get(),
+		entries: make(map[K]*onceEntry),
+	}
+}
+
+// Do runs f if it wasn't run for the key yet and reports whether f was run by this call.
+// Like sync.Once, a panicking f is considered returned.
+func (o *OncePerKey[K]) Do(key K, f func()) bool {
+	o.mtx.Lock()
+	o.cleanup(time.Now())
+	e, ok := o.entries[key]
+	if !ok {
+		e = &onceEntry{}
+		o.entries[key] = e
+	}
+	o.mtx.Unlock()
+
+	executed := false
+	e.once.Do(func() {
+		executed = true
+		defer o.done(key, e)
+		f()
+	})
+
+	return executed
+}
+
+// Forget removes the key, so the next Do for it runs the function again.
+func (o *OncePerKey[K]) Forget(key K) {
+	o.mtx.Lock()
+	defer o.mtx.Unlock()
+	delete(o.entries, key)
+}
+
+// Len returns the number of remembered keys.
+func (o *OncePerKey[K]) Len() int {
+	o.mtx.Lock()
+	defer o.mtx.Unlock()
+	o.cleanup(time.Now())
+
+	return len(o.entries)
+}
+
+func (o *OncePerKey[K]) done(key K, e *onceEntry) {
+	if o.ttl == nil {
+		return
+	}
+
+	o.mtx.Lock()
+	defer o.mtx.Unlock()
+	e.doneAt = time.Now()
+	o.expiry = append(o.expiry, onceExpiry[K]{key: key, entry: e})
+}
+
+// cleanup drops the expired keys. Functions return in time order, so the expiry queue is sorted by doneAt.
+func (o *OncePerKey[K]) cleanup(now time.Time) {
+	if o.ttl == nil {
+		return
+	}
+
+	i := 0
+	for ; i < len(o.expiry) && now.Sub(o.expiry[i].entry.doneAt) >= *o.ttl; i++ {
+		exp := o.expiry[i]
+		// The key may have been forgotten and run again, in which case the entry is not the same.
+		if o.entries[exp.key] == exp.entry {
+			delete(o.entries, exp.key)
+		}
+	}
+	if i > 0 {
+		clear(o.expiry[:i])
+		o.expiry = o.expiry[i:]
+	}
+}
@@ -0,0 +1,44 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package umutex
+
+import (
+	"context"
+
+	"golang.org/x/sync/semaphore"
+)
+
+/*
+Semaphore is a weighted semaphore limiting the total weight of the concurrently held resources.
+Waiters are served in FIFO order, so a large request isn't starved by a stream of small ones.
+It's backed by golang.org/x/sync/semaphore.Weighted.
+*/
+type Semaphore struct {
+	weighted *semaphore.Weighted
+}
+
+// NewSemaphore creates a new Semaphore with the specified maximum combined weight.
+func NewSemaphore(size int64) *Semaphore {
+	return &Semaphore{weighted: semaphore.NewWeighted(size)}
+}
+
+// Acquire acquires the weight n, blocking until the resources are available or the context is done.
+// On failure, it returns the context error and leaves the semaphore unchanged.
+// Requests exceeding the semaphore size block until the context is done.
+func (s *Semaphore) Acquire(ctx context.Context, n int64) error {
+	return s.weighted.Acquire(ctx, n)
+}
+
+// TryAcquire acquires the weight n without blocking and reports whether it succeeded.
+func (s *Semaphore) TryAcquire(n int64) bool {
+	return s.weighted.TryAcquire(n)
+}
+
+// Release releases the weight n. It panics if more weight is released than held.
+func (s *Semaphore) Release(n int64) {
+	s.weighted.Release(n)
+}
@@ -0,0 +1,190 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package umutex_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kordax/basic-utils/umutex"
+	"github.com/kordax/basic-utils/uopt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyedMutex(t *testing.T) {
+	var m umutex.KeyedMutex[string]
+	counters := map[string]*int{"a": new(int), "b": new(int)}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		for key, counter := range counters {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				m.Lock(key)
+				defer m.Unlock(key)
+				*counter++
+			}()
+		}
+	}
+	wg.Wait()
+
+	assert.Equal(t, 50, *counters["a"])
+	assert.Equal(t, 50, *counters["b"])
+	assert.Zero(t, m.Len(), "unused locks must be released")
+}
+
+func TestKeyedMutex_TryLock(t *testing.T) {
+	var m umutex.KeyedMutex[int]
+	require.True(t, m.TryLock(1))
+	assert.False(t, m.TryLock(1))
+	assert.True(t, m.TryLock(2), "other keys must not be blocked")
+	assert.Equal(t, 2, m.Len())
+
+	m.Unlock(1)
+	m.Unlock(2)
+	assert.Zero(t, m.Len())
+	assert.Panics(t, func() { m.Unlock(1) })
+
+	l := m.Locker(3)
+	l.Lock()
+	assert.False(t, m.TryLock(3))
+	l.Unlock()
+}
+
+func TestSemaphore(t *testing.T) {
+	s := umutex.NewSemaphore(3)
+	ctx := context.Background()
+
+	require.NoError(t, s.Acquire(ctx, 2))
+	assert.True(t, s.TryAcquire(1))
+	assert.False(t, s.TryAcquire(1))
+
+	acquired := make(chan struct{})
+	go func() {
+		assert.NoError(t, s.Acquire(ctx, 2))
+		close(acquired)
+	}()
+
+	s.Release(1)
+	select {
+	case <-acquired:
+		t.Fatal("acquired before enough weight was released")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	s.Release(2)
+	<-acquired
+	s.Release(2)
+	assert.Panics(t, func() { s.Release(1) })
+}
+
+func TestSemaphore_Cancel(t *testing.T) {
+	s := umutex.NewSemaphore(2)
+	require.True(t, s.TryAcquire(2))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, s.Acquire(ctx, 1), context.DeadlineExceeded)
+
+	cancelled, cancelNow := context.WithCancel(context.Background())
+	cancelNow()
+	assert.ErrorIs(t, s.Acquire(cancelled, 0), context.Canceled)
+
+	s.Release(2)
+	assert.True(t, s.TryAcquire(2), "cancelled waiters must not hold the weight")
+}
+
+func TestSemaphore_FIFO(t *testing.T) {
+	s := umutex.NewSemaphore(2)
+	require.True(t, s.TryAcquire(2))
+
+	large := make(chan struct{})
+	go func() {
+		assert.NoError(t, s.Acquire(context.Background(), 2))
+		close(large)
+	}()
+	require.Eventually(t, func() bool { return !s.TryAcquire(0) }, time.Second, time.Millisecond)
+
+	assert.False(t, s.TryAcquire(1), "a queued waiter must not be overtaken")
+	s.Release(2)
+	<-large
+	s.Release(2)
+}
+
+func TestSemaphore_Concurrency(t *testing.T) {
+	s := umutex.NewSemaphore(4)
+	var current, peak atomic.Int64
+
+	var wg sync.WaitGroup
+	for i := 0; i < 32; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			require.NoError(t, s.Acquire(context.Background(), 1))
+			defer s.Release(1)
+			n := current.Add(1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+			current.Add(-1)
+		}()
+	}
+	wg.Wait()
+
+	assert.LessOrEqual(t, peak.Load(), int64(4))
+}
+
+func TestOncePerKey(t *testing.T) {
+	o := umutex.NewOncePerKey[string](uopt.Null[time.Duration]())
+	var calls atomic.Int32
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			o.Do("a", func() {
+				time.Sleep(5 * time.Millisecond)
+				calls.Add(1)
+			})
+			assert.Equal(t, int32(1), calls.Load(), "Do must wait for the running call")
+		}()
+	}
+	wg.Wait()
+
+	assert.False(t, o.Do("a", func() { calls.Add(1) }))
+	assert.True(t, o.Do("b", func() { calls.Add(1) }))
+	assert.Equal(t, int32(2), calls.Load())
+	assert.Equal(t, 2, o.Len())
+
+	o.Forget("a")
+	assert.True(t, o.Do("a", func() {}))
+
+	assert.Panics(t, func() { o.Do("panic", func() { panic("boom") }) })
+	assert.False(t, o.Do("panic", func() {}), "a panicking function is considered done")
+}
+
+func TestOncePerKey_TTL(t *testing.T) {
+	o := umutex.NewOncePerKey[int](uopt.Of(20 * time.Millisecond))
+
+	assert.True(t, o.Do(1, func() {}))
+	assert.False(t, o.Do(1, func() {}))
+	assert.Equal(t, 1, o.Len())
+
+	time.Sleep(30 * time.Millisecond)
+	assert.Zero(t, o.Len(), "expired keys must be cleaned up")
+	assert.True(t, o.Do(1, func() {}))
+}
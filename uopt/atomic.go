@@ -0,0 +1,67 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package uopt
+
+import "sync/atomic"
+
+// AtomicOpt is an optional value that can be loaded and updated from multiple goroutines without locks,
+// e.g. a config snapshot that is replaced on reload and read on every request.
+// The zero value is an empty AtomicOpt ready to use. AtomicOpt must not be copied after the first use.
+//
+// Stored values are copied, while the Opts returned by Load and Swap point to the shared value,
+// so the values must be treated as immutable: use Store to change them instead of Opt.Apply or Opt.Set.
+type AtomicOpt[T any] struct {
+	p atomic.Pointer[T]
+}
+
+// NewAtomic creates a new AtomicOpt holding the value of the provided Opt.
+func NewAtomic[T any](o Opt[T]) *AtomicOpt[T] {
+	a := &AtomicOpt[T]{}
+	a.Store(o)
+
+	return a
+}
+
+// Load returns the current value.
+func (a *AtomicOpt[T]) Load() Opt[T] {
+	return Opt[T]{v: a.p.Load()}
+}
+
+// Store replaces the current value with the value of the provided Opt. Storing an empty Opt clears the value.
+func (a *AtomicOpt[T]) Store(o Opt[T]) {
+	a.p.Store(copyValue(o))
+}
+
+// Swap stores the value of the provided Opt and returns the previous one.
+func (a *AtomicOpt[T]) Swap(o Opt[T]) Opt[T] {
+	return Opt[T]{v: a.p.Swap(copyValue(o))}
+}
+
+// CompareAndSwap stores the value of new if the current value is still old and reports whether it happened.
+// Values are compared by identity rather than by equality, so old must be the result of a previous Load or Swap,
+// or an empty Opt to swap only if no value is stored.
+//
+// Example usage:
+//
+//	for {
+//		old := cfg.Load()
+//		if cfg.CompareAndSwap(old, uopt.Of(reload(old))) {
+//			break
+//		}
+//	}
+func (a *AtomicOpt[T]) CompareAndSwap(old, new Opt[T]) bool {
+	return a.p.CompareAndSwap(old.v, copyValue(new))
+}
+
+func copyValue[T any](o Opt[T]) *T {
+	if o.v == nil {
+		return nil
+	}
+	v := *o.v
+
+	return &v
+}
@@ -0,0 +1,62 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package uopt_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/kordax/basic-utils/uopt"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAtomicOpt(t *testing.T) {
+	var a uopt.AtomicOpt[string]
+	assert.False(t, a.Load().Present())
+
+	value := uopt.Of("v1")
+	a.Store(value)
+	assert.Equal(t, "v1", *a.Load().Get())
+	value.Apply(func(s *string) { *s = "changed" })
+	assert.Equal(t, "v1", *a.Load().Get(), "the stored value must be a copy")
+
+	old := a.Swap(uopt.Of("v2"))
+	assert.Equal(t, "v1", *old.Get())
+	assert.Equal(t, "v2", *a.Load().Get())
+
+	assert.False(t, a.CompareAndSwap(uopt.Of("v2"), uopt.Of("v3")), "values are compared by identity")
+	current := a.Load()
+	assert.True(t, a.CompareAndSwap(current, uopt.Null[string]()))
+	assert.False(t, a.Load().Present())
+	assert.True(t, a.CompareAndSwap(uopt.Null[string](), uopt.Of("v4")))
+	assert.Equal(t, "v4", *a.Load().Get())
+
+	assert.Equal(t, 5, *uopt.NewAtomic(uopt.Of(5)).Load().Get())
+	assert.False(t, uopt.NewAtomic(uopt.Null[int]()).Load().Present())
+}
+
+func TestAtomicOpt_Concurrent(t *testing.T) {
+	a := uopt.NewAtomic(uopt.Of(0))
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				for {
+					old := a.Load()
+					if a.CompareAndSwap(old, uopt.Of(*old.Get()+1)) {
+						break
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 8000, *a.Load().Get())
+}
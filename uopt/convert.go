@@ -0,0 +1,74 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package uopt
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"github.com/kordax/basic-utils/ucast"
+	basicutils "github.com/kordax/basic-utils/uconst"
+)
+
+// As converts the optional value into the R type using ucast, which is useful for untyped values
+// from dynamic configs or decoded JSON. Strings are parsed, other values are converted through their
+// default string representation, so a float64 of 42 decoded from JSON becomes an int of 42.
+// Floats are converted to integers only if they are whole numbers in the range of R.
+// An absent Opt is returned if the value is absent or can't be converted.
+func As[R basicutils.BasicType, T any](o Opt[T]) Opt[R] {
+	if o.v == nil {
+		return Null[R]()
+	}
+
+	var value any = *o.v
+	if r, ok := value.(R); ok {
+		return Of(r)
+	}
+
+	var s string
+	switch v := value.(type) {
+	case string:
+		s = v
+	case nil:
+		return Null[R]()
+	default:
+		if rv := reflect.ValueOf(v); rv.Kind() == reflect.Float32 || rv.Kind() == reflect.Float64 {
+			// fmt.Sprint uses the exponent for large values, e.g. "1e+06", which is not parsed as an integer
+			s = strconv.FormatFloat(rv.Float(), 'f', -1, rv.Type().Bits())
+		} else {
+			s = fmt.Sprint(v)
+		}
+	}
+
+	return Try(ucast.String[R](s))
+}
+
+// AsInt converts the optional value into an int, see As.
+func AsInt[T any](o Opt[T]) OptInt {
+	return As[int](o)
+}
+
+// AsInt64 converts the optional value into an int64, see As.
+func AsInt64[T any](o Opt[T]) OptInt64 {
+	return As[int64](o)
+}
+
+// AsFloat converts the optional value into a float64, see As.
+func AsFloat[T any](o Opt[T]) OptFloat64 {
+	return As[float64](o)
+}
+
+// AsBool converts the optional value into a bool, see As.
+func AsBool[T any](o Opt[T]) OptBool {
+	return As[bool](o)
+}
+
+// AsString converts the optional value into a string, see As.
+func AsString[T any](o Opt[T]) OptString {
+	return As[string](o)
+}
@@ -0,0 +1,49 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package uopt_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/kordax/basic-utils/uopt"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAs(t *testing.T) {
+	assert.Equal(t, uopt.Of(42), uopt.AsInt(uopt.Of("42")))
+	assert.Equal(t, uopt.Of(42), uopt.AsInt(uopt.Of[any](42)))
+	assert.Equal(t, uopt.Of(42), uopt.AsInt(uopt.Of[any](float64(42))))
+	assert.Equal(t, uopt.Null[int](), uopt.AsInt(uopt.Of[any](42.5)))
+	assert.Equal(t, uopt.Null[int](), uopt.AsInt(uopt.Of("abc")))
+	assert.Equal(t, uopt.Null[int](), uopt.AsInt(uopt.Null[string]()))
+	assert.Equal(t, uopt.Null[int](), uopt.AsInt(uopt.Of[any](nil)))
+
+	assert.Equal(t, uopt.Of(int64(-7)), uopt.AsInt64(uopt.Of[any](int8(-7))))
+	assert.Equal(t, uopt.Of(1.5), uopt.AsFloat(uopt.Of("1.5")))
+	assert.Equal(t, uopt.Of(true), uopt.AsBool(uopt.Of("true")))
+	assert.Equal(t, uopt.Of(false), uopt.AsBool(uopt.Of[any](false)))
+	assert.Equal(t, uopt.Of("3.25"), uopt.AsString(uopt.Of[any](3.25)))
+	assert.Equal(t, uopt.Of(uint8(200)), uopt.As[uint8](uopt.Of("200")))
+	assert.Equal(t, uopt.Null[uint8](), uopt.As[uint8](uopt.Of("300")))
+
+	assert.Equal(t, uopt.Of(1000000), uopt.AsInt(uopt.Of[any](1e6)))
+	assert.Equal(t, uopt.Of(int64(1e15)), uopt.AsInt64(uopt.Of[any](float32(1e15))))
+	assert.Equal(t, uopt.Of(uint16(60000)), uopt.As[uint16](uopt.Of[any](6e4)))
+	assert.Equal(t, uopt.Null[int8](), uopt.As[int8](uopt.Of[any](1e3)), "out of range floats must not be converted")
+	assert.Equal(t, uopt.Null[int64](), uopt.AsInt64(uopt.Of[any](1e300)))
+	assert.Equal(t, uopt.Null[int](), uopt.AsInt(uopt.Of[any](1e-7)))
+	assert.Equal(t, uopt.Of("1000000"), uopt.AsString(uopt.Of[any](1e6)))
+	assert.Equal(t, uopt.Of(1e21), uopt.AsFloat(uopt.Of[any](float32(1e21))))
+
+	var config map[string]any
+	assert.NoError(t, json.Unmarshal([]byte(`{"port": 8080, "debug": "true", "ratio": "0.5"}`), &config))
+	assert.Equal(t, uopt.Of(8080), uopt.AsInt(uopt.Of(config["port"])))
+	assert.Equal(t, uopt.Of(true), uopt.AsBool(uopt.Of(config["debug"])))
+	assert.Equal(t, uopt.Of(0.5), uopt.AsFloat(uopt.Of(config["ratio"])))
+	assert.Equal(t, uopt.Null[int](), uopt.AsInt(uopt.Of(config["missing"])))
+}
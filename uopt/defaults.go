@@ -0,0 +1,176 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package uopt
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/kordax/basic-utils/ucast"
+)
+
+// DefaultTag is the struct tag holding the default values used by FillDefaults.
+const DefaultTag = "default"
+
+// defaultsSep separates the elements of slice defaults.
+const defaultsSep = ","
+
+// defaulter is implemented by every *Opt[T] and lets FillDefaults handle any instantiation via reflection.
+type defaulter interface {
+	fillDefault(s string) error
+}
+
+/*
+FillDefaults walks the struct pointed by ptr, including nested and embedded structs, and sets absent Opt fields
+to the values from their `default:"..."` tags, which enables declarative config structs:
+
+	type Config struct {
+		Host    uopt.Opt[string]        `default:"localhost"`
+		Port    uopt.Opt[int]           `default:"8080"`
+		Timeout uopt.Opt[time.Duration] `default:"5s"`
+		Tags    uopt.Opt[[]string]      `default:"a,b"`
+	}
+
+Present values are left intact, so FillDefaults is meant to be called after decoding.
+Values are converted with ucast; durations, encoding.TextUnmarshaler implementations (e.g. time.Time in RFC 3339)
+and comma-separated slices are supported as well. The default tag on a non-Opt field is an error.
+*/
+func FillDefaults(ptr any) error {
+	v := reflect.ValueOf(ptr)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return errors.New("uopt: FillDefaults expects a non-nil pointer to a struct")
+	}
+
+	return fillStruct(v.Elem())
+}
+
+func fillStruct(v reflect.Value) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		field := v.Field(i)
+		tag, hasTag := sf.Tag.Lookup(DefaultTag)
+
+		if d, ok := field.Addr().Interface().(defaulter); ok {
+			if !hasTag {
+				continue
+			}
+			if err := d.fillDefault(tag); err != nil {
+				return fmt.Errorf("uopt: field %s: invalid default %q: %w", sf.Name, tag, err)
+			}
+			continue
+		}
+		if hasTag {
+			return fmt.Errorf("uopt: field %s: default tag is only supported on Opt fields", sf.Name)
+		}
+
+		switch {
+		case field.Kind() == reflect.Struct:
+			if err := fillStruct(field); err != nil {
+				return err
+			}
+		case field.Kind() == reflect.Pointer && !field.IsNil() && field.Elem().Kind() == reflect.Struct:
+			if err := fillStruct(field.Elem()); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (o *Opt[T]) fillDefault(s string) error {
+	if o.v != nil {
+		return nil
+	}
+
+	var value T
+	if err := parseDefault(reflect.ValueOf(&value).Elem(), s); err != nil {
+		return err
+	}
+	o.v = &value
+
+	return nil
+}
+
+func parseDefault(v reflect.Value, s string) error {
+	switch u := v.Addr().Interface().(type) {
+	case encoding.TextUnmarshaler:
+		return u.UnmarshalText([]byte(s))
+	case *time.Duration:
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return err
+		}
+		*u = d
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := ucast.StringToBool(&s)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := ucast.StringToInt64(&s)
+		if err != nil {
+			return err
+		}
+		if v.OverflowInt(i) {
+			return fmt.Errorf("value %d overflows %s", i, v.Type())
+		}
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := ucast.StringToUint64(&s)
+		if err != nil {
+			return err
+		}
+		if v.OverflowUint(u) {
+			return fmt.Errorf("value %d overflows %s", u, v.Type())
+		}
+		v.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := ucast.StringToFloat64(&s)
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	case reflect.Slice:
+		var parts []string
+		if s != "" {
+			parts = strings.Split(s, defaultsSep)
+		}
+		result := reflect.MakeSlice(v.Type(), len(parts), len(parts))
+		for i, part := range parts {
+			if err := parseDefault(result.Index(i), strings.TrimSpace(part)); err != nil {
+				return err
+			}
+		}
+		v.Set(result)
+	case reflect.Pointer:
+		ptr := reflect.New(v.Type().Elem())
+		if err := parseDefault(ptr.Elem(), s); err != nil {
+			return err
+		}
+		v.Set(ptr)
+	default:
+		return fmt.Errorf("unsupported type %s", v.Type())
+	}
+
+	return nil
+}
@@ -0,0 +1,77 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package uopt_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kordax/basic-utils/uopt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type dbConfig struct {
+	DSN      uopt.Opt[string] `default:"postgres://localhost"`
+	PoolSize uopt.Opt[uint8]  `default:"10"`
+}
+
+type appConfig struct {
+	Host       uopt.Opt[string]        `default:"localhost"`
+	Port       uopt.Opt[int]           `default:"8080"`
+	Debug      uopt.Opt[bool]          `default:"true"`
+	Ratio      uopt.Opt[float64]       `default:"0.75"`
+	Timeout    uopt.Opt[time.Duration] `default:"5s"`
+	Since      uopt.Opt[time.Time]     `default:"2024-01-02T03:04:05Z"`
+	Tags       uopt.Opt[[]string]      `default:"a, b,c"`
+	Ports      uopt.Opt[[]int]         `default:"1,2"`
+	NoDefault  uopt.Opt[int]
+	Plain      string
+	DB         dbConfig
+	Replica    *dbConfig
+	unexported uopt.Opt[int] `default:"1"`
+}
+
+func TestFillDefaults(t *testing.T) {
+	cfg := appConfig{Port: uopt.Of(9090), Replica: &dbConfig{}}
+	require.NoError(t, uopt.FillDefaults(&cfg))
+
+	assert.Equal(t, "localhost", cfg.Host.Def())
+	assert.Equal(t, 9090, cfg.Port.Def(), "present values must be preserved")
+	assert.True(t, cfg.Debug.Def())
+	assert.Equal(t, 0.75, cfg.Ratio.Def())
+	assert.Equal(t, 5*time.Second, cfg.Timeout.Def())
+	assert.Equal(t, time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), cfg.Since.Def())
+	assert.Equal(t, []string{"a", "b", "c"}, cfg.Tags.Def())
+	assert.Equal(t, []int{1, 2}, cfg.Ports.Def())
+	assert.False(t, cfg.NoDefault.Present())
+	assert.Equal(t, "postgres://localhost", cfg.DB.DSN.Def())
+	assert.Equal(t, uint8(10), cfg.DB.PoolSize.Def())
+	assert.Equal(t, uint8(10), cfg.Replica.PoolSize.Def())
+	assert.False(t, cfg.unexported.Present())
+}
+
+func TestFillDefaults_Errors(t *testing.T) {
+	assert.Error(t, uopt.FillDefaults(nil))
+	assert.Error(t, uopt.FillDefaults(appConfig{}))
+	assert.Error(t, uopt.FillDefaults((*appConfig)(nil)))
+
+	var overflow struct {
+		V uopt.Opt[int8] `default:"300"`
+	}
+	assert.ErrorContains(t, uopt.FillDefaults(&overflow), "field V")
+
+	var invalid struct {
+		V uopt.Opt[time.Duration] `default:"soon"`
+	}
+	assert.Error(t, uopt.FillDefaults(&invalid))
+
+	var plain struct {
+		V int `default:"1"`
+	}
+	assert.ErrorContains(t, uopt.FillDefaults(&plain), "only supported on Opt fields")
+}
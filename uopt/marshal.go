@@ -0,0 +1,373 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package uopt
+
+import (
+	"bytes"
+	"cmp"
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+type marshalOptions struct {
+	omitAbsent bool
+}
+
+// MarshalOption configures MarshalJSON.
+type MarshalOption func(o *marshalOptions)
+
+// OmitAbsent makes MarshalJSON omit the absent Opt fields of the structs instead of encoding them as null,
+// as if they were tagged with `json:",omitzero"`.
+func OmitAbsent() MarshalOption {
+	return func(o *marshalOptions) {
+		o.omitAbsent = true
+	}
+}
+
+/*
+MarshalJSON encodes the value with encoding/json, applying the options. With OmitAbsent the absent Opt fields
+are omitted from the encoded objects, including the nested ones:
+
+	type Patch struct {
+		Name uopt.Opt[string] `json:"name"`
+		Age  uopt.Opt[int]    `json:"age"`
+	}
+
+	uopt.MarshalJSON(Patch{Name: uopt.Of("john")}, uopt.OmitAbsent()) // {"name":"john"}
+
+This shrinks the payloads with many optional fields and distinguishes absent fields from explicit nulls,
+which is handy for partial updates. Since Go 1.24 the same is achieved with the `json:",omitzero"` tag option,
+see Opt.IsZero. The values implementing json.Marshaler, except Opt, are encoded as is.
+*/
+func MarshalJSON(v any, opts ...MarshalOption) ([]byte, error) {
+	var o marshalOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil || !o.omitAbsent {
+		return data, err
+	}
+
+	return omitAbsent(data, reflect.ValueOf(v))
+}
+
+// optional is implemented by Opt and the types embedding it, e.g. OptZero.
+type optional interface {
+	// optionalValue returns the value of the Opt and whether it is present.
+	optionalValue() (reflect.Value, bool)
+}
+
+func (o Opt[T]) optionalValue() (reflect.Value, bool) {
+	if o.v == nil {
+		return reflect.Value{}, false
+	}
+
+	return reflect.ValueOf(o.v).Elem(), true
+}
+
+var (
+	optionalType      = reflect.TypeFor[optional]()
+	marshalerType     = reflect.TypeFor[json.Marshaler]()
+	textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+)
+
+// optValue returns the value of an Opt, a pointer to it or a type embedding it, and whether it is present.
+func optValue(v reflect.Value) (value reflect.Value, present bool) {
+	if v.Kind() == reflect.Pointer && v.IsNil() || !v.CanInterface() {
+		return reflect.Value{}, false
+	}
+
+	return v.Interface().(optional).optionalValue()
+}
+
+// omitAbsent removes the absent Opt fields from the encoded value in a single pass, walking the value along with its encoding.
+func omitAbsent(data []byte, v reflect.Value) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var buf bytes.Buffer
+	buf.Grow(len(data))
+	if err := rewriteValue(dec, &buf, v); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// walkable returns the value whose encoding can contain the Opt fields, dereferencing the pointers, the interfaces
+// and the present Opt values, or an invalid value if there is nothing to walk.
+func walkable(v reflect.Value) reflect.Value {
+	for v.IsValid() {
+		switch {
+		case v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface:
+			if v.IsNil() {
+				return reflect.Value{}
+			}
+			v = v.Elem()
+		case v.Type().Implements(optionalType):
+			v, _ = optValue(v)
+		case v.Type().Implements(marshalerType) || reflect.PointerTo(v.Type()).Implements(marshalerType),
+			v.Type().Implements(textMarshalerType) || reflect.PointerTo(v.Type()).Implements(textMarshalerType):
+			return reflect.Value{}
+		default:
+			return v
+		}
+	}
+
+	return v
+}
+
+// rewriteValue copies the next JSON value from the decoder to the buffer, omitting the absent Opt fields of v.
+// The parts of the encoding that don't match v are copied as is.
+func rewriteValue(dec *json.Decoder, buf *bytes.Buffer, v reflect.Value) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	v = walkable(v)
+
+	switch tok {
+	case json.Delim('{'):
+		return rewriteObject(dec, buf, v)
+	case json.Delim('['):
+		buf.WriteByte('[')
+		for i := 0; dec.More(); i++ {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			var item reflect.Value
+			if v.IsValid() && (v.Kind() == reflect.Slice || v.Kind() == reflect.Array) && i < v.Len() {
+				item = v.Index(i)
+			}
+			if err = rewriteValue(dec, buf, item); err != nil {
+				return err
+			}
+		}
+		if _, err = dec.Token(); err != nil {
+			return err
+		}
+		buf.WriteByte(']')
+		return nil
+	case nil:
+		buf.WriteString("null")
+		return nil
+	}
+	if n, ok := tok.(json.Number); ok {
+		buf.WriteString(n.String())
+		return nil
+	}
+	encoded, err := json.Marshal(tok)
+	if err != nil {
+		return err
+	}
+	buf.Write(encoded)
+
+	return nil
+}
+
+// rewriteObject copies the rest of the JSON object after its opening brace, dropping the absent Opt fields of v.
+func rewriteObject(dec *json.Decoder, buf *bytes.Buffer, v reflect.Value) error {
+	var fields map[string]jsonField
+	if v.IsValid() && v.Kind() == reflect.Struct {
+		fields = jsonFieldsByName(v.Type())
+	}
+
+	buf.WriteByte('{')
+	first := true
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		key := tok.(string)
+
+		var value reflect.Value
+		switch {
+		case fields != nil:
+			if f, ok := fields[key]; ok {
+				value, _ = fieldByIndex(v, f.index)
+				if value.IsValid() && value.Type().Implements(optionalType) {
+					if _, present := optValue(value); !present {
+						var skipped json.RawMessage
+						if err = dec.Decode(&skipped); err != nil {
+							return err
+						}
+						continue
+					}
+				}
+			}
+		case v.IsValid() && v.Kind() == reflect.Map && v.Type().Key().Kind() == reflect.String:
+			value = v.MapIndex(reflect.ValueOf(key).Convert(v.Type().Key()))
+		}
+
+		if !first {
+			buf.WriteByte(',')
+		}
+		first = false
+		encodedKey, err := json.Marshal(key)
+		if err != nil {
+			return err
+		}
+		buf.Write(encodedKey)
+		buf.WriteByte(':')
+		if err = rewriteValue(dec, buf, value); err != nil {
+			return err
+		}
+	}
+	if _, err := dec.Token(); err != nil {
+		return err
+	}
+	buf.WriteByte('}')
+
+	return nil
+}
+
+// jsonField is a struct field encoded by encoding/json.
+type jsonField struct {
+	name   string
+	index  []int
+	tagged bool
+}
+
+var jsonFieldsCache sync.Map // reflect.Type -> map[string]jsonField
+
+// jsonFieldsByName maps the JSON names of the struct fields to the fields encoded by encoding/json.
+// The fields promoted from the embedded structs follow the encoding/json rules: of the fields with the same name
+// the shallowest one wins, then the tagged one, and the remaining conflicting fields are not encoded at all.
+func jsonFieldsByName(t reflect.Type) map[string]jsonField {
+	if cached, ok := jsonFieldsCache.Load(t); ok {
+		return cached.(map[string]jsonField)
+	}
+
+	type embedded struct {
+		typ   reflect.Type
+		index []int
+	}
+	var fields []jsonField
+	var current []embedded
+	next := []embedded{{typ: t}}
+	var count, nextCount map[reflect.Type]int
+	visited := make(map[reflect.Type]bool)
+	for len(next) > 0 {
+		current, next = next, current[:0]
+		count, nextCount = nextCount, make(map[reflect.Type]int)
+		for _, e := range current {
+			if visited[e.typ] {
+				continue
+			}
+			visited[e.typ] = true
+			for i := 0; i < e.typ.NumField(); i++ {
+				sf := e.typ.Field(i)
+				ft := sf.Type
+				if ft.Kind() == reflect.Pointer {
+					ft = ft.Elem()
+				}
+				if sf.Anonymous {
+					if !sf.IsExported() && ft.Kind() != reflect.Struct {
+						continue
+					}
+				} else if !sf.IsExported() {
+					continue
+				}
+				tag := sf.Tag.Get("json")
+				if tag == "-" {
+					continue
+				}
+				name, _, _ := strings.Cut(tag, ",")
+				if !validFieldName(name) {
+					name = ""
+				}
+				index := append(slices.Clone(e.index), i)
+
+				if name != "" || !sf.Anonymous || ft.Kind() != reflect.Struct {
+					f := jsonField{name: name, index: index, tagged: name != ""}
+					if f.name == "" {
+						f.name = sf.Name
+					}
+					fields = append(fields, f)
+					if count[e.typ] > 1 {
+						// the struct is embedded several times at the same depth, so its fields annihilate each other
+						fields = append(fields, f)
+					}
+					continue
+				}
+				nextCount[ft]++
+				if nextCount[ft] == 1 {
+					next = append(next, embedded{typ: ft, index: index})
+				}
+			}
+		}
+	}
+
+	slices.SortStableFunc(fields, func(a, b jsonField) int {
+		if c := cmp.Compare(a.name, b.name); c != 0 {
+			return c
+		}
+		if c := cmp.Compare(len(a.index), len(b.index)); c != 0 {
+			return c
+		}
+		if a.tagged != b.tagged {
+			if a.tagged {
+				return -1
+			}
+			return 1
+		}
+		return 0
+	})
+	byName := make(map[string]jsonField, len(fields))
+	for i := 0; i < len(fields); {
+		j := i + 1
+		for j < len(fields) && fields[j].name == fields[i].name {
+			j++
+		}
+		// the fields are sorted by depth with the tagged ones first, so the first field wins unless the second one ties
+		if j-i == 1 || len(fields[i].index) != len(fields[i+1].index) || fields[i].tagged != fields[i+1].tagged {
+			byName[fields[i].name] = fields[i]
+		}
+		i = j
+	}
+	cached, _ := jsonFieldsCache.LoadOrStore(t, byName)
+
+	return cached.(map[string]jsonField)
+}
+
+// validFieldName reports whether the name from a json tag is used by encoding/json.
+func validFieldName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, c := range name {
+		switch {
+		case strings.ContainsRune("!#$%&()*+-./:;<=>?@[]^_{|}~ ", c):
+		case !unicode.IsLetter(c) && !unicode.IsDigit(c):
+			return false
+		}
+	}
+
+	return true
+}
+
+// fieldByIndex returns the nested field of the struct, or false if it is reached through a nil embedded pointer.
+func fieldByIndex(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+
+	return v, true
+}
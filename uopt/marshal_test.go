@@ -0,0 +1,148 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package uopt_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/kordax/basic-utils/uopt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type marshalAddress struct {
+	City uopt.Opt[string] `json:"city"`
+	Zip  uopt.Opt[string] `json:"zip,omitempty"`
+}
+
+type marshalBase struct {
+	ID      uopt.Opt[int] `json:"id"`
+	Created time.Time     `json:"created"`
+}
+
+type marshalUser struct {
+	marshalBase
+	Name      uopt.Opt[string]                    `json:"name"`
+	Nick      uopt.OptZero[string]                `json:"nick"`
+	Email     uopt.Opt[string]                    // untagged
+	Ignored   uopt.Opt[string]                    `json:"-"`
+	Address   uopt.Opt[marshalAddress]            `json:"address"`
+	Previous  []marshalAddress                    `json:"previous"`
+	Extra     map[string]uopt.Opt[marshalAddress] `json:"extra"`
+	Pointer   *marshalAddress                     `json:"pointer"`
+	Explicit  *string                             `json:"explicit"`
+	Anything  any                                 `json:"anything"`
+	Timestamp uopt.Opt[time.Time]                 `json:"timestamp"`
+}
+
+func TestMarshalJSON_OmitAbsent(t *testing.T) {
+	user := marshalUser{
+		marshalBase: marshalBase{Created: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)},
+		Name:        uopt.Of("john"),
+		Address:     uopt.Of(marshalAddress{Zip: uopt.Of("12345")}),
+		Previous:    []marshalAddress{{City: uopt.Of("Paris")}, {}},
+		Extra:       map[string]uopt.Opt[marshalAddress]{"work": uopt.Of(marshalAddress{}), "none": uopt.Null[marshalAddress]()},
+		Pointer:     &marshalAddress{City: uopt.Of("Rome")},
+		Anything:    marshalAddress{Zip: uopt.Of("00100")},
+	}
+
+	data, err := uopt.MarshalJSON(user, uopt.OmitAbsent())
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"created": "2026-01-02T03:04:05Z",
+		"name": "john",
+		"address": {"zip": "12345"},
+		"previous": [{"city": "Paris"}, {}],
+		"extra": {"work": {}, "none": null},
+		"pointer": {"city": "Rome"},
+		"explicit": null,
+		"anything": {"zip": "00100"}
+	}`, string(data))
+
+	var decoded marshalUser
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, user.Name, decoded.Name)
+	assert.False(t, decoded.Email.Present())
+
+	pointerData, err := uopt.MarshalJSON(&user, uopt.OmitAbsent())
+	require.NoError(t, err)
+	assert.Equal(t, data, pointerData)
+}
+
+type collisionInner struct {
+	Name uopt.Opt[string] `json:"name"`
+	Age  uopt.Opt[int]
+}
+
+type collisionOther struct {
+	Age   uopt.Opt[int]
+	Email uopt.Opt[string]
+}
+
+type collisionTagged struct {
+	Mail uopt.Opt[string] `json:"Email"`
+}
+
+type collisionOuter struct {
+	collisionInner
+	collisionOther
+	*collisionTagged
+	Name uopt.Opt[string] `json:"name"`
+}
+
+func TestMarshalJSON_OmitAbsentNameCollisions(t *testing.T) {
+	value := collisionOuter{
+		collisionInner:  collisionInner{Age: uopt.Of(1)},
+		collisionOther:  collisionOther{Email: uopt.Of("other@example.com")},
+		collisionTagged: &collisionTagged{},
+		Name:            uopt.Of("john"),
+	}
+	plain, err := json.Marshal(value)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"name": "john", "Email": null}`, string(plain))
+
+	// the shallowest field wins, the conflicting age fields are not encoded and the tagged Email field wins
+	data, err := uopt.MarshalJSON(value, uopt.OmitAbsent())
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"name": "john"}`, string(data))
+
+	value.Name = uopt.Null[string]()
+	value.collisionInner.Name = uopt.Of("hidden")
+	value.collisionTagged = nil
+	data, err = uopt.MarshalJSON(value, uopt.OmitAbsent())
+	require.NoError(t, err)
+	assert.JSONEq(t, `{}`, string(data), "the absent winning field must be omitted even if a deeper one is present")
+}
+
+func TestMarshalJSON_NoOptions(t *testing.T) {
+	value := marshalAddress{City: uopt.Of("Paris")}
+
+	data, err := uopt.MarshalJSON(value)
+	require.NoError(t, err)
+	expected, err := json.Marshal(value)
+	require.NoError(t, err)
+	assert.Equal(t, expected, data, "without options MarshalJSON must match json.Marshal")
+
+	data, err = uopt.MarshalJSON(uopt.Null[int](), uopt.OmitAbsent())
+	require.NoError(t, err)
+	assert.Equal(t, "null", string(data))
+
+	data, err = uopt.MarshalJSON([]uopt.Opt[int]{uopt.Of(1), uopt.Null[int]()}, uopt.OmitAbsent())
+	require.NoError(t, err)
+	assert.Equal(t, "[1,null]", string(data), "absent values are only omitted from objects")
+
+	_, err = uopt.MarshalJSON(make(chan int), uopt.OmitAbsent())
+	assert.Error(t, err)
+}
+
+func TestOpt_IsZero(t *testing.T) {
+	assert.True(t, uopt.Null[int]().IsZero())
+	assert.False(t, uopt.Of(0).IsZero())
+	assert.True(t, uopt.OfZero("").IsZero())
+}
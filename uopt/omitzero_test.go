@@ -0,0 +1,30 @@
+//go:build go1.24
+
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package uopt_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/kordax/basic-utils/uopt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpt_OmitZero(t *testing.T) {
+	type patch struct {
+		Name uopt.Opt[string] `json:"name,omitzero"`
+		Age  uopt.Opt[int]    `json:"age,omitzero"`
+		Note uopt.Opt[string] `json:"note"`
+	}
+
+	data, err := json.Marshal(patch{Age: uopt.Of(0)})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"age": 0, "note": null}`, string(data))
+}
@@ -33,6 +33,7 @@ type OptByte = Opt[byte]
 type OptRune = Opt[rune]
 
 type OptDuration = Opt[time.Duration]
+type OptTime = Opt[time.Time]
 
 func NullBool() OptBool {
 	return OptBool{v: nil}
@@ -109,3 +110,43 @@ func NullRune() OptRune {
 func NullDuration() OptDuration {
 	return OptDuration{v: nil}
 }
+
+func NullTime() OptTime {
+	return OptTime{v: nil}
+}
+
+// OfNonZeroTime creates an OptTime containing the time, or a null OptTime if the time is zero.
+// Unlike Of, it treats the zero time as absent, like OfString and OfNumeric do for their zero values,
+// since time.Time{} usually means "not set", e.g. in the structs scanned from nullable database columns.
+// Use Of to keep the zero time as a present value.
+func OfNonZeroTime(v time.Time) OptTime {
+	if v.IsZero() {
+		return NullTime()
+	}
+
+	return OptTime{v: &v}
+}
+
+// OfPtrTime creates an OptTime containing a copy of the pointed time, or a null OptTime if the pointer is nil.
+func OfPtrTime(v *time.Time) OptTime {
+	return OfNullable(v)
+}
+
+// OfPtrDuration creates an OptDuration containing a copy of the pointed duration, or a null OptDuration if the pointer is nil.
+func OfPtrDuration(v *time.Duration) OptDuration {
+	return OfNullable(v)
+}
+
+// OfDurationString parses the duration in time.ParseDuration format, e.g. "1m30s".
+// An empty string produces a null OptDuration, invalid strings produce an error.
+func OfDurationString(s string) (OptDuration, error) {
+	if s == "" {
+		return NullDuration(), nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return NullDuration(), err
+	}
+
+	return OptDuration{v: &d}, nil
+}
@@ -8,9 +8,11 @@ package uopt_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/kordax/basic-utils/uopt"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNullBool(t *testing.T) {
@@ -120,3 +122,39 @@ func TestNullComplex128(t *testing.T) {
 	assert.False(t, v.Present())
 	assert.Nil(t, v.Get())
 }
+
+func TestNullTime(t *testing.T) {
+	v := uopt.NullTime()
+	assert.False(t, v.Present())
+	assert.Nil(t, v.Get())
+}
+
+func TestOfNonZeroTime(t *testing.T) {
+	now := time.Now()
+	assert.Equal(t, now, *uopt.OfNonZeroTime(now).Get())
+	assert.False(t, uopt.OfNonZeroTime(time.Time{}).Present())
+	assert.True(t, uopt.Of(time.Time{}).Present(), "Of must keep the zero time")
+
+	o := uopt.OfPtrTime(&now)
+	assert.Equal(t, now, *o.Get())
+	assert.NotSame(t, &now, o.Get(), "value must be copied")
+	assert.False(t, uopt.OfPtrTime(nil).Present())
+}
+
+func TestOfDuration(t *testing.T) {
+	d := time.Second
+	assert.Equal(t, time.Second, *uopt.OfPtrDuration(&d).Get())
+	assert.False(t, uopt.OfPtrDuration(nil).Present())
+
+	o, err := uopt.OfDurationString("1m30s")
+	require.NoError(t, err)
+	assert.Equal(t, 90*time.Second, *o.Get())
+
+	o, err = uopt.OfDurationString("")
+	require.NoError(t, err)
+	assert.False(t, o.Present())
+
+	o, err = uopt.OfDurationString("soon")
+	assert.Error(t, err)
+	assert.False(t, o.Present())
+}
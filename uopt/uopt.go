@@ -7,10 +7,13 @@
 package uopt
 
 import (
+	"database/sql"
 	"database/sql/driver"
+	"encoding"
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"slices"
 	"strconv"
 	"time"
 
@@ -39,6 +42,23 @@ func (o Opt[T]) Present() bool {
 	return o.v != nil
 }
 
+// IsPresent is an alias for Present.
+func (o Opt[T]) IsPresent() bool {
+	return o.Present()
+}
+
+// IsEmpty checks if the Opt contains no value. It is the opposite of Present.
+func (o Opt[T]) IsEmpty() bool {
+	return !o.Present()
+}
+
+// IsZero reports whether the Opt contains no value. It makes the `json:",omitzero"` tag option
+// (supported by encoding/json since Go 1.24) omit absent Opt fields instead of encoding them as null.
+// See MarshalJSON for the older Go versions and the structs that can't be tagged.
+func (o Opt[T]) IsZero() bool {
+	return !o.Present()
+}
+
 // IfPresent invokes the provided function if the Opt contains a value.
 func (o Opt[T]) IfPresent(f func(t T)) {
 	if o.Present() {
@@ -46,6 +66,33 @@ func (o Opt[T]) IfPresent(f func(t T)) {
 	}
 }
 
+// IfPresentOrElse invokes the present function with the value if the Opt contains a value,
+// otherwise invokes the absent function.
+func (o Opt[T]) IfPresentOrElse(present func(t T), absent func()) {
+	if o.Present() {
+		present(*o.v)
+	} else {
+		absent()
+	}
+}
+
+// Peek invokes the provided function with the value if the Opt contains one and returns the same Opt,
+// so side effects like logging or metrics can be inserted in the middle of a chain.
+func (o Opt[T]) Peek(f func(t T)) Opt[T] {
+	o.IfPresent(f)
+	return o
+}
+
+// Apply mutates the value in place with the provided function if the Opt contains a value and returns the same Opt.
+// Copies of an Opt share the value, so the mutation is visible through all of them.
+func (o Opt[T]) Apply(f func(t *T)) Opt[T] {
+	if o.Present() {
+		f(o.v)
+	}
+
+	return o
+}
+
 // Null creates an Opt with no value.
 func Null[T any]() Opt[T] {
 	return Opt[T]{v: nil}
@@ -130,6 +177,23 @@ func OfBuilder[T any](build func() T) Opt[T] {
 	}
 }
 
+// Try creates an Opt from the result of a call returning a value and an error.
+// The Opt contains the value if err is nil and is null otherwise, the error itself is dropped:
+//
+//	port := uopt.Try(strconv.Atoi(os.Getenv("PORT"))).OrElse(8080)
+func Try[T any](v T, err error) Opt[T] {
+	if err != nil {
+		return Null[T]()
+	}
+
+	return Of(v)
+}
+
+// TryWith is the same as Try, but returns the error alongside the Opt, so it can still be handled or wrapped.
+func TryWith[T any](v T, err error) (Opt[T], error) {
+	return Try(v, err), err
+}
+
 // OrElse retrieves the value within the Opt or a provided default if the Opt is null.
 func (o Opt[T]) OrElse(v T) T {
 	if o.v == nil {
@@ -150,6 +214,29 @@ func (o Opt[T]) Def() T {
 	return o.OrElse(*new(T))
 }
 
+// Expect retrieves the value within the Opt or panics with the message and the type name if the Opt is null.
+// It's meant for the values that must be present, where the panic message explains the broken invariant.
+func (o Opt[T]) Expect(msg string) T {
+	if o.v == nil {
+		panic(fmt.Sprintf("%s: %s is empty", msg, o.typeName()))
+	}
+
+	return *o.v
+}
+
+// String implements fmt.Stringer for debugging purposes, e.g. "Opt[int]{42}" or "Opt[int]{empty}".
+func (o Opt[T]) String() string {
+	if o.v == nil {
+		return o.typeName() + "{empty}"
+	}
+
+	return fmt.Sprintf("%s{%v}", o.typeName(), *o.v)
+}
+
+func (o Opt[T]) typeName() string {
+	return "Opt[" + reflect.TypeFor[T]().String() + "]"
+}
+
 // Set sets the value within the Opt.
 func (o *Opt[T]) Set(v *T) {
 	o.v = v
@@ -185,6 +272,36 @@ func (o Opt[T]) MarshalJSON() ([]byte, error) {
 	return json.Marshal(o.Get())
 }
 
+// OptZero is an Opt that treats zero values as absent during JSON unmarshalling,
+// mirroring the OfString and OfNumeric semantics for payloads that use zero values instead of null,
+// e.g. both "" and null produce an empty OptZero[string].
+// All the Opt methods are available through embedding.
+type OptZero[T any] struct {
+	Opt[T]
+}
+
+// OfZero creates an OptZero containing the value, or an empty OptZero if the value is zero.
+func OfZero[T any](v T) OptZero[T] {
+	if reflect.ValueOf(&v).Elem().IsZero() {
+		return OptZero[T]{}
+	}
+
+	return OptZero[T]{Opt: Of(v)}
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for the OptZero type.
+// Both null and zero values produce an empty OptZero.
+func (o *OptZero[T]) UnmarshalJSON(bytes []byte) error {
+	if err := o.Opt.UnmarshalJSON(bytes); err != nil {
+		return err
+	}
+	if o.v != nil && reflect.ValueOf(o.v).Elem().IsZero() {
+		o.v = nil
+	}
+
+	return nil
+}
+
 // Value implements the driver.Valuer interface for the Opt type, converting its value to a SQL value.
 func (o Opt[T]) Value() (driver.Value, error) {
 	if o.v != nil {
@@ -219,10 +336,18 @@ func (o Opt[T]) Value() (driver.Value, error) {
 			return *p, nil
 		case *bool:
 			return *p, nil
+		case *json.RawMessage:
+			return []byte(*p), nil
 		case map[string]any, []any:
 			return json.Marshal(o.v)
 		case driver.Valuer:
 			return p.Value()
+		case encoding.TextMarshaler:
+			text, err := p.MarshalText()
+			if err != nil {
+				return nil, err
+			}
+			return string(text), nil
 		}
 		return driver.Value(*o.v), nil
 	}
@@ -236,6 +361,10 @@ func (o *Opt[T]) Scan(src interface{}) error {
 		return nil
 	}
 
+	if ok, err := o.scanSpecial(src); ok {
+		return err
+	}
+
 	var v *T
 	switch src.(type) {
 	case []uint8:
@@ -516,3 +645,44 @@ func (o *Opt[T]) Scan(src interface{}) error {
 
 	return nil
 }
+
+// scanSpecial handles the targets that know how to decode themselves: json.RawMessage (passthrough),
+// sql.Scanner implementations (e.g. uuid.UUID in string or 16-byte form) and encoding.TextUnmarshaler implementations
+// (e.g. decimal types). Returns false if T is not one of those.
+func (o *Opt[T]) scanSpecial(src any) (bool, error) {
+	var target T
+	switch t := any(&target).(type) {
+	case *json.RawMessage:
+		switch s := src.(type) {
+		case []byte:
+			*t = slices.Clone(s)
+		case string:
+			*t = json.RawMessage(s)
+		default:
+			return true, fmt.Errorf("incompatible type for Opt[%T]: %T", target, src)
+		}
+	case sql.Scanner:
+		if err := t.Scan(src); err != nil {
+			return true, fmt.Errorf("failed to scan sql value to Opt[%T]: %w", target, err)
+		}
+	case encoding.TextUnmarshaler:
+		var err error
+		switch s := src.(type) {
+		case []byte:
+			err = t.UnmarshalText(s)
+		case string:
+			err = t.UnmarshalText([]byte(s))
+		default:
+			return false, nil
+		}
+		if err != nil {
+			return true, fmt.Errorf("failed to unmarshal sql value to Opt[%T]: %w", target, err)
+		}
+	default:
+		return false, nil
+	}
+
+	*o = Of(target)
+
+	return true, nil
+}
@@ -16,6 +16,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/kordax/basic-utils/uopt"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -55,6 +56,33 @@ func TestIfPresent(t *testing.T) {
 	})
 }
 
+// TestIfPresentOrElse tests the IfPresentOrElse method.
+func TestIfPresentOrElse(t *testing.T) {
+	var result int
+	uopt.Of(42).IfPresentOrElse(func(v int) {
+		result = v
+	}, func() {
+		assert.Fail(t, "IfPresentOrElse should not execute the absent function when value is present")
+	})
+	assert.Equal(t, 42, result)
+
+	absentCalled := false
+	uopt.Null[int]().IfPresentOrElse(func(v int) {
+		assert.Fail(t, "IfPresentOrElse should not execute the present function when value is not present")
+	}, func() {
+		absentCalled = true
+	})
+	assert.True(t, absentCalled)
+}
+
+// TestIsEmpty tests the IsEmpty and IsPresent methods.
+func TestIsEmpty(t *testing.T) {
+	assert.False(t, uopt.Of(0).IsEmpty())
+	assert.True(t, uopt.Of(0).IsPresent())
+	assert.True(t, uopt.Null[int]().IsEmpty())
+	assert.False(t, uopt.Null[int]().IsPresent())
+}
+
 // TestNull tests the Null method.
 func TestNull(t *testing.T) {
 	o := uopt.Null[int]()
@@ -1068,3 +1096,175 @@ func TestOpt_Scan_Errors(t *testing.T) {
 		assert.Contains(t, err.Error(), "failed to parse varchar sql value to bool opt")
 	})
 }
+
+// decimalText is a minimal TextUnmarshaler/TextMarshaler used to emulate decimal types.
+type decimalText struct {
+	units, cents int64
+}
+
+func (d *decimalText) UnmarshalText(text []byte) error {
+	_, err := fmt.Sscanf(string(text), "%d.%d", &d.units, &d.cents)
+	return err
+}
+
+func (d decimalText) MarshalText() ([]byte, error) {
+	return []byte(fmt.Sprintf("%d.%02d", d.units, d.cents)), nil
+}
+
+func TestOpt_ScanUUID(t *testing.T) {
+	id := uuid.New()
+
+	var fromString uopt.Opt[uuid.UUID]
+	require.NoError(t, fromString.Scan(id.String()))
+	require.True(t, fromString.Present())
+	assert.Equal(t, id, *fromString.Get())
+
+	var fromBytes uopt.Opt[uuid.UUID]
+	require.NoError(t, fromBytes.Scan(id[:]))
+	assert.Equal(t, id, *fromBytes.Get())
+
+	var fromText uopt.Opt[uuid.UUID]
+	require.NoError(t, fromText.Scan([]byte(id.String())))
+	assert.Equal(t, id, *fromText.Get())
+
+	var invalid uopt.Opt[uuid.UUID]
+	assert.Error(t, invalid.Scan("not-a-uuid"))
+	assert.False(t, invalid.Present())
+
+	value, err := fromString.Value()
+	require.NoError(t, err)
+	assert.Equal(t, id.String(), value)
+}
+
+func TestOpt_ScanRawMessage(t *testing.T) {
+	src := []byte(`{"a": 1}`)
+	var o uopt.Opt[json.RawMessage]
+	require.NoError(t, o.Scan(src))
+	require.True(t, o.Present())
+	assert.Equal(t, json.RawMessage(src), *o.Get())
+
+	src[0] = 'x'
+	assert.Equal(t, byte('{'), (*o.Get())[0], "Scanned bytes must be copied")
+
+	require.NoError(t, o.Scan(`[1,2]`))
+	assert.Equal(t, json.RawMessage(`[1,2]`), *o.Get())
+
+	value, err := o.Value()
+	require.NoError(t, err)
+	assert.Equal(t, []byte(`[1,2]`), value)
+
+	assert.Error(t, o.Scan(int64(5)))
+}
+
+func TestOpt_ScanTextUnmarshaler(t *testing.T) {
+	var o uopt.Opt[decimalText]
+	require.NoError(t, o.Scan([]byte("12.34")))
+	assert.Equal(t, decimalText{units: 12, cents: 34}, *o.Get())
+
+	require.NoError(t, o.Scan("7.05"))
+	assert.Equal(t, decimalText{units: 7, cents: 5}, *o.Get())
+
+	assert.Error(t, o.Scan("abc"))
+
+	value, err := uopt.Of(decimalText{units: 1, cents: 5}).Value()
+	require.NoError(t, err)
+	assert.Equal(t, "1.05", value)
+}
+
+func TestOptZero_UnmarshalJSON(t *testing.T) {
+	type payload struct {
+		Name  uopt.OptZero[string]   `json:"name"`
+		Count uopt.OptZero[int]      `json:"count"`
+		Rate  uopt.OptZero[float64]  `json:"rate"`
+		Tags  uopt.OptZero[[]string] `json:"tags"`
+	}
+
+	var p payload
+	require.NoError(t, json.Unmarshal([]byte(`{"name":"","count":0,"rate":0.0,"tags":null}`), &p))
+	assert.False(t, p.Name.Present())
+	assert.False(t, p.Count.Present())
+	assert.False(t, p.Rate.Present())
+	assert.False(t, p.Tags.Present())
+
+	require.NoError(t, json.Unmarshal([]byte(`{"name":"john","count":3,"rate":0.5,"tags":[]}`), &p))
+	assert.Equal(t, "john", *p.Name.Get())
+	assert.Equal(t, 3, *p.Count.Get())
+	assert.Equal(t, 0.5, *p.Rate.Get())
+	assert.Equal(t, []string{}, *p.Tags.Get(), "empty slice is not a zero value")
+
+	assert.Error(t, json.Unmarshal([]byte(`{"count":"x"}`), &p))
+}
+
+func TestOptZero_MarshalJSON(t *testing.T) {
+	data, err := json.Marshal(struct {
+		A uopt.OptZero[string] `json:"a"`
+		B uopt.OptZero[string] `json:"b"`
+	}{A: uopt.OfZero("x"), B: uopt.OfZero("")})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"a":"x","b":null}`, string(data))
+}
+
+func TestOfZero(t *testing.T) {
+	assert.False(t, uopt.OfZero(0).Present())
+	assert.False(t, uopt.OfZero(time.Time{}).Present())
+	assert.True(t, uopt.OfZero(1).Present())
+	assert.Equal(t, "v", uopt.OfZero("v").OrElse("def"))
+}
+
+func TestTry(t *testing.T) {
+	parse := func(s string) (int, error) {
+		var v int
+		_, err := fmt.Sscan(s, &v)
+		return v, err
+	}
+
+	assert.Equal(t, 42, *uopt.Try(parse("42")).Get())
+	assert.False(t, uopt.Try(parse("x")).Present())
+	assert.Equal(t, 8080, uopt.Try(parse("")).OrElse(8080))
+
+	o, err := uopt.TryWith(parse("7"))
+	require.NoError(t, err)
+	assert.Equal(t, 7, *o.Get())
+
+	o, err = uopt.TryWith(parse("x"))
+	assert.Error(t, err)
+	assert.False(t, o.Present())
+}
+
+func TestOpt_Expect(t *testing.T) {
+	assert.Equal(t, 42, uopt.Of(42).Expect("answer must be set"))
+	assert.PanicsWithValue(t, "answer must be set: Opt[int] is empty", func() {
+		uopt.Null[int]().Expect("answer must be set")
+	})
+}
+
+func TestOpt_String(t *testing.T) {
+	assert.Equal(t, "Opt[int]{42}", uopt.Of(42).String())
+	assert.Equal(t, "Opt[int]{empty}", uopt.Null[int]().String())
+	assert.Equal(t, "Opt[string]{abc}", fmt.Sprint(uopt.Of("abc")))
+	assert.Equal(t, "Opt[time.Duration]{1s}", fmt.Sprintf("%v", uopt.Of(time.Second)))
+	assert.Equal(t, "Opt[error]{empty}", uopt.Null[error]().String())
+}
+
+func TestOpt_Peek(t *testing.T) {
+	var peeked []int
+	result := uopt.Of(1).Peek(func(v int) { peeked = append(peeked, v) })
+	assert.Equal(t, uopt.Of(1), result)
+	assert.Equal(t, uopt.Null[int](), uopt.Null[int]().Peek(func(v int) { peeked = append(peeked, v) }))
+	assert.Equal(t, []int{1}, peeked)
+}
+
+func TestOpt_Apply(t *testing.T) {
+	type config struct {
+		Port int
+	}
+
+	opt := uopt.Of(config{Port: 80})
+	result := opt.Apply(func(c *config) { c.Port = 8080 })
+	assert.Equal(t, 8080, result.Get().Port)
+	assert.Equal(t, 8080, opt.Get().Port, "copies share the value")
+
+	called := false
+	assert.False(t, uopt.Null[config]().Apply(func(*config) { called = true }).Present())
+	assert.False(t, called)
+}
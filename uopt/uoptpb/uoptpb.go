@@ -0,0 +1,147 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+// Package uoptpb converts uopt.Opt values to and from protobuf well-known wrapper types,
+// so optional API fields can be mapped without hand-written nil checks.
+//
+// A nil wrapper is converted into an absent Opt and an absent Opt is converted into a nil wrapper.
+// It lives in a separate package to keep the protobuf dependency out of uopt.
+package uoptpb
+
+import (
+	"time"
+
+	"github.com/kordax/basic-utils/uopt"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// FromStringValue converts the wrapper into an Opt.
+func FromStringValue(v *wrapperspb.StringValue) uopt.Opt[string] {
+	return from(v, (*wrapperspb.StringValue).GetValue)
+}
+
+// ToStringValue converts the Opt into a wrapper.
+func ToStringValue(o uopt.Opt[string]) *wrapperspb.StringValue {
+	return to(o, wrapperspb.String)
+}
+
+// FromBoolValue converts the wrapper into an Opt.
+func FromBoolValue(v *wrapperspb.BoolValue) uopt.Opt[bool] {
+	return from(v, (*wrapperspb.BoolValue).GetValue)
+}
+
+// ToBoolValue converts the Opt into a wrapper.
+func ToBoolValue(o uopt.Opt[bool]) *wrapperspb.BoolValue {
+	return to(o, wrapperspb.Bool)
+}
+
+// FromInt32Value converts the wrapper into an Opt.
+func FromInt32Value(v *wrapperspb.Int32Value) uopt.Opt[int32] {
+	return from(v, (*wrapperspb.Int32Value).GetValue)
+}
+
+// ToInt32Value converts the Opt into a wrapper.
+func ToInt32Value(o uopt.Opt[int32]) *wrapperspb.Int32Value {
+	return to(o, wrapperspb.Int32)
+}
+
+// FromInt64Value converts the wrapper into an Opt.
+func FromInt64Value(v *wrapperspb.Int64Value) uopt.Opt[int64] {
+	return from(v, (*wrapperspb.Int64Value).GetValue)
+}
+
+// ToInt64Value converts the Opt into a wrapper.
+func ToInt64Value(o uopt.Opt[int64]) *wrapperspb.Int64Value {
+	return to(o, wrapperspb.Int64)
+}
+
+// FromUInt32Value converts the wrapper into an Opt.
+func FromUInt32Value(v *wrapperspb.UInt32Value) uopt.Opt[uint32] {
+	return from(v, (*wrapperspb.UInt32Value).GetValue)
+}
+
+// ToUInt32Value converts the Opt into a wrapper.
+func ToUInt32Value(o uopt.Opt[uint32]) *wrapperspb.UInt32Value {
+	return to(o, wrapperspb.UInt32)
+}
+
+// FromUInt64Value converts the wrapper into an Opt.
+func FromUInt64Value(v *wrapperspb.UInt64Value) uopt.Opt[uint64] {
+	return from(v, (*wrapperspb.UInt64Value).GetValue)
+}
+
+// ToUInt64Value converts the Opt into a wrapper.
+func ToUInt64Value(o uopt.Opt[uint64]) *wrapperspb.UInt64Value {
+	return to(o, wrapperspb.UInt64)
+}
+
+// FromFloatValue converts the wrapper into an Opt.
+func FromFloatValue(v *wrapperspb.FloatValue) uopt.Opt[float32] {
+	return from(v, (*wrapperspb.FloatValue).GetValue)
+}
+
+// ToFloatValue converts the Opt into a wrapper.
+func ToFloatValue(o uopt.Opt[float32]) *wrapperspb.FloatValue {
+	return to(o, wrapperspb.Float)
+}
+
+// FromDoubleValue converts the wrapper into an Opt.
+func FromDoubleValue(v *wrapperspb.DoubleValue) uopt.Opt[float64] {
+	return from(v, (*wrapperspb.DoubleValue).GetValue)
+}
+
+// ToDoubleValue converts the Opt into a wrapper.
+func ToDoubleValue(o uopt.Opt[float64]) *wrapperspb.DoubleValue {
+	return to(o, wrapperspb.Double)
+}
+
+// FromBytesValue converts the wrapper into an Opt.
+func FromBytesValue(v *wrapperspb.BytesValue) uopt.Opt[[]byte] {
+	return from(v, (*wrapperspb.BytesValue).GetValue)
+}
+
+// ToBytesValue converts the Opt into a wrapper.
+func ToBytesValue(o uopt.Opt[[]byte]) *wrapperspb.BytesValue {
+	return to(o, wrapperspb.Bytes)
+}
+
+// FromTimestamp converts the timestamp into an Opt. The resulting time is in UTC.
+func FromTimestamp(v *timestamppb.Timestamp) uopt.Opt[time.Time] {
+	return from(v, (*timestamppb.Timestamp).AsTime)
+}
+
+// ToTimestamp converts the Opt into a timestamp.
+func ToTimestamp(o uopt.Opt[time.Time]) *timestamppb.Timestamp {
+	return to(o, timestamppb.New)
+}
+
+// FromDuration converts the duration into an Opt. Out of range durations are clamped, see durationpb.Duration.AsDuration.
+func FromDuration(v *durationpb.Duration) uopt.Opt[time.Duration] {
+	return from(v, (*durationpb.Duration).AsDuration)
+}
+
+// ToDuration converts the Opt into a duration.
+func ToDuration(o uopt.Opt[time.Duration]) *durationpb.Duration {
+	return to(o, durationpb.New)
+}
+
+func from[W any, T any](w *W, get func(*W) T) uopt.Opt[T] {
+	if w == nil {
+		return uopt.Null[T]()
+	}
+
+	return uopt.Of(get(w))
+}
+
+func to[W any, T any](o uopt.Opt[T], wrap func(T) *W) *W {
+	if !o.Present() {
+		return nil
+	}
+
+	return wrap(*o.Get())
+}
@@ -0,0 +1,64 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package uoptpb_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kordax/basic-utils/uopt"
+	"github.com/kordax/basic-utils/uopt/uoptpb"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestWrappers(t *testing.T) {
+	assert.Equal(t, uopt.Of("a"), uoptpb.FromStringValue(wrapperspb.String("a")))
+	assert.Equal(t, uopt.Null[string](), uoptpb.FromStringValue(nil))
+	assert.Equal(t, "a", uoptpb.ToStringValue(uopt.Of("a")).GetValue())
+	assert.Nil(t, uoptpb.ToStringValue(uopt.Null[string]()))
+
+	assert.Equal(t, uopt.Of(false), uoptpb.FromBoolValue(wrapperspb.Bool(false)))
+	assert.True(t, uoptpb.ToBoolValue(uopt.Of(true)).GetValue())
+
+	assert.Equal(t, uopt.Of(int32(-1)), uoptpb.FromInt32Value(wrapperspb.Int32(-1)))
+	assert.Equal(t, int32(7), uoptpb.ToInt32Value(uopt.Of(int32(7))).GetValue())
+	assert.Equal(t, uopt.Of(int64(0)), uoptpb.FromInt64Value(wrapperspb.Int64(0)))
+	assert.Nil(t, uoptpb.ToInt64Value(uopt.Null[int64]()))
+	assert.Equal(t, uopt.Of(uint32(3)), uoptpb.FromUInt32Value(wrapperspb.UInt32(3)))
+	assert.Equal(t, uint64(9), uoptpb.ToUInt64Value(uopt.Of(uint64(9))).GetValue())
+	assert.Equal(t, uopt.Null[uint64](), uoptpb.FromUInt64Value(nil))
+	assert.Nil(t, uoptpb.ToUInt32Value(uopt.Null[uint32]()))
+
+	assert.Equal(t, uopt.Of(float32(1.5)), uoptpb.FromFloatValue(wrapperspb.Float(1.5)))
+	assert.Nil(t, uoptpb.ToFloatValue(uopt.Null[float32]()))
+	assert.Equal(t, 2.5, uoptpb.ToDoubleValue(uopt.Of(2.5)).GetValue())
+	assert.Equal(t, uopt.Null[float64](), uoptpb.FromDoubleValue(nil))
+
+	assert.Equal(t, uopt.Of([]byte("x")), uoptpb.FromBytesValue(wrapperspb.Bytes([]byte("x"))))
+	assert.Nil(t, uoptpb.ToBytesValue(uopt.Null[[]byte]()))
+}
+
+func TestTimestamp(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 6, time.UTC)
+
+	ts := uoptpb.ToTimestamp(uopt.Of(now))
+	assert.Equal(t, now, ts.AsTime())
+	assert.Equal(t, uopt.Of(now), uoptpb.FromTimestamp(ts))
+	assert.Nil(t, uoptpb.ToTimestamp(uopt.Null[time.Time]()))
+	assert.Equal(t, uopt.Null[time.Time](), uoptpb.FromTimestamp((*timestamppb.Timestamp)(nil)))
+}
+
+func TestDuration(t *testing.T) {
+	d := uoptpb.ToDuration(uopt.Of(90 * time.Second))
+	assert.Equal(t, int64(90), d.GetSeconds())
+	assert.Equal(t, uopt.Of(90*time.Second), uoptpb.FromDuration(d))
+	assert.Nil(t, uoptpb.ToDuration(uopt.Null[time.Duration]()))
+	assert.Equal(t, uopt.Null[time.Duration](), uoptpb.FromDuration((*durationpb.Duration)(nil)))
+}
@@ -0,0 +1,165 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package uopt
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+// ValidationError is returned when a present Opt value is rejected by its validator.
+type ValidationError struct {
+	Path string // Path is the JSON path of the field, e.g. "user.id" or "items[2].id", empty for a standalone Opt.
+	Err  error  // Err is the error returned by the validator.
+}
+
+func (e *ValidationError) Error() string {
+	if e.Path == "" {
+		return fmt.Sprintf("uopt: invalid value: %v", e.Err)
+	}
+
+	return fmt.Sprintf("uopt: invalid value of %s: %v", e.Path, e.Err)
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+var validators sync.Map // reflect.Type -> func(v any) error
+
+// RegisterValidator registers the validator of the present Opt[T] values decoded by UnmarshalValid,
+// replacing the previously registered one. Passing nil removes the validator. The operation is thread-safe,
+// though the validators are meant to be registered during the initialization:
+//
+//	uopt.RegisterValidator(func(id UserID) error {
+//		if id <= 0 {
+//			return errors.New("must be positive")
+//		}
+//		return nil
+//	})
+func RegisterValidator[T any](validate func(v T) error) {
+	t := reflect.TypeFor[T]()
+	if validate == nil {
+		validators.Delete(t)
+		return
+	}
+	validators.Store(t, func(v any) error {
+		return validate(v.(T))
+	})
+}
+
+// UnmarshalJSONValid decodes the value like UnmarshalJSON and checks the present value with validate,
+// which may be nil. The Opt is left unchanged if the decoding or the validation fails,
+// in the latter case the error is a *ValidationError.
+func (o *Opt[T]) UnmarshalJSONValid(bytes []byte, validate func(v T) error) error {
+	var decoded Opt[T]
+	if err := decoded.UnmarshalJSON(bytes); err != nil {
+		return err
+	}
+	if decoded.v != nil && validate != nil {
+		if err := validate(*decoded.v); err != nil {
+			return &ValidationError{Err: err}
+		}
+	}
+	*o = decoded
+
+	return nil
+}
+
+/*
+UnmarshalValid decodes the JSON data into v with encoding/json and checks the present Opt values, including the ones
+nested in structs, maps and slices, with the validators registered for their types with RegisterValidator:
+
+	type Request struct {
+		User  uopt.Opt[UserID]   `json:"user"`
+		Items []Item             `json:"items"` // Item has an `json:"id"` field of type uopt.Opt[UserID]
+	}
+
+	err := uopt.UnmarshalValid(data, &req) // uopt: invalid value of items[2].id: must be positive
+
+The errors of all the rejected values are joined, each of them is a *ValidationError carrying the JSON path
+of the value. The fields are named by the encoding/json rules, the map keys and slice indices are put in brackets.
+Like json.Unmarshal, UnmarshalValid may leave v partially populated on failure.
+Note that json.Unmarshal doesn't run the registered validators.
+*/
+func UnmarshalValid(data []byte, v any) error {
+	if err := json.Unmarshal(data, v); err != nil {
+		return err
+	}
+
+	var errs []error
+	validateValue(reflect.ValueOf(v), "", &errs)
+
+	return errors.Join(errs...)
+}
+
+func validateValue(v reflect.Value, path string, errs *[]error) {
+	for v.IsValid() && (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return
+	}
+	if v.Type().Implements(optionalType) {
+		value, present := optValue(v)
+		if !present {
+			return
+		}
+		if validate, ok := validators.Load(value.Type()); ok {
+			if err := validate.(func(v any) error)(value.Interface()); err != nil {
+				*errs = append(*errs, &ValidationError{Path: path, Err: err})
+				return
+			}
+		}
+		validateValue(value, path, errs)
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		fields := jsonFieldsByName(v.Type())
+		names := make([]string, 0, len(fields))
+		for name := range fields {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			field, ok := fieldByIndex(v, fields[name].index)
+			if !ok {
+				continue
+			}
+			child := name
+			if path != "" {
+				child = path + "." + name
+			}
+			validateValue(field, child, errs)
+		}
+	case reflect.Map:
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j])
+		})
+		for _, key := range keys {
+			validateValue(v.MapIndex(key), fmt.Sprintf("%s[%v]", path, key), errs)
+		}
+	case reflect.Slice, reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return
+		}
+		for i := 0; i < v.Len(); i++ {
+			validateValue(v.Index(i), path+"["+strconv.Itoa(i)+"]", errs)
+		}
+	}
+}
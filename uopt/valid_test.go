@@ -0,0 +1,123 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package uopt_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kordax/basic-utils/uopt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type validID int
+
+var errNotPositive = errors.New("must be positive")
+
+func validatePositive(id validID) error {
+	if id <= 0 {
+		return errNotPositive
+	}
+	return nil
+}
+
+func TestOpt_UnmarshalJSONValid(t *testing.T) {
+	var opt uopt.Opt[validID]
+	require.NoError(t, opt.UnmarshalJSONValid([]byte("5"), validatePositive))
+	assert.Equal(t, uopt.Of[validID](5), opt)
+
+	err := opt.UnmarshalJSONValid([]byte("-1"), validatePositive)
+	var validationErr *uopt.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.ErrorIs(t, err, errNotPositive)
+	assert.Empty(t, validationErr.Path)
+	assert.EqualError(t, err, "uopt: invalid value: must be positive")
+	assert.Equal(t, uopt.Of[validID](5), opt, "the Opt must be left unchanged")
+
+	require.NoError(t, opt.UnmarshalJSONValid([]byte("null"), validatePositive), "absent values must not be validated")
+	assert.False(t, opt.Present())
+	require.NoError(t, opt.UnmarshalJSONValid([]byte("-1"), nil))
+	assert.Equal(t, uopt.Of[validID](-1), opt)
+	require.Error(t, opt.UnmarshalJSONValid([]byte(`"x"`), validatePositive))
+}
+
+type validItem struct {
+	ID   uopt.Opt[validID] `json:"id"`
+	Note string            `json:"note"`
+}
+
+type validEmbedded struct {
+	Owner uopt.Opt[validID] `json:"owner"`
+}
+
+type validRequest struct {
+	validEmbedded
+	User   uopt.Opt[validID]            `json:"user"`
+	Items  []validItem                  `json:"items"`
+	ByName map[string]uopt.Opt[validID] `json:"by_name"`
+	Nested uopt.Opt[validItem]          `json:"nested"`
+	Ptr    *validItem
+	Other  uopt.Opt[int] `json:"other"`
+}
+
+func TestUnmarshalValid(t *testing.T) {
+	uopt.RegisterValidator(validatePositive)
+	t.Cleanup(func() { uopt.RegisterValidator[validID](nil) })
+
+	var req validRequest
+	require.NoError(t, uopt.UnmarshalValid([]byte(`{"user":1,"items":[{"id":2},{"note":"x"}],"other":-1,"owner":3}`), &req))
+	assert.Equal(t, uopt.Of[validID](1), req.User)
+	assert.Equal(t, uopt.Of[validID](3), req.Owner)
+
+	err := uopt.UnmarshalValid([]byte(`{
+		"user": -1,
+		"owner": 0,
+		"items": [{"id": 1}, {"id": 1}, {"id": -2}],
+		"by_name": {"b": -3, "a": 4, "c": null},
+		"nested": {"id": -4},
+		"Ptr": {"id": -5},
+		"other": -6
+	}`), &req)
+	require.ErrorIs(t, err, errNotPositive)
+
+	var paths []string
+	for _, e := range err.(interface{ Unwrap() []error }).Unwrap() {
+		var validationErr *uopt.ValidationError
+		require.ErrorAs(t, e, &validationErr)
+		paths = append(paths, validationErr.Path)
+	}
+	assert.Equal(t, []string{"Ptr.id", "by_name[b]", "items[2].id", "nested.id", "owner", "user"}, paths)
+	assert.Contains(t, err.Error(), "uopt: invalid value of items[2].id: must be positive")
+}
+
+type validKey string
+
+func TestUnmarshalValid_NestedOpt(t *testing.T) {
+	uopt.RegisterValidator(validatePositive)
+	t.Cleanup(func() { uopt.RegisterValidator[validID](nil) })
+
+	// the values stored in an Opt and in maps can't be addressed
+	var req map[validKey]uopt.Opt[map[validKey]uopt.Opt[validItem]]
+	err := uopt.UnmarshalValid([]byte(`{"outer": {"b": {"id": -1}, "a": {"id": 1}}}`), &req)
+	var validationErr *uopt.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "[outer][b].id", validationErr.Path)
+}
+
+func TestUnmarshalValid_Errors(t *testing.T) {
+	var req validRequest
+	require.Error(t, uopt.UnmarshalValid([]byte(`{"user":`), &req))
+	require.NoError(t, uopt.UnmarshalValid([]byte(`{"user":-1}`), &req), "no validator is registered")
+
+	var opt uopt.Opt[validID]
+	uopt.RegisterValidator(validatePositive)
+	t.Cleanup(func() { uopt.RegisterValidator[validID](nil) })
+	var validationErr *uopt.ValidationError
+	require.ErrorAs(t, uopt.UnmarshalValid([]byte(`-1`), &opt), &validationErr)
+	assert.Empty(t, validationErr.Path)
+}
@@ -0,0 +1,132 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package uorderedmap
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// MarshalJSON encodes the map as a JSON object with the keys in their order.
+// Keys are encoded like encoding/json encodes map keys: strings as is, integers as decimal strings
+// and encoding.TextMarshaler implementations with MarshalText. Other key types are not supported.
+func (o *OrderedMap[K, V]) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	first := true
+	for k, v := range o.All() {
+		key, err := encodeKey(k)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		if !first {
+			buf.WriteByte(',')
+		}
+		first = false
+		encodedKey, _ := json.Marshal(key) // marshalling a string never fails
+		buf.Write(encodedKey)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
+
+// UnmarshalJSON decodes a JSON object into the map preserving the order of its keys.
+// The existing entries are kept, the decoded keys are set like with Set. JSON null leaves the map unchanged.
+// Keys are decoded following the MarshalJSON rules.
+func (o *OrderedMap[K, V]) UnmarshalJSON(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	token, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if token == nil {
+		return nil
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '{' {
+		return fmt.Errorf("uorderedmap: cannot unmarshal %v into an ordered map, object expected", token)
+	}
+
+	o.lazyInit()
+	for dec.More() {
+		token, err = dec.Token()
+		if err != nil {
+			return err
+		}
+		key, err := decodeKey[K](token.(string)) // object keys are always strings
+		if err != nil {
+			return err
+		}
+		var value V
+		if err = dec.Decode(&value); err != nil {
+			return err
+		}
+		o.Set(key, value)
+	}
+	_, err = dec.Token() // the closing brace
+
+	return err
+}
+
+func encodeKey[K comparable](key K) (string, error) {
+	if m, ok := any(key).(encoding.TextMarshaler); ok {
+		text, err := m.MarshalText()
+		return string(text), err
+	}
+
+	v := reflect.ValueOf(key)
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	default:
+		return "", fmt.Errorf("uorderedmap: unsupported key type %T", key)
+	}
+}
+
+func decodeKey[K comparable](s string) (K, error) {
+	var key K
+	if u, ok := any(&key).(encoding.TextUnmarshaler); ok {
+		err := u.UnmarshalText([]byte(s))
+		return key, err
+	}
+
+	v := reflect.ValueOf(&key).Elem()
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return key, fmt.Errorf("uorderedmap: invalid key %q: %w", s, err)
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := strconv.ParseUint(s, 10, v.Type().Bits())
+		if err != nil {
+			return key, fmt.Errorf("uorderedmap: invalid key %q: %w", s, err)
+		}
+		v.SetUint(n)
+	default:
+		return key, fmt.Errorf("uorderedmap: unsupported key type %T", key)
+	}
+
+	return key, nil
+}
@@ -0,0 +1,194 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+// Package uorderedmap provides a generic map preserving the insertion order of its keys.
+package uorderedmap
+
+import "iter"
+
+type entry[K comparable, V any] struct {
+	key        K
+	value      V
+	prev, next *entry[K, V]
+}
+
+// OrderedMap is a map that iterates its entries in the order the keys were first inserted.
+// Lookups, insertions and removals take constant time, the entries are kept in a doubly linked list.
+// The zero value is an empty map ready to use. OrderedMap must not be copied after the first use and is not thread-safe.
+type OrderedMap[K comparable, V any] struct {
+	m    map[K]*entry[K, V]
+	root entry[K, V] // root.next is the first entry and root.prev is the last one
+}
+
+// New creates a new empty OrderedMap.
+func New[K comparable, V any]() *OrderedMap[K, V] {
+	return &OrderedMap[K, V]{}
+}
+
+func (o *OrderedMap[K, V]) lazyInit() {
+	if o.m == nil {
+		o.m = make(map[K]*entry[K, V])
+		o.root.next = &o.root
+		o.root.prev = &o.root
+	}
+}
+
+// Set sets the value for the key. A new key is appended to the end, an existing one keeps its position.
+func (o *OrderedMap[K, V]) Set(key K, value V) {
+	o.lazyInit()
+	if e, ok := o.m[key]; ok {
+		e.value = value
+		return
+	}
+
+	e := &entry[K, V]{key: key, value: value}
+	o.insertBefore(e, &o.root)
+	o.m[key] = e
+}
+
+// Get returns the value for the key and whether the key is present.
+func (o *OrderedMap[K, V]) Get(key K) (V, bool) {
+	if e, ok := o.m[key]; ok {
+		return e.value, true
+	}
+
+	var zero V
+	return zero, false
+}
+
+// Has reports whether the key is present.
+func (o *OrderedMap[K, V]) Has(key K) bool {
+	_, ok := o.m[key]
+	return ok
+}
+
+// Delete removes the key and reports whether it was present.
+func (o *OrderedMap[K, V]) Delete(key K) bool {
+	e, ok := o.m[key]
+	if !ok {
+		return false
+	}
+	o.unlink(e)
+	delete(o.m, key)
+
+	return true
+}
+
+// Clear removes all the entries.
+func (o *OrderedMap[K, V]) Clear() {
+	o.m = nil
+	o.lazyInit()
+}
+
+// MoveToFront moves the key to the front, making it the first one to iterate, and reports whether it was present.
+func (o *OrderedMap[K, V]) MoveToFront(key K) bool {
+	e, ok := o.m[key]
+	if !ok {
+		return false
+	}
+	o.unlink(e)
+	o.insertBefore(e, o.root.next)
+
+	return true
+}
+
+// MoveToBack moves the key to the back, making it the last one to iterate, and reports whether it was present.
+// Moving the accessed keys to the back keeps the least recently used key at the front, which is how an LRU works.
+func (o *OrderedMap[K, V]) MoveToBack(key K) bool {
+	e, ok := o.m[key]
+	if !ok {
+		return false
+	}
+	o.unlink(e)
+	o.insertBefore(e, &o.root)
+
+	return true
+}
+
+// Front returns the first entry, ok is false if the map is empty.
+func (o *OrderedMap[K, V]) Front() (key K, value V, ok bool) {
+	if len(o.m) == 0 {
+		return key, value, false
+	}
+
+	return o.root.next.key, o.root.next.value, true
+}
+
+// Back returns the last entry, ok is false if the map is empty.
+func (o *OrderedMap[K, V]) Back() (key K, value V, ok bool) {
+	if len(o.m) == 0 {
+		return key, value, false
+	}
+
+	return o.root.prev.key, o.root.prev.value, true
+}
+
+// Len returns the number of entries.
+func (o *OrderedMap[K, V]) Len() int {
+	return len(o.m)
+}
+
+// Keys returns the keys in their order.
+func (o *OrderedMap[K, V]) Keys() []K {
+	result := make([]K, 0, len(o.m))
+	for k := range o.All() {
+		result = append(result, k)
+	}
+
+	return result
+}
+
+// Values returns the values in the order of their keys.
+func (o *OrderedMap[K, V]) Values() []V {
+	result := make([]V, 0, len(o.m))
+	for _, v := range o.All() {
+		result = append(result, v)
+	}
+
+	return result
+}
+
+// All returns an iterator over the entries in their order. Deleting entries during the iteration is safe.
+func (o *OrderedMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		if o.m == nil {
+			return
+		}
+		for e := o.root.next; e != &o.root; e = e.next {
+			if !yield(e.key, e.value) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over the entries in the reverse order.
+func (o *OrderedMap[K, V]) Backward() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		if o.m == nil {
+			return
+		}
+		for e := o.root.prev; e != &o.root; e = e.prev {
+			if !yield(e.key, e.value) {
+				return
+			}
+		}
+	}
+}
+
+func (o *OrderedMap[K, V]) unlink(e *entry[K, V]) {
+	// The links of the entry are kept, so iterators standing on it can proceed to the next entry.
+	e.prev.next = e.next
+	e.next.prev = e.prev
+}
+
+// insertBefore links the detached entry before the mark.
+func (o *OrderedMap[K, V]) insertBefore(e, mark *entry[K, V]) {
+	e.prev = mark.prev
+	e.next = mark
+	mark.prev.next = e
+	mark.prev = e
+}
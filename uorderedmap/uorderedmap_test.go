@@ -0,0 +1,156 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package uorderedmap_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/kordax/basic-utils/uorderedmap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrderedMap_SetGet(t *testing.T) {
+	m := uorderedmap.New[string, int]()
+	m.Set("c", 3)
+	m.Set("a", 1)
+	m.Set("b", 2)
+	m.Set("c", 30)
+
+	v, ok := m.Get("c")
+	assert.True(t, ok)
+	assert.Equal(t, 30, v)
+	_, ok = m.Get("missing")
+	assert.False(t, ok)
+
+	assert.Equal(t, 3, m.Len())
+	assert.Equal(t, []string{"c", "a", "b"}, m.Keys(), "an update must keep the key position")
+	assert.Equal(t, []int{30, 1, 2}, m.Values())
+
+	var keys []string
+	for k := range m.All() {
+		keys = append(keys, k)
+		if k == "a" {
+			break
+		}
+	}
+	assert.Equal(t, []string{"c", "a"}, keys)
+}
+
+func TestOrderedMap_ZeroValue(t *testing.T) {
+	var m uorderedmap.OrderedMap[int, string]
+	assert.Zero(t, m.Len())
+	assert.Empty(t, m.Keys())
+	_, ok := m.Get(1)
+	assert.False(t, ok)
+
+	m.Set(1, "one")
+	assert.Equal(t, []int{1}, m.Keys())
+}
+
+func TestOrderedMap_Delete(t *testing.T) {
+	m := uorderedmap.New[string, int]()
+	m.Set("a", 1)
+	m.Set("b", 2)
+	m.Set("c", 3)
+
+	assert.True(t, m.Delete("b"))
+	assert.False(t, m.Delete("b"))
+	assert.False(t, m.Has("b"))
+	assert.True(t, m.Has("a"))
+	assert.Equal(t, []string{"a", "c"}, m.Keys())
+
+	m.Set("b", 20)
+	assert.Equal(t, []string{"a", "c", "b"}, m.Keys(), "a re-added key must go to the back")
+
+	for k := range m.All() {
+		m.Delete(k)
+	}
+	assert.Zero(t, m.Len())
+
+	m.Set("x", 1)
+	m.Clear()
+	assert.Zero(t, m.Len())
+	assert.Empty(t, m.Keys())
+	_, _, ok := m.Front()
+	assert.False(t, ok)
+}
+
+func TestOrderedMap_Move(t *testing.T) {
+	m := uorderedmap.New[int, string]()
+	for i, v := range []string{"zero", "one", "two", "three"} {
+		m.Set(i, v)
+	}
+
+	assert.True(t, m.MoveToFront(2))
+	assert.Equal(t, []int{2, 0, 1, 3}, m.Keys())
+	assert.True(t, m.MoveToBack(0))
+	assert.Equal(t, []int{2, 1, 3, 0}, m.Keys())
+	assert.False(t, m.MoveToFront(10))
+	assert.False(t, m.MoveToBack(10))
+
+	k, v, ok := m.Front()
+	assert.True(t, ok)
+	assert.Equal(t, 2, k)
+	assert.Equal(t, "two", v)
+	k, v, ok = m.Back()
+	assert.True(t, ok)
+	assert.Equal(t, 0, k)
+	assert.Equal(t, "zero", v)
+
+	var keys []int
+	for k := range m.Backward() {
+		keys = append(keys, k)
+	}
+	assert.Equal(t, []int{0, 3, 1, 2}, keys)
+}
+
+func TestOrderedMap_JSON(t *testing.T) {
+	m := uorderedmap.New[string, int]()
+	m.Set("zeta", 1)
+	m.Set("alpha", 2)
+	m.Set("mid", 3)
+
+	data, err := json.Marshal(m)
+	require.NoError(t, err)
+	assert.Equal(t, `{"zeta":1,"alpha":2,"mid":3}`, string(data))
+
+	var decoded uorderedmap.OrderedMap[string, int]
+	require.NoError(t, json.Unmarshal([]byte(`{"b": 2, "c": 3, "a": 1, "b": 20}`), &decoded))
+	assert.Equal(t, []string{"b", "c", "a"}, decoded.Keys())
+	assert.Equal(t, []int{20, 3, 1}, decoded.Values())
+
+	empty, err := json.Marshal(uorderedmap.New[string, int]())
+	require.NoError(t, err)
+	assert.Equal(t, `{}`, string(empty))
+
+	assert.Error(t, json.Unmarshal([]byte(`[1, 2]`), &decoded))
+	assert.Error(t, json.Unmarshal([]byte(`{"a": "str"}`), &decoded))
+}
+
+func TestOrderedMap_JSONKeys(t *testing.T) {
+	m := uorderedmap.New[int, []string]()
+	m.Set(10, []string{"x"})
+	m.Set(-1, nil)
+
+	data, err := json.Marshal(m)
+	require.NoError(t, err)
+	assert.Equal(t, `{"10":["x"],"-1":null}`, string(data))
+
+	decoded := uorderedmap.New[int, []string]()
+	require.NoError(t, json.Unmarshal(data, decoded))
+	assert.Equal(t, []int{10, -1}, decoded.Keys())
+
+	assert.Error(t, json.Unmarshal([]byte(`{"nan": []}`), decoded))
+
+	type point struct{ X, Y int }
+	points := uorderedmap.New[point, int]()
+	points.Set(point{1, 2}, 1)
+	_, err = json.Marshal(points)
+	assert.Error(t, err)
+}
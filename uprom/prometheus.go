@@ -0,0 +1,321 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package uprom
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+type metricKind string
+
+const (
+	kindCounter   metricKind = "counter"
+	kindGauge     metricKind = "gauge"
+	kindHistogram metricKind = "histogram"
+)
+
+/*
+PrometheusRegistry is a Registry keeping the metrics in memory and exposing them in the Prometheus text format,
+so they can be scraped by Prometheus without the client library:
+
+	registry := uprom.NewPrometheusRegistry()
+	http.Handle("/metrics", registry)
+
+Registering a metric name with a different type, help or histogram buckets panics, as it's a programming error.
+*/
+type PrometheusRegistry struct {
+	mtx      sync.RWMutex
+	families map[string]*family
+}
+
+type family struct {
+	kind    metricKind
+	help    string
+	buckets []float64
+	series  map[string]*series
+}
+
+type series struct {
+	labels []Label
+	value  atomicFloat
+	// histograms only, the value holds the sum of the observations
+	buckets []float64
+	counts  []atomic.Uint64
+	count   atomic.Uint64
+}
+
+// NewPrometheusRegistry creates an empty PrometheusRegistry.
+func NewPrometheusRegistry() *PrometheusRegistry {
+	return &PrometheusRegistry{families: make(map[string]*family)}
+}
+
+// Counter returns the counter with the name and labels, creating it if needed.
+func (r *PrometheusRegistry) Counter(name, help string, labels ...Label) Counter {
+	return (*counter)(r.series(name, help, kindCounter, nil, labels))
+}
+
+// Gauge returns the gauge with the name and labels, creating it if needed.
+func (r *PrometheusRegistry) Gauge(name, help string, labels ...Label) Gauge {
+	return (*gauge)(r.series(name, help, kindGauge, nil, labels))
+}
+
+// Histogram returns the histogram with the name and labels, creating it if needed.
+func (r *PrometheusRegistry) Histogram(name, help string, buckets []float64, labels ...Label) Histogram {
+	if len(buckets) == 0 {
+		buckets = DefaultBuckets
+	}
+	if !slices.IsSorted(buckets) {
+		panic(fmt.Sprintf("uprom: buckets of %s are not sorted", name))
+	}
+	return (*histogram)(r.series(name, help, kindHistogram, buckets, labels))
+}
+
+func (r *PrometheusRegistry) series(name, help string, kind metricKind, buckets []float64, labels []Label) *series {
+	labels = slices.Clone(labels)
+	sort.SliceStable(labels, func(i, j int) bool { return labels[i].Name < labels[j].Name })
+	id := labelsID(labels)
+
+	r.mtx.RLock()
+	f, ok := r.families[name]
+	var s *series
+	if ok {
+		s = f.series[id]
+	}
+	r.mtx.RUnlock()
+	if s != nil {
+		f.check(name, help, kind, buckets)
+		return s
+	}
+
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	f, ok = r.families[name]
+	if !ok {
+		f = &family{kind: kind, help: help, buckets: slices.Clone(buckets), series: make(map[string]*series)}
+		r.families[name] = f
+	}
+	f.check(name, help, kind, buckets)
+	if s = f.series[id]; s == nil {
+		s = &series{labels: labels}
+		if kind == kindHistogram {
+			s.buckets = f.buckets
+			s.counts = make([]atomic.Uint64, len(buckets))
+		}
+		f.series[id] = s
+	}
+
+	return s
+}
+
+func (f *family) check(name, help string, kind metricKind, buckets []float64) {
+	if f.kind != kind || f.help != help || !slices.Equal(f.buckets, buckets) {
+		panic(fmt.Sprintf("uprom: %s is already registered as a %s with different help or buckets", name, f.kind))
+	}
+}
+
+// WriteTo writes all the metrics in the Prometheus text format, ordered by their names and labels.
+func (r *PrometheusRegistry) WriteTo(w io.Writer) (int64, error) {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+
+	names := make([]string, 0, len(r.families))
+	for name := range r.families {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	cw := &countingWriter{w: bufio.NewWriter(w)}
+	for _, name := range names {
+		f := r.families[name]
+		if f.help != "" {
+			fmt.Fprintf(cw, "# HELP %s %s\n", name, escapeHelp(f.help))
+		}
+		fmt.Fprintf(cw, "# TYPE %s %s\n", name, f.kind)
+
+		ids := make([]string, 0, len(f.series))
+		for id := range f.series {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		for _, id := range ids {
+			f.series[id].write(cw, name, f)
+		}
+	}
+	if err := cw.w.Flush(); err != nil && cw.err == nil {
+		cw.err = err
+	}
+
+	return cw.n, cw.err
+}
+
+// ServeHTTP writes the metrics in the Prometheus text format, so the registry can be used as the /metrics handler.
+func (r *PrometheusRegistry) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_, _ = r.WriteTo(w)
+}
+
+func (s *series) write(w io.Writer, name string, f *family) {
+	if f.kind != kindHistogram {
+		fmt.Fprintf(w, "%s%s %s\n", name, formatLabels(s.labels), formatFloat(s.value.load()))
+		return
+	}
+
+	// the bucket counters are not updated atomically together, so the cumulative counts are capped by the total
+	// to keep the exposition consistent while observations are being made
+	total := s.count.Load()
+	var cumulative uint64
+	for i, bound := range f.buckets {
+		cumulative += s.counts[i].Load()
+		fmt.Fprintf(w, "%s_bucket%s %d\n", name, formatLabels(s.labels, L("le", formatFloat(bound))), min(cumulative, total))
+	}
+	fmt.Fprintf(w, "%s_bucket%s %d\n", name, formatLabels(s.labels, L("le", "+Inf")), total)
+	fmt.Fprintf(w, "%s_sum%s %s\n", name, formatLabels(s.labels), formatFloat(s.value.load()))
+	fmt.Fprintf(w, "%s_count%s %d\n", name, formatLabels(s.labels), total)
+}
+
+type counter series
+
+func (c *counter) Inc() {
+	c.value.add(1)
+}
+
+func (c *counter) Add(delta float64) {
+	if delta > 0 {
+		c.value.add(delta)
+	}
+}
+
+type gauge series
+
+func (g *gauge) Set(value float64) {
+	g.value.store(value)
+}
+
+func (g *gauge) Add(delta float64) {
+	g.value.add(delta)
+}
+
+type histogram series
+
+func (h *histogram) Observe(value float64) {
+	if math.IsNaN(value) {
+		return
+	}
+	if i := sort.SearchFloat64s(h.buckets, value); i < len(h.counts) {
+		h.counts[i].Add(1)
+	}
+	h.value.add(value)
+	h.count.Add(1)
+}
+
+// atomicFloat is a float64 updated atomically with compare-and-swap.
+type atomicFloat struct {
+	bits atomic.Uint64
+}
+
+func (f *atomicFloat) load() float64 {
+	return math.Float64frombits(f.bits.Load())
+}
+
+func (f *atomicFloat) store(v float64) {
+	f.bits.Store(math.Float64bits(v))
+}
+
+func (f *atomicFloat) add(delta float64) {
+	for {
+		old := f.bits.Load()
+		if f.bits.CompareAndSwap(old, math.Float64bits(math.Float64frombits(old)+delta)) {
+			return
+		}
+	}
+}
+
+type countingWriter struct {
+	w   *bufio.Writer
+	n   int64
+	err error
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	if c.err != nil {
+		return 0, c.err
+	}
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	c.err = err
+
+	return n, err
+}
+
+func labelsID(labels []Label) string {
+	var b strings.Builder
+	for _, l := range labels {
+		b.WriteString(l.Name)
+		b.WriteByte(0)
+		b.WriteString(l.Value)
+		b.WriteByte(0)
+	}
+
+	return b.String()
+}
+
+func formatLabels(labels []Label, extra ...Label) string {
+	if len(labels)+len(extra) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteByte('{')
+	for i, l := range append(slices.Clip(labels), extra...) {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(l.Name)
+		b.WriteString(`="`)
+		b.WriteString(escapeLabelValue(l.Value))
+		b.WriteByte('"')
+	}
+	b.WriteByte('}')
+
+	return b.String()
+}
+
+var (
+	helpReplacer  = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+	labelReplacer = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+)
+
+func escapeHelp(s string) string {
+	return helpReplacer.Replace(s)
+}
+
+func escapeLabelValue(s string) string {
+	return labelReplacer.Replace(s)
+}
+
+func formatFloat(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	default:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	}
+}
@@ -0,0 +1,101 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package uprom_test
+
+import (
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/kordax/basic-utils/uprom"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrometheusRegistry_WriteTo(t *testing.T) {
+	registry := uprom.NewPrometheusRegistry()
+
+	registry.Counter("requests_total", "Served requests.", uprom.L("method", "GET"), uprom.L("code", "200")).Add(3)
+	registry.Counter("requests_total", "Served requests.", uprom.L("code", "200"), uprom.L("method", "GET")).Inc()
+	registry.Counter("requests_total", "Served requests.", uprom.L("code", "500"), uprom.L("method", "GET")).Add(-1)
+	registry.Gauge("queue_depth", "Pending jobs,\nper queue.", uprom.L("queue", `a"b`)).Set(5)
+	registry.Gauge("queue_depth", "Pending jobs,\nper queue.", uprom.L("queue", `a"b`)).Add(-2)
+
+	latency := registry.Histogram("latency_seconds", "", []float64{0.1, 1})
+	for _, v := range []float64{0.05, 0.1, 0.5, 2, math.NaN()} {
+		latency.Observe(v)
+	}
+
+	var out strings.Builder
+	n, err := registry.WriteTo(&out)
+	require.NoError(t, err)
+	assert.EqualValues(t, out.Len(), n)
+	assert.Equal(t, `# TYPE latency_seconds histogram
+latency_seconds_bucket{le="0.1"} 2
+latency_seconds_bucket{le="1"} 3
+latency_seconds_bucket{le="+Inf"} 4
+latency_seconds_sum 2.65
+latency_seconds_count 4
+# HELP queue_depth Pending jobs,\nper queue.
+# TYPE queue_depth gauge
+queue_depth{queue="a\"b"} 3
+# HELP requests_total Served requests.
+# TYPE requests_total counter
+requests_total{code="200",method="GET"} 4
+requests_total{code="500",method="GET"} 0
+`, out.String())
+}
+
+func TestPrometheusRegistry_Conflicts(t *testing.T) {
+	registry := uprom.NewPrometheusRegistry()
+	registry.Counter("jobs", "Jobs.")
+
+	assert.Panics(t, func() { registry.Gauge("jobs", "Jobs.") })
+	assert.Panics(t, func() { registry.Counter("jobs", "Other help.") })
+	assert.Panics(t, func() { registry.Histogram("unsorted", "", []float64{2, 1}) })
+
+	registry.Histogram("sizes", "", nil)
+	assert.NotPanics(t, func() { registry.Histogram("sizes", "", uprom.DefaultBuckets) })
+	assert.Panics(t, func() { registry.Histogram("sizes", "", []float64{1}) })
+}
+
+func TestPrometheusRegistry_ServeHTTP(t *testing.T) {
+	registry := uprom.NewPrometheusRegistry()
+	registry.Counter("hits", "").Inc()
+
+	rec := httptest.NewRecorder()
+	registry.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Header().Get("Content-Type"), "text/plain")
+	assert.Equal(t, "# TYPE hits counter\nhits 1\n", rec.Body.String())
+}
+
+func TestPrometheusRegistry_Concurrent(t *testing.T) {
+	registry := uprom.NewPrometheusRegistry()
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 1000 {
+				registry.Counter("ops_total", "").Inc()
+				registry.Histogram("op_seconds", "", nil).Observe(0.01)
+			}
+		}()
+	}
+	wg.Wait()
+
+	var out strings.Builder
+	_, err := registry.WriteTo(&out)
+	require.NoError(t, err)
+	assert.Contains(t, out.String(), "ops_total 8000\n")
+	assert.Contains(t, out.String(), "op_seconds_count 8000\n")
+}
@@ -0,0 +1,89 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+// Package uprom is a minimal metrics facade, so the library internals and applications can report metrics
+// without depending on any particular metrics library.
+package uprom
+
+// Label is a single metric dimension.
+type Label struct {
+	Name  string
+	Value string
+}
+
+// L is a short constructor for Label.
+func L(name, value string) Label {
+	return Label{Name: name, Value: value}
+}
+
+// Counter is a metric that only goes up, e.g. the number of served requests.
+// Implementations must be safe for concurrent use.
+type Counter interface {
+	// Inc increments the counter by 1.
+	Inc()
+	// Add increases the counter by the delta. Negative deltas are ignored.
+	Add(delta float64)
+}
+
+// Gauge is a metric that can go up and down, e.g. the queue depth.
+// Implementations must be safe for concurrent use.
+type Gauge interface {
+	Set(value float64)
+	Add(delta float64)
+}
+
+// Histogram samples observations, e.g. latencies, into buckets.
+// Implementations must be safe for concurrent use.
+type Histogram interface {
+	Observe(value float64)
+}
+
+// DefaultBuckets are the histogram buckets used when none are provided, suited for latencies in seconds.
+var DefaultBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// Registry creates the metrics. Requesting a metric with the same name and labels returns the same metric,
+// so the components can create their metrics on demand.
+// Implementations must be safe for concurrent use.
+type Registry interface {
+	Counter(name, help string, labels ...Label) Counter
+	Gauge(name, help string, labels ...Label) Gauge
+	// Histogram returns a histogram with the provided bucket upper bounds sorted in ascending order,
+	// DefaultBuckets are used if none are provided.
+	Histogram(name, help string, buckets []float64, labels ...Label) Histogram
+}
+
+type nopRegistry struct{}
+
+type nopMetric struct{}
+
+// Nop returns a Registry whose metrics discard all the values.
+// This is the default registry for all the components that accept a Registry.
+func Nop() Registry {
+	return nopRegistry{}
+}
+
+func (nopRegistry) Counter(string, string, ...Label) Counter { return nopMetric{} }
+
+func (nopRegistry) Gauge(string, string, ...Label) Gauge { return nopMetric{} }
+
+func (nopRegistry) Histogram(string, string, []float64, ...Label) Histogram { return nopMetric{} }
+
+func (nopMetric) Inc() {}
+
+func (nopMetric) Add(float64) {}
+
+func (nopMetric) Set(float64) {}
+
+func (nopMetric) Observe(float64) {}
+
+// OrNop returns the provided registry or a Nop registry if it is nil.
+func OrNop(registry Registry) Registry {
+	if registry == nil {
+		return Nop()
+	}
+
+	return registry
+}
@@ -0,0 +1,36 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package uprom_test
+
+import (
+	"testing"
+
+	"github.com/kordax/basic-utils/uprom"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestL(t *testing.T) {
+	assert.Equal(t, uprom.Label{Name: "cache", Value: "users"}, uprom.L("cache", "users"))
+}
+
+func TestNop(t *testing.T) {
+	registry := uprom.Nop()
+	assert.NotPanics(t, func() {
+		registry.Counter("requests_total", "").Inc()
+		registry.Counter("requests_total", "").Add(2)
+		registry.Gauge("queue_depth", "").Set(1)
+		registry.Gauge("queue_depth", "").Add(-1)
+		registry.Histogram("latency_seconds", "", nil).Observe(0.1)
+	})
+}
+
+func TestOrNop(t *testing.T) {
+	assert.Equal(t, uprom.Nop(), uprom.OrNop(nil))
+
+	registry := uprom.NewPrometheusRegistry()
+	assert.Same(t, registry, uprom.OrNop(registry))
+}
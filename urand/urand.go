@@ -0,0 +1,173 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+/*
+Package urand provides random strings, numbers, bytes and choices on top of an injectable source.
+Use NewSeeded in tests to get reproducible values and NewCrypto whenever the values must be unpredictable,
+e.g. for tokens. The package-level functions use a randomly seeded, non-cryptographic source.
+*/
+package urand
+
+import (
+	crand "crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// Common alphabets for String.
+const (
+	Digits       = "0123456789"
+	LowerLetters = "abcdefghijklmnopqrstuvwxyz"
+	UpperLetters = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	Letters      = LowerLetters + UpperLetters
+	AlphaNumeric = Letters + Digits
+	Hex          = "0123456789abcdef"
+)
+
+var defaultRand = NewSeeded(time.Now().UnixNano())
+
+// Rand generates random values from a source. Unlike rand.Rand, it is safe for concurrent use.
+type Rand struct {
+	mtx sync.Mutex
+	r   *rand.Rand
+}
+
+// New creates a Rand drawing values from the source.
+func New(src rand.Source) *Rand {
+	return &Rand{r: rand.New(src)}
+}
+
+// NewSeeded creates a Rand producing the same sequence of values for the same seed, which makes tests reproducible.
+func NewSeeded(seed int64) *Rand {
+	return New(rand.NewSource(seed))
+}
+
+// NewCrypto creates a Rand backed by crypto/rand, which is suitable for security-sensitive values.
+func NewCrypto() *Rand {
+	return New(CryptoSource{})
+}
+
+// String returns a string of n characters picked from the alphabet, which may contain any Unicode characters.
+// It panics if the alphabet is empty.
+func (r *Rand) String(n int, alphabet string) string {
+	if alphabet == "" {
+		panic("urand: empty alphabet")
+	}
+
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	if len(alphabet) == utf8.RuneCountInString(alphabet) {
+		result := make([]byte, n)
+		for i := range result {
+			result[i] = alphabet[r.r.Intn(len(alphabet))]
+		}
+		return string(result)
+	}
+
+	runes := []rune(alphabet)
+	result := make([]rune, n)
+	for i := range result {
+		result[i] = runes[r.r.Intn(len(runes))]
+	}
+
+	return string(result)
+}
+
+// Int64Between returns a uniformly distributed number within [lo, hi], both bounds inclusive.
+// It panics if lo > hi.
+func (r *Rand) Int64Between(lo, hi int64) int64 {
+	if lo > hi {
+		panic(fmt.Sprintf("urand: invalid range [%d, %d]", lo, hi))
+	}
+
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	span := uint64(hi-lo) + 1
+	switch {
+	case span == 0:
+		// the whole int64 range
+		return int64(r.r.Uint64())
+	case span <= math.MaxInt64:
+		return lo + r.r.Int63n(int64(span))
+	default:
+		// rejection sampling keeps the distribution uniform for the spans exceeding Int63n
+		for {
+			if v := r.r.Uint64(); v < span {
+				return lo + int64(v)
+			}
+		}
+	}
+}
+
+// Intn returns a uniformly distributed number within [0, n). It panics if n <= 0.
+func (r *Rand) Intn(n int) int {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	return r.r.Intn(n)
+}
+
+// Bytes returns n random bytes.
+func (r *Rand) Bytes(n int) []byte {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	result := make([]byte, n)
+	_, _ = r.r.Read(result)
+
+	return result
+}
+
+// ChoiceOf returns a random element of the values using the Rand. It panics if the values are empty.
+func ChoiceOf[T any](r *Rand, values []T) T {
+	if len(values) == 0 {
+		panic("urand: choice from an empty slice")
+	}
+
+	return values[r.Intn(len(values))]
+}
+
+// String returns a string of n characters picked from the alphabet. See Rand.String.
+func String(n int, alphabet string) string {
+	return defaultRand.String(n, alphabet)
+}
+
+// Int64Between returns a uniformly distributed number within [lo, hi]. See Rand.Int64Between.
+func Int64Between(lo, hi int64) int64 {
+	return defaultRand.Int64Between(lo, hi)
+}
+
+// Bytes returns n random bytes.
+func Bytes(n int) []byte {
+	return defaultRand.Bytes(n)
+}
+
+// Choice returns a random element of the values. It panics if the values are empty.
+func Choice[T any](values []T) T {
+	return ChoiceOf(defaultRand, values)
+}
+
+// CryptoSource is a rand.Source64 backed by crypto/rand. Seeding it has no effect.
+type CryptoSource struct{}
+
+func (CryptoSource) Int63() int64 {
+	return int64(CryptoSource{}.Uint64() & math.MaxInt64)
+}
+
+func (CryptoSource) Uint64() uint64 {
+	var b [8]byte
+	if _, err := crand.Read(b[:]); err != nil {
+		panic(fmt.Sprintf("urand: crypto/rand failed: %v", err))
+	}
+
+	return binary.LittleEndian.Uint64(b[:])
+}
+
+func (CryptoSource) Seed(int64) {}
@@ -0,0 +1,89 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package urand_test
+
+import (
+	"math"
+	"strings"
+	"sync"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/kordax/basic-utils/urand"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSeeded_Reproducible(t *testing.T) {
+	r1, r2 := urand.NewSeeded(42), urand.NewSeeded(42)
+	assert.Equal(t, r1.String(16, urand.AlphaNumeric), r2.String(16, urand.AlphaNumeric))
+	assert.Equal(t, r1.Int64Between(-100, 100), r2.Int64Between(-100, 100))
+	assert.Equal(t, r1.Bytes(8), r2.Bytes(8))
+	assert.Equal(t, urand.ChoiceOf(r1, []string{"a", "b", "c"}), urand.ChoiceOf(r2, []string{"a", "b", "c"}))
+}
+
+func TestRand_String(t *testing.T) {
+	for _, r := range []*urand.Rand{urand.NewSeeded(1), urand.NewCrypto()} {
+		s := r.String(64, urand.Hex)
+		assert.Len(t, s, 64)
+		assert.Empty(t, strings.Trim(s, urand.Hex))
+
+		u := r.String(10, "αβγ")
+		assert.Equal(t, 10, utf8.RuneCountInString(u))
+		assert.Empty(t, strings.Trim(u, "αβγ"))
+	}
+	assert.Empty(t, urand.String(0, urand.Digits))
+	assert.Panics(t, func() { urand.String(1, "") })
+}
+
+func TestRand_Int64Between(t *testing.T) {
+	r := urand.NewSeeded(7)
+	seen := make(map[int64]bool)
+	for i := 0; i < 1000; i++ {
+		v := r.Int64Between(-2, 2)
+		assert.True(t, v >= -2 && v <= 2)
+		seen[v] = true
+	}
+	assert.Len(t, seen, 5, "both bounds must be inclusive")
+
+	assert.Equal(t, int64(3), r.Int64Between(3, 3))
+	for i := 0; i < 100; i++ {
+		v := r.Int64Between(math.MinInt64+1, math.MaxInt64)
+		assert.NotEqual(t, int64(math.MinInt64), v)
+	}
+	r.Int64Between(math.MinInt64, math.MaxInt64)
+	assert.Positive(t, urand.Int64Between(1, math.MaxInt64))
+	assert.Panics(t, func() { r.Int64Between(1, 0) })
+}
+
+func TestChoice(t *testing.T) {
+	values := []int{1, 2, 3}
+	for i := 0; i < 100; i++ {
+		assert.Contains(t, values, urand.Choice(values))
+	}
+	assert.Panics(t, func() { urand.Choice([]int{}) })
+}
+
+func TestBytes(t *testing.T) {
+	assert.Len(t, urand.Bytes(33), 33)
+	assert.NotEqual(t, urand.NewCrypto().Bytes(16), urand.NewCrypto().Bytes(16))
+}
+
+func TestRand_Concurrent(t *testing.T) {
+	r := urand.NewSeeded(1)
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				r.String(8, urand.Letters)
+				r.Int64Between(0, 10)
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,173 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package uref
+
+import (
+	"reflect"
+	"time"
+	"unsafe"
+)
+
+// Cloner is implemented by types that know how to copy themselves.
+type Cloner[T any] interface {
+	Clone() T
+}
+
+// Clone returns a copy of v. If v implements Cloner[T], its Clone method is used,
+// otherwise a shallow copy is returned, so pointers, slices and maps inside v are shared with the original.
+func Clone[T any](v T) T {
+	if c, ok := any(v).(Cloner[T]); ok {
+		return c.Clone()
+	}
+
+	return v
+}
+
+// DeepCopy returns a deep copy of v using reflection.
+// Pointers, slices, maps, arrays, interfaces and structs (including unexported fields) are copied recursively.
+// Values implementing Cloner of their own type are copied with their Clone method,
+// so Clone implementations must not call DeepCopy on their own receiver.
+// Cyclic references are detected, so the copy has the same reference graph as the original.
+// Channels, functions and unsafe pointers are shared with the original, time.Time is copied as is.
+func DeepCopy[T any](v T) T {
+	src := reflect.ValueOf(&v).Elem()
+	dst := reflect.New(src.Type()).Elem()
+	c := &copier{visited: make(map[visitKey]reflect.Value)}
+	c.copy(dst, src)
+
+	result, _ := dst.Interface().(T) // nil interfaces fail the assertion and produce zero T
+	return result
+}
+
+type visitKey struct {
+	ptr uintptr
+	len int
+	typ reflect.Type
+}
+
+type copier struct {
+	visited map[visitKey]reflect.Value
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+func (c *copier) copy(dst, src reflect.Value) {
+	if cloned, ok := cloneWithMethod(src); ok {
+		dst.Set(cloned)
+		return
+	}
+
+	switch src.Kind() {
+	case reflect.Pointer:
+		if src.IsNil() {
+			return
+		}
+		key := visitKey{ptr: src.Pointer(), typ: src.Type()}
+		if seen, ok := c.visited[key]; ok {
+			dst.Set(seen)
+			return
+		}
+		ptr := reflect.New(src.Type().Elem())
+		c.visited[key] = ptr
+		c.copy(ptr.Elem(), src.Elem())
+		dst.Set(ptr)
+	case reflect.Interface:
+		if src.IsNil() {
+			return
+		}
+		elem := addressable(src.Elem())
+		value := reflect.New(elem.Type()).Elem()
+		c.copy(value, elem)
+		dst.Set(value)
+	case reflect.Slice:
+		if src.IsNil() {
+			return
+		}
+		key := visitKey{ptr: src.Pointer(), len: src.Len(), typ: src.Type()}
+		if seen, ok := c.visited[key]; ok {
+			dst.Set(seen)
+			return
+		}
+		slice := reflect.MakeSlice(src.Type(), src.Len(), src.Cap())
+		c.visited[key] = slice
+		for i := 0; i < src.Len(); i++ {
+			c.copy(slice.Index(i), src.Index(i))
+		}
+		dst.Set(slice)
+	case reflect.Array:
+		for i := 0; i < src.Len(); i++ {
+			c.copy(dst.Index(i), src.Index(i))
+		}
+	case reflect.Map:
+		if src.IsNil() {
+			return
+		}
+		key := visitKey{ptr: src.Pointer(), typ: src.Type()}
+		if seen, ok := c.visited[key]; ok {
+			dst.Set(seen)
+			return
+		}
+		m := reflect.MakeMapWithSize(src.Type(), src.Len())
+		c.visited[key] = m
+		iter := src.MapRange()
+		for iter.Next() {
+			k := reflect.New(src.Type().Key()).Elem()
+			c.copy(k, addressable(iter.Key()))
+			v := reflect.New(src.Type().Elem()).Elem()
+			c.copy(v, addressable(iter.Value()))
+			m.SetMapIndex(k, v)
+		}
+		dst.Set(m)
+	case reflect.Struct:
+		if src.Type() == timeType {
+			dst.Set(src)
+			return
+		}
+		for i := 0; i < src.NumField(); i++ {
+			c.copy(accessible(dst.Field(i)), accessible(src.Field(i)))
+		}
+	default:
+		dst.Set(src)
+	}
+}
+
+// cloneWithMethod calls Clone method of the value if it has signature func() T, where T is the value type.
+func cloneWithMethod(v reflect.Value) (reflect.Value, bool) {
+	if v.Kind() == reflect.Interface || (v.Kind() == reflect.Pointer && v.IsNil()) || !v.CanInterface() {
+		return reflect.Value{}, false
+	}
+	method := v.MethodByName("Clone")
+	if !method.IsValid() {
+		return reflect.Value{}, false
+	}
+	mt := method.Type()
+	if mt.NumIn() != 0 || mt.NumOut() != 1 || mt.Out(0) != v.Type() {
+		return reflect.Value{}, false
+	}
+
+	return method.Call(nil)[0], true
+}
+
+// accessible makes an addressable value settable and interfaceable, even if it was obtained from an unexported field.
+func accessible(v reflect.Value) reflect.Value {
+	if v.CanSet() {
+		return v
+	}
+
+	return reflect.NewAt(v.Type(), unsafe.Pointer(v.UnsafeAddr())).Elem()
+}
+
+// addressable returns an addressable copy of the value.
+func addressable(v reflect.Value) reflect.Value {
+	if v.CanAddr() {
+		return v
+	}
+	value := reflect.New(v.Type()).Elem()
+	value.Set(v)
+
+	return value
+}
@@ -0,0 +1,113 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package uref_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kordax/basic-utils/uref"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type clonerStruct struct {
+	values []int
+}
+
+func (c clonerStruct) Clone() clonerStruct {
+	return clonerStruct{values: append([]int{}, c.values...)}
+}
+
+type node struct {
+	Name     string
+	Next     *node
+	Children []*node
+	Attrs    map[string]any
+	secret   *int
+	Created  time.Time
+}
+
+func TestClone(t *testing.T) {
+	original := clonerStruct{values: []int{1, 2}}
+	cloned := uref.Clone(original)
+	cloned.values[0] = 10
+	assert.Equal(t, []int{1, 2}, original.values, "Cloner implementation must be used")
+
+	shallow := []int{1, 2}
+	copied := uref.Clone(shallow)
+	copied[0] = 10
+	assert.Equal(t, 10, shallow[0], "Values without Cloner are copied shallowly")
+}
+
+func TestDeepCopy(t *testing.T) {
+	secret := 42
+	original := &node{
+		Name:    "root",
+		Attrs:   map[string]any{"list": []string{"a"}, "nested": map[string]int{"x": 1}},
+		secret:  &secret,
+		Created: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	child := &node{Name: "child", Next: original}
+	original.Children = []*node{child, child}
+
+	copied := uref.DeepCopy(original)
+	require.NotSame(t, original, copied)
+	assert.Equal(t, "root", copied.Name)
+	assert.True(t, original.Created.Equal(copied.Created))
+
+	require.Len(t, copied.Children, 2)
+	assert.NotSame(t, child, copied.Children[0])
+	assert.Same(t, copied.Children[0], copied.Children[1], "Shared references stay shared")
+	assert.Same(t, copied, copied.Children[0].Next, "Cycles point to the copy")
+
+	require.NotNil(t, copied.secret)
+	assert.NotSame(t, original.secret, copied.secret, "Unexported fields are copied too")
+	assert.Equal(t, 42, *copied.secret)
+
+	copied.Attrs["list"].([]string)[0] = "changed"
+	copied.Attrs["nested"].(map[string]int)["x"] = 2
+	assert.Equal(t, "a", original.Attrs["list"].([]string)[0])
+	assert.Equal(t, 1, original.Attrs["nested"].(map[string]int)["x"])
+}
+
+func TestDeepCopy_CyclicContainers(t *testing.T) {
+	m := map[string]any{}
+	m["self"] = m
+
+	copied := uref.DeepCopy(m)
+	copied["key"] = 1
+	assert.NotContains(t, m, "key")
+	assert.Contains(t, copied["self"].(map[string]any), "key", "Cyclic map refers to the copy")
+
+	s := []any{nil}
+	s[0] = s
+	copiedSlice := uref.DeepCopy(s)
+	copiedSlice[0].([]any)[0] = "x"
+	assert.Equal(t, "x", copiedSlice[0])
+	_, isSlice := s[0].([]any)
+	assert.True(t, isSlice)
+}
+
+func TestDeepCopy_Cloner(t *testing.T) {
+	original := map[string]clonerStruct{"a": {values: []int{1}}}
+	copied := uref.DeepCopy(original)
+	copied["a"].values[0] = 5
+	assert.Equal(t, 1, original["a"].values[0])
+}
+
+func TestDeepCopy_Primitives(t *testing.T) {
+	assert.Equal(t, 5, uref.DeepCopy(5))
+	assert.Equal(t, "s", uref.DeepCopy("s"))
+	assert.Nil(t, uref.DeepCopy[any](nil))
+	assert.Nil(t, uref.DeepCopy[*node](nil))
+
+	arr := [2][]int{{1}, {2}}
+	copied := uref.DeepCopy(arr)
+	copied[0][0] = 10
+	assert.Equal(t, 1, arr[0][0])
+}
@@ -0,0 +1,114 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package uref
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// Lazy holds a value computed by the initializer on the first Get, e.g. a configuration, a client or a singleton.
+// The initializer runs exactly once, even if Get is called concurrently, unless it panics: the panic is propagated
+// to the caller and the next Get runs the initializer again. The operations are thread-safe.
+type Lazy[T any] struct {
+	mtx   sync.Mutex
+	init  func() T
+	value atomic.Pointer[T]
+}
+
+// NewLazy creates a Lazy computing its value with init.
+func NewLazy[T any](init func() T) *Lazy[T] {
+	return &Lazy[T]{init: init}
+}
+
+// Get returns the value, running the initializer if it's the first call since the creation or Reset.
+// Concurrent calls wait for the initializer to finish.
+func (l *Lazy[T]) Get() T {
+	if value := l.value.Load(); value != nil {
+		return *value
+	}
+
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	if value := l.value.Load(); value != nil {
+		return *value
+	}
+	value := l.init()
+	l.value.Store(&value)
+
+	return value
+}
+
+// Initialized reports whether the value has been computed.
+func (l *Lazy[T]) Initialized() bool {
+	return l.value.Load() != nil
+}
+
+// Reset discards the value, so the next Get runs the initializer again. It's mostly useful in tests.
+func (l *Lazy[T]) Reset() {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	l.value.Store(nil)
+}
+
+// LazyErr is a Lazy with a fallible initializer. Errors are not cached: a failed initialization is returned to
+// the callers waiting for it, and the next Get runs the initializer again, so the initializer runs until it succeeds
+// once. The operations are thread-safe.
+type LazyErr[T any] struct {
+	mtx   sync.Mutex
+	init  func() (T, error)
+	value atomic.Pointer[T]
+}
+
+// NewLazyErr creates a LazyErr computing its value with init.
+func NewLazyErr[T any](init func() (T, error)) *LazyErr[T] {
+	return &LazyErr[T]{init: init}
+}
+
+// Get returns the value, running the initializer if it hasn't succeeded since the creation or Reset.
+// Returns the zero value and the initializer error if it fails.
+func (l *LazyErr[T]) Get() (T, error) {
+	if value := l.value.Load(); value != nil {
+		return *value, nil
+	}
+
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	if value := l.value.Load(); value != nil {
+		return *value, nil
+	}
+	value, err := l.init()
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	l.value.Store(&value)
+
+	return value, nil
+}
+
+// MustGet returns the value like Get, but panics if the initializer fails.
+func (l *LazyErr[T]) MustGet() T {
+	value, err := l.Get()
+	if err != nil {
+		panic(err)
+	}
+
+	return value
+}
+
+// Initialized reports whether the value has been computed successfully.
+func (l *LazyErr[T]) Initialized() bool {
+	return l.value.Load() != nil
+}
+
+// Reset discards the value, so the next Get runs the initializer again. It's mostly useful in tests.
+func (l *LazyErr[T]) Reset() {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	l.value.Store(nil)
+}
@@ -0,0 +1,124 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package uref_test
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/kordax/basic-utils/uref"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLazy(t *testing.T) {
+	var calls atomic.Int32
+	lazy := uref.NewLazy(func() string {
+		calls.Add(1)
+		return "value"
+	})
+	assert.False(t, lazy.Initialized())
+
+	var wg sync.WaitGroup
+	for range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.Equal(t, "value", lazy.Get())
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), calls.Load())
+	assert.True(t, lazy.Initialized())
+
+	lazy.Reset()
+	assert.False(t, lazy.Initialized())
+	assert.Equal(t, "value", lazy.Get())
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestLazy_Panic(t *testing.T) {
+	fail := true
+	lazy := uref.NewLazy(func() int {
+		if fail {
+			panic("boom")
+		}
+		return 1
+	})
+
+	assert.PanicsWithValue(t, "boom", func() { lazy.Get() })
+	assert.False(t, lazy.Initialized())
+	fail = false
+	assert.Equal(t, 1, lazy.Get())
+}
+
+func TestLazyErr(t *testing.T) {
+	errInit := errors.New("init failed")
+	var calls atomic.Int32
+	lazy := uref.NewLazyErr(func() (int, error) {
+		if calls.Add(1) == 1 {
+			return 0, errInit
+		}
+		return 42, nil
+	})
+
+	v, err := lazy.Get()
+	require.ErrorIs(t, err, errInit)
+	assert.Zero(t, v)
+	assert.False(t, lazy.Initialized())
+
+	var wg sync.WaitGroup
+	for range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			v, err := lazy.Get()
+			assert.NoError(t, err)
+			assert.Equal(t, 42, v)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(2), calls.Load(), "the errors must not be cached and the value must be")
+	assert.True(t, lazy.Initialized())
+	assert.Equal(t, 42, lazy.MustGet())
+
+	lazy.Reset()
+	assert.False(t, lazy.Initialized())
+	v, err = lazy.Get()
+	require.NoError(t, err)
+	assert.Equal(t, 42, v)
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestLazyErr_MustGet(t *testing.T) {
+	errInit := errors.New("init failed")
+	lazy := uref.NewLazyErr(func() (string, error) { return "", errInit })
+	assert.PanicsWithError(t, errInit.Error(), func() { lazy.MustGet() })
+}
+
+func TestLazy_ConcurrentReset(t *testing.T) {
+	lazy := uref.NewLazy(func() int { return 1 })
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				assert.Equal(t, 1, lazy.Get())
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				lazy.Reset()
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,168 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+// Package uslices provides slice-backed containers.
+package uslices
+
+import (
+	"cmp"
+	"iter"
+	"slices"
+
+	"github.com/kordax/basic-utils/uarray"
+	"github.com/kordax/basic-utils/uconst"
+)
+
+// SortedSlice keeps its values sorted on insert, so lookups and range queries are binary searches.
+// It is a middle ground between a plain slice and a tree: lookups are O(log n) and iteration is cache friendly,
+// while inserts and deletes are O(n) because of shifting, which is cheap for small and read-mostly collections.
+// Duplicates are allowed: equal values are kept in the insertion order.
+// SortedSlice is not thread-safe.
+type SortedSlice[T any] struct {
+	values  []T
+	compare func(a, b T) int
+}
+
+// NewSortedSlice creates a SortedSlice of ordered values in ascending order, initialized with the values.
+func NewSortedSlice[T uconst.Ordered](values ...T) *SortedSlice[T] {
+	return NewSortedSliceFunc(cmp.Compare[T], values...)
+}
+
+// NewSortedSliceFunc creates a SortedSlice ordered by the compare function, initialized with the values.
+// compare returns a negative number if a < b, a positive number if a > b and zero if they are equal.
+func NewSortedSliceFunc[T any](compare func(a, b T) int, values ...T) *SortedSlice[T] {
+	s := &SortedSlice[T]{
+		values:  slices.Clone(values),
+		compare: compare,
+	}
+	slices.SortStableFunc(s.values, compare)
+
+	return s
+}
+
+// Insert adds the values keeping the slice sorted. Values equal to the existing ones are placed after them.
+func (s *SortedSlice[T]) Insert(values ...T) {
+	for _, v := range values {
+		s.values = slices.Insert(s.values, s.upperBound(v), v)
+	}
+}
+
+// Delete removes one occurrence of the value and reports whether it was present.
+func (s *SortedSlice[T]) Delete(value T) bool {
+	i, ok := s.Index(value)
+	if ok {
+		s.values = slices.Delete(s.values, i, i+1)
+	}
+
+	return ok
+}
+
+// DeleteAll removes all the occurrences of the value and returns their number.
+func (s *SortedSlice[T]) DeleteAll(value T) int {
+	from, to := s.lowerBound(value), s.upperBound(value)
+	s.values = slices.Delete(s.values, from, to)
+
+	return to - from
+}
+
+// Contains reports whether the value is present.
+func (s *SortedSlice[T]) Contains(value T) bool {
+	_, ok := s.Index(value)
+	return ok
+}
+
+// Index returns the index of the first occurrence of the value and whether it was found.
+// If the value is absent, the index is where it would be inserted.
+func (s *SortedSlice[T]) Index(value T) (int, bool) {
+	i, found := uarray.FindBinary(s.values, func(v *T) bool {
+		return s.compare(*v, value) >= 0
+	})
+
+	return i, found != nil && s.compare(*found, value) == 0
+}
+
+// Count returns the number of occurrences of the value.
+func (s *SortedSlice[T]) Count(value T) int {
+	return s.upperBound(value) - s.lowerBound(value)
+}
+
+// At returns the i-th smallest value. It panics if the index is out of range.
+func (s *SortedSlice[T]) At(i int) T {
+	return s.values[i]
+}
+
+// Min returns the smallest value, or false if the slice is empty.
+func (s *SortedSlice[T]) Min() (T, bool) {
+	if len(s.values) == 0 {
+		var zero T
+		return zero, false
+	}
+	return s.values[0], true
+}
+
+// Max returns the largest value, or false if the slice is empty.
+func (s *SortedSlice[T]) Max() (T, bool) {
+	if len(s.values) == 0 {
+		var zero T
+		return zero, false
+	}
+	return s.values[len(s.values)-1], true
+}
+
+// Range returns the values v such that from <= v < to in ascending order.
+func (s *SortedSlice[T]) Range(from, to T) []T {
+	start, end := s.lowerBound(from), s.lowerBound(to)
+	if start >= end {
+		return []T{}
+	}
+	return slices.Clone(s.values[start:end])
+}
+
+// From returns the values greater than or equal to the value in ascending order.
+func (s *SortedSlice[T]) From(value T) []T {
+	return slices.Clone(s.values[s.lowerBound(value):])
+}
+
+// To returns the values less than the value in ascending order.
+func (s *SortedSlice[T]) To(value T) []T {
+	return slices.Clone(s.values[:s.lowerBound(value)])
+}
+
+// Len returns the number of values.
+func (s *SortedSlice[T]) Len() int {
+	return len(s.values)
+}
+
+// Values returns a copy of the values in ascending order.
+func (s *SortedSlice[T]) Values() []T {
+	return slices.Clone(s.values)
+}
+
+// All returns an iterator over the values in ascending order. The slice must not be modified during the iteration.
+func (s *SortedSlice[T]) All() iter.Seq2[int, T] {
+	return slices.All(s.values)
+}
+
+// Clear removes all the values.
+func (s *SortedSlice[T]) Clear() {
+	clear(s.values)
+	s.values = s.values[:0]
+}
+
+// lowerBound returns the index of the first value not less than the value.
+func (s *SortedSlice[T]) lowerBound(value T) int {
+	i, _ := s.Index(value)
+	return i
+}
+
+// upperBound returns the index of the first value greater than the value.
+func (s *SortedSlice[T]) upperBound(value T) int {
+	i, _ := uarray.FindBinary(s.values, func(v *T) bool {
+		return s.compare(*v, value) > 0
+	})
+
+	return i
+}
@@ -0,0 +1,128 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package uslices_test
+
+import (
+	"cmp"
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/kordax/basic-utils/uslices"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSortedSlice_Insert(t *testing.T) {
+	s := uslices.NewSortedSlice(5, 1, 3)
+	s.Insert(4, 0, 3, 6)
+
+	assert.Equal(t, []int{0, 1, 3, 3, 4, 5, 6}, s.Values())
+	assert.Equal(t, 7, s.Len())
+	assert.Equal(t, 3, s.At(2))
+
+	minV, ok := s.Min()
+	assert.True(t, ok)
+	assert.Equal(t, 0, minV)
+	maxV, ok := s.Max()
+	assert.True(t, ok)
+	assert.Equal(t, 6, maxV)
+
+	var collected []int
+	for _, v := range s.All() {
+		collected = append(collected, v)
+	}
+	assert.Equal(t, s.Values(), collected)
+
+	values := s.Values()
+	values[0] = 100
+	assert.Equal(t, 0, s.At(0), "Values must return a copy")
+}
+
+func TestSortedSlice_Lookup(t *testing.T) {
+	s := uslices.NewSortedSlice(10, 20, 20, 30)
+
+	assert.True(t, s.Contains(20))
+	assert.False(t, s.Contains(25))
+
+	i, ok := s.Index(20)
+	assert.True(t, ok)
+	assert.Equal(t, 1, i)
+	i, ok = s.Index(25)
+	assert.False(t, ok)
+	assert.Equal(t, 3, i)
+
+	assert.Equal(t, 2, s.Count(20))
+	assert.Equal(t, 0, s.Count(15))
+}
+
+func TestSortedSlice_Ranges(t *testing.T) {
+	s := uslices.NewSortedSlice(1, 2, 2, 3, 5, 8)
+
+	assert.Equal(t, []int{2, 2, 3}, s.Range(2, 5))
+	assert.Equal(t, []int{}, s.Range(5, 2))
+	assert.Equal(t, []int{}, s.Range(6, 8))
+	assert.Equal(t, []int{5, 8}, s.From(4))
+	assert.Equal(t, []int{1, 2, 2}, s.To(3))
+	assert.Empty(t, s.From(9))
+	assert.Empty(t, s.To(1))
+}
+
+func TestSortedSlice_Delete(t *testing.T) {
+	s := uslices.NewSortedSlice(1, 2, 2, 2, 3)
+
+	assert.True(t, s.Delete(2))
+	assert.False(t, s.Delete(4))
+	assert.Equal(t, []int{1, 2, 2, 3}, s.Values())
+	assert.Equal(t, 2, s.DeleteAll(2))
+	assert.Equal(t, 0, s.DeleteAll(2))
+	assert.Equal(t, []int{1, 3}, s.Values())
+
+	s.Clear()
+	assert.Zero(t, s.Len())
+	_, ok := s.Min()
+	assert.False(t, ok)
+	_, ok = s.Max()
+	assert.False(t, ok)
+}
+
+func TestSortedSlice_Func(t *testing.T) {
+	type user struct {
+		name string
+		age  int
+	}
+	s := uslices.NewSortedSliceFunc(func(a, b user) int { return cmp.Compare(a.age, b.age) },
+		user{"bob", 30}, user{"alice", 25})
+	s.Insert(user{"carol", 30}, user{"dave", 20})
+
+	var names []string
+	for _, u := range s.All() {
+		names = append(names, u.name)
+	}
+	assert.Equal(t, "dave alice bob carol", strings.Join(names, " "), "equal values keep the insertion order")
+	assert.Len(t, s.Range(user{age: 25}, user{age: 31}), 3)
+}
+
+func TestSortedSlice_Random(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	s := uslices.NewSortedSlice[int]()
+	var expected []int
+	for range 500 {
+		v := r.Intn(100)
+		if r.Intn(3) == 0 {
+			assert.Equal(t, slices.Contains(expected, v), s.Delete(v))
+			if i := slices.Index(expected, v); i >= 0 {
+				expected = slices.Delete(expected, i, i+1)
+			}
+			continue
+		}
+		s.Insert(v)
+		expected = append(expected, v)
+	}
+	slices.Sort(expected)
+	assert.Equal(t, expected, s.Values())
+}
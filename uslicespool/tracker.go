@@ -0,0 +1,20 @@
+//go:build !uslicespool_debug
+
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package uslicespool
+
+// tracker is a no-op unless the package is built with the uslicespool_debug tag.
+type tracker struct{}
+
+func (tracker) acquire(any) {}
+
+func (tracker) release(any) {}
+
+func (tracker) leaks() []string {
+	return nil
+}
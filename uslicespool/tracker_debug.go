@@ -0,0 +1,64 @@
+//go:build uslicespool_debug
+
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package uslicespool
+
+import (
+	"reflect"
+	"runtime/debug"
+	"sort"
+	"sync"
+)
+
+// tracker records the backing arrays of the slices handed out by the pool along with the stack traces of their Get calls.
+// Slices grown past their capacity get a new backing array, so they are unknown to the tracker and the original
+// array is reported as leaked, which usually means the size hint was too small.
+type tracker struct {
+	mtx         sync.Mutex
+	outstanding map[uintptr]string
+	returned    map[uintptr]struct{}
+}
+
+func (t *tracker) acquire(s any) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	if t.outstanding == nil {
+		t.outstanding = make(map[uintptr]string)
+		t.returned = make(map[uintptr]struct{})
+	}
+	ptr := reflect.ValueOf(s).Pointer()
+	delete(t.returned, ptr)
+	t.outstanding[ptr] = string(debug.Stack())
+}
+
+func (t *tracker) release(s any) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	ptr := reflect.ValueOf(s).Pointer()
+	if _, ok := t.returned[ptr]; ok {
+		panic("uslicespool: slice was already returned to the pool")
+	}
+	if _, ok := t.outstanding[ptr]; !ok {
+		// Unknown slices, e.g. grown ones, are accepted, but not tracked.
+		return
+	}
+	delete(t.outstanding, ptr)
+	t.returned[ptr] = struct{}{}
+}
+
+func (t *tracker) leaks() []string {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	result := make([]string, 0, len(t.outstanding))
+	for _, stack := range t.outstanding {
+		result = append(result, stack)
+	}
+	sort.Strings(result)
+
+	return result
+}
@@ -0,0 +1,33 @@
+//go:build uslicespool_debug
+
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package uslicespool_test
+
+import (
+	"testing"
+
+	"github.com/kordax/basic-utils/uopt"
+	"github.com/kordax/basic-utils/uslicespool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPool_Leaks(t *testing.T) {
+	p := uslicespool.New[int](uopt.Null[int]())
+	s := p.Get(8)
+	leaked := p.Get(8)
+	_ = leaked
+
+	require.Len(t, p.Leaks(), 2)
+	assert.Contains(t, p.Leaks()[0], "TestPool_Leaks")
+
+	p.Put(s)
+	assert.Len(t, p.Leaks(), 1)
+	assert.Panics(t, func() { p.Put(s) }, "double Put must be detected")
+	assert.NotPanics(t, func() { p.Put(make([]int, 0, 8)) }, "foreign slices are accepted")
+}
@@ -0,0 +1,100 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+// Package uslicespool provides sync.Pool backed pools of slices grouped by power-of-two capacity classes.
+//
+// Slices are obtained with Get and returned with Put. A returned slice must not be used anymore.
+// Building with the uslicespool_debug tag enables leak detection: the pool tracks the slices it handed out,
+// Leaks reports the stack traces of the slices that were not returned, and Put panics on slices
+// that were already returned.
+package uslicespool
+
+import (
+	"math/bits"
+	"sync"
+
+	"github.com/kordax/basic-utils/uopt"
+)
+
+// DefaultMaxCap is the largest capacity pooled when no explicit limit is provided.
+// Larger slices are allocated directly and dropped on Put, so a single spike doesn't pin memory.
+const DefaultMaxCap = 1 << 16
+
+// Pool is a pool of []T slices. It is safe for concurrent use.
+type Pool[T any] struct {
+	maxCap  int
+	classes []sync.Pool
+	tracker tracker
+}
+
+// New creates a Pool that keeps slices with capacities up to maxCap elements (DefaultMaxCap if absent).
+func New[T any](maxCap uopt.Opt[int]) *Pool[T] {
+	limit := maxCap.OrElse(DefaultMaxCap)
+	if limit <= 0 {
+		limit = DefaultMaxCap
+	}
+
+	return &Pool[T]{
+		maxCap:  limit,
+		classes: make([]sync.Pool, bits.Len(uint(limit))),
+	}
+}
+
+// Get returns an empty slice with a capacity of at least sizeHint elements.
+func (p *Pool[T]) Get(sizeHint int) []T {
+	if sizeHint < 1 {
+		sizeHint = 1
+	}
+	class := bits.Len(uint(sizeHint - 1))
+	size := 1 << class
+	if size > p.maxCap {
+		return make([]T, 0, sizeHint)
+	}
+
+	var s []T
+	if v := p.classes[class].Get(); v != nil {
+		s = (*v.(*[]T))[:0]
+	} else {
+		s = make([]T, 0, size)
+	}
+	p.tracker.acquire(s)
+
+	return s
+}
+
+// Put returns the slice to the pool. The slice elements are zeroed, so pooled slices don't retain references.
+// Slices larger than the pool limit and zero-capacity slices are dropped.
+func (p *Pool[T]) Put(s []T) {
+	c := cap(s)
+	if c == 0 || c > p.maxCap {
+		return
+	}
+	s = s[:c]
+	p.tracker.release(s)
+	clear(s)
+	s = s[:0]
+
+	// A slice is stored in the largest class it fully satisfies.
+	p.classes[bits.Len(uint(c))-1].Put(&s)
+}
+
+// Leaks returns the stack traces of the Get calls whose slices were not returned yet.
+// It always returns nil unless the package is built with the uslicespool_debug tag.
+func (p *Pool[T]) Leaks() []string {
+	return p.tracker.leaks()
+}
+
+var bytesPool = New[byte](uopt.Null[int]())
+
+// GetBytes returns an empty byte slice with a capacity of at least sizeHint bytes from the shared pool.
+func GetBytes(sizeHint int) []byte {
+	return bytesPool.Get(sizeHint)
+}
+
+// PutBytes returns the byte slice to the shared pool.
+func PutBytes(b []byte) {
+	bytesPool.Put(b)
+}
@@ -0,0 +1,79 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package uslicespool_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/kordax/basic-utils/uopt"
+	"github.com/kordax/basic-utils/uslicespool"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPool_GetPut(t *testing.T) {
+	p := uslicespool.New[int](uopt.Null[int]())
+
+	for _, hint := range []int{-1, 0, 1, 3, 4, 5, 1000} {
+		s := p.Get(hint)
+		assert.Empty(t, s)
+		assert.GreaterOrEqual(t, cap(s), hint)
+		s = append(s, 1, 2, 3)
+		p.Put(s)
+	}
+
+	s := p.Get(8)
+	assert.Empty(t, s)
+	assert.Equal(t, make([]int, cap(s)), s[:cap(s)], "pooled slices must be zeroed")
+	p.Put(s)
+}
+
+func TestPool_MaxCap(t *testing.T) {
+	p := uslicespool.New[byte](uopt.Of(16))
+	s := p.Get(100)
+	assert.Equal(t, 100, cap(s))
+	p.Put(s)
+	p.Put(nil)
+}
+
+func TestPool_Pointers(t *testing.T) {
+	p := uslicespool.New[*int](uopt.Null[int]())
+	v := 1
+	s := append(p.Get(4), &v)
+	p.Put(s)
+
+	s = p.Get(4)
+	for _, ptr := range s[:cap(s)] {
+		assert.Nil(t, ptr)
+	}
+	p.Put(s)
+}
+
+func TestBytes(t *testing.T) {
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				b := uslicespool.GetBytes(64)
+				b = append(b, "payload"...)
+				uslicespool.PutBytes(b)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func BenchmarkGetBytes(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		buf := uslicespool.GetBytes(256)
+		buf = append(buf, "payload"...)
+		uslicespool.PutBytes(buf)
+	}
+}
@@ -0,0 +1,186 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+// Package utx provides in-memory transactions over ucache caches, enabling atomic multi-key updates.
+package utx
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/kordax/basic-utils/ucache"
+)
+
+// ErrTxDone is returned when a transaction is used after it was committed or rolled back.
+var ErrTxDone = errors.New("utx: transaction has already been committed or rolled back")
+
+// Store wraps a cache and serializes its modifications, so transactions are committed atomically:
+// readers going through the Store see either all the changes of a transaction or none of them.
+// All the modifications of the cache should be made through the Store or its transactions.
+//
+// Transactions are not isolated from each other: concurrent transactions modifying the same keys
+// are not detected, and the last committed one wins.
+type Store[K comparable, T any] struct {
+	cache ucache.BaseCache[K, T]
+	mtx   sync.RWMutex
+}
+
+// New creates a new Store on top of the provided cache.
+func New[K comparable, T any](cache ucache.BaseCache[K, T]) *Store[K, T] {
+	return &Store[K, T]{cache: cache}
+}
+
+// Begin starts a new transaction.
+func (s *Store[K, T]) Begin() *Tx[K, T] {
+	return &Tx[K, T]{
+		store: s,
+		index: make(map[K]int),
+	}
+}
+
+// Update runs f in a new transaction. The transaction is committed if f returns nil and rolled back otherwise,
+// in which case the error of f is returned.
+func (s *Store[K, T]) Update(f func(tx *Tx[K, T]) error) error {
+	tx := s.Begin()
+	if err := f(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Get retrieves the committed value associated with the provided key. The operation is thread-safe.
+func (s *Store[K, T]) Get(key K) (*T, bool) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	return s.cache.Get(key)
+}
+
+// Set updates the value for the provided key outside any transaction. The operation is thread-safe.
+func (s *Store[K, T]) Set(key K, value T) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.cache.Set(key, value)
+}
+
+// DropKey removes the value associated with the provided key outside any transaction. The operation is thread-safe.
+func (s *Store[K, T]) DropKey(key K) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.cache.DropKey(key)
+}
+
+// Cache returns the underlying cache.
+func (s *Store[K, T]) Cache() ucache.BaseCache[K, T] {
+	return s.cache
+}
+
+type write[K comparable, T any] struct {
+	key     K
+	value   T
+	deleted bool
+}
+
+// Tx is a transaction buffering the writes until it's committed. The writes are visible only to the transaction itself.
+// Tx is thread-safe, but is meant to be used by a single goroutine.
+type Tx[K comparable, T any] struct {
+	store *Store[K, T]
+
+	mtx    sync.Mutex
+	writes []write[K, T] // the latest write of every key in the order the keys were first written
+	index  map[K]int
+	done   bool
+}
+
+// Get retrieves the value associated with the provided key as seen by the transaction:
+// the buffered write if the key was modified in the transaction, the committed value otherwise.
+func (tx *Tx[K, T]) Get(key K) (*T, bool) {
+	tx.mtx.Lock()
+	if i, ok := tx.index[key]; ok {
+		w := tx.writes[i]
+		tx.mtx.Unlock()
+		if w.deleted {
+			return nil, false
+		}
+		return &w.value, true
+	}
+	tx.mtx.Unlock()
+
+	return tx.store.Get(key)
+}
+
+// Set buffers the value for the provided key. Returns ErrTxDone if the transaction is finished.
+func (tx *Tx[K, T]) Set(key K, value T) error {
+	return tx.buffer(write[K, T]{key: key, value: value})
+}
+
+// Delete buffers the removal of the provided key. Returns ErrTxDone if the transaction is finished.
+func (tx *Tx[K, T]) Delete(key K) error {
+	return tx.buffer(write[K, T]{key: key, deleted: true})
+}
+
+// Len returns the number of keys modified in the transaction.
+func (tx *Tx[K, T]) Len() int {
+	tx.mtx.Lock()
+	defer tx.mtx.Unlock()
+
+	return len(tx.writes)
+}
+
+// Commit applies all the buffered writes to the cache under the store lock, so they become visible at once.
+// Returns ErrTxDone if the transaction is already finished.
+func (tx *Tx[K, T]) Commit() error {
+	tx.mtx.Lock()
+	defer tx.mtx.Unlock()
+	if tx.done {
+		return ErrTxDone
+	}
+	tx.done = true
+	if len(tx.writes) == 0 {
+		return nil
+	}
+
+	s := tx.store
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	for _, w := range tx.writes {
+		if w.deleted {
+			s.cache.DropKey(w.key)
+		} else {
+			s.cache.Set(w.key, w.value)
+		}
+	}
+	tx.writes, tx.index = nil, nil
+
+	return nil
+}
+
+// Rollback discards the buffered writes. Rolling back a finished transaction has no effect,
+// so it's safe to defer Rollback right after Begin.
+func (tx *Tx[K, T]) Rollback() {
+	tx.mtx.Lock()
+	defer tx.mtx.Unlock()
+	tx.done = true
+	tx.writes, tx.index = nil, nil
+}
+
+func (tx *Tx[K, T]) buffer(w write[K, T]) error {
+	tx.mtx.Lock()
+	defer tx.mtx.Unlock()
+	if tx.done {
+		return ErrTxDone
+	}
+	if i, ok := tx.index[w.key]; ok {
+		tx.writes[i] = w
+		return nil
+	}
+	tx.index[w.key] = len(tx.writes)
+	tx.writes = append(tx.writes, w)
+
+	return nil
+}
@@ -0,0 +1,132 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package utx_test
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kordax/basic-utils/ucache"
+	"github.com/kordax/basic-utils/uopt"
+	"github.com/kordax/basic-utils/utx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newStore() *utx.Store[string, int] {
+	return utx.New[string, int](ucache.NewInMemoryComparableMapCache[string, int](uopt.Null[time.Duration]()))
+}
+
+func TestTx_Commit(t *testing.T) {
+	s := newStore()
+	s.Set("a", 1)
+	s.Set("b", 2)
+
+	tx := s.Begin()
+	require.NoError(t, tx.Set("a", 10))
+	require.NoError(t, tx.Set("c", 3))
+	require.NoError(t, tx.Delete("b"))
+	require.NoError(t, tx.Set("c", 30))
+	assert.Equal(t, 3, tx.Len())
+
+	value, ok := tx.Get("a")
+	require.True(t, ok)
+	assert.Equal(t, 10, *value, "the transaction must see its own writes")
+	_, ok = tx.Get("b")
+	assert.False(t, ok)
+
+	value, ok = s.Get("a")
+	require.True(t, ok)
+	assert.Equal(t, 1, *value, "the writes must not be visible before commit")
+	_, ok = s.Get("c")
+	assert.False(t, ok)
+
+	require.NoError(t, tx.Commit())
+	value, _ = s.Get("a")
+	assert.Equal(t, 10, *value)
+	_, ok = s.Get("b")
+	assert.False(t, ok)
+	value, _ = s.Get("c")
+	assert.Equal(t, 30, *value)
+
+	assert.ErrorIs(t, tx.Commit(), utx.ErrTxDone)
+	assert.ErrorIs(t, tx.Set("d", 4), utx.ErrTxDone)
+	tx.Rollback()
+	value, _ = s.Get("c")
+	assert.Equal(t, 30, *value, "rollback after commit must have no effect")
+}
+
+func TestTx_Rollback(t *testing.T) {
+	s := newStore()
+	s.Set("a", 1)
+
+	tx := s.Begin()
+	require.NoError(t, tx.Set("a", 10))
+	require.NoError(t, tx.Delete("a"))
+	tx.Rollback()
+
+	value, ok := s.Get("a")
+	require.True(t, ok)
+	assert.Equal(t, 1, *value)
+	value, ok = tx.Get("a")
+	require.True(t, ok, "a finished transaction reads the committed values")
+	assert.Equal(t, 1, *value)
+	assert.ErrorIs(t, tx.Delete("a"), utx.ErrTxDone)
+	assert.ErrorIs(t, tx.Commit(), utx.ErrTxDone)
+}
+
+func TestStore_Update(t *testing.T) {
+	s := newStore()
+	require.NoError(t, s.Update(func(tx *utx.Tx[string, int]) error {
+		return tx.Set("a", 1)
+	}))
+	value, ok := s.Get("a")
+	require.True(t, ok)
+	assert.Equal(t, 1, *value)
+
+	fail := errors.New("fail")
+	err := s.Update(func(tx *utx.Tx[string, int]) error {
+		_ = tx.Set("a", 2)
+		return fail
+	})
+	assert.ErrorIs(t, err, fail)
+	value, _ = s.Get("a")
+	assert.Equal(t, 1, *value)
+}
+
+func TestTx_CommitIsAtomic(t *testing.T) {
+	s := newStore()
+	s.Set("a", 0)
+	s.Set("b", 0)
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 1; i <= 1000; i++ {
+			tx := s.Begin()
+			_ = tx.Set("a", i)
+			_ = tx.Set("b", -i)
+			assert.NoError(t, tx.Commit())
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for range 1000 {
+			err := s.Update(func(tx *utx.Tx[string, int]) error {
+				a, _ := tx.Get("a")
+				b, _ := tx.Get("b")
+				assert.Equal(t, 0, *a+*b)
+				return nil
+			})
+			assert.NoError(t, err)
+		}
+	}()
+	wg.Wait()
+}
@@ -0,0 +1,342 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+// Package uvalidate validates structs against the rules declared in their `validate:"..."` tags.
+package uvalidate
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kordax/basic-utils/uerror"
+)
+
+// Tag is the struct tag holding the validation rules.
+const Tag = "validate"
+
+// ErrInvalid is matched by every FieldError.
+var ErrInvalid = errors.New("uvalidate: invalid value")
+
+// FieldError describes a field violating a rule.
+type FieldError struct {
+	Field string // Field is the path of the field, e.g. "Server.Port" or "Users[2].Name".
+	Rule  string // Rule is the name of the violated rule, e.g. "min".
+	Param string // Param is the rule parameter, e.g. "1" for min=1, empty for the rules without parameters.
+	Msg   string // Msg describes the violation.
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Field, e.Msg)
+}
+
+func (e *FieldError) Unwrap() error {
+	return ErrInvalid
+}
+
+// FieldErrors returns the field errors contained in the error returned by Validate.
+func FieldErrors(err error) []*FieldError {
+	var multi *uerror.Multi
+	if !errors.As(err, &multi) {
+		var fieldErr *FieldError
+		if errors.As(err, &fieldErr) {
+			return []*FieldError{fieldErr}
+		}
+		return nil
+	}
+
+	result := make([]*FieldError, 0, multi.Len())
+	for _, e := range multi.Errors() {
+		var fieldErr *FieldError
+		if errors.As(e, &fieldErr) {
+			result = append(result, fieldErr)
+		}
+	}
+
+	return result
+}
+
+// optional is implemented by uopt.Opt, whose value is retrieved with the Get method via reflection,
+// so any instantiation of Opt is supported.
+type optional interface {
+	IsPresent() bool
+}
+
+var optionalType = reflect.TypeFor[optional]()
+
+/*
+Validate checks the struct, or a pointer to it, against the rules in the `validate:"..."` tags of its fields,
+including the fields of nested structs, pointers to structs and slices of them:
+
+	type Request struct {
+		Name  string                  `validate:"required,max=64"`
+		Page  uopt.Opt[int]           `validate:"min=1,max=100"`
+		Sort  uopt.Opt[string]        `validate:"oneof=asc desc"`
+		Tags  []string                `validate:"max=10"`
+		Retry uopt.Opt[time.Duration] `validate:"required,min=1s"`
+	}
+
+The supported rules are:
+  - required: the value must not be the zero value. An Opt must be present, but its value may be zero.
+  - min=N and max=N: numbers must be within the bound, strings, slices and maps must have at least or at most N elements.
+    Bounds of time.Duration values are durations, e.g. min=1s.
+  - len=N: strings, slices and maps must have exactly N elements.
+  - oneof=A B C: the value must be one of the space-separated options.
+
+Absent Opt values and nil pointers pass all the rules except required. String lengths are counted in runes.
+
+All the violations are collected: Validate returns nil if the value is valid and otherwise an error aggregating
+a *FieldError for each violation, see FieldErrors. Malformed tags and rules not applicable to the field type
+are reported as plain errors.
+*/
+func Validate(v any) error {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return fmt.Errorf("uvalidate: Validate expects a struct or a non-nil pointer to it, got %T", v)
+	}
+
+	var errs uerror.Multi
+	if err := validateStruct(rv, "", &errs); err != nil {
+		return err
+	}
+
+	return errs.ErrorOrNil()
+}
+
+func validateStruct(v reflect.Value, path string, errs *uerror.Multi) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		name := sf.Name
+		if path != "" {
+			name = path + "." + sf.Name
+		}
+		field := v.Field(i)
+
+		value, present, isOpt := unwrapOpt(field)
+		if tag, ok := sf.Tag.Lookup(Tag); ok && tag != "-" {
+			if err := validateField(value, present, isOpt, name, tag, errs); err != nil {
+				return err
+			}
+		}
+		if present {
+			if err := validateNested(value, name, errs); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateNested(v reflect.Value, path string, errs *uerror.Multi) error {
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		if v.Type() == reflect.TypeFor[time.Time]() {
+			return nil
+		}
+		return validateStruct(v, path, errs)
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if err := validateNested(v.Index(i), fmt.Sprintf("%s[%d]", path, i), errs); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// unwrapOpt returns the value of an Opt field and whether it's present. Other fields are returned as is,
+// nil pointers are reported as absent.
+func unwrapOpt(field reflect.Value) (value reflect.Value, present bool, isOpt bool) {
+	if field.Type().Implements(optionalType) {
+		get := field.MethodByName("Get")
+		if get.IsValid() && get.Type().NumIn() == 0 && get.Type().NumOut() == 1 && get.Type().Out(0).Kind() == reflect.Pointer {
+			if !field.Interface().(optional).IsPresent() {
+				return reflect.Value{}, false, true
+			}
+			return get.Call(nil)[0].Elem(), true, true
+		}
+	}
+	if field.Kind() == reflect.Pointer && field.IsNil() {
+		return field, false, false
+	}
+
+	return field, true, false
+}
+
+func validateField(v reflect.Value, present, isOpt bool, name, tag string, errs *uerror.Multi) error {
+	for _, rule := range strings.Split(tag, ",") {
+		rule = strings.TrimSpace(rule)
+		if rule == "" {
+			continue
+		}
+		ruleName, param, _ := strings.Cut(rule, "=")
+
+		if ruleName == "required" {
+			if !present || (!isOpt && v.IsZero()) {
+				errs.Append(&FieldError{Field: name, Rule: ruleName, Msg: "is required"})
+			}
+			continue
+		}
+		if !present {
+			continue
+		}
+
+		value := v
+		for value.Kind() == reflect.Pointer {
+			if value.IsNil() {
+				break
+			}
+			value = value.Elem()
+		}
+		msg, err := check(value, ruleName, param)
+		if err != nil {
+			return fmt.Errorf("uvalidate: field %s: rule %q: %w", name, rule, err)
+		}
+		if msg != "" {
+			errs.Append(&FieldError{Field: name, Rule: ruleName, Param: param, Msg: msg})
+		}
+	}
+
+	return nil
+}
+
+// check applies the rule to the value. Returns the violation message if the value is invalid
+// and an error if the rule can't be applied.
+func check(v reflect.Value, rule, param string) (string, error) {
+	switch rule {
+	case "min", "max":
+		return checkBound(v, rule, param)
+	case "len":
+		n, err := strconv.Atoi(param)
+		if err != nil {
+			return "", err
+		}
+		l, ok := length(v)
+		if !ok {
+			return "", fmt.Errorf("unsupported type %s", v.Type())
+		}
+		if l != n {
+			return fmt.Sprintf("must have exactly %d elements, got %d", n, l), nil
+		}
+		return "", nil
+	case "oneof":
+		options := strings.Fields(param)
+		if len(options) == 0 {
+			return "", errors.New("no options")
+		}
+		s, ok := scalarString(v)
+		if !ok {
+			return "", fmt.Errorf("unsupported type %s", v.Type())
+		}
+		for _, option := range options {
+			if s == option {
+				return "", nil
+			}
+		}
+		return fmt.Sprintf("must be one of [%s], got %s", strings.Join(options, " "), s), nil
+	default:
+		return "", errors.New("unknown rule")
+	}
+}
+
+func checkBound(v reflect.Value, rule, param string) (string, error) {
+	isMin := rule == "min"
+	if v.Type() == reflect.TypeFor[time.Duration]() {
+		bound, err := time.ParseDuration(param)
+		if err != nil {
+			return "", err
+		}
+		d := time.Duration(v.Int())
+		if isMin && d < bound {
+			return fmt.Sprintf("must be at least %s, got %s", bound, d), nil
+		}
+		if !isMin && d > bound {
+			return fmt.Sprintf("must be at most %s, got %s", bound, d), nil
+		}
+		return "", nil
+	}
+
+	if l, ok := length(v); ok {
+		n, err := strconv.Atoi(param)
+		if err != nil {
+			return "", err
+		}
+		if isMin && l < n {
+			return fmt.Sprintf("must have at least %d elements, got %d", n, l), nil
+		}
+		if !isMin && l > n {
+			return fmt.Sprintf("must have at most %d elements, got %d", n, l), nil
+		}
+		return "", nil
+	}
+
+	bound, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		return "", err
+	}
+	var f float64
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		f = float64(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		f = float64(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		f = v.Float()
+	default:
+		return "", fmt.Errorf("unsupported type %s", v.Type())
+	}
+	if isMin && f < bound {
+		return fmt.Sprintf("must be at least %s, got %v", param, v.Interface()), nil
+	}
+	if !isMin && f > bound {
+		return fmt.Sprintf("must be at most %s, got %v", param, v.Interface()), nil
+	}
+
+	return "", nil
+}
+
+// length returns the number of elements of strings, slices, arrays and maps. Strings are measured in runes.
+func length(v reflect.Value) (int, bool) {
+	switch v.Kind() {
+	case reflect.String:
+		return len([]rune(v.String())), true
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return v.Len(), true
+	default:
+		return 0, false
+	}
+}
+
+func scalarString(v reflect.Value) (string, bool) {
+	switch v.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return fmt.Sprint(v.Interface()), true
+	default:
+		return "", false
+	}
+}
@@ -0,0 +1,153 @@
+/*
+ * @kordax (Dmitry Morozov)
+ * dmorozov@valoru-software.com
+ * Copyright (c) 2026.
+ */
+
+package uvalidate_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kordax/basic-utils/uopt"
+	"github.com/kordax/basic-utils/uvalidate"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type address struct {
+	City string `validate:"required"`
+	Zip  string `validate:"len=5"`
+}
+
+type user struct {
+	Name      string                  `validate:"required,min=2,max=8"`
+	Age       uopt.Opt[int]           `validate:"min=18,max=120"`
+	Role      uopt.Opt[string]        `validate:"required,oneof=admin user"`
+	Timeout   uopt.Opt[time.Duration] `validate:"min=1s"`
+	Score     float64                 `validate:"max=1.5"`
+	Tags      []string                `validate:"max=2"`
+	Home      *address                `validate:"required"`
+	Addresses []address
+	Ignored   string `validate:"-"`
+	private   string `validate:"required"`
+}
+
+func validUser() user {
+	return user{
+		Name: "john",
+		Role: uopt.Of("admin"),
+		Home: &address{City: "Paris", Zip: "75001"},
+	}
+}
+
+func TestValidate_Valid(t *testing.T) {
+	u := validUser()
+	require.NoError(t, uvalidate.Validate(u))
+	require.NoError(t, uvalidate.Validate(&u))
+
+	u.Age = uopt.Of(30)
+	u.Timeout = uopt.Of(time.Minute)
+	u.Tags = []string{"a", "b"}
+	u.Name = "жанна"
+	assert.NoError(t, uvalidate.Validate(&u))
+}
+
+func TestValidate_Violations(t *testing.T) {
+	u := user{
+		Name:      "j",
+		Age:       uopt.Of(10),
+		Timeout:   uopt.Of(time.Millisecond),
+		Score:     2,
+		Tags:      []string{"a", "b", "c"},
+		Addresses: []address{{City: "Rome", Zip: "00100"}, {Zip: "123"}},
+	}
+
+	err := uvalidate.Validate(&u)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, uvalidate.ErrInvalid))
+
+	type violation struct{ field, rule, param string }
+	var violations []violation
+	for _, e := range uvalidate.FieldErrors(err) {
+		violations = append(violations, violation{e.Field, e.Rule, e.Param})
+	}
+	assert.Equal(t, []violation{
+		{"Name", "min", "2"},
+		{"Age", "min", "18"},
+		{"Role", "required", ""},
+		{"Timeout", "min", "1s"},
+		{"Score", "max", "1.5"},
+		{"Tags", "max", "2"},
+		{"Home", "required", ""},
+		{"Addresses[1].City", "required", ""},
+		{"Addresses[1].Zip", "len", "5"},
+	}, violations)
+	assert.Contains(t, err.Error(), "Age: must be at least 18, got 10")
+}
+
+func TestValidate_OptPresence(t *testing.T) {
+	u := validUser()
+	u.Role = uopt.Of("guest")
+	err := uvalidate.Validate(u)
+	require.Error(t, err)
+	fieldErrs := uvalidate.FieldErrors(err)
+	require.Len(t, fieldErrs, 1)
+	assert.Equal(t, "Role", fieldErrs[0].Field)
+	assert.Equal(t, "oneof", fieldErrs[0].Rule)
+
+	type zero struct {
+		Count uopt.Opt[int] `validate:"required"`
+	}
+	assert.NoError(t, uvalidate.Validate(zero{Count: uopt.Of(0)}), "a present zero value satisfies required")
+	assert.Error(t, uvalidate.Validate(zero{}))
+}
+
+func TestValidate_Nested(t *testing.T) {
+	type inner struct {
+		Port uopt.Opt[int] `validate:"min=1,max=65535"`
+	}
+	type outer struct {
+		Inner    inner
+		Optional uopt.Opt[inner]
+		Pointers []*inner
+	}
+
+	err := uvalidate.Validate(outer{
+		Inner:    inner{Port: uopt.Of(0)},
+		Optional: uopt.Of(inner{Port: uopt.Of(70000)}),
+		Pointers: []*inner{nil, {Port: uopt.Of(-1)}},
+	})
+	require.Error(t, err)
+
+	var fields []string
+	for _, e := range uvalidate.FieldErrors(err) {
+		fields = append(fields, e.Field)
+	}
+	assert.Equal(t, []string{"Inner.Port", "Optional.Port", "Pointers[1].Port"}, fields)
+}
+
+func TestValidate_InvalidUsage(t *testing.T) {
+	assert.Error(t, uvalidate.Validate(42))
+	assert.Error(t, uvalidate.Validate((*user)(nil)))
+
+	type unknownRule struct {
+		Name string `validate:"email"`
+	}
+	err := uvalidate.Validate(unknownRule{Name: "x"})
+	require.Error(t, err)
+	assert.False(t, errors.Is(err, uvalidate.ErrInvalid))
+	assert.Empty(t, uvalidate.FieldErrors(err))
+
+	type badParam struct {
+		Count int `validate:"min=abc"`
+	}
+	assert.Error(t, uvalidate.Validate(badParam{}))
+
+	type badType struct {
+		Flag bool `validate:"max=1"`
+	}
+	assert.Error(t, uvalidate.Validate(badType{}))
+}